@@ -0,0 +1,231 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compliance runs the kubectl-mtb benchmark suite continuously from inside the cluster,
+// instead of only ad-hoc from the CLI, driven by the MTBRun/MTBResult CRDs.
+package compliance
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	compliancev1alpha1 "sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/apis/compliance/v1alpha1"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test"
+	mtbtypes "sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/types"
+)
+
+// MTBRunReconciler periodically runs the kubectl-mtb benchmark suite against every namespace
+// selected by an MTBRun, impersonating the identity named by that namespace's
+// compliancev1alpha1.TenantUserAnnotation, and publishes the outcome as an MTBResult in the
+// namespace itself.
+//
+// It calls the same benchmark.Benchmark.PreRun/Run/PostRun functions the CLI's "kubectl mtb run"
+// uses, but only runs each benchmark once per sweep: the CLI's retry/backoff and resumable-state
+// handling exist to make an interactive run resilient to transient failures and let a user resume
+// an interrupted one, neither of which applies to a reconciler that's just going to try again on
+// its own timer regardless.
+type MTBRunReconciler struct {
+	client.Client
+	Log logr.Logger
+
+	// RestConfig is the cluster-admin config used both directly (to list/read namespaces) and as
+	// the base for the per-tenant impersonated configs built for each benchmarked namespace.
+	RestConfig *rest.Config
+}
+
+// +kubebuilder:rbac:groups=compliance.mtb.x-k8s.io,resources=mtbruns,verbs=get;list;watch
+// +kubebuilder:rbac:groups=compliance.mtb.x-k8s.io,resources=mtbruns/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=compliance.mtb.x-k8s.io,resources=mtbresults,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=compliance.mtb.x-k8s.io,resources=mtbresults/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+func (r *MTBRunReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("mtbrun", req.NamespacedName)
+
+	run := &compliancev1alpha1.MTBRun{}
+	if err := r.Get(ctx, req.NamespacedName, run); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.V(1).Info("MTBRun has been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(run.Spec.NamespaceSelector)
+	if err != nil {
+		log.Error(err, "invalid namespaceSelector")
+		return ctrl.Result{}, nil // won't succeed on retry without a spec change
+	}
+
+	nsList := &corev1.NamespaceList{}
+	if err := r.List(ctx, nsList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	profileLevel := run.Spec.ProfileLevel
+	if profileLevel == 0 {
+		profileLevel = compliancev1alpha1.DefaultProfileLevel
+	}
+	benchmarks := test.BenchmarkSuite.ProfileLevel(profileLevel)
+
+	checked, skipped := 0, 0
+	for _, ns := range nsList.Items {
+		tenantUser, ok := ns.Annotations[compliancev1alpha1.TenantUserAnnotation]
+		if !ok {
+			log.V(1).Info("skipping namespace missing tenant-user annotation", "namespace", ns.Name)
+			skipped++
+			continue
+		}
+
+		result, err := r.runSuite(ns.Name, tenantUser, benchmarks)
+		if err != nil {
+			log.Error(err, "failed to run benchmark suite", "namespace", ns.Name)
+			continue
+		}
+		if err := r.writeResult(ctx, run, ns.Name, result); err != nil {
+			log.Error(err, "failed to write MTBResult", "namespace", ns.Name)
+			continue
+		}
+		checked++
+	}
+
+	now := metav1.Now()
+	run.Status.LastRunTime = &now
+	run.Status.NamespacesChecked = checked
+	run.Status.NamespacesSkipped = skipped
+	if err := r.Status().Update(ctx, run); err != nil {
+		log.Error(err, "while updating MTBRun status")
+		return ctrl.Result{}, err
+	}
+
+	interval := run.Spec.IntervalSeconds
+	if interval == 0 {
+		interval = compliancev1alpha1.DefaultIntervalSeconds
+	}
+	return ctrl.Result{RequeueAfter: time.Duration(interval) * time.Second}, nil
+}
+
+// runSuite runs every benchmark in benchmarks against namespace ns, impersonating tenantUser, and
+// summarizes the outcome. Benchmarks that require a second tenant namespace (NamespaceRequired >
+// 1) are skipped, the same as a CLI run given only a single "--as"/"--namespace" pair.
+func (r *MTBRunReconciler) runSuite(ns, tenantUser string, benchmarks []*benchmark.Benchmark) (compliancev1alpha1.MTBResultStatus, error) {
+	opts, err := r.tenantRunOptions(ns, tenantUser)
+	if err != nil {
+		return compliancev1alpha1.MTBResultStatus{}, err
+	}
+
+	status := compliancev1alpha1.MTBResultStatus{TenantUser: tenantUser}
+	for _, b := range benchmarks {
+		if b.NamespaceRequired > 1 {
+			status.Skipped++
+			continue
+		}
+
+		outcome := compliancev1alpha1.BenchmarkOutcome{
+			ID:          b.ID,
+			Title:       b.Title,
+			Category:    b.Category,
+			Remediation: b.Remediation,
+		}
+
+		if err := b.PreRun(opts); err != nil {
+			outcome.Status = "Error"
+			status.Errored++
+		} else if err := b.Run(opts); err != nil {
+			outcome.Status = "Fail"
+			status.Failed++
+		} else {
+			if b.PostRun != nil {
+				_ = b.PostRun(opts)
+			}
+			outcome.Status = "Pass"
+			status.Passed++
+		}
+
+		status.Results = append(status.Results, outcome)
+	}
+	status.Total = len(status.Results) + status.Skipped
+
+	return status, nil
+}
+
+// tenantRunOptions builds the mtbtypes.RunOptions used to benchmark namespace ns as tenantUser,
+// the in-cluster equivalent of the CLI's initConfig() impersonating "--as" against "--namespace".
+func (r *MTBRunReconciler) tenantRunOptions(ns, tenantUser string) (mtbtypes.RunOptions, error) {
+	adminClient, err := kubernetes.NewForConfig(r.RestConfig)
+	if err != nil {
+		return mtbtypes.RunOptions{}, err
+	}
+
+	tenantConfig := rest.CopyConfig(r.RestConfig)
+	tenantConfig.Impersonate = rest.ImpersonationConfig{UserName: tenantUser}
+
+	tenantClient, err := kubernetes.NewForConfig(tenantConfig)
+	if err != nil {
+		return mtbtypes.RunOptions{}, err
+	}
+	tenantDynamicClient, err := dynamic.NewForConfig(tenantConfig)
+	if err != nil {
+		return mtbtypes.RunOptions{}, err
+	}
+
+	return mtbtypes.RunOptions{
+		Tenant:               tenantUser,
+		TenantNamespace:      ns,
+		ClusterAdminClient:   adminClient,
+		Tenant1Client:        tenantClient,
+		Tenant1DynamicClient: tenantDynamicClient,
+	}, nil
+}
+
+// writeResult creates or updates the MTBResult named after run in namespace ns with status.
+func (r *MTBRunReconciler) writeResult(ctx context.Context, run *compliancev1alpha1.MTBRun, ns string, status compliancev1alpha1.MTBResultStatus) error {
+	now := metav1.Now()
+	status.LastRunTime = &now
+
+	result := &compliancev1alpha1.MTBResult{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: ns, Name: run.Name}, result)
+	if apierrors.IsNotFound(err) {
+		result = &compliancev1alpha1.MTBResult{
+			ObjectMeta: metav1.ObjectMeta{Name: run.Name, Namespace: ns},
+		}
+		if err := r.Create(ctx, result); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	result.Status = status
+	return r.Status().Update(ctx, result)
+}
+
+func (r *MTBRunReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&compliancev1alpha1.MTBRun{}).
+		Complete(r)
+}
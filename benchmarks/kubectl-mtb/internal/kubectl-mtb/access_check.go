@@ -0,0 +1,123 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/types"
+)
+
+// checkAccess prints what each runner identity - cluster-admin, and the impersonated tenant(s) -
+// is actually allowed to do in the namespace(s) under test, via a SelfSubjectRulesReview, so a
+// security reviewer can see the blast radius of the account running the suite before (or instead
+// of) granting it access. It's a best-effort summary: the review API itself warns if its answer
+// might be incomplete (e.g. because of webhook-based authorizers it can't introspect).
+func checkAccess(opts types.RunOptions) error {
+	fmt.Println("Checking what the runner's identity can do...")
+
+	if err := printAccess("cluster-admin", opts.ClusterAdminClient, ""); err != nil {
+		return err
+	}
+	if err := printAccess(opts.Tenant, opts.Tenant1Client, opts.TenantNamespace); err != nil {
+		return err
+	}
+	if opts.Tenant2Client != nil {
+		if err := printAccess(opts.OtherTenant, opts.Tenant2Client, opts.OtherNamespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printAccess(identity string, client *kubernetes.Clientset, namespace string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	review := &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+	}
+	result, err := client.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("checking access for %s: %w", identity, err)
+	}
+
+	ns := namespace
+	if ns == "" {
+		ns = "<cluster-scoped>"
+	}
+	fmt.Printf("\n%s in %s can:\n", identity, ns)
+	if result.Status.Incomplete {
+		fmt.Printf("  (warning: this list may be incomplete: %s)\n", result.Status.EvaluationError)
+	}
+	if len(result.Status.ResourceRules) == 0 {
+		fmt.Println("  (nothing)")
+	}
+	for _, r := range result.Status.ResourceRules {
+		group := strings.Join(r.APIGroups, ",")
+		if group == "" {
+			group = "core"
+		}
+		fmt.Printf("  %s %s (%s)\n", strings.Join(r.Verbs, ","), strings.Join(r.Resources, ","), group)
+	}
+	return nil
+}
+
+// mutatingVerbs are the verbs a --read-only run refuses to let any selected benchmark declare
+// outside of a dry run, since they persist a change to the cluster.
+var mutatingVerbs = map[string]bool{
+	"create":           true,
+	"update":           true,
+	"patch":            true,
+	"delete":           true,
+	"deletecollection": true,
+}
+
+// requiresWrite returns true if b declares any API call that isn't a dry run and uses a mutating
+// verb, i.e. it would fail --read-only's least-privilege requirement.
+func requiresWrite(b *benchmark.Benchmark) bool {
+	for _, c := range b.APICalls {
+		if c.DryRun {
+			continue
+		}
+		for _, v := range strings.Split(c.Verb, ",") {
+			if mutatingVerbs[strings.TrimSpace(v)] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterReadOnlyBenchmarks drops any benchmark that requiresWrite, so --read-only can be used
+// with a least-privileged account that's only been granted read access.
+func filterReadOnlyBenchmarks() {
+	var kept []*benchmark.Benchmark
+	for _, b := range benchmarks {
+		if requiresWrite(b) {
+			continue
+		}
+		kept = append(kept, b)
+	}
+	benchmarks = kept
+}
@@ -0,0 +1,85 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package kubectl
+
+import (
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/internal/compliance"
+	compliancev1alpha1 "sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/apis/compliance/v1alpha1"
+)
+
+// newControllerCmd runs the continuous compliance monitoring controller in the foreground,
+// reconciling MTBRun objects instead of running the benchmark suite once and exiting like "run"
+// does. It's meant to be deployed in-cluster, e.g. as a Deployment.
+func newControllerCmd() *cobra.Command {
+	controllerCmd := &cobra.Command{
+		Use:   "controller",
+		Short: "Run the continuous compliance monitoring controller",
+		Long: "Runs an in-cluster controller that reconciles MTBRun objects: on a timer, it runs " +
+			"the benchmark suite against every namespace an MTBRun selects and publishes the " +
+			"outcome as an MTBResult in that namespace, so compliance can be monitored continuously " +
+			"instead of only via ad-hoc \"kubectl mtb run\" invocations.",
+		RunE: runController,
+	}
+
+	controllerCmd.Flags().String("metrics-bind-address", ":8080", "address the controller binds the metrics endpoint to")
+	controllerCmd.Flags().Bool("leader-elect", false, "enable leader election, for running more than one replica")
+
+	return controllerCmd
+}
+
+func runController(cmd *cobra.Command, args []string) error {
+	metricsAddr, _ := cmd.Flags().GetString("metrics-bind-address")
+	leaderElect, _ := cmd.Flags().GetBool("leader-elect")
+
+	kubecfgFlags := genericclioptions.NewConfigFlags(false)
+	restConfig, err := kubecfgFlags.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if err := compliancev1alpha1.AddToScheme(scheme); err != nil {
+		return err
+	}
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
+		Scheme:             scheme,
+		MetricsBindAddress: metricsAddr,
+		LeaderElection:     leaderElect,
+		LeaderElectionID:   "kubectl-mtb-controller",
+	})
+	if err != nil {
+		return err
+	}
+
+	reconciler := &compliance.MTBRunReconciler{
+		Client:     mgr.GetClient(),
+		Log:        ctrl.Log.WithName("controllers").WithName("MTBRun"),
+		RestConfig: restConfig,
+	}
+	if err := reconciler.SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	return mgr.Start(ctrl.SetupSignalHandler())
+}
@@ -0,0 +1,63 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package kubectl
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark"
+)
+
+// explainBenchmarks prints, for each of benchmarks, the API requests it declares in its
+// APICalls - without executing PreRun/Run/PostRun or going through the reporter lifecycle, since
+// nothing was actually run. This lets a security reviewer see what "mtb run" would do before
+// granting it access to a cluster.
+func explainBenchmarks(benchmarks []*benchmark.Benchmark) error {
+	for _, b := range benchmarks {
+		fmt.Printf("[%s] %s\n", b.ID, b.Title)
+		if len(b.APICalls) == 0 {
+			fmt.Println("    (no declared API calls)")
+			continue
+		}
+		for _, c := range b.APICalls {
+			fmt.Println("    " + formatAPICall(c))
+		}
+	}
+	return nil
+}
+
+func formatAPICall(c benchmark.APICall) string {
+	gvr := c.Resource
+	if c.Group != "" {
+		gvr = c.Group + "/" + c.Resource
+	}
+	if c.Version != "" {
+		gvr = gvr + " (" + c.Version + ")"
+	}
+
+	ns := c.Namespace
+	if ns == "" {
+		ns = "<cluster-scoped>"
+	}
+
+	line := fmt.Sprintf("as %s: %s %s in %s", c.Impersonate, c.Verb, gvr, ns)
+	if c.DryRun {
+		line += " [server-side dry-run]"
+	}
+	if c.Note != "" {
+		line += " - " + c.Note
+	}
+	return line
+}
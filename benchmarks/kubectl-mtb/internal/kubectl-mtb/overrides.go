@@ -0,0 +1,42 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package kubectl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/types"
+)
+
+// loadOverrides reads the expected-value overrides file at path. An empty path means the user
+// didn't pass --overrides, which just means every benchmark uses its own defaults.
+func loadOverrides(path string) (types.Overrides, error) {
+	overrides := types.Overrides{}
+	if path == "" {
+		return overrides, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read overrides file %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(b, &overrides); err != nil {
+		return nil, fmt.Errorf("cannot parse overrides file %s: %w", path, err)
+	}
+	return overrides, nil
+}
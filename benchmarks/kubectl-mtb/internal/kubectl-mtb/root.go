@@ -1,10 +1,9 @@
 /*
-
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-    http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -20,7 +19,10 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/internal/messages"
 	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark/external"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test"
 )
 
 var rootCmd *cobra.Command
@@ -39,10 +41,30 @@ func init() {
 	}
 
 	rootCmd.PersistentFlags().IntP("profile-level", "p", maxProfileLevel, "ProfileLevel of the benchmarks.")
+	rootCmd.PersistentFlags().String("locale", messages.DefaultLocale, "Locale to use for runner output, e.g. \"en\" or \"es\". Falls back to \"en\" if unrecognized.")
+	rootCmd.PersistentFlags().String("external-benchmarks", "", "(optional) directory of declarative YAML benchmark specs "+
+		"to load in addition to the built-in benchmarks, so org-specific checks can be added without forking")
+	cobra.OnInitialize(func() {
+		locale, _ := rootCmd.PersistentFlags().GetString("locale")
+		messages.SetLocale(locale)
+
+		externalDir, _ := rootCmd.PersistentFlags().GetString("external-benchmarks")
+		if externalDir != "" {
+			loaded, err := external.Load(externalDir)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			for _, b := range loaded {
+				test.BenchmarkSuite.Add(b)
+			}
+		}
+	})
 
 	// Commands
 	rootCmd.AddCommand(newGetCmd())
 	rootCmd.AddCommand(newRunCmd())
+	rootCmd.AddCommand(newControllerCmd())
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
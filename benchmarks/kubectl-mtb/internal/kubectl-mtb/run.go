@@ -1,10 +1,9 @@
 /*
-
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-    http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -23,8 +22,10 @@ import (
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/internal/metadata"
 	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/internal/reporter"
 	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark"
 	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test"
@@ -34,6 +35,11 @@ import (
 
 var benchmarkRunOptions = types.RunOptions{}
 
+// runRetries and runRetryBackoff configure executeRun's retry behavior; they're set from the
+// --retries and --retry-backoff flags in runTests.
+var runRetries int
+var runRetryBackoff time.Duration
+
 var runCmd = &cobra.Command{
 	Use:   "run [benchmark|benchmarks] [<benchmark ID>]",
 	Short: "run one or more multi-tenancy benchmarks",
@@ -82,6 +88,13 @@ func initConfig() error {
 		return err
 	}
 
+	// create the tenant dynamic client, used by external (YAML-declared) benchmarks to create
+	// arbitrary resource kinds
+	benchmarkRunOptions.Tenant1DynamicClient, err = dynamic.NewForConfig(tenantConfig)
+	if err != nil {
+		return err
+	}
+
 	if benchmarkRunOptions.OtherNamespace != "" && benchmarkRunOptions.OtherTenant != "" {
 		otherTenantConfig := config
 		otherTenantConfig.Impersonate.UserName = benchmarkRunOptions.OtherTenant
@@ -160,6 +173,13 @@ func validateFlags(cmd *cobra.Command) error {
 		return err
 	}
 
+	// --explain only describes what a run would do; it never talks to the cluster, so there's
+	// nothing to reach here.
+	explain, _ := cmd.Flags().GetBool("explain")
+	if explain {
+		return nil
+	}
+
 	_, err = benchmarkRunOptions.ClusterAdminClient.CoreV1().Namespaces().Get(context.TODO(), benchmarkRunOptions.TenantNamespace, metav1.GetOptions{})
 	if err != nil {
 		return err
@@ -182,7 +202,13 @@ func setupReporters(cmd *cobra.Command) ([]reporter.Reporter, error) {
 	// Get reporters from the user
 	reporterFlag, _ := cmd.Flags().GetString("out")
 	reporters := strings.Split(reporterFlag, ",")
-	return reporter.GetReporters(reporters)
+	outputFile, _ := cmd.Flags().GetString("output-file")
+	publishURL, _ := cmd.Flags().GetString("publish")
+
+	return reporter.GetReporters(reporters, reporter.ReporterConfig{
+		OutputFile: outputFile,
+		PublishURL: publishURL,
+	})
 }
 
 func executePreRun(b *benchmark.Benchmark, suiteSummary *reporter.SuiteSummary, ts *reporter.TestSummary) {
@@ -196,19 +222,40 @@ func executePreRun(b *benchmark.Benchmark, suiteSummary *reporter.SuiteSummary,
 	}
 }
 
+// executeRun runs the benchmark, retrying up to runRetries times with a runRetryBackoff pause
+// between attempts if it fails. A benchmark that only passed after a retry is marked "Flaky"
+// rather than "Pass", so the report distinguishes it from a benchmark that passed outright.
 func executeRun(b *benchmark.Benchmark, suiteSummary *reporter.SuiteSummary, ts *reporter.TestSummary) {
-	if ts.Validation {
-		err := b.Run(benchmarkRunOptions)
-		if err != nil {
-			benchmarkRunOptions.Logger.Debug(err.Error())
-			suiteSummary.NumberOfFailedTests++
-			ts.Test = false
-			ts.TestError = err
-			b.Status = "Fail"
-		} else {
-			suiteSummary.NumberOfPassedTests++
-			b.Status = "Pass"
+	if !ts.Validation {
+		return
+	}
+
+	var err error
+	retried := false
+	for attempt := 0; attempt <= runRetries; attempt++ {
+		if attempt > 0 {
+			retried = true
+			time.Sleep(runRetryBackoff)
+		}
+		if err = b.Run(benchmarkRunOptions); err == nil {
+			break
 		}
+		benchmarkRunOptions.Logger.Debug(err.Error())
+	}
+
+	if err != nil {
+		suiteSummary.NumberOfFailedTests++
+		ts.Test = false
+		ts.TestError = err
+		b.Status = "Fail"
+		return
+	}
+
+	suiteSummary.NumberOfPassedTests++
+	if retried {
+		b.Status = "Flaky"
+	} else {
+		b.Status = "Pass"
 	}
 }
 
@@ -233,27 +280,80 @@ func shouldSkipTest(b *benchmark.Benchmark, suiteSummary *reporter.SuiteSummary,
 	return false
 }
 
+// runtimeParams captures the flags that shaped this run, for inclusion in the report's metadata.
+// It's read straight from the flag set rather than benchmarkRunOptions so it stays accurate even
+// as new flags are added, without having to thread each of them through that struct too.
+func runtimeParams(cmd *cobra.Command) map[string]string {
+	params := map[string]string{}
+	for _, name := range []string{"profile-level", "labels", "skip", "retries", "retry-backoff", "read-only", "check-access"} {
+		if f := cmd.Flags().Lookup(name); f != nil {
+			params[name] = f.Value.String()
+		}
+	}
+	return params
+}
+
 func runTests(cmd *cobra.Command, args []string) error {
 
 	benchmarkRunOptions.Label, _ = cmd.Flags().GetString("labels")
 	// Get log level
 	setupLogger(cmd)
 
+	// Get benchmark ids from the user to skip them
+	skipFlag, _ := cmd.Flags().GetString("skip")
+	skipIDs := strings.Split(skipFlag, ",")
+	removeBenchmarksWithIDs(skipIDs)
+
+	explain, _ := cmd.Flags().GetBool("explain")
+	if explain {
+		return explainBenchmarks(benchmarks)
+	}
+
+	readOnly, _ := cmd.Flags().GetBool("read-only")
+	if readOnly {
+		filterReadOnlyBenchmarks()
+	}
+
+	checkAccessFlag, _ := cmd.Flags().GetBool("check-access")
+	if checkAccessFlag {
+		if err := checkAccess(benchmarkRunOptions); err != nil {
+			return err
+		}
+	}
+
 	reportersArray, err := setupReporters(cmd)
 	if err != nil {
 		return err
 	}
 
-	// Get benchmark ids from the user to skip them
-	skipFlag, _ := cmd.Flags().GetString("skip")
-	skipIDs := strings.Split(skipFlag, ",")
-	removeBenchmarksWithIDs(skipIDs)
+	overridesFile, _ := cmd.Flags().GetString("overrides")
+	overrides, err := loadOverrides(overridesFile)
+	if err != nil {
+		return err
+	}
+	benchmarkRunOptions.Overrides = overrides
+
+	resume, _ := cmd.Flags().GetBool("resume")
+	stateFile, _ := cmd.Flags().GetString("state-file")
+	runRetries, _ = cmd.Flags().GetInt("retries")
+	runRetryBackoff, _ = cmd.Flags().GetDuration("retry-backoff")
+
+	state, err := loadRunState(stateFile)
+	if err != nil {
+		return err
+	}
+
+	clusterMetadata, err := metadata.Collect(benchmarkRunOptions.ClusterAdminClient, test.BenchmarkSuite, runtimeParams(cmd))
+	if err != nil {
+		benchmarkRunOptions.Logger.Debug(err.Error())
+	}
 
 	suiteSummary := &reporter.SuiteSummary{
 		Suite:              test.BenchmarkSuite,
 		NumberOfTotalTests: len(benchmarks),
 		Namespace:          benchmarkRunOptions.TenantNamespace,
 		User:               benchmarkRunOptions.Tenant,
+		Metadata:           clusterMetadata,
 	}
 
 	suiteStartTime := time.Now()
@@ -263,6 +363,7 @@ func runTests(cmd *cobra.Command, args []string) error {
 
 		ts := &reporter.TestSummary{
 			Benchmark: b,
+			Overrides: benchmarkRunOptions.Overrides[b.ID],
 		}
 
 		err := ts.SetDefaults()
@@ -277,6 +378,14 @@ func runTests(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
+		if resume && resumable(state.Results[b.ID]) {
+			b.Status = state.Results[b.ID]
+			suiteSummary.NumberOfPassedTests++
+			ts.RunTime = time.Since(startTest)
+			reportTestWillRun(ts, reportersArray)
+			continue
+		}
+
 		// Lifecycles
 		executePreRun(b, suiteSummary, ts)
 
@@ -287,6 +396,11 @@ func runTests(cmd *cobra.Command, args []string) error {
 		elapsed := time.Since(startTest)
 		ts.RunTime = elapsed
 		reportTestWillRun(ts, reportersArray)
+
+		state.Results[b.ID] = b.Status
+		if err := saveRunState(stateFile, state); err != nil {
+			benchmarkRunOptions.Logger.Debug(err.Error())
+		}
 	}
 
 	suiteElapsedTime := time.Since(suiteStartTime)
@@ -301,9 +415,22 @@ func newRunCmd() *cobra.Command {
 	runCmd.Flags().BoolP("debug", "d", false, "Use debugging mode")
 	runCmd.Flags().StringSliceP("namespace", "n", []string{}, "(required) tenant namespace")
 	runCmd.Flags().StringSlice("as", []string{}, "(required) user name to impersonate")
-	runCmd.Flags().StringP("out", "o", "default", "(optional) output reporters (default, policyreport)")
+	runCmd.Flags().StringP("out", "o", "default", "(optional) output reporters (default, policyreport, json, yaml, junit)")
+	runCmd.Flags().String("output-file", "kubectl-mtb-report", "(optional) base path (without extension) the json/yaml/junit reporters write their report to")
 	runCmd.Flags().StringP("skip", "s", "", "(optional) benchmark IDs to skip")
+	runCmd.Flags().String("overrides", "", "(optional) path to a JSON file of per-benchmark expected-value overrides, "+
+		"for clusters that legitimately differ from a benchmark's defaults (e.g. allowed host ports)")
 	runCmd.Flags().StringP("labels", "l", "", "(optional) labels")
+	runCmd.Flags().String("publish", "", "(optional) URL to publish serialized results to (e.g. an object storage bucket or HTTP collector); "+
+		"auth token is read from the "+reporter.PublishAuthTokenEnv+" environment variable")
+	runCmd.Flags().Bool("resume", false, "(optional) skip benchmarks recorded as passed in --state-file by a previous run")
+	runCmd.Flags().String("state-file", ".kubectl-mtb-state.json", "(optional) path to the run state file read by --resume and updated after every benchmark")
+	runCmd.Flags().Int("retries", 0, "(optional) number of times to retry a benchmark before marking it Fail")
+	runCmd.Flags().Duration("retry-backoff", 2*time.Second, "(optional) how long to wait between retries")
+	runCmd.Flags().Bool("explain", false, "(optional) print the API requests each selected benchmark would make - "+
+		"impersonation identity, group/version/resource/verb, and whether it creates any resources - without running them")
+	runCmd.Flags().Bool("check-access", false, "(optional) before running, print what each runner identity (cluster-admin and the impersonated tenant(s)) is actually allowed to do in its namespace")
+	runCmd.Flags().Bool("read-only", false, "(optional) skip any selected benchmark that would create, update, patch, or delete a resource outside of a dry run, for running with a least-privileged, read-only account")
 
 	return runCmd
 }
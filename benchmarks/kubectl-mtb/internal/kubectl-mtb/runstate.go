@@ -0,0 +1,63 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package kubectl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// RunState records the last known outcome of each benchmark, keyed by benchmark ID, so a run
+// against a flaky cluster can be resumed with --resume instead of restarting from scratch.
+type RunState struct {
+	Results map[string]string `json:"results"`
+}
+
+// loadRunState reads the run state file at path. A missing file isn't an error - it just means
+// there's nothing to resume from yet, which is the normal case for the first run of a suite.
+func loadRunState(path string) (*RunState, error) {
+	state := &RunState{Results: map[string]string{}}
+
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, state); err != nil {
+		return nil, fmt.Errorf("cannot parse run state file %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// saveRunState overwrites the run state file at path with state. It's called after every
+// benchmark so a run that's killed midway still leaves behind a state file that --resume can use.
+func saveRunState(path string, state *RunState) error {
+	b, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+// resumable reports whether a benchmark's previously recorded status means --resume can skip it.
+// Only a clean pass counts: a benchmark that failed, errored, or merely passed after retries
+// (Flaky) is worth running again.
+func resumable(status string) bool {
+	return status == "Pass"
+}
@@ -0,0 +1,92 @@
+// Package messages provides a small message catalogue used to localize kubectl-mtb's
+// user-facing runner output (benchmark status lines, table headers, and the like). Benchmark
+// content itself - titles, descriptions, remediation text - still comes from each benchmark's
+// own config.yaml and is not covered by this catalogue.
+package messages
+
+import "fmt"
+
+// DefaultLocale is used when no locale has been selected, or the selected locale isn't in the
+// catalogue.
+const DefaultLocale = "en"
+
+// catalogs maps a locale to its set of translated messages, keyed by message ID. Every locale is
+// expected to provide every key in the "en" catalog; Get falls back to "en" for anything missing.
+var catalogs = map[string]map[string]string{
+	"en": {
+		"reporter.passed":         "Passed",
+		"reporter.failed":         "Failed",
+		"reporter.remediation":    "Remediation: ",
+		"reporter.completedIn":    "Completed in %v",
+		"reporter.running":        "Running %d of %d as %s in namespace %s",
+		"reporter.preRunError":    "[PreRun-Validation Error]",
+		"reporter.overrides":      "Overrides: %v",
+		"reporter.summaryPassed":  "%d Passed | ",
+		"reporter.summaryFailed":  "%d Failed | ",
+		"reporter.summarySkipped": "%d Skipped | ",
+		"reporter.summaryErrors":  "%d Errors | ",
+		"reporter.table.no":       "No.",
+		"reporter.table.id":       "ID",
+		"reporter.table.test":     "Test",
+		"reporter.table.result":   "Result",
+		"reporter.status.error":   "Error",
+		"reporter.status.pass":    "Passed",
+		"reporter.status.fail":    "Failed",
+		"reporter.status.skip":    "Skipped",
+		"reporter.status.flaky":   "Flaky",
+	},
+	"es": {
+		"reporter.passed":         "Superado",
+		"reporter.failed":         "Fallido",
+		"reporter.remediation":    "Remediación: ",
+		"reporter.completedIn":    "Completado en %v",
+		"reporter.running":        "Ejecutando %d de %d como %s en el namespace %s",
+		"reporter.preRunError":    "[Error de validación previa]",
+		"reporter.overrides":      "Anulaciones: %v",
+		"reporter.summaryPassed":  "%d superados | ",
+		"reporter.summaryFailed":  "%d fallidos | ",
+		"reporter.summarySkipped": "%d omitidos | ",
+		"reporter.summaryErrors":  "%d errores | ",
+		"reporter.table.no":       "N.º",
+		"reporter.table.id":       "ID",
+		"reporter.table.test":     "Prueba",
+		"reporter.table.result":   "Resultado",
+		"reporter.status.error":   "Error",
+		"reporter.status.pass":    "Superado",
+		"reporter.status.fail":    "Fallido",
+		"reporter.status.skip":    "Omitido",
+		"reporter.status.flaky":   "Inestable",
+	},
+}
+
+var locale = DefaultLocale
+
+// SetLocale selects the locale used by Get. It's a no-op if the locale isn't in the catalogue, so
+// that an unrecognized --locale flag falls back to DefaultLocale rather than failing the run.
+func SetLocale(l string) {
+	if _, ok := catalogs[l]; ok {
+		locale = l
+	}
+}
+
+// Locale returns the currently selected locale.
+func Locale() string {
+	return locale
+}
+
+// Get returns the message for id in the current locale, formatted with args as if by
+// fmt.Sprintf. If id isn't present in the current locale, it falls back to DefaultLocale; if it's
+// missing there too, id itself is returned so a missing translation is visible instead of blank.
+func Get(id string, args ...interface{}) string {
+	msg, ok := catalogs[locale][id]
+	if !ok {
+		msg, ok = catalogs[DefaultLocale][id]
+	}
+	if !ok {
+		msg = id
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
@@ -0,0 +1,135 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metadata collects the cluster and tool information that's stamped onto every report,
+// so a report read months later is still interpretable without having to ask whoever ran it what
+// cluster/version/flags were in play at the time.
+package metadata
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	benchmarksuite "sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark_suite"
+)
+
+// Metadata is the cluster and tool information recorded alongside a benchmark suite's results.
+type Metadata struct {
+	// ClusterVersion is the tenant apiserver's reported version (GitVersion), e.g. "v1.20.4".
+	ClusterVersion string `json:"clusterVersion" yaml:"clusterVersion"`
+	// Provider is a best-effort guess at the cloud provider hosting the cluster's nodes, derived
+	// from their spec.providerID prefix (e.g. "aws", "gce", "azure"). "unknown" if no node has a
+	// recognized providerID, e.g. on a bare-metal or local (kind/minikube) cluster.
+	Provider string `json:"provider" yaml:"provider"`
+	// NodeCount is the number of nodes in the cluster at the time the suite ran.
+	NodeCount int `json:"nodeCount" yaml:"nodeCount"`
+	// PSPAvailable reports whether the deprecated policy/v1beta1 PodSecurityPolicy API is served by
+	// the apiserver.
+	PSPAvailable bool `json:"pspAvailable" yaml:"pspAvailable"`
+	// PSAAvailable reports whether the cluster's version is new enough to have a built-in Pod
+	// Security admission controller (PSA), which replaced PSP starting in Kubernetes v1.22.
+	PSAAvailable bool `json:"psaAvailable" yaml:"psaAvailable"`
+	// SuiteVersion is the benchmark suite's own Version, e.g. "1.0.0".
+	SuiteVersion string `json:"suiteVersion" yaml:"suiteVersion"`
+	// GitSHA is the kubectl-mtb binary's build commit, set via ldflags at release time (see
+	// internal/metadata/version.go). "unknown" for a binary built without them, e.g. `go run`.
+	GitSHA string `json:"gitSHA" yaml:"gitSHA"`
+	// RuntimeParams records the CLI flags that shaped this run (profile level, labels, skipped
+	// IDs, retries, ...), keyed by flag name.
+	RuntimeParams map[string]string `json:"runtimeParams,omitempty" yaml:"runtimeParams,omitempty"`
+}
+
+// providerPrefixes maps a node's spec.providerID scheme to the provider name it identifies. See
+// https://kubernetes.io/docs/concepts/architecture/nodes/#node-name-uniqueness for the format.
+var providerPrefixes = map[string]string{
+	"aws":       "aws",
+	"azure":     "azure",
+	"gce":       "gce",
+	"openstack": "openstack",
+	"vsphere":   "vsphere",
+	"kind":      "kind",
+}
+
+// Collect gathers cluster metadata from client and combines it with suite's own version and
+// runtimeParams into a Metadata. It never fails outright on a cluster-side error - a report with
+// partial metadata is still more useful months later than no report at all - but the first error
+// encountered is still returned so the caller can log it.
+func Collect(client kubernetes.Interface, suite *benchmarksuite.BenchmarkSuite, runtimeParams map[string]string) (*Metadata, error) {
+	md := &Metadata{
+		Provider:      "unknown",
+		SuiteVersion:  suite.Version,
+		GitSHA:        GitSHA,
+		RuntimeParams: runtimeParams,
+	}
+
+	var firstErr error
+	record := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if v, err := client.Discovery().ServerVersion(); err != nil {
+		record(err)
+	} else {
+		md.ClusterVersion = v.GitVersion
+		md.PSAAvailable = isPSAAvailable(v.Major, v.Minor)
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	record(err)
+	if nodes != nil {
+		md.NodeCount = len(nodes.Items)
+		md.Provider = detectProvider(nodes.Items)
+	}
+
+	if _, err := client.Discovery().ServerResourcesForGroupVersion("policy/v1beta1"); err == nil {
+		md.PSPAvailable = true
+	}
+
+	return md, firstErr
+}
+
+// detectProvider returns the first recognized cloud provider among nodes' spec.providerID, or
+// "unknown" if none is recognized (or there are no nodes).
+func detectProvider(nodes []corev1.Node) string {
+	for _, n := range nodes {
+		scheme := strings.SplitN(n.Spec.ProviderID, "://", 2)[0]
+		if provider, ok := providerPrefixes[scheme]; ok {
+			return provider
+		}
+	}
+	return "unknown"
+}
+
+// isPSAAvailable reports whether major.minor is new enough to ship Pod Security admission built
+// in, i.e. v1.22 or later. minor can have a "+" suffix (as reported by some managed providers),
+// which is stripped before parsing. Either part failing to parse as a number is treated as "not
+// available" rather than guessed at.
+func isPSAAvailable(major, minor string) bool {
+	maj, err := strconv.Atoi(strings.TrimSuffix(major, "+"))
+	if err != nil {
+		return false
+	}
+	min, err := strconv.Atoi(strings.TrimSuffix(minor, "+"))
+	if err != nil {
+		return false
+	}
+	return maj > 1 || (maj == 1 && min >= 22)
+}
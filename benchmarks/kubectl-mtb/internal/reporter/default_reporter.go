@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/olekukonko/tablewriter"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/internal/messages"
 	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark"
 	benchmarksuite "sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark_suite"
 )
@@ -23,8 +24,8 @@ func NewDefaultReporter() *DefaultReporter {
 // SuiteWillBegin prints banner and total benchmarks to be run
 func (r *DefaultReporter) SuiteWillBegin(suiteSummary *SuiteSummary) {
 	writer.PrintBanner(writer.Colorize(boldStyle, "%s", suiteSummary.Suite.Title), "=")
-	writer.Println(0, writer.Colorize(lightGrayColor, "Running %d of %d as %s in namespace %s",
-		suiteSummary.NumberOfTotalTests, suiteSummary.Suite.Totals(), suiteSummary.User, suiteSummary.Namespace))
+	writer.Println(0, writer.Colorize(lightGrayColor, messages.Get("reporter.running",
+		suiteSummary.NumberOfTotalTests, suiteSummary.Suite.Totals(), suiteSummary.User, suiteSummary.Namespace)))
 }
 
 // TestWillRun prints each test status
@@ -33,20 +34,23 @@ func (r *DefaultReporter) TestWillRun(testSummary *TestSummary) {
 		writer.Print(0, writer.Colorize(cyanColor, "[%s] [%s] ", testSummary.Benchmark.ID, testSummary.Benchmark.Category))
 		writer.Println(0, testSummary.Benchmark.Title)
 		writer.Println(0, writer.Colorize(grayColor, "%s", testSummary.Benchmark.Description))
+		if len(testSummary.Overrides) > 0 {
+			writer.Println(0, writer.Colorize(grayColor, messages.Get("reporter.overrides", testSummary.Overrides)))
+		}
 		if testSummary.Test {
-			passed := "Passed " + tick
+			passed := messages.Get("reporter.passed") + " " + tick
 			writer.Println(0, writer.Colorize(greenColor, passed))
 		} else {
-			failed := "Failed " + cross
+			failed := messages.Get("reporter.failed") + " " + cross
 			writer.Println(0, writer.Colorize(redColor, failed))
-			writer.Print(0, writer.Colorize(lilac, "Remediation: "))
+			writer.Print(0, writer.Colorize(lilac, messages.Get("reporter.remediation")))
 			writer.Println(0, writer.Colorize(lightGrayColor, testSummary.Benchmark.Remediation))
 
 		}
-		writer.PrintBanner(writer.Colorize(grayColor, "Completed in %v", testSummary.RunTime), "-")
+		writer.PrintBanner(writer.Colorize(grayColor, messages.Get("reporter.completedIn", testSummary.RunTime)), "-")
 		return
 	}
-	preRunfmt := writer.Colorize(magentaColor, "[PreRun-Validation Error]")
+	preRunfmt := writer.Colorize(magentaColor, messages.Get("reporter.preRunError"))
 	errormsg := writer.Colorize(redColor, testSummary.ValidationError.Error())
 	bannerText := fmt.Sprintf("%s [%s] %s: %s %s", preRunfmt, testSummary.Benchmark.ID, testSummary.Benchmark.Title, errormsg, cross)
 	writer.PrintBanner(bannerText, "-")
@@ -55,12 +59,12 @@ func (r *DefaultReporter) TestWillRun(testSummary *TestSummary) {
 
 // SuiteDidEnd prints end result summary of benchmark suite
 func (r *DefaultReporter) SuiteDidEnd(suiteSummary *SuiteSummary) {
-	writer.Print(0, writer.Colorize(greenColor, "%d Passed | ", suiteSummary.NumberOfPassedTests))
-	writer.Print(0, writer.Colorize(redColor, "%d Failed | ", suiteSummary.NumberOfFailedTests))
-	writer.Print(0, writer.Colorize(yellowColor, "%d Skipped | ", suiteSummary.NumberOfSkippedTests))
-	writer.Print(0, writer.Colorize(magentaColor, "%d Errors | ", suiteSummary.NumberOfFailedValidations))
+	writer.Print(0, writer.Colorize(greenColor, messages.Get("reporter.summaryPassed", suiteSummary.NumberOfPassedTests)))
+	writer.Print(0, writer.Colorize(redColor, messages.Get("reporter.summaryFailed", suiteSummary.NumberOfFailedTests)))
+	writer.Print(0, writer.Colorize(yellowColor, messages.Get("reporter.summarySkipped", suiteSummary.NumberOfSkippedTests)))
+	writer.Print(0, writer.Colorize(magentaColor, messages.Get("reporter.summaryErrors", suiteSummary.NumberOfFailedValidations)))
 	writer.PrintNewLine()
-	writer.PrintBanner(writer.Colorize(grayColor, "Completed in %v", suiteSummary.RunTime), "=")
+	writer.PrintBanner(writer.Colorize(grayColor, messages.Get("reporter.completedIn", suiteSummary.RunTime)), "=")
 
 	printScoreCard(benchmarksuite.SortedBenchmarks)
 }
@@ -76,13 +80,15 @@ func printScoreCard(benchmarks []*benchmark.Benchmark) {
 
 		switch b.Status {
 		case "Error":
-			status = writer.Colorize(magentaColor, "Error")
+			status = writer.Colorize(magentaColor, messages.Get("reporter.status.error"))
 		case "Pass":
-			status = writer.Colorize(greenColor, "Passed")
+			status = writer.Colorize(greenColor, messages.Get("reporter.status.pass"))
+		case "Flaky":
+			status = writer.Colorize(cyanColor, messages.Get("reporter.status.flaky"))
 		case "Fail":
-			status = writer.Colorize(redColor, "Failed")
+			status = writer.Colorize(redColor, messages.Get("reporter.status.fail"))
 		default:
-			status = writer.Colorize(yellowColor, "Skipped")
+			status = writer.Colorize(yellowColor, messages.Get("reporter.status.skip"))
 		}
 
 		testName := b.Title
@@ -91,7 +97,12 @@ func printScoreCard(benchmarks []*benchmark.Benchmark) {
 	}
 
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"No.", "ID", "Test", "Result"})
+	table.SetHeader([]string{
+		messages.Get("reporter.table.no"),
+		messages.Get("reporter.table.id"),
+		messages.Get("reporter.table.test"),
+		messages.Get("reporter.table.result"),
+	})
 	table.SetAutoWrapText(false)
 
 	for _, v := range data {
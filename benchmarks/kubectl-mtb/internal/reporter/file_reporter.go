@@ -0,0 +1,202 @@
+package reporter
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/internal/metadata"
+)
+
+// fileResult is the structured, CI-ingestible view of a SuiteSummary and its TestSummaries shared
+// by the JSON and YAML reporters. It's a flattened copy rather than a serialization of
+// SuiteSummary/TestSummary directly, since neither of those is safe to marshal (both hold errors
+// and a *benchmark.Benchmark with unexported fields).
+type fileResult struct {
+	Suite     string             `json:"suite" yaml:"suite"`
+	Namespace string             `json:"namespace" yaml:"namespace"`
+	User      string             `json:"user" yaml:"user"`
+	RunTime   string             `json:"runTime" yaml:"runTime"`
+	Passed    int                `json:"passed" yaml:"passed"`
+	Failed    int                `json:"failed" yaml:"failed"`
+	Skipped   int                `json:"skipped" yaml:"skipped"`
+	Errors    int                `json:"errors" yaml:"errors"`
+	Metadata  *metadata.Metadata `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Tests     []fileTestResult   `json:"tests" yaml:"tests"`
+}
+
+type fileTestResult struct {
+	ID           string                 `json:"id" yaml:"id"`
+	Title        string                 `json:"title" yaml:"title"`
+	Category     string                 `json:"category" yaml:"category"`
+	ProfileLevel int                    `json:"profileLevel" yaml:"profileLevel"`
+	Status       string                 `json:"status" yaml:"status"`
+	Error        string                 `json:"error,omitempty" yaml:"error,omitempty"`
+	Overrides    map[string]interface{} `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+}
+
+func newFileTestResult(testSummary *TestSummary) fileTestResult {
+	tr := fileTestResult{
+		ID:           testSummary.Benchmark.ID,
+		Title:        testSummary.Benchmark.Title,
+		Category:     testSummary.Benchmark.Category,
+		ProfileLevel: testSummary.Benchmark.ProfileLevel,
+		Status:       testSummary.Benchmark.Status,
+		Overrides:    testSummary.Overrides,
+	}
+	if testSummary.ValidationError != nil {
+		tr.Error = testSummary.ValidationError.Error()
+	} else if testSummary.TestError != nil {
+		tr.Error = testSummary.TestError.Error()
+	}
+	return tr
+}
+
+// structuredReporter accumulates a fileResult over the run and writes it to path as either JSON
+// or YAML once the suite ends, depending on format. It backs both NewJSONReporter and
+// NewYAMLReporter since the two only differ in how the result is marshaled.
+type structuredReporter struct {
+	format  string
+	path    string
+	results fileResult
+}
+
+// NewJSONReporter returns a Reporter that writes the suite results to path as JSON.
+func NewJSONReporter(path string) Reporter {
+	return &structuredReporter{format: "json", path: path}
+}
+
+// NewYAMLReporter returns a Reporter that writes the suite results to path as YAML.
+func NewYAMLReporter(path string) Reporter {
+	return &structuredReporter{format: "yaml", path: path}
+}
+
+// SuiteWillBegin records the suite-level metadata that isn't available in SuiteDidEnd.
+func (r *structuredReporter) SuiteWillBegin(suiteSummary *SuiteSummary) {
+	r.results.Suite = suiteSummary.Suite.Title
+	r.results.Namespace = suiteSummary.Namespace
+	r.results.User = suiteSummary.User
+	r.results.Metadata = suiteSummary.Metadata
+}
+
+// TestWillRun records the result of a single benchmark for the eventual report.
+func (r *structuredReporter) TestWillRun(testSummary *TestSummary) {
+	r.results.Tests = append(r.results.Tests, newFileTestResult(testSummary))
+}
+
+// SuiteDidEnd finishes assembling the report and writes it to r.path.
+func (r *structuredReporter) SuiteDidEnd(suiteSummary *SuiteSummary) {
+	r.results.RunTime = suiteSummary.RunTime.String()
+	r.results.Passed = suiteSummary.NumberOfPassedTests
+	r.results.Failed = suiteSummary.NumberOfFailedTests
+	r.results.Skipped = suiteSummary.NumberOfSkippedTests
+	r.results.Errors = suiteSummary.NumberOfFailedValidations
+
+	var out []byte
+	var err error
+	switch r.format {
+	case "yaml":
+		out, err = yaml.Marshal(r.results)
+	default:
+		out, err = json.MarshalIndent(r.results, "", "  ")
+	}
+	if err != nil {
+		writer.Println(0, writer.Colorize(redColor, "Failed to marshal %s report: %v", r.format, err))
+		return
+	}
+	if err := ioutil.WriteFile(r.path, out, 0644); err != nil {
+		writer.Println(0, writer.Colorize(redColor, "Failed to write %s report to %s: %v", r.format, r.path, err))
+		return
+	}
+	writer.Println(0, writer.Colorize(boldStyle, "Wrote %s report to %s", r.format, r.path))
+}
+
+// junitTestSuite and junitTestCase model the subset of the JUnit XML schema that CI systems (e.g.
+// Jenkins, GitLab, GitHub Actions) expect for pass/fail reporting.
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Detail  string `xml:",chardata"`
+}
+
+// JUnitReporter accumulates the run as a junitTestSuite and writes it to path as JUnit XML once
+// the suite ends.
+type JUnitReporter struct {
+	path  string
+	suite junitTestSuite
+}
+
+// NewJUnitReporter returns a Reporter that writes the suite results to path as JUnit XML.
+func NewJUnitReporter(path string) *JUnitReporter {
+	return &JUnitReporter{path: path}
+}
+
+// SuiteWillBegin records the suite title used as the testsuite name.
+func (r *JUnitReporter) SuiteWillBegin(suiteSummary *SuiteSummary) {
+	r.suite.Name = suiteSummary.Suite.Title
+}
+
+// TestWillRun records the result of a single benchmark as a JUnit testcase.
+func (r *JUnitReporter) TestWillRun(testSummary *TestSummary) {
+	tc := junitTestCase{
+		Name:      testSummary.Benchmark.Title,
+		ClassName: fmt.Sprintf("%s.%s", testSummary.Benchmark.Category, testSummary.Benchmark.ID),
+	}
+	switch {
+	case testSummary.ValidationError != nil:
+		tc.Error = &junitFailure{Message: "validation error", Detail: testSummary.ValidationError.Error()}
+	case !testSummary.Validation:
+		tc.Skipped = &struct{}{}
+	case !testSummary.Test:
+		tc.Failure = &junitFailure{Message: "benchmark failed", Detail: errString(testSummary.TestError)}
+	}
+	r.suite.TestCases = append(r.suite.TestCases, tc)
+}
+
+// SuiteDidEnd finishes assembling the testsuite and writes it to r.path.
+func (r *JUnitReporter) SuiteDidEnd(suiteSummary *SuiteSummary) {
+	r.suite.Tests = suiteSummary.NumberOfTotalTests
+	r.suite.Failures = suiteSummary.NumberOfFailedTests
+	r.suite.Errors = suiteSummary.NumberOfFailedValidations
+	r.suite.Skipped = suiteSummary.NumberOfSkippedTests
+	r.suite.Time = suiteSummary.RunTime.String()
+
+	out, err := xml.MarshalIndent(r.suite, "", "  ")
+	if err != nil {
+		writer.Println(0, writer.Colorize(redColor, "Failed to marshal junit report: %v", err))
+		return
+	}
+	out = append([]byte(xml.Header), out...)
+	if err := ioutil.WriteFile(r.path, out, 0644); err != nil {
+		writer.Println(0, writer.Colorize(redColor, "Failed to write junit report to %s: %v", r.path, err))
+		return
+	}
+	writer.Println(0, writer.Colorize(boldStyle, "Wrote junit report to %s", r.path))
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,140 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/internal/metadata"
+)
+
+// PublishAuthTokenEnv is the environment variable read for the bearer token sent with published
+// results, if any. It's read from the environment rather than a flag so the token never ends up
+// in shell history or process listings.
+const PublishAuthTokenEnv = "MTB_PUBLISH_TOKEN"
+
+const publishRetries = 3
+const publishRetryBackoff = 2 * time.Second
+
+// publishResult is the payload sent to the --publish endpoint. It's a flattened, JSON-friendly
+// view of a SuiteSummary and its TestSummaries, since neither is safe to serialize directly (both
+// hold errors and a *benchmark.Benchmark with unexported fields).
+type publishResult struct {
+	Suite     string              `json:"suite"`
+	Namespace string              `json:"namespace"`
+	User      string              `json:"user"`
+	RunTime   string              `json:"runTime"`
+	Passed    int                 `json:"passed"`
+	Failed    int                 `json:"failed"`
+	Skipped   int                 `json:"skipped"`
+	Errors    int                 `json:"errors"`
+	Metadata  *metadata.Metadata  `json:"metadata,omitempty"`
+	Tests     []publishTestResult `json:"tests"`
+}
+
+type publishTestResult struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// PublishReporter sends the suite results to an HTTP(S) endpoint as a JSON POST - e.g. an object
+// storage bucket endpoint that accepts POST uploads, or an HTTP collector - so results from many
+// clusters can be aggregated centrally without a wrapper script.
+type PublishReporter struct {
+	url     string
+	client  *http.Client
+	results publishResult
+}
+
+// NewPublishReporter returns the pointer of PublishReporter. url is the destination given via
+// --publish.
+func NewPublishReporter(url string) *PublishReporter {
+	return &PublishReporter{
+		url:    url,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// SuiteWillBegin records the suite-level metadata that isn't available in SuiteDidEnd.
+func (p *PublishReporter) SuiteWillBegin(suiteSummary *SuiteSummary) {
+	p.results.Suite = suiteSummary.Suite.Title
+	p.results.Namespace = suiteSummary.Namespace
+	p.results.User = suiteSummary.User
+	p.results.Metadata = suiteSummary.Metadata
+}
+
+// TestWillRun records the result of a single benchmark for the eventual payload.
+func (p *PublishReporter) TestWillRun(testSummary *TestSummary) {
+	tr := publishTestResult{
+		ID:     testSummary.Benchmark.ID,
+		Title:  testSummary.Benchmark.Title,
+		Status: testSummary.Benchmark.Status,
+	}
+	if testSummary.ValidationError != nil {
+		tr.Error = testSummary.ValidationError.Error()
+	} else if testSummary.TestError != nil {
+		tr.Error = testSummary.TestError.Error()
+	}
+	p.results.Tests = append(p.results.Tests, tr)
+}
+
+// SuiteDidEnd finishes assembling the payload and publishes it to the configured endpoint.
+func (p *PublishReporter) SuiteDidEnd(suiteSummary *SuiteSummary) {
+	p.results.RunTime = suiteSummary.RunTime.String()
+	p.results.Passed = suiteSummary.NumberOfPassedTests
+	p.results.Failed = suiteSummary.NumberOfFailedTests
+	p.results.Skipped = suiteSummary.NumberOfSkippedTests
+	p.results.Errors = suiteSummary.NumberOfFailedValidations
+
+	if err := p.publish(); err != nil {
+		writer.Println(0, writer.Colorize(redColor, "Failed to publish results to %s: %v", p.url, err))
+		return
+	}
+	writer.Println(0, writer.Colorize(boldStyle, "Published results to %s", p.url))
+}
+
+// publish serializes the results and POSTs them to p.url, retrying on transient failures.
+func (p *PublishReporter) publish() error {
+	body, err := json.Marshal(p.results)
+	if err != nil {
+		return fmt.Errorf("marshaling results: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= publishRetries; attempt++ {
+		if lastErr != nil {
+			time.Sleep(publishRetryBackoff)
+		}
+		if lastErr = p.send(body); lastErr == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", publishRetries, lastErr)
+}
+
+func (p *PublishReporter) send(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token := os.Getenv(PublishAuthTokenEnv); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
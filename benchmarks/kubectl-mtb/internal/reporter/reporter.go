@@ -13,19 +13,73 @@ type Reporter interface {
 	SuiteDidEnd(suiteSummary *SuiteSummary)
 }
 
-// GetReporters returns the Reporter array as per the user input
-func GetReporters(reporters []string) ([]Reporter, error) {
+// ReporterConfig carries the configuration built-in sinks need to construct themselves. A
+// downstream sink that needs more than this (e.g. an API token for a ticketing system) is
+// expected to read it itself, from its own flags or environment variables, the same way
+// PublishReporter reads PublishAuthTokenEnv rather than taking it as a parameter here.
+type ReporterConfig struct {
+	// OutputFile is the base path given via --output-file; file-based sinks append their own
+	// extension to it.
+	OutputFile string
+	// PublishURL is the destination given via --publish, if any.
+	PublishURL string
+}
+
+// ReporterFactory constructs the Reporter registered under a given sink name.
+type ReporterFactory func(cfg ReporterConfig) (Reporter, error)
+
+// sinks holds the registered factory for every named sink that can be requested via --out.
+var sinks = map[string]ReporterFactory{}
+
+// RegisterSink registers the factory that builds the Reporter for the sink named name, so
+// `--out <name>` (or, for "publish", a non-empty --publish) constructs it. Downstream forks call
+// this from an init() in their own package to add proprietary sinks (e.g. ServiceNow, Jira)
+// without patching GetReporters. Registering under a name that's already taken overwrites it,
+// which lets a fork replace a built-in sink as well as add a new one.
+func RegisterSink(name string, factory ReporterFactory) {
+	sinks[name] = factory
+}
+
+func init() {
+	RegisterSink("policyreport", func(cfg ReporterConfig) (Reporter, error) { return NewPolicyReporter(), nil })
+	RegisterSink("json", func(cfg ReporterConfig) (Reporter, error) { return NewJSONReporter(cfg.OutputFile + ".json"), nil })
+	RegisterSink("yaml", func(cfg ReporterConfig) (Reporter, error) { return NewYAMLReporter(cfg.OutputFile + ".yaml"), nil })
+	RegisterSink("junit", func(cfg ReporterConfig) (Reporter, error) { return NewJUnitReporter(cfg.OutputFile + ".xml"), nil })
+	RegisterSink("publish", func(cfg ReporterConfig) (Reporter, error) { return NewPublishReporter(cfg.PublishURL), nil })
+}
+
+// GetReporters returns the Reporter array as per the user input. Unknown names in reporters are
+// silently skipped, same as an unset --out. The "publish" sink is added automatically whenever
+// cfg.PublishURL is set, regardless of whether "publish" was named in reporters, to preserve the
+// pre-existing behavior of the --publish flag.
+func GetReporters(reporters []string, cfg ReporterConfig) ([]Reporter, error) {
 	var reportersArray []Reporter
 
 	// Add the default reporter
 	reportersArray = append(reportersArray, NewDefaultReporter())
 
+	named := make(map[string]bool, len(reporters))
 	for _, r := range reporters {
-		switch r {
-		case "policyreport":
-			reportersArray = append(reportersArray, NewPolicyReporter())
+		named[r] = true
+		factory, ok := sinks[r]
+		if !ok {
+			continue
+		}
+		rep, err := factory(cfg)
+		if err != nil {
+			return nil, err
+		}
+		reportersArray = append(reportersArray, rep)
+	}
+
+	if cfg.PublishURL != "" && !named["publish"] {
+		rep, err := sinks["publish"](cfg)
+		if err != nil {
+			return nil, err
 		}
+		reportersArray = append(reportersArray, rep)
 	}
+
 	return reportersArray, nil
 }
 
@@ -44,4 +98,4 @@ const lightGrayColor = "\x1b[37m"
 const lilac = "\033[38;2;200;162;200m"
 const tick = "\u2705"
 const cross = "\u274c"
-const skipped = "\u23ed"
\ No newline at end of file
+const skipped = "\u23ed"
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/creasty/defaults"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/internal/metadata"
 	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark"
 	benchmarksuite "sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark_suite"
 )
@@ -20,6 +21,9 @@ type SuiteSummary struct {
 	NumberOfFailedValidations int
 	RunTime                   time.Duration
 	Suite                     *benchmarksuite.BenchmarkSuite
+	// Metadata is the cluster and tool metadata collected for this run (see
+	// internal/metadata.Collect), nil if the caller didn't collect any, e.g. in unit tests.
+	Metadata *metadata.Metadata
 }
 
 // TestSummary summaries the result of benchmark
@@ -30,6 +34,9 @@ type TestSummary struct {
 	TestError       error
 	RunTime         time.Duration
 	Benchmark       *benchmark.Benchmark
+	// Overrides records the expected-value overrides, if any, that were in effect for this
+	// benchmark, so the report shows when a cluster's non-default configuration was accounted for.
+	Overrides map[string]interface{}
 }
 
 // SetDefaults usage := https://github.com/creasty/defaults#usage
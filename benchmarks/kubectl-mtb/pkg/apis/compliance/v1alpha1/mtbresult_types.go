@@ -0,0 +1,100 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Constants for the MTBResult resource type.
+const (
+	MTBResults    = "mtbresults"
+	MTBResultKind = "MTBResult"
+)
+
+// MTBResultSpec defines the desired state of MTBResult. It has no user-settable fields - an
+// MTBResult is entirely owned and written by MTBRunReconciler.
+type MTBResultSpec struct {
+}
+
+// BenchmarkOutcome is the outcome of running a single benchmark against the namespace, mirroring
+// the fields reporter.TestSummary surfaces for a CLI run.
+type BenchmarkOutcome struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Category string `json:"category"`
+
+	// Status is one of "Pass", "Fail" or "Error", matching the values benchmark.Benchmark's own
+	// Status field takes during a CLI run. Skipped benchmarks get no BenchmarkOutcome; they are
+	// only counted in MTBResultStatus.Skipped.
+	Status string `json:"status"`
+
+	// Remediation carries the benchmark's own remediation text, so a tenant reading their
+	// MTBResult doesn't need read access to the (cluster-scoped) benchmark suite definition to
+	// learn how to fix a failure.
+	// +optional
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// MTBResultStatus is a point-in-time snapshot of the benchmark suite's outcome for this
+// namespace, refreshed every time MTBRunReconciler sweeps the MTBRun that selected it.
+type MTBResultStatus struct {
+	// LastRunTime is when this snapshot was computed.
+	// +optional
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+
+	// TenantUser is the identity that was impersonated to produce this snapshot, read from the
+	// namespace's TenantUserAnnotation at run time.
+	TenantUser string `json:"tenantUser"`
+
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Errored int `json:"errored"`
+	Skipped int `json:"skipped"`
+
+	// Results holds one BenchmarkOutcome per benchmark that was run, in suite order.
+	// +optional
+	Results []BenchmarkOutcome `json:"results,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=mtbresults,scope=Namespaced
+// +kubebuilder:subresource:status
+
+// MTBResult is the most recent compliance benchmark outcome for a single tenant namespace,
+// written by MTBRunReconciler. Tenants who don't have RBAC to run "kubectl mtb run" or to read
+// the cluster-scoped MTBRun that selected their namespace can still read their own compliance
+// standing via ordinary namespaced RBAC on this object.
+type MTBResult struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MTBResultSpec   `json:"spec,omitempty"`
+	Status MTBResultStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MTBResultList contains a list of MTBResult.
+type MTBResultList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MTBResult `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MTBResult{}, &MTBResultList{})
+}
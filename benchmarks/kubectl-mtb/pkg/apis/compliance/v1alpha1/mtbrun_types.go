@@ -0,0 +1,105 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Constants for the MTBRun resource type.
+const (
+	MTBRuns    = "mtbruns"
+	MTBRunKind = "MTBRun"
+
+	// DefaultProfileLevel is the profile level used when MTBRunSpec.ProfileLevel is unset, matching
+	// the CLI's own "-p/--profile-level" default.
+	DefaultProfileLevel = 3
+
+	// DefaultIntervalSeconds is the requeue period used when MTBRunSpec.IntervalSeconds is unset.
+	DefaultIntervalSeconds = 3600
+
+	// TenantUserAnnotation is the namespace annotation MTBRunReconciler reads to learn which
+	// identity to impersonate when benchmarking that namespace, the in-cluster equivalent of the
+	// CLI's "--as" flag. A namespace matched by an MTBRun's NamespaceSelector but missing this
+	// annotation is skipped rather than benchmarked as cluster-admin, since that would defeat the
+	// point of testing what the tenant itself can and can't do.
+	TenantUserAnnotation = "compliance.mtb.x-k8s.io/tenant-user"
+)
+
+// MTBRunSpec defines which tenant namespaces to continuously benchmark and how often.
+type MTBRunSpec struct {
+	// NamespaceSelector selects the namespaces to benchmark. Every matched namespace must carry
+	// the TenantUserAnnotation naming the identity to impersonate; namespaces without it are
+	// skipped. An empty selector matches every namespace in the cluster.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ProfileLevel bounds which benchmarks are run to those at or below this profile level, the
+	// same as the CLI's "-p/--profile-level" flag. Defaults to DefaultProfileLevel.
+	// +optional
+	ProfileLevel int `json:"profileLevel,omitempty"`
+
+	// IntervalSeconds is how often the benchmark suite is re-run against every matched namespace.
+	// Defaults to DefaultIntervalSeconds.
+	// +optional
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+}
+
+// MTBRunStatus reports the outcome of the most recent sweep across every namespace matched by
+// NamespaceSelector. Per-namespace results are written to an MTBResult in each namespace itself,
+// not here, since RBAC to view compliance status should follow ordinary namespaced RBAC rather
+// than requiring cluster-wide read access to this cluster-scoped resource.
+type MTBRunStatus struct {
+	// LastRunTime is when this sweep last completed.
+	// +optional
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+
+	// NamespacesChecked is the number of matched namespaces that were benchmarked in the last
+	// sweep.
+	NamespacesChecked int `json:"namespacesChecked"`
+
+	// NamespacesSkipped is the number of matched namespaces skipped in the last sweep because they
+	// were missing TenantUserAnnotation.
+	NamespacesSkipped int `json:"namespacesSkipped"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=mtbruns,scope=Cluster
+// +kubebuilder:subresource:status
+
+// MTBRun configures continuous compliance monitoring: MTBRunReconciler periodically runs the
+// kubectl-mtb benchmark suite against every namespace matched by NamespaceSelector, impersonating
+// the identity named by that namespace's TenantUserAnnotation, and publishes the outcome as an
+// MTBResult in the namespace itself.
+type MTBRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MTBRunSpec   `json:"spec,omitempty"`
+	Status MTBRunStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MTBRunList contains a list of MTBRun.
+type MTBRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MTBRun `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MTBRun{}, &MTBRunList{})
+}
@@ -9,20 +9,44 @@ import (
 
 // Benchmark consists the benchmark information like benchmark id, name, remediation etc.
 type Benchmark struct {
-	ID            string `yaml:"id"`
-	Title         string `yaml:"title"`
-	BenchmarkType string `yaml:"benchmarkType"`
-	Category      string `yaml:"category"`
-	Description   string `yaml:"description"`
-	Remediation   string `yaml:"remediation"`
-	ProfileLevel  int    `yaml:"profileLevel"`
-	Status        string `yaml:"status"`
-	Rationale     string `yaml:"rationale"`
-	Audit         string `yaml:"audit"`
-	NamespaceRequired int `yaml:"namespaceRequired"`
-	PreRun        func(types.RunOptions) error
-	Run           func(types.RunOptions) error
-	PostRun       func(types.RunOptions) error
+	ID                string `yaml:"id"`
+	Title             string `yaml:"title"`
+	BenchmarkType     string `yaml:"benchmarkType"`
+	Category          string `yaml:"category"`
+	Description       string `yaml:"description"`
+	Remediation       string `yaml:"remediation"`
+	ProfileLevel      int    `yaml:"profileLevel"`
+	Status            string `yaml:"status"`
+	Rationale         string `yaml:"rationale"`
+	Audit             string `yaml:"audit"`
+	NamespaceRequired int    `yaml:"namespaceRequired"`
+	PreRun            func(types.RunOptions) error
+	Run               func(types.RunOptions) error
+	PostRun           func(types.RunOptions) error
+	// APICalls declares, for the benefit of "mtb run --explain", the API requests this benchmark's
+	// PreRun/Run/PostRun make: as which identity, against which group/version/resource/verb, and
+	// whether the request is a server-side dry-run. It's maintained by hand alongside the benchmark
+	// code, so a security reviewer can see what the tool will do before granting it access to run.
+	APICalls []APICall
+}
+
+// APICall describes a single API request, or class of requests, that a benchmark makes.
+type APICall struct {
+	// Impersonate is the identity the request is made as, e.g. "tenant", "other-tenant" or
+	// "cluster-admin".
+	Impersonate string
+	Verb        string
+	Group       string
+	Version     string
+	Resource    string
+	// Namespace is the namespace the request targets, or "" for a cluster-scoped request.
+	Namespace string
+	// DryRun is true if the request is submitted with server-side dry-run, so it never actually
+	// persists a change.
+	DryRun bool
+	// Note carries any extra detail a static description can't, e.g. that the resource is chosen
+	// at runtime via discovery.
+	Note string
 }
 
 // ReadConfig reads the yaml representation of struct from []file
@@ -0,0 +1,189 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package external loads benchmarks that are declared as YAML specs on disk rather than compiled
+// into the kubectl-mtb binary, so a platform team can add org-specific checks without forking.
+package external
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/types"
+)
+
+// Spec is the declarative, on-disk representation of an external benchmark. Unlike the built-in
+// benchmarks under test/benchmarks, it carries no Go code: Resource and ExpectedResult are enough
+// to derive a PreRun/Run pair generically.
+type Spec struct {
+	ID                string `json:"id"`
+	Title             string `json:"title"`
+	BenchmarkType     string `json:"benchmarkType"`
+	Category          string `json:"category"`
+	Description       string `json:"description"`
+	Remediation       string `json:"remediation"`
+	ProfileLevel      int    `json:"profileLevel"`
+	Rationale         string `json:"rationale"`
+	Audit             string `json:"audit"`
+	NamespaceRequired int    `json:"namespaceRequired"`
+
+	// ExpectedResult is "deny" if a compliant cluster must reject Resource when the tenant tries
+	// to create it (the common case: proving some cluster-level guard rail is in place), or
+	// "allow" if the tenant must be able to create it.
+	ExpectedResult string `json:"expectedResult"`
+
+	// Resource is the manifest the benchmark attempts to create as the tenant, e.g. a Pod or
+	// NetworkPolicy. Its namespace is overwritten with the tenant namespace at run time.
+	Resource map[string]interface{} `json:"resource"`
+}
+
+// Load reads every *.yaml/*.yml file directly under dir and turns it into a *benchmark.Benchmark.
+// It doesn't recurse into subdirectories.
+func Load(dir string) ([]*benchmark.Benchmark, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read external benchmark directory %s: %v", dir, err)
+	}
+
+	var benchmarks []*benchmark.Benchmark
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(entry.Name())
+		if ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		raw, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", path, err)
+		}
+
+		var spec Spec
+		if err := sigsyaml.Unmarshal(raw, &spec); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+		}
+
+		b, err := newBenchmark(path, spec)
+		if err != nil {
+			return nil, err
+		}
+		benchmarks = append(benchmarks, b)
+	}
+
+	return benchmarks, nil
+}
+
+func newBenchmark(path string, spec Spec) (*benchmark.Benchmark, error) {
+	if spec.ID == "" {
+		return nil, fmt.Errorf("%s: id is required", path)
+	}
+	if spec.Resource == nil {
+		return nil, fmt.Errorf("%s: resource is required", path)
+	}
+	switch spec.ExpectedResult {
+	case "allow", "deny":
+	default:
+		return nil, fmt.Errorf("%s: expectedResult must be \"allow\" or \"deny\", got %q", path, spec.ExpectedResult)
+	}
+
+	obj := &unstructured.Unstructured{Object: spec.Resource}
+	gvk := obj.GroupVersionKind()
+	if gvk.Kind == "" {
+		return nil, fmt.Errorf("%s: resource is missing kind", path)
+	}
+
+	return &benchmark.Benchmark{
+		ID:                spec.ID,
+		Title:             spec.Title,
+		BenchmarkType:     spec.BenchmarkType,
+		Category:          spec.Category,
+		Description:       spec.Description,
+		Remediation:       spec.Remediation,
+		ProfileLevel:      spec.ProfileLevel,
+		Rationale:         spec.Rationale,
+		Audit:             spec.Audit,
+		NamespaceRequired: spec.NamespaceRequired,
+
+		APICalls: []benchmark.APICall{
+			{Impersonate: "tenant", Verb: "create", Group: gvk.Group, Version: gvk.Version, Resource: gvk.Kind,
+				Namespace: "<tenant namespace>", DryRun: true, Note: "external benchmark loaded from " + path},
+		},
+
+		PreRun: func(options types.RunOptions) error {
+			return nil
+		},
+
+		Run: func(options types.RunOptions) error {
+			return run(options, obj.DeepCopy(), spec.ExpectedResult)
+		},
+	}, nil
+}
+
+func run(options types.RunOptions, obj *unstructured.Unstructured, expectedResult string) error {
+	obj.SetNamespace(options.TenantNamespace)
+	if obj.GetName() == "" && obj.GetGenerateName() == "" {
+		obj.SetGenerateName("mtb-external-")
+	}
+
+	gvk := obj.GroupVersionKind()
+	mapping, err := restMapping(options.ClusterAdminClient, gvk)
+	if err != nil {
+		return fmt.Errorf("failed to resolve resource kind for %s: %v", gvk, err)
+	}
+
+	_, err = options.Tenant1DynamicClient.Resource(*mapping).Namespace(options.TenantNamespace).
+		Create(context.TODO(), obj, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+
+	switch expectedResult {
+	case "deny":
+		if err == nil {
+			return fmt.Errorf("tenant must not be allowed to create %s %q", gvk.Kind, obj.GetName())
+		}
+		options.Logger.Debug("Test passed: ", err.Error())
+	case "allow":
+		if err != nil {
+			return fmt.Errorf("tenant must be allowed to create %s %q: %v", gvk.Kind, obj.GetName(), err)
+		}
+		options.Logger.Debug("Test passed")
+	}
+	return nil
+}
+
+// restMapping resolves a GroupVersionKind (as parsed from a resource's apiVersion/kind) to the
+// GroupVersionResource the dynamic client needs, using cluster-admin discovery.
+func restMapping(client *kubernetes.Clientset, gvk schema.GroupVersionKind) (*schema.GroupVersionResource, error) {
+	groupResources, err := restmapper.GetAPIGroupResources(client.Discovery())
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := restmapper.NewDiscoveryRESTMapper(groupResources).RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	return &mapping.Resource, nil
+}
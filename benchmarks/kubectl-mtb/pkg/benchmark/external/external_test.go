@@ -0,0 +1,125 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const validSpec = `
+id: MTB-EXT-1
+title: Block use of the foo annotation
+category: Custom
+profileLevel: 1
+namespaceRequired: 1
+rationale: org-specific check
+remediation: install the foo admission webhook
+audit: attempt to create a pod with the foo annotation; creation must fail
+expectedResult: deny
+resource:
+  apiVersion: v1
+  kind: Pod
+  metadata:
+    name: mtb-ext-test
+  spec:
+    containers:
+    - name: c
+      image: busybox
+`
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mtb-external")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFile(t, dir, "block-foo.yaml", validSpec)
+	writeFile(t, dir, "README.md", "not a benchmark")
+
+	benchmarks, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(benchmarks) != 1 {
+		t.Fatalf("expected 1 benchmark, got %d", len(benchmarks))
+	}
+
+	b := benchmarks[0]
+	if b.ID != "MTB-EXT-1" {
+		t.Errorf("expected ID MTB-EXT-1, got %s", b.ID)
+	}
+	if b.PreRun == nil || b.Run == nil {
+		t.Errorf("expected PreRun and Run to be set")
+	}
+	if len(b.APICalls) != 1 || b.APICalls[0].Resource != "Pod" {
+		t.Errorf("expected a single APICall describing the Pod, got %+v", b.APICalls)
+	}
+}
+
+func TestLoadRejectsInvalidSpecs(t *testing.T) {
+	testcases := map[string]string{
+		"missing id": `
+expectedResult: deny
+resource:
+  apiVersion: v1
+  kind: Pod
+`,
+		"missing resource": `
+id: MTB-EXT-2
+expectedResult: deny
+`,
+		"bad expectedResult": `
+id: MTB-EXT-3
+expectedResult: sometimes
+resource:
+  apiVersion: v1
+  kind: Pod
+`,
+		"resource missing kind": `
+id: MTB-EXT-4
+expectedResult: deny
+resource:
+  apiVersion: v1
+`,
+	}
+
+	for name, content := range testcases {
+		t.Run(name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "mtb-external")
+			if err != nil {
+				t.Fatalf("failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(dir)
+
+			writeFile(t, dir, "spec.yaml", content)
+
+			if _, err := Load(dir); err == nil {
+				t.Errorf("expected an error, got none")
+			}
+		})
+	}
+}
@@ -15,6 +15,18 @@ var verbs = []string{"get", "update"}
 
 var b = &benchmark.Benchmark{
 
+	APICalls: []benchmark.APICall{
+		{
+			Impersonate: "tenant",
+			Verb:        "get, update",
+			Group:       "*",
+			Version:     "*",
+			Resource:    "*",
+			DryRun:      true,
+			Note:        "checked (via a dry-run SelfSubjectAccessReview) against every cluster-scoped resource the API server reports",
+		},
+	},
+
 	PreRun: func(options types.RunOptions) error {
 
 		return nil
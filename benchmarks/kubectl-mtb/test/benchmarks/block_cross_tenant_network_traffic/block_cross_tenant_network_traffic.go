@@ -0,0 +1,167 @@
+package blockcrosstenantnetworktraffic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/bundle/box"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test/utils"
+	podutil "sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test/utils/resources/pod"
+	probeutil "sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test/utils/resources/probe"
+	serviceutil "sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test/utils/resources/service"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/types"
+)
+
+// targetPort is the port the probe target pod listens on, and the port both TCP probes connect to.
+const targetPort = 8080
+
+// targetLabelKey/targetLabelValue select the target pod from the probe Service; they only need to
+// be unique within the target pod itself, since the Service's selector is scoped to its namespace.
+const targetLabelKey = "mtb-probe-target"
+const targetLabelValue = "true"
+
+// probeTimeout bounds how long each TCP probe pod is given to connect before it's considered done.
+const probeTimeout = 10 * time.Second
+
+// targetReadyTimeout bounds how long we wait for the probe target pod to be scheduled and get an
+// IP, before giving up.
+const targetReadyTimeout = 30 * time.Second
+
+var b = &benchmark.Benchmark{
+
+	APICalls: []benchmark.APICall{
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "pods", Namespace: "<tenant namespace>", DryRun: true, Note: "SelfSubjectAccessReview check, in PreRun"},
+		{Impersonate: "cluster-admin", Verb: "create", Group: "", Version: "v1", Resource: "pods, services", Namespace: "<other tenant's namespace>", Note: "creates a real listening pod and a Service in front of it, as probe targets"},
+		{Impersonate: "cluster-admin", Verb: "delete", Group: "", Version: "v1", Resource: "pods, services", Namespace: "<other tenant's namespace>", Note: "deletes the probe targets on cleanup"},
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "pods", Namespace: "<tenant namespace>", Note: "runs short-lived pods that each attempt a TCP connection to the probe targets"},
+	},
+
+	PreRun: func(options types.RunOptions) error {
+
+		resource := utils.GroupResource{
+			APIGroup: "",
+			APIResource: metav1.APIResource{
+				Name: "pods",
+			},
+		}
+
+		access, msg, err := utils.RunAccessCheck(options.Tenant1Client, options.TenantNamespace, resource, "create")
+		if err != nil {
+			options.Logger.Debug(err.Error())
+			return err
+		}
+		if !access {
+			return fmt.Errorf(msg)
+		}
+
+		return nil
+	},
+
+	Run: func(options types.RunOptions) error {
+
+		// Stand up a real, listening pod in the other tenant's namespace, and a Service in front of
+		// it, so there's a concrete pod IP and Service DNS name to probe.
+		targetSpec := &podutil.PodSpec{
+			NS:      options.OtherNamespace,
+			Command: fmt.Sprintf("while true; do nc -lk -p %d; done", targetPort),
+		}
+		if err := targetSpec.SetDefaults(); err != nil {
+			return err
+		}
+		target := targetSpec.MakeSecPod()
+		target.Labels = map[string]string{targetLabelKey: targetLabelValue}
+		target.Spec.RestartPolicy = v1.RestartPolicyAlways
+		target, err := options.ClusterAdminClient.CoreV1().Pods(options.OtherNamespace).Create(context.TODO(), target, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to set up probe target pod: %v", err)
+		}
+		defer func() {
+			_ = options.ClusterAdminClient.CoreV1().Pods(options.OtherNamespace).Delete(context.TODO(), target.Name, metav1.DeleteOptions{})
+		}()
+
+		svcSpec := &serviceutil.ServiceConfig{Type: v1.ServiceTypeClusterIP, Selector: map[string]string{targetLabelKey: targetLabelValue}}
+		svc := svcSpec.CreateServiceSpec()
+		svc, err = options.ClusterAdminClient.CoreV1().Services(options.OtherNamespace).Create(context.TODO(), svc, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to set up probe target service: %v", err)
+		}
+		defer func() {
+			_ = options.ClusterAdminClient.CoreV1().Services(options.OtherNamespace).Delete(context.TODO(), svc.Name, metav1.DeleteOptions{})
+		}()
+
+		targetIP, err := waitForPodIP(options.ClusterAdminClient, options.OtherNamespace, target.Name, targetReadyTimeout)
+		if err != nil {
+			return fmt.Errorf("probe target pod never became ready: %v", err)
+		}
+
+		// A tenant's pods must not be able to reach the other tenant's pod, whether by pod IP or by
+		// Service DNS name.
+		podResult, err := probeutil.RunTCPProbe(options.Tenant1Client, probeutil.TCPSpec{
+			NS:      options.TenantNamespace,
+			Host:    targetIP,
+			Port:    targetPort,
+			Timeout: probeTimeout,
+		})
+		if err != nil {
+			options.Logger.Debug(err.Error())
+			return err
+		}
+		if podResult.Reachable {
+			return fmt.Errorf("Tenant must be unable to reach a pod IP in another tenant's namespace: %s", podResult.Output)
+		}
+		options.Logger.Debug("Test passed: pod IP was unreachable: ", podResult.Output)
+
+		dnsName := fmt.Sprintf("%s.%s.svc.cluster.local", svc.Name, options.OtherNamespace)
+		svcResult, err := probeutil.RunTCPProbe(options.Tenant1Client, probeutil.TCPSpec{
+			NS:      options.TenantNamespace,
+			Host:    dnsName,
+			Port:    targetPort,
+			Timeout: probeTimeout,
+		})
+		if err != nil {
+			options.Logger.Debug(err.Error())
+			return err
+		}
+		if svcResult.Reachable {
+			return fmt.Errorf("Tenant must be unable to reach a Service in another tenant's namespace: %s", svcResult.Output)
+		}
+		options.Logger.Debug("Test passed: Service DNS name was unreachable: ", svcResult.Output)
+
+		return nil
+	},
+}
+
+// waitForPodIP polls until the given pod has been assigned a pod IP, or the timeout elapses.
+func waitForPodIP(client *kubernetes.Clientset, ns, name string, timeout time.Duration) (string, error) {
+	var ip string
+	err := wait.PollImmediate(2*time.Second, timeout, func() (bool, error) {
+		pod, err := client.CoreV1().Pods(ns).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if pod.Status.PodIP == "" {
+			return false, nil
+		}
+		ip = pod.Status.PodIP
+		return true, nil
+	})
+	return ip, err
+}
+
+func init() {
+	// Get the []byte representation of a file, or an error if it doesn't exist:
+	err := b.ReadConfig(box.Get("block_cross_tenant_network_traffic/config.yaml"))
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+
+	test.BenchmarkSuite.Add(b)
+}
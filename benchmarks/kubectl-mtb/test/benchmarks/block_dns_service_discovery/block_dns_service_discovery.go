@@ -0,0 +1,108 @@
+package blockdnsservicediscovery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/bundle/box"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test/utils"
+	probeutil "sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test/utils/resources/probe"
+	serviceutil "sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test/utils/resources/service"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/types"
+)
+
+// probeTimeout bounds how long the DNS probe pod is given to resolve (or fail to resolve) the
+// SRV query before it's considered done.
+const probeTimeout = 10 * time.Second
+
+var verbs = []string{"get", "list"}
+
+var b = &benchmark.Benchmark{
+
+	APICalls: []benchmark.APICall{
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "pods", Namespace: "<tenant namespace>", DryRun: true, Note: "SelfSubjectAccessReview check, in PreRun"},
+		{Impersonate: "tenant", Verb: "get, list", Group: "", Version: "v1", Resource: "services, endpoints", Namespace: "<other tenant's namespace>", DryRun: true, Note: "SelfSubjectAccessReview checks, in Run"},
+		{Impersonate: "cluster-admin", Verb: "create", Group: "", Version: "v1", Resource: "services", Namespace: "<other tenant's namespace>", Note: "creates a real headless Service as a DNS probe target"},
+		{Impersonate: "cluster-admin", Verb: "delete", Group: "", Version: "v1", Resource: "services", Namespace: "<other tenant's namespace>", Note: "deletes the probe Service on cleanup"},
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "pods", Namespace: "<tenant namespace>", Note: "runs a short-lived pod that attempts a DNS SRV query for the probe Service"},
+	},
+
+	PreRun: func(options types.RunOptions) error {
+
+		resource := utils.GroupResource{
+			APIGroup: "",
+			APIResource: metav1.APIResource{
+				Name: "pods",
+			},
+		}
+
+		access, msg, err := utils.RunAccessCheck(options.Tenant1Client, options.TenantNamespace, resource, "create")
+		if err != nil {
+			options.Logger.Debug(err.Error())
+			return err
+		}
+		if !access {
+			return fmt.Errorf(msg)
+		}
+
+		return nil
+	},
+
+	Run: func(options types.RunOptions) error {
+
+		// A tenant must not be able to list or get Services/Endpoints belonging to another tenant
+		// via the API.
+		resources := []utils.GroupResource{
+			{APIGroup: "", APIResource: metav1.APIResource{Name: "services"}},
+			{APIGroup: "", APIResource: metav1.APIResource{Name: "endpoints"}},
+		}
+		if err := utils.CheckAccessOnResourcesInNamespace(options.Tenant1Client, options.OtherNamespace, resources, verbs); err != nil {
+			return err
+		}
+
+		// Create a headless Service in the other tenant's namespace so there's something concrete
+		// to probe for via DNS SRV.
+		svcSpec := &serviceutil.ServiceConfig{Type: v1.ServiceTypeClusterIP, Headless: true}
+		svc := svcSpec.CreateServiceSpec()
+		svc, err := options.ClusterAdminClient.CoreV1().Services(options.OtherNamespace).Create(context.TODO(), svc, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to set up probe target service: %v", err)
+		}
+		defer func() {
+			_ = options.ClusterAdminClient.CoreV1().Services(options.OtherNamespace).Delete(context.TODO(), svc.Name, metav1.DeleteOptions{})
+		}()
+
+		query := fmt.Sprintf("_http._tcp.%s.%s.svc.cluster.local", svc.Name, options.OtherNamespace)
+		result, err := probeutil.RunDNSSRVProbe(options.Tenant1Client, probeutil.SRVSpec{
+			NS:      options.TenantNamespace,
+			Query:   query,
+			Timeout: probeTimeout,
+		})
+		if err != nil {
+			options.Logger.Debug(err.Error())
+			return err
+		}
+		if result.Resolved {
+			return fmt.Errorf("Tenant must be unable to resolve SRV records for a Service in another tenant's namespace: %s", result.Output)
+		}
+		options.Logger.Debug("Test passed: SRV query did not resolve: ", result.Output)
+
+		return nil
+	},
+}
+
+func init() {
+	// Get the []byte representation of a file, or an error if it doesn't exist:
+	err := b.ReadConfig(box.Get("block_dns_service_discovery/config.yaml"))
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+
+	test.BenchmarkSuite.Add(b)
+}
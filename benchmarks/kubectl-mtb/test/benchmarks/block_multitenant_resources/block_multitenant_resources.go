@@ -26,6 +26,19 @@ var resources []gvr
 
 var b = &benchmark.Benchmark{
 
+	APICalls: []benchmark.APICall{
+		{
+			Impersonate: "tenant",
+			Verb:        "list, delete",
+			Group:       "*",
+			Version:     "*",
+			Resource:    "*",
+			Namespace:   "<tenant namespace>",
+			DryRun:      true,
+			Note:        "for every namespaced resource the API server reports, lists objects matching --label and dry-run deletes each one found, using its own impersonated dynamic client (not options.Tenant1Client)",
+		},
+	},
+
 	PreRun: func(options types.RunOptions) error {
 
 		if options.Label == "" {
@@ -13,6 +13,10 @@ import (
 
 var b = &benchmark.Benchmark{
 
+	APICalls: []benchmark.APICall{
+		{Impersonate: "tenant", Verb: "create, update, patch, delete, deletecollection", Group: "", Version: "v1", Resource: "resourcequotas", Namespace: "<tenant namespace>", DryRun: true, Note: "SelfSubjectAccessReview checks, in Run"},
+	},
+
 	PreRun: func(options types.RunOptions) error {
 
 		return nil
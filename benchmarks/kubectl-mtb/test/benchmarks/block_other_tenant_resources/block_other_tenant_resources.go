@@ -15,6 +15,11 @@ var verbs = []string{"get", "update"}
 
 var b = &benchmark.Benchmark{
 
+	APICalls: []benchmark.APICall{
+		{Impersonate: "other-tenant", Verb: "get, update", Group: "*", Version: "*", Resource: "*", Namespace: "<tenant namespace>", DryRun: true, Note: "checked against every namespaced resource the API server reports"},
+		{Impersonate: "tenant", Verb: "get, update", Group: "*", Version: "*", Resource: "*", Namespace: "<other tenant's namespace>", DryRun: true, Note: "checked against every namespaced resource the API server reports"},
+	},
+
 	PreRun: func(options types.RunOptions) error {
 
 		return nil
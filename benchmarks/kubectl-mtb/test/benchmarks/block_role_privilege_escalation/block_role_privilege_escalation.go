@@ -13,6 +13,11 @@ import (
 
 var b = &benchmark.Benchmark{
 
+	APICalls: []benchmark.APICall{
+		{Impersonate: "tenant", Verb: "escalate", Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "role", Namespace: "<tenant namespace>", DryRun: true, Note: "SelfSubjectAccessReview check, in Run"},
+		{Impersonate: "tenant", Verb: "bind", Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterrole", Namespace: "<tenant namespace>", DryRun: true, Note: "SelfSubjectAccessReview check against resource name \"cluster-admin\", in Run"},
+	},
+
 	PreRun: func(options types.RunOptions) error {
 
 		return nil
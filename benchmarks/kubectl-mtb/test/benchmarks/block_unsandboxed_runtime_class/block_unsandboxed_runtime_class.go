@@ -0,0 +1,78 @@
+package blockunsandboxedruntimeclass
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/bundle/box"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/pkg/benchmark"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test/utils"
+	podutil "sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test/utils/resources/pod"
+	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/types"
+)
+
+// unsandboxedRuntimeClass is the name of the RuntimeClass that maps to the default,
+// shared-kernel container runtime (e.g. runc). Clusters that sandbox untrusted tenants are
+// expected to reject pods that request it, in favor of a sandboxed class such as gvisor or kata.
+const unsandboxedRuntimeClass = "runc"
+
+var b = &benchmark.Benchmark{
+
+	APICalls: []benchmark.APICall{
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "pods", Namespace: "<tenant namespace>", DryRun: true, Note: "SelfSubjectAccessReview check, in PreRun"},
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "pods", Namespace: "<tenant namespace>", DryRun: true, Note: "attempts to create a pod that selects the unsandboxed runtime class"},
+	},
+
+	PreRun: func(options types.RunOptions) error {
+
+		resource := utils.GroupResource{
+			APIGroup: "",
+			APIResource: metav1.APIResource{
+				Name: "pods",
+			},
+		}
+
+		access, msg, err := utils.RunAccessCheck(options.Tenant1Client, options.TenantNamespace, resource, "create")
+		if err != nil {
+			options.Logger.Debug(err.Error())
+			return err
+		}
+		if !access {
+			return fmt.Errorf(msg)
+		}
+
+		return nil
+	},
+	Run: func(options types.RunOptions) error {
+
+		// Explicitly request the unsandboxed runtime class so that pod creation fails if the
+		// cluster forces tenants onto a sandboxed runtime.
+		podSpec := &podutil.PodSpec{NS: options.TenantNamespace, RuntimeClassName: unsandboxedRuntimeClass}
+		err := podSpec.SetDefaults()
+		if err != nil {
+			options.Logger.Debug(err.Error())
+			return err
+		}
+
+		// Try to create a pod as tenant-admin impersonation
+		pod := podSpec.MakeSecPod()
+		_, err = options.Tenant1Client.CoreV1().Pods(options.TenantNamespace).Create(context.TODO(), pod, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+		if err == nil {
+			return fmt.Errorf("Tenant must be unable to create pod that selects the unsandboxed runtime class %q", unsandboxedRuntimeClass)
+		}
+		options.Logger.Debug("Test passed: ", err.Error())
+		return nil
+	},
+}
+
+func init() {
+	// Get the []byte representation of a file, or an error if it doesn't exist:
+	err := b.ReadConfig(box.Get("block_unsandboxed_runtime_class/config.yaml"))
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+
+	test.BenchmarkSuite.Add(b)
+}
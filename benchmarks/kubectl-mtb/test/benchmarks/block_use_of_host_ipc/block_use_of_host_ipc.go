@@ -15,6 +15,11 @@ import (
 
 var b = &benchmark.Benchmark{
 
+	APICalls: []benchmark.APICall{
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "pods", Namespace: "<tenant namespace>", DryRun: true, Note: "SelfSubjectAccessReview check, in PreRun"},
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "pods", Namespace: "<tenant namespace>", DryRun: true, Note: "attempts to create a pod with HostIPC set to true"},
+	},
+
 	PreRun: func(options types.RunOptions) error {
 
 		resource := utils.GroupResource{
@@ -15,8 +15,23 @@ import (
 	"sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/types"
 )
 
+// benchmarkID must match the "id" in config.yaml; it's duplicated here rather than read back off
+// b.ID because b's own initializer can't reference b.
+const benchmarkID = "MTB-PL1-BC-HI-3"
+
+// probeHostPort is the host port used to verify tenants can't request host ports. It can be
+// overridden per-cluster via the "hostPort" key in the --overrides file, for clusters where this
+// port is legitimately reserved for something else.
+const probeHostPort = 8086
+
 var b = &benchmark.Benchmark{
 
+	APICalls: []benchmark.APICall{
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "pods", Namespace: "<tenant namespace>", DryRun: true, Note: "SelfSubjectAccessReview check, in PreRun"},
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "pods", Namespace: "<tenant namespace>", DryRun: true, Note: "attempts to create a pod with hostNetwork set to true"},
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "pods", Namespace: "<tenant namespace>", DryRun: true, Note: "attempts to create a pod that requests a host port (see the \"hostPort\" override)"},
+	},
+
 	PreRun: func(options types.RunOptions) error {
 
 		resource := utils.GroupResource{
@@ -56,10 +71,11 @@ var b = &benchmark.Benchmark{
 		}
 
 		//Tenant should not be allowed to use host ports
+		hostPort := int32(options.Overrides.IntParam(benchmarkID, "hostPort", probeHostPort))
 		ports := []v1.ContainerPort{
 			{
-				HostPort:      8086,
-				ContainerPort: 8086,
+				HostPort:      hostPort,
+				ContainerPort: hostPort,
 			},
 		}
 
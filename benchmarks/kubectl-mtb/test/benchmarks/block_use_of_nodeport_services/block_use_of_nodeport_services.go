@@ -16,6 +16,11 @@ import (
 
 var b = &benchmark.Benchmark{
 
+	APICalls: []benchmark.APICall{
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "services", Namespace: "<tenant namespace>", DryRun: true, Note: "SelfSubjectAccessReview check, in PreRun"},
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "services", Namespace: "<tenant namespace>", DryRun: true, Note: "attempts to create a Service of type NodePort"},
+	},
+
 	PreRun: func(options types.RunOptions) error {
 
 		resources := []utils.GroupResource{
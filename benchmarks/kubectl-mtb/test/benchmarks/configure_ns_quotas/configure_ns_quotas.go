@@ -13,6 +13,12 @@ import (
 )
 
 var b = &benchmark.Benchmark{
+
+	APICalls: []benchmark.APICall{
+		{Impersonate: "tenant", Verb: "list, get", Group: "", Version: "v1", Resource: "resourcequotas", Namespace: "<tenant namespace>", DryRun: true, Note: "SelfSubjectAccessReview checks, in PreRun"},
+		{Impersonate: "tenant", Verb: "list", Group: "", Version: "v1", Resource: "resourcequotas", Namespace: "<tenant namespace>", Note: "real List, in Run, to inspect which hard limits are covered by the tenant's quota"},
+	},
+
 	PreRun: func(options types.RunOptions) error {
 
 		verbs := []string{"list", "get"}
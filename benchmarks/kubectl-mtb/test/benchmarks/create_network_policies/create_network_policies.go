@@ -13,6 +13,10 @@ import (
 
 var b = &benchmark.Benchmark{
 
+	APICalls: []benchmark.APICall{
+		{Impersonate: "tenant", Verb: "get, create, update, patch, delete, deletecollection", Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies", Namespace: "<tenant namespace>", DryRun: true, Note: "SelfSubjectAccessReview checks, in Run"},
+	},
+
 	PreRun: func(options types.RunOptions) error {
 
 		return nil
@@ -15,6 +15,13 @@ import (
 
 var b = &benchmark.Benchmark{
 
+	APICalls: []benchmark.APICall{
+		{Impersonate: "tenant", Verb: "get, list, create, update, patch, watch, delete, deletecollection", Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "roles, rolebindings", Namespace: "<tenant namespace>", DryRun: true, Note: "SelfSubjectAccessReview checks, in Run"},
+		{Impersonate: "tenant", Verb: "create", Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "roles", Namespace: "<tenant namespace>", Note: "creates a real Role, in Run"},
+		{Impersonate: "tenant", Verb: "create", Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "rolebindings", Namespace: "<tenant namespace>", DryRun: true, Note: "dry-run creates a RoleBinding to the Role above, in Run"},
+		{Impersonate: "tenant", Verb: "delete", Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "roles", Namespace: "<tenant namespace>", Note: "deletes the Role created above, in PostRun"},
+	},
+
 	PreRun: func(options types.RunOptions) error {
 
 		return nil
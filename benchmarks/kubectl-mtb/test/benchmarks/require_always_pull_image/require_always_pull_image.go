@@ -17,6 +17,11 @@ import (
 
 var b = &benchmark.Benchmark{
 
+	APICalls: []benchmark.APICall{
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "pods", Namespace: "<tenant namespace>", DryRun: true, Note: "SelfSubjectAccessReview check, in PreRun"},
+		{Impersonate: "tenant", Verb: "create", Group: "", Version: "v1", Resource: "pods", Namespace: "<tenant namespace>", DryRun: true, Note: "creates a pod with imagePullPolicy set to Never, to see whether it's rejected or mutated to Always"},
+	},
+
 	PreRun: func(options types.RunOptions) error {
 		resource := utils.GroupResource{
 			APIGroup: "",
@@ -28,6 +28,8 @@ type PodSpec struct {
 	Ports                    []v1.ContainerPort `default:"-"`
 	AllowPrivilegeEscalation bool               `default:"-"`
 	ImagePullPolicy          v1.PullPolicy      `default:"Always"`
+	Env                      []v1.EnvVar        `default:"-"`
+	RuntimeClassName         string             `default:""`
 }
 
 // SetDefaults usage := https://github.com/creasty/defaults#usage
@@ -84,6 +86,7 @@ func (p PodSpec) MakeSecPod() *v1.Pod {
 					Command: []string{"/bin/sh"},
 					Args:    []string{"-c", p.Command},
 					Ports:   p.Ports,
+					Env:     p.Env,
 					SecurityContext: &v1.SecurityContext{
 						RunAsNonRoot: &p.RunAsNonRoot,
 						Privileged:   &p.IsPrivileged,
@@ -97,6 +100,9 @@ func (p PodSpec) MakeSecPod() *v1.Pod {
 			RestartPolicy: v1.RestartPolicyOnFailure,
 		},
 	}
+	if p.RuntimeClassName != "" {
+		podSpec.Spec.RuntimeClassName = &p.RuntimeClassName
+	}
 	var volumeMounts = make([]v1.VolumeMount, 0)
 	var volumeDevices = make([]v1.VolumeDevice, 0)
 	var volumes = make([]v1.Volume, len(p.Pvclaims)+len(p.InlineVolumeSources))
@@ -0,0 +1,292 @@
+// Package probe provides helpers for benchmarks that need to verify a tenant pod cannot reach
+// node-level endpoints (e.g. the kubelet's read-only port, or metrics endpoints) or enumerate
+// another tenant's Services via DNS, by launching a short-lived pod that runs the check and
+// reporting whether it succeeded.
+package probe
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	podutil "sigs.k8s.io/multi-tenancy/benchmarks/kubectl-mtb/test/utils/resources/pod"
+)
+
+// unreachableMarker is printed by the probe pod when wget could not even establish a connection
+// (timeout or connection refused). Any other outcome, including a non-2xx HTTP response, means the
+// endpoint was reachable, which is what an isolation check cares about.
+const unreachableMarker = "MTB_PROBE_UNREACHABLE"
+
+// wgetNetworkFailure is the exit code wget uses for network failures such as a connection timeout
+// or refusal, as opposed to e.g. a non-2xx response (8) or a malformed URL (1, 2).
+const wgetNetworkFailure = 4
+
+// Well-known node-level ports that isolation benchmarks care about.
+const (
+	// KubeletReadOnlyPort is the kubelet's unauthenticated read-only HTTP port, which exposes
+	// /pods, /metrics and similar endpoints with no auth if it's enabled on a node.
+	KubeletReadOnlyPort = 10255
+	// KubeletMetricsPort is the kubelet's authenticated HTTPS port, which also serves /metrics.
+	KubeletMetricsPort = 10250
+)
+
+// NodeURL builds the URL of an HTTP(S) endpoint on the node a probe pod is scheduled to, for use
+// as Spec.URL.
+func NodeURL(https bool, port int, path string) string {
+	scheme := "http"
+	if https {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://$NODE_IP:%d%s", scheme, port, path)
+}
+
+// Spec describes an HTTP probe to run against a node-level endpoint from inside a tenant
+// namespace.
+type Spec struct {
+	// NS is the namespace the probe pod is created in.
+	NS string
+	// URL is the address to probe, e.g. "http://$NODE_IP:10255/pods" for the kubelet read-only
+	// port. It may reference the pod's own node IP via the $NODE_IP shell variable, which
+	// RunHTTPProbe populates from the downward API.
+	URL string
+	// Timeout bounds the probe's own connection attempt; RunHTTPProbe waits up to Timeout plus 30
+	// seconds for the probe pod to finish.
+	Timeout time.Duration
+}
+
+// Result is the outcome of a single probe.
+type Result struct {
+	// Reachable is true if the probe pod could establish a connection to the endpoint at all,
+	// regardless of the HTTP status it got back - for isolation checks, simply reaching the endpoint
+	// is often already a violation.
+	Reachable bool
+	// Output is the raw output of the probe pod, kept around for debugging failed benchmarks.
+	Output string
+}
+
+// RunHTTPProbe launches a short-lived pod in spec.NS that attempts to fetch spec.URL, waits for it
+// to finish (or for spec.Timeout plus 30 seconds to elapse), and returns whether the endpoint was
+// reachable. The
+// probe pod is always deleted before returning, whether or not the probe succeeded.
+func RunHTTPProbe(client *kubernetes.Clientset, spec Spec) (Result, error) {
+	podSpec := &podutil.PodSpec{
+		NS: spec.NS,
+		Env: []v1.EnvVar{
+			{Name: "NODE_IP", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "status.hostIP"}}},
+		},
+		Command:      probeCommand(spec.URL, spec.Timeout),
+		RunAsNonRoot: true,
+	}
+	if err := podSpec.SetDefaults(); err != nil {
+		return Result{}, err
+	}
+
+	pod := podSpec.MakeSecPod()
+	pod.Spec.RestartPolicy = v1.RestartPolicyNever
+	pod, err := client.CoreV1().Pods(spec.NS).Create(context.TODO(), pod, metav1.CreateOptions{})
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create probe pod: %v", err)
+	}
+	defer func() {
+		_ = client.CoreV1().Pods(spec.NS).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
+	}()
+
+	if err := waitForPodDone(client, spec.NS, pod.Name, spec.Timeout+30*time.Second); err != nil {
+		return Result{}, fmt.Errorf("probe pod did not finish: %v", err)
+	}
+
+	raw, err := client.CoreV1().Pods(spec.NS).GetLogs(pod.Name, &v1.PodLogOptions{}).DoRaw(context.TODO())
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to fetch probe pod logs: %v", err)
+	}
+	output := string(raw)
+
+	return Result{
+		Reachable: !strings.Contains(output, unreachableMarker),
+		Output:    output,
+	}, nil
+}
+
+// probeCommand builds a shell command that fetches url with the given timeout, printing
+// unreachableMarker only if wget reports a network-level failure.
+func probeCommand(url string, timeout time.Duration) string {
+	secs := int(timeout.Seconds())
+	if secs <= 0 {
+		secs = 5
+	}
+	return fmt.Sprintf(
+		"wget -q -T %d -O /dev/null '%s'; if [ $? -eq %d ]; then echo %s; fi",
+		secs, url, wgetNetworkFailure, unreachableMarker)
+}
+
+// srvUnresolvedMarker is printed by the probe pod when the SRV query returned no records, e.g.
+// NXDOMAIN. Any other outcome means the query resolved to at least one record, which is what a
+// DNS-based service discovery isolation check cares about.
+const srvUnresolvedMarker = "MTB_PROBE_SRV_UNRESOLVED"
+
+// SRVSpec describes a DNS SRV lookup to run from inside a tenant namespace, to check whether that
+// tenant can enumerate SRV records - and so the Endpoints - of a Service owned by another tenant.
+type SRVSpec struct {
+	// NS is the namespace the probe pod is created in.
+	NS string
+	// Query is the SRV name to look up, e.g. "_http._tcp.my-svc.other-ns.svc.cluster.local".
+	Query string
+	// Timeout bounds the probe's own lookup attempt; RunDNSSRVProbe waits up to Timeout plus 30
+	// seconds for the probe pod to finish.
+	Timeout time.Duration
+}
+
+// SRVResult is the outcome of a single SRV lookup.
+type SRVResult struct {
+	// Resolved is true if the query returned at least one SRV record, regardless of what it
+	// contains - for isolation checks, resolving any record at all is already a violation.
+	Resolved bool
+	// Output is the raw output of the probe pod, kept around for debugging failed benchmarks.
+	Output string
+}
+
+// RunDNSSRVProbe launches a short-lived pod in spec.NS that runs an SRV lookup for spec.Query,
+// waits for it to finish (or for spec.Timeout plus 30 seconds to elapse), and returns whether the
+// query resolved.
+// The probe pod is always deleted before returning, whether or not the lookup succeeded.
+func RunDNSSRVProbe(client *kubernetes.Clientset, spec SRVSpec) (SRVResult, error) {
+	podSpec := &podutil.PodSpec{
+		NS:           spec.NS,
+		Command:      srvProbeCommand(spec.Query, spec.Timeout),
+		RunAsNonRoot: true,
+	}
+	if err := podSpec.SetDefaults(); err != nil {
+		return SRVResult{}, err
+	}
+
+	pod := podSpec.MakeSecPod()
+	pod.Spec.RestartPolicy = v1.RestartPolicyNever
+	pod, err := client.CoreV1().Pods(spec.NS).Create(context.TODO(), pod, metav1.CreateOptions{})
+	if err != nil {
+		return SRVResult{}, fmt.Errorf("failed to create probe pod: %v", err)
+	}
+	defer func() {
+		_ = client.CoreV1().Pods(spec.NS).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
+	}()
+
+	if err := waitForPodDone(client, spec.NS, pod.Name, spec.Timeout+30*time.Second); err != nil {
+		return SRVResult{}, fmt.Errorf("probe pod did not finish: %v", err)
+	}
+
+	raw, err := client.CoreV1().Pods(spec.NS).GetLogs(pod.Name, &v1.PodLogOptions{}).DoRaw(context.TODO())
+	if err != nil {
+		return SRVResult{}, fmt.Errorf("failed to fetch probe pod logs: %v", err)
+	}
+	output := string(raw)
+
+	return SRVResult{
+		Resolved: !strings.Contains(output, srvUnresolvedMarker),
+		Output:   output,
+	}, nil
+}
+
+// srvProbeCommand builds a shell command that runs an SRV lookup for query with the given
+// timeout, printing srvUnresolvedMarker only if nslookup couldn't resolve any record.
+func srvProbeCommand(query string, timeout time.Duration) string {
+	secs := int(timeout.Seconds())
+	if secs <= 0 {
+		secs = 5
+	}
+	return fmt.Sprintf(
+		"nslookup -type=srv -timeout=%d '%s' >/tmp/out 2>&1; if [ $? -ne 0 ]; then echo %s; fi; cat /tmp/out",
+		secs, query, srvUnresolvedMarker)
+}
+
+// tcpUnreachableMarker is printed by the probe pod when it could not open a TCP connection to the
+// target within the timeout. Any other outcome, including a connection that's immediately reset,
+// means the address was reachable, which is what a network isolation check cares about.
+const tcpUnreachableMarker = "MTB_PROBE_TCP_UNREACHABLE"
+
+// TCPSpec describes a raw TCP connection attempt to run from inside a tenant namespace, to check
+// whether that tenant's pods can reach a pod or Service IP/DNS name in another tenant's namespace.
+type TCPSpec struct {
+	// NS is the namespace the probe pod is created in.
+	NS string
+	// Host is the address to connect to: a pod IP or a Service DNS name.
+	Host string
+	// Port is the TCP port to connect to.
+	Port int
+	// Timeout bounds the probe's own connection attempt; RunTCPProbe waits up to Timeout plus 30
+	// seconds for the probe pod to finish.
+	Timeout time.Duration
+}
+
+// RunTCPProbe launches a short-lived pod in spec.NS that attempts to open a TCP connection to
+// spec.Host:spec.Port, waits for it to finish (or for spec.Timeout plus 30 seconds to elapse), and
+// returns whether the connection succeeded. The probe pod is always deleted before returning, whether or not the
+// probe succeeded.
+func RunTCPProbe(client *kubernetes.Clientset, spec TCPSpec) (Result, error) {
+	podSpec := &podutil.PodSpec{
+		NS:           spec.NS,
+		Command:      tcpProbeCommand(spec.Host, spec.Port, spec.Timeout),
+		RunAsNonRoot: true,
+	}
+	if err := podSpec.SetDefaults(); err != nil {
+		return Result{}, err
+	}
+
+	pod := podSpec.MakeSecPod()
+	pod.Spec.RestartPolicy = v1.RestartPolicyNever
+	pod, err := client.CoreV1().Pods(spec.NS).Create(context.TODO(), pod, metav1.CreateOptions{})
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create probe pod: %v", err)
+	}
+	defer func() {
+		_ = client.CoreV1().Pods(spec.NS).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
+	}()
+
+	if err := waitForPodDone(client, spec.NS, pod.Name, spec.Timeout+30*time.Second); err != nil {
+		return Result{}, fmt.Errorf("probe pod did not finish: %v", err)
+	}
+
+	raw, err := client.CoreV1().Pods(spec.NS).GetLogs(pod.Name, &v1.PodLogOptions{}).DoRaw(context.TODO())
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to fetch probe pod logs: %v", err)
+	}
+	output := string(raw)
+
+	return Result{
+		Reachable: !strings.Contains(output, tcpUnreachableMarker),
+		Output:    output,
+	}, nil
+}
+
+// tcpProbeCommand builds a shell command that attempts a TCP connection to host:port with the
+// given timeout, printing tcpUnreachableMarker only if nc could not connect at all.
+func tcpProbeCommand(host string, port int, timeout time.Duration) string {
+	secs := int(timeout.Seconds())
+	if secs <= 0 {
+		secs = 5
+	}
+	return fmt.Sprintf(
+		"nc -z -w %d '%s' %d >/dev/null 2>&1; if [ $? -ne 0 ]; then echo %s; fi",
+		secs, host, port, tcpUnreachableMarker)
+}
+
+// waitForPodDone polls until the given pod has finished running (Succeeded or Failed) or the
+// timeout elapses.
+func waitForPodDone(client *kubernetes.Clientset, ns, name string, timeout time.Duration) error {
+	return wait.PollImmediate(2*time.Second, timeout, func() (bool, error) {
+		pod, err := client.CoreV1().Pods(ns).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		switch pod.Status.Phase {
+		case v1.PodSucceeded, v1.PodFailed:
+			return true, nil
+		default:
+			return false, nil
+		}
+	})
+}
@@ -9,6 +9,10 @@ import (
 type ServiceConfig struct {
 	Type     v1.ServiceType
 	Selector map[string]string
+	// Headless, if true, sets ClusterIP to "None" instead of leaving it to be allocated. Headless
+	// services are what CoreDNS publishes per-pod SRV/A records for, so DNS-based service discovery
+	// checks need one to probe against.
+	Headless bool
 }
 
 func (s *ServiceConfig) CreateServiceSpec() *v1.Service {
@@ -25,5 +29,8 @@ func (s *ServiceConfig) CreateServiceSpec() *v1.Service {
 	service.Spec.Ports = []v1.ServicePort{
 		{Port: 80, Name: "http", Protocol: v1.ProtocolTCP},
 	}
+	if s.Headless {
+		service.Spec.ClusterIP = v1.ClusterIPNone
+	}
 	return service
 }
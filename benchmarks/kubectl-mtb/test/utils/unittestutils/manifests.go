@@ -212,3 +212,23 @@ spec:
           - securityContext:
               runAsNonRoot: true
 `
+
+const RequireSandboxedRuntimeClass = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-sandboxed-runtime-class
+spec:
+  validationFailureAction: enforce
+  rules:
+    - name: validate-runtimeClassName
+      match:
+        resources:
+          kinds:
+            - Pod
+      validate:
+        message: "Pods must set runtimeClassName to a sandboxed RuntimeClass such as gvisor or kata"
+        pattern:
+          spec:
+            runtimeClassName: "gvisor | kata"
+`
@@ -2,18 +2,53 @@ package types
 
 import (
 	"go.uber.org/zap"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
 
 // RunOptions contains benchmark running options
 type RunOptions struct {
-	Tenant             string
-	TenantNamespace    string
-	OtherTenant        string
-	OtherNamespace     string
-	Label              string
-	ClusterAdminClient *kubernetes.Clientset
-	Tenant1Client      *kubernetes.Clientset
-	Tenant2Client      *kubernetes.Clientset
-	Logger             *zap.SugaredLogger
+	Tenant               string
+	TenantNamespace      string
+	OtherTenant          string
+	OtherNamespace       string
+	Label                string
+	ClusterAdminClient   *kubernetes.Clientset
+	Tenant1Client        *kubernetes.Clientset
+	Tenant2Client        *kubernetes.Clientset
+	Tenant1DynamicClient dynamic.Interface
+	Logger               *zap.SugaredLogger
+	Overrides            Overrides
+}
+
+// Overrides maps a benchmark ID to a set of named parameter overrides for that benchmark. Some
+// organizations legitimately differ from a benchmark's built-in defaults (e.g. which host ports
+// are allowed); an override lets a benchmark honor that difference instead of failing on a
+// cluster that was configured correctly on purpose.
+type Overrides map[string]map[string]interface{}
+
+// IntParam returns the override for key under benchmarkID, or def if no such override is set.
+func (o Overrides) IntParam(benchmarkID, key string, def int) int {
+	v, ok := o.param(benchmarkID, key)
+	if !ok {
+		return def
+	}
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		// encoding/json unmarshals numbers in an interface{} as float64.
+		return int(n)
+	default:
+		return def
+	}
+}
+
+func (o Overrides) param(benchmarkID, key string) (interface{}, bool) {
+	params, ok := o[benchmarkID]
+	if !ok {
+		return nil, false
+	}
+	v, ok := params[key]
+	return v, ok
 }
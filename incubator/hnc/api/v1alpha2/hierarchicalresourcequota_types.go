@@ -0,0 +1,90 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Constants for the hierarchical resource quota resource type.
+const (
+	HierarchicalResourceQuotas    = "hierarchicalresourcequotas"
+	HierarchicalResourceQuotaKind = "HierarchicalResourceQuota"
+)
+
+// HierarchicalResourceQuotaSpec defines the desired state of HierarchicalResourceQuota. It's
+// deliberately a subset of corev1.ResourceQuotaSpec - just the Hard limits - since Scopes and
+// ScopeSelector are about restricting which pods in a single namespace are counted, which isn't
+// meaningful for a budget that's enforced across a whole subtree.
+type HierarchicalResourceQuotaSpec struct {
+	// Hard is the set of desired hard limits for the namespace this object is created in, and
+	// every one of its descendants. It's enforced the same way plain ResourceQuota.Spec.Hard is:
+	// once a namespace hits its share, further creations of that resource are rejected there.
+	// +optional
+	Hard corev1.ResourceList `json:"hard,omitempty"`
+}
+
+// HierarchicalResourceQuotaStatus defines the observed state of HierarchicalResourceQuota.
+type HierarchicalResourceQuotaStatus struct {
+	// Hard is the last set of hard limits this object propagated to the subtree. It mirrors
+	// Spec.Hard once the reconciler has caught up.
+	// +optional
+	Hard corev1.ResourceList `json:"hard,omitempty"`
+
+	// Used is the sum of Status.Used, for every one of the resources named in Spec.Hard, across
+	// the ResourceQuota in this namespace and every descendant namespace's ResourceQuota. It's
+	// only as fresh as the last time each namespace's ResourceQuota controller updated its own
+	// status, so briefly over-budget usage can't be ruled out between reconciles.
+	// +optional
+	Used corev1.ResourceList `json:"used,omitempty"`
+
+	// Namespaces lists the descendant namespaces (including this one) that this object's budget
+	// was last divided across. It's informational only, to make it easier to see which
+	// per-namespace ResourceQuota objects were written on this object's behalf.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=hierarchicalresourcequotas,shortName=hrq,scope=Namespaced
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+
+// HierarchicalResourceQuota lets a namespace's admin set a single resource budget that's
+// enforced across that namespace and all of its descendants, instead of every descendant
+// needing its own independently-sized ResourceQuota. See HierarchicalResourceQuotaReconciler for
+// how the budget is divided among descendants.
+type HierarchicalResourceQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HierarchicalResourceQuotaSpec   `json:"spec,omitempty"`
+	Status HierarchicalResourceQuotaStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HierarchicalResourceQuotaList contains a list of HierarchicalResourceQuota.
+type HierarchicalResourceQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HierarchicalResourceQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HierarchicalResourceQuota{}, &HierarchicalResourceQuotaList{})
+}
@@ -40,6 +40,13 @@ const (
 	AnnotationTreeSelector = AnnotationPropagatePrefix + "/treeSelect"
 	AnnotationNoneSelector = AnnotationPropagatePrefix + "/none"
 
+	// AnnotationScaleQuotaFactor may be set on a source ResourceQuota or LimitRange to shrink the
+	// quantities in its propagated copies by this factor for every tree level between the source
+	// namespace and the descendant it's propagated into, e.g. a factor of "0.5" gives each child
+	// half its parent's budget, each grandchild a quarter, and so on. It must parse as a float64 in
+	// the range (0, 1]; any other value is ignored and the object is propagated unscaled.
+	AnnotationScaleQuotaFactor = AnnotationPropagatePrefix + "/scaleQuotaFactor"
+
 	// LabelManagedByStandard will eventually replace our own managed-by annotation (we didn't know
 	// about this standard label when we invented our own).
 	LabelManagedByApps = "app.kubernetes.io/managed-by"
@@ -47,12 +54,84 @@ const (
 	// LabelExcludedNamespace is the label added by users on the namespaces that
 	// should be excluded from our validators, e.g. "kube-system".
 	LabelExcludedNamespace = MetaGroup + "/excluded-namespace"
+
+	// AnnotationPreviousParents records the parent values that HNC has automatically overwritten on
+	// this object's HierarchyConfiguration (e.g. via the SubnamespaceOf annotation), most recent
+	// first, so a surprising automatic change can be diagnosed or manually undone. See
+	// reconcilers.maxPreviousParents for the retention limit.
+	AnnotationPreviousParents = MetaGroup + "/previous-parents"
+
+	// AnnotationUnmanaged is added by users on a namespace to fully opt it out of HNC, without
+	// having to add it to the (cluster-wide, restart-requiring) --excluded-namespace flag. Unlike
+	// excluded namespaces, this is validated and reconciled like any other HNC-managed field: it
+	// only accepts "true" or "false", and can be toggled at any time. Setting it to "true" halts
+	// all object propagation into and out of the namespace and stops HNC from writing tree labels
+	// past it, though the namespace still keeps its place in the tree and is reported as unmanaged.
+	AnnotationUnmanaged = MetaGroup + "/unmanaged"
+
+	// AnnotationSourceResourceVersion records the resourceVersion of the source object that a
+	// propagated copy was last written from, so an incident responder looking at a bad copy in a
+	// leaf namespace can find the exact source revision that produced it.
+	AnnotationSourceResourceVersion = MetaGroup + "/source-resource-version"
+
+	// AnnotationPropagatedAt records the RFC3339 timestamp of the last time HNC wrote this copy from
+	// its source.
+	AnnotationPropagatedAt = MetaGroup + "/propagated-at"
+
+	// AnnotationPropagatedByVersion records the HNC build (see internal/version) that last wrote
+	// this copy, so a bug that only affects certain HNC versions can be correlated with the copies
+	// it produced.
+	AnnotationPropagatedByVersion = MetaGroup + "/propagated-by-version"
+
+	// AnnotationManagerIdentity records the identity (see config.ManagerIdentity, set from the
+	// --manager-identity flag) of the HNC instance that last wrote this copy. It lets a reconciler
+	// notice a propagated copy that was actually last written by a *different* HNC installation -
+	// e.g. an accidental double install, where leader election within each installation doesn't stop
+	// the other one from also propagating into the same cluster - instead of silently fighting over
+	// it forever. See EventManagerConflict.
+	AnnotationManagerIdentity = MetaGroup + "/manager-identity"
+
+	// AnnotationSuspend, if set to "true" on a namespace's HierarchyConfiguration, pauses object
+	// propagation and deletion for the subtree rooted at that namespace, while leaving the rest of
+	// HNC's bookkeeping (tree structure, conditions on other namespaces, etc.) running as normal.
+	// This is meant for incident response and migrations, where an operator needs to freeze a
+	// subtree's objects in place - e.g. to compare them against a backup, or while manually
+	// reconciling a conflict - without unmanaging the namespace or tearing down the tree.
+	AnnotationSuspend = MetaGroup + "/suspend"
+
+	// AnnotationSourceUID records the UID of the source object a propagated copy was written from -
+	// an owner-reference-like back-link that survives the copy being moved between sources (e.g. if
+	// the source namespace's parent changes), unlike a real metav1.OwnerReference, which Kubernetes
+	// doesn't allow to cross namespaces. Only set on copies of a type configured with
+	// ResourceSpec.LinkToSource.
+	AnnotationSourceUID = MetaGroup + "/source-uid"
+
+	// FinalizerPropagatedCopy is added to a propagated copy when its type is configured with
+	// ResourceSpec.LinkToSource, so that external GC tooling - and anyone who removes HNC without
+	// first cleaning up its objects - finds those copies stuck in "Terminating" instead of quietly
+	// disappearing or being silently orphaned. HNC itself always removes this finalizer before
+	// deleting a copy it's intentionally cleaning up (e.g. because the type's mode changed).
+	FinalizerPropagatedCopy = MetaGroup + "/propagated-copy"
+
+	// AnnotationPropagationWindow, if set on a source object, restricts HNC to only creating or
+	// updating its propagated copies while the declared window is open. Changes made while the
+	// window is closed aren't dropped - they're left pending on the source and applied as soon as
+	// the window next opens - so this is meant for change-freeze periods, where propagation changes
+	// (but not ordinary application traffic) must land only during an approved maintenance slot.
+	//
+	// The value is either a one-off RFC3339 interval, "<start>/<end>", or a recurring daily UTC
+	// time-of-day range, "HH:MM-HH:MM" (wrapping past midnight if the end is earlier than the
+	// start). See internal/pkg/window for the exact parsing rules. A value HNC can't parse is
+	// reported via EventCannotParsePropagationWindow on the source object and otherwise ignored, as
+	// if the annotation weren't set.
+	AnnotationPropagationWindow = MetaGroup + "/propagation-window"
 )
 
 const (
 	// Condition types.
 	ConditionActivitiesHalted string = "ActivitiesHalted"
 	ConditionBadConfiguration string = "BadConfiguration"
+	ConditionSyncPaused       string = "SyncPaused"
 
 	// Condition reasons.
 	ReasonAncestor      string = "AncestorHaltActivities"
@@ -61,6 +140,22 @@ const (
 	ReasonParentMissing string = "ParentMissing"
 	ReasonIllegalParent string = "IllegalParent"
 	ReasonAnchorMissing string = "SubnamespaceAnchorMissing"
+	ReasonUnmanaged     string = "UnmanagedNamespace"
+	ReasonSuspended     string = "SuspendAnnotation"
+
+	// ReasonTreeLabelsExceeded is set on a namespace whose tree labels (see api.LabelTreeDepthSuffix)
+	// already exceed config.MaxTreeLabels, i.e. a legacy tree that predates the --max-tree-labels
+	// flag, or was created while it was set to a larger value. Unlike the other BadConfiguration
+	// reasons, this doesn't halt activities - it's just a flag for an admin to notice and either
+	// flatten the tree or raise the limit.
+	ReasonTreeLabelsExceeded string = "MaxTreeLabelsExceeded"
+
+	// ReasonMaxSubnamespacesExceeded is set on a namespace that already has more subnamespaces than
+	// its own spec.maxSubnamespaces allows, e.g. because the limit was lowered after some of them
+	// were created. Like ReasonTreeLabelsExceeded, this doesn't halt activities or delete anything -
+	// it's a flag for an admin to notice, since the anchor validator only stops the count from
+	// growing further, not from already being over.
+	ReasonMaxSubnamespacesExceeded string = "MaxSubnamespacesExceeded"
 )
 
 // AllConditions have all the conditions by type and reason. Please keep this
@@ -73,9 +168,15 @@ var AllConditions = map[string][]string{
 		ReasonInCycle,
 		ReasonParentMissing,
 		ReasonIllegalParent,
+		ReasonUnmanaged,
 	},
 	ConditionBadConfiguration: {
 		ReasonAnchorMissing,
+		ReasonMaxSubnamespacesExceeded,
+		ReasonTreeLabelsExceeded,
+	},
+	ConditionSyncPaused: {
+		ReasonSuspended,
 	},
 }
 
@@ -96,6 +197,17 @@ const (
 	// EventCannotGetSelector is for events when an object has annotations that cannot be
 	// parsed into a valid selector
 	EventCannotParseSelector string = "CannotParseSelector"
+	// EventCannotParsePropagationWindow is for events when a source object's
+	// AnnotationPropagationWindow can't be parsed.
+	EventCannotParsePropagationWindow string = "CannotParsePropagationWindow"
+	// EventManagerConflict is for events when a propagated copy was last written by a different HNC
+	// instance than this one (see AnnotationManagerIdentity), e.g. because of an accidental double
+	// install. The write is skipped instead of overwriting the other instance's copy.
+	EventManagerConflict string = "ManagerConflict"
+	// EventWebhookExemption is for events when a modification or deletion of a propagated object
+	// was allowed because the requesting identity is listed in
+	// HNCConfigurationSpec.WebhookExemptions, instead of being denied as usual.
+	EventWebhookExemption string = "WebhookExemptionUsed"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -103,6 +215,12 @@ const (
 
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Parent",type="string",JSONPath=".spec.parent"
+// +kubebuilder:printcolumn:name="Halted",type="string",JSONPath=".status.conditions[?(@.type==\"ActivitiesHalted\")].status"
+// +kubebuilder:printcolumn:name="SyncPaused",type="string",JSONPath=".status.conditions[?(@.type==\"SyncPaused\")].status",priority=1
+// +kubebuilder:printcolumn:name="Children",type="string",JSONPath=".status.children",priority=1
+// +kubebuilder:printcolumn:name="Allow Cascading Deletion",type="boolean",JSONPath=".spec.allowCascadingDeletion",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Hierarchy is the Schema for the hierarchies API
 type HierarchyConfiguration struct {
@@ -124,6 +242,59 @@ type HierarchyConfigurationSpec struct {
 	// AllowCascadingDeletion indicates if the subnamespaces of this namespace are
 	// allowed to cascading delete.
 	AllowCascadingDeletion bool `json:"allowCascadingDeletion,omitempty"`
+
+	// ClusterPlacement expresses which clusters the subtree rooted at this namespace should exist
+	// on, as a hint for fleet management tools built on top of HNC. HNC itself doesn't act on this
+	// field - it doesn't replicate namespaces or their contents to other clusters - it only
+	// validates the field and stores it, so those tools have one standard place to read and write
+	// this information instead of inventing their own annotations.
+	// +optional
+	ClusterPlacement *ClusterPlacement `json:"clusterPlacement,omitempty"`
+
+	// RequireSubnamespaceApproval indicates that new subnamespaces of this namespace must be
+	// approved before HNC will create them, by setting SubnamespaceApprovedAnnotation to "true" on
+	// their anchor. This lets a cluster admin allow self-service subnamespace creation in a subtree
+	// while still gating it behind manual review, e.g. in a regulated environment.
+	RequireSubnamespaceApproval bool `json:"requireSubnamespaceApproval,omitempty"`
+
+	// Labels are label key/value pairs that HierarchyConfigReconciler writes onto this namespace and
+	// propagates down to every descendant namespace, e.g. so a `team=payments` label set once on a
+	// root namespace shows up on every namespace in that tree. If two ancestors declare the same
+	// key, the one closer to the namespace wins. The Namespace validator rejects changes that would
+	// remove or alter a propagated label, so a tenant can't tamper with values set by an ancestor
+	// they don't control.
+	// +optional
+	Labels []MetaKVP `json:"labels,omitempty"`
+
+	// Annotations is the annotation equivalent of Labels; see its documentation for details.
+	// +optional
+	Annotations []MetaKVP `json:"annotations,omitempty"`
+
+	// MaxSubnamespaces limits how many subnamespaces may be created directly under this namespace,
+	// e.g. to stop a self-service tenant from creating an unbounded number of them. A value of 0 (the
+	// default) means no limit. It's enforced by the anchor validator, which denies the creation of a
+	// new SubnamespaceAnchor once this namespace already has this many; see
+	// ReasonMaxSubnamespacesExceeded for the condition reported if the limit is ever exceeded anyway,
+	// e.g. because it was lowered after the fact.
+	// +optional
+	MaxSubnamespaces int `json:"maxSubnamespaces,omitempty"`
+}
+
+// MetaKVP is a label or annotation key/value pair declared in HierarchyConfigurationSpec.Labels or
+// HierarchyConfigurationSpec.Annotations.
+type MetaKVP struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ClusterPlacement is a fleet-management hint expressing which clusters a subtree should exist
+// on. See HierarchyConfigurationSpec.ClusterPlacement.
+type ClusterPlacement struct {
+	// Clusters is the list of cluster names this subtree is placed on. Names must be unique and
+	// follow RFC 1123 DNS label rules, matching the naming conventions used by most fleet/cluster
+	// registries.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
 }
 
 // HierarchyStatus defines the observed state of Hierarchy
@@ -154,17 +325,18 @@ type HierarchyConfigurationList struct {
 // Condition contains details for one aspect of the current state of this API Resource.
 // ---
 // This struct is intended for direct use as an array at the field path .status.conditions.  For example,
-// type FooStatus struct{
-//     // Represents the observations of a foo's current state.
-//     // Known .status.conditions.type are: "Available", "Progressing", and "Degraded"
-//     // +patchMergeKey=type
-//     // +patchStrategy=merge
-//     // +listType=map
-//     // +listMapKey=type
-//     Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 //
-//     // other fields
-// }
+//	type FooStatus struct{
+//	    // Represents the observations of a foo's current state.
+//	    // Known .status.conditions.type are: "Available", "Progressing", and "Degraded"
+//	    // +patchMergeKey=type
+//	    // +patchStrategy=merge
+//	    // +listType=map
+//	    // +listMapKey=type
+//	    Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+//
+//	    // other fields
+//	}
 type Condition struct {
 	// type of condition in CamelCase or in foo.example.com/CamelCase.
 	// ---
@@ -28,10 +28,11 @@ const (
 	RoleKind            = "Role"
 	RoleBindingResource = "rolebindings"
 	RoleBindingKind     = "RoleBinding"
+	SecretResource      = "secrets"
 )
 
 // SynchronizationMode describes propagation mode of objects of the same kind.
-// The only three modes currently supported are "Propagate", "Ignore", and "Remove".
+// The four modes currently supported are "Propagate", "AllowPropagate", "Ignore", and "Remove".
 // See detailed definition below. An unsupported mode will be treated as "ignore".
 type SynchronizationMode string
 
@@ -39,6 +40,13 @@ const (
 	// Propagate objects from ancestors to descendants and deletes obsolete descendants.
 	Propagate SynchronizationMode = "Propagate"
 
+	// AllowPropagate is like Propagate, except that a source object is only propagated if it
+	// carries the AllowPropagateAnnotation itself; source objects without the annotation are left
+	// alone, as if the type were configured with Ignore. This lets a cluster admin enable
+	// propagation for a type - such as Secrets - without every existing and future object of that
+	// type suddenly being copied into every descendant namespace.
+	AllowPropagate SynchronizationMode = "AllowPropagate"
+
 	// Ignore the modification of this resource. New or changed objects will not be propagated, and
 	// obsolete objects will not be deleted. The inheritedFrom label is not removed.  Any unknown mode
 	// is treated as Ignore.
@@ -48,6 +56,23 @@ const (
 	Remove SynchronizationMode = "Remove"
 )
 
+// AllowPropagateAnnotation, when set to "true" on a source object of a type configured with the
+// AllowPropagate mode, opts that object in to being propagated to descendant namespaces. It has
+// no effect on objects of a type configured with any other mode.
+const AllowPropagateAnnotation = MetaGroup + "/allow-propagate"
+
+// ConfirmLargeConfigChangeAnnotation must be present on an HNCConfiguration whose Resources change
+// is estimated to create or remove more than LargeConfigChangeThreshold objects across the
+// cluster - e.g. switching a widely-used type into or out of Propagate mode. The validating
+// webhook denies the change otherwise; add this annotation (to any value) and re-apply once
+// you've reviewed the impact.
+const ConfirmLargeConfigChangeAnnotation = MetaGroup + "/confirm-large-change"
+
+// LargeConfigChangeThreshold is the number of objects a single HNCConfiguration change may
+// create or remove across the cluster before it's considered high-impact and requires
+// ConfirmLargeConfigChangeAnnotation.
+const LargeConfigChangeThreshold = 100
+
 const (
 	// Condition types.
 	ConditionBadTypeConfiguration = "BadConfiguration"
@@ -56,11 +81,41 @@ const (
 	// in the HierarchyConfiguration objects. The condition reasons would be the
 	// condition types in HierarchyConfiguration, e.g. "ActivitiesHalted".
 	ConditionNamespace = "NamespaceCondition"
+	// ConditionBadWebhookCert is set if the webhook serving cert is unhealthy - e.g. it took too
+	// long to be generated at startup, or it's about to expire - since HNC's webhooks will reject
+	// all requests once the cert actually becomes invalid.
+	ConditionBadWebhookCert = "BadWebhookCert"
+	// ConditionBadLibraryConfiguration is set if one or more entries in spec.libraries can't be
+	// applied - e.g. because the library namespace or one of its subscribing subtrees doesn't exist.
+	// The offending entry is simply skipped rather than blocking the rest of the config.
+	ConditionBadLibraryConfiguration = "BadLibraryConfiguration"
+	// ConditionSecretPropagationNotAllowed is set if spec.resources configures "secrets" to
+	// "Propagate" without spec.allowSecretPropagation also being set to true. Propagating Secrets
+	// copies their (possibly encrypted-at-rest) contents into every descendant namespace, which
+	// widens the set of principals who can read them; HNC requires an explicit acknowledgment of
+	// that blast radius before it will do so.
+	ConditionSecretPropagationNotAllowed = "SecretPropagationNotAllowed"
+	// ConditionPropagationDeferred is set if one or more source objects have a closed
+	// api.AnnotationPropagationWindow, so their propagated copies are out of date until the window
+	// next opens. See ResourceStatus.NumDeferredObjects for which types and how many objects are
+	// affected.
+	ConditionPropagationDeferred = "PropagationDeferred"
 
 	// Condition reasons for BadConfiguration
 	ReasonMultipleConfigsForType = "MultipleConfigurationsForType"
 	ReasonResourceNotFound       = "ResourceNotFound"
 
+	// Condition reason for SecretPropagationNotAllowed
+	ReasonSecretPropagationNotAllowed = "SecretPropagationNotAllowed"
+
+	// Condition reason for PropagationDeferred
+	ReasonOutsideWindow = "OutsidePropagationWindow"
+
+	// Condition reasons for BadLibraryConfiguration
+	ReasonLibraryNamespaceNotFound = "LibraryNamespaceNotFound"
+	ReasonLibrarySubtreeNotFound   = "LibrarySubtreeNotFound"
+	ReasonLibrarySelfSubscription  = "LibrarySelfSubscription"
+
 	// Condition reason for OutOfSync, e.g. errors when creating a reconciler.
 	ReasonUnknown = "Unknown"
 )
@@ -93,8 +148,53 @@ type ResourceSpec struct {
 	// Synchronization mode of the kind. If the field is empty, it will be treated
 	// as "Propagate".
 	// +optional
-	// +kubebuilder:validation:Enum=Propagate;Ignore;Remove
+	// +kubebuilder:validation:Enum=Propagate;AllowPropagate;Ignore;Remove
 	Mode SynchronizationMode `json:"mode,omitempty"`
+
+	// Transform declares fields of this resource whose string values should have the destination
+	// namespace substituted in before the copy is written. If unset, propagated copies are written
+	// verbatim, as before.
+	// +optional
+	Transform *ObjectTransform `json:"transform,omitempty"`
+
+	// IgnoreObjectWebhook, if true, excludes this resource's objects from the object admission
+	// webhook entirely - admission requests are always allowed, regardless of whether they'd
+	// modify or delete a propagated object. Reconciliation-based propagation is unaffected:
+	// propagated copies are still created, kept in sync, and removed as usual.
+	//
+	// This trades away the webhook's real-time protection against writers racing HNC: an external
+	// write that would normally be denied by the "cannot modify/delete propagated object" checks is
+	// instead allowed to land, and is only corrected on the next reconcile (typically sub-second,
+	// but not instantaneous). Use this for resources with extremely high write rates - e.g.
+	// ConfigMaps that some other controller churns continuously - where the admission webhook's
+	// added apiserver latency matters more than that brief window of drift.
+	// +optional
+	IgnoreObjectWebhook bool `json:"ignoreObjectWebhook,omitempty"`
+
+	// LinkToSource, if true, marks every propagated copy of this type with an owner-reference-like
+	// back-link to its source object (see AnnotationSourceUID) and a finalizer (see
+	// FinalizerPropagatedCopy), so external GC tooling and `kubectl get -o wide` can show a copy's
+	// provenance, and stray copies are easier to spot if HNC is ever uninstalled without first
+	// cleaning them up. It defaults to false, since the finalizer means a copy can only be deleted
+	// while HNC is running to remove it.
+	// +optional
+	LinkToSource bool `json:"linkToSource,omitempty"`
+}
+
+// NamespacePlaceholder is the literal string ObjectTransform.Fields looks for in the source
+// object's field values. Every occurrence is replaced with the name of the destination namespace
+// when a copy of the object is propagated there.
+const NamespacePlaceholder = "{{namespace}}"
+
+// ObjectTransform declares field-level substitutions HNC applies to a propagated copy of an
+// object, so a single source object can carry namespace-specific values (e.g. a ConfigMap key
+// that must embed the name of the namespace it ends up in) instead of being byte-identical in
+// every descendant.
+type ObjectTransform struct {
+	// Fields lists the dot-separated paths (e.g. "data.host") of string fields within the object
+	// whose value may contain NamespacePlaceholder. Paths that don't exist, or whose value isn't a
+	// string, are silently left alone.
+	Fields []string `json:"fields,omitempty"`
 }
 
 // ResourceStatus defines the actual synchronization state of a specific resource.
@@ -123,6 +223,13 @@ type ResourceStatus struct {
 	// +kubebuilder:validation:Minimum=0
 	// +optional
 	NumSourceObjects *int `json:"numSourceObjects,omitempty"`
+
+	// Tracks the number of propagated copies of this resource that are out of date because their
+	// source has a closed api.AnnotationPropagationWindow. They'll be brought up to date as soon as
+	// the window next opens.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	NumDeferredObjects *int `json:"numDeferredObjects,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -146,6 +253,88 @@ type HNCConfigurationSpec struct {
 	// or 'rolebindings' are not allowed. To learn more, see
 	// https://github.com/kubernetes-sigs/multi-tenancy/blob/master/incubator/hnc/docs/user-guide/how-to.md#admin-types
 	Resources []ResourceSpec `json:"resources,omitempty"`
+
+	// Libraries declares "library" namespaces whose source objects should also be propagated to
+	// one or more selected subtrees, in addition to the library namespace's own descendants. This
+	// allows objects to be shared with sibling subtrees without making the library namespace their
+	// common ancestor.
+	// +optional
+	Libraries []LibrarySpec `json:"libraries,omitempty"`
+
+	// AllowSecretPropagation must be set to true before "secrets" can be configured with
+	// "Propagate" mode in Resources. Propagating a Secret copies its contents - which may or may
+	// not be encrypted at rest, depending on the cluster's apiserver configuration - into every
+	// descendant namespace, widening the set of principals who can read it. If this is false (the
+	// default) and Resources still configures "secrets" as "Propagate", HNC treats it as "Ignore"
+	// and reports ConditionSecretPropagationNotAllowed instead.
+	// +optional
+	AllowSecretPropagation bool `json:"allowSecretPropagation,omitempty"`
+
+	// RootAllowlist, if non-empty, restricts which namespaces may act as the root of a
+	// multi-namespace tree - that is, a namespace that has descendants but no parent of its own.
+	// The hierarchy webhook denies any change that would give a namespace outside this list both a
+	// child and no parent, whether that's by attaching a child to an already-parentless namespace
+	// or by orphaning a namespace that already has children. Namespaces that already have a parent,
+	// or that never acquire children, are unaffected either way.
+	//
+	// If empty (the default), any namespace may become a root, as before.
+	// +optional
+	RootAllowlist []string `json:"rootAllowlist,omitempty"`
+
+	// WebhookExemptions lists identities that the object validator trusts to modify or delete
+	// propagated objects without going through HNC - for example, a GitOps controller that must be
+	// able to reconcile its own copy of an object HNC also propagates. Exempted requests still have
+	// to satisfy every other check (e.g. they can't rename the object's source or create a new
+	// object carrying api.LabelInheritedFrom), and each one is recorded as an event on the object so
+	// the exemption stays auditable.
+	//
+	// If empty (the default), only the HNC service account itself is trusted with propagated
+	// objects, as before.
+	// +optional
+	WebhookExemptions WebhookExemption `json:"webhookExemptions,omitempty"`
+}
+
+// WebhookExemption identifies a set of identities - by username, group membership, or service
+// account - that HNC's object validator exempts from its "cannot modify/delete propagated
+// object" denials. See HNCConfigurationSpec.WebhookExemptions.
+type WebhookExemption struct {
+	// Users lists Kubernetes usernames, exactly as they appear in an admission request's
+	// userInfo.username, that are exempt.
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Groups lists Kubernetes groups, exactly as they appear in an admission request's
+	// userInfo.groups, that are exempt. Any identity belonging to one of these groups is exempt,
+	// regardless of its username.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// ServiceAccounts lists "<namespace>/<name>" service accounts that are exempt. This is a
+	// shorthand for the equivalent Users entry, which would otherwise have to spell out Kubernetes'
+	// "system:serviceaccount:<namespace>:<name>" username convention.
+	// +optional
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+}
+
+// LibrarySpec declares that the source objects of Namespace should be propagated to the
+// subtrees listed in Subtrees, in addition to being propagated normally to Namespace's own
+// descendants.
+//
+// If an object with the same name and GVK also exists as a source in a subscribing namespace's
+// own ancestors, the ancestor's copy always wins: library propagation only ever fills in objects
+// that ordinary ancestor-to-descendant propagation wouldn't otherwise deliver. If more than one
+// subscribed library defines an object with the same name and GVK, the first matching entry in
+// Libraries wins.
+type LibrarySpec struct {
+	// Namespace is the library namespace whose source objects can be consumed by the subtrees
+	// listed in Subtrees.
+	Namespace string `json:"namespace"`
+
+	// Subtrees lists the namespaces whose subtrees - that is, the namespace itself and all its
+	// descendants - may consume objects from this library. A subtree root cannot be the library
+	// namespace itself, or an ancestor of it.
+	// +optional
+	Subtrees []string `json:"subtrees,omitempty"`
 }
 
 // HNCConfigurationStatus defines the observed state of HNC configuration.
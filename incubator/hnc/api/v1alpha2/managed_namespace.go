@@ -0,0 +1,96 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Constants for the managed namespace resource type.
+const (
+	ManagedNamespaces    = "managednamespaces"
+	ManagedNamespaceKind = "ManagedNamespace"
+)
+
+// ManagedNamespaceState describes the relationship between a ManagedNamespace and the namespace it
+// names. The state could be "Missing", "Ok", "Conflict" or "Forbidden"; see the comment on
+// ManagedNamespaceStatus.State for what each one means.
+type ManagedNamespaceState string
+
+const (
+	MNSMissing   ManagedNamespaceState = "Missing"
+	MNSOk        ManagedNamespaceState = "Ok"
+	MNSConflict  ManagedNamespaceState = "Conflict"
+	MNSForbidden ManagedNamespaceState = "Forbidden"
+)
+
+// ManagedNamespaceSpec defines the desired state of a ManagedNamespace.
+type ManagedNamespaceSpec struct {
+	// Parent is the name of the namespace that should become the parent of the namespace named by
+	// this ManagedNamespace, in the same way that a SubnamespaceAnchor's namespace is the parent of
+	// the subnamespace it creates.
+	Parent string `json:"parent"`
+}
+
+// ManagedNamespaceStatus defines the observed state of a ManagedNamespace.
+type ManagedNamespaceStatus struct {
+	// Describes the state of the managed namespace.
+	//
+	// Currently, the supported values are:
+	//
+	// - "Missing": the namespace has not been created yet. This should be the default state when
+	// the ManagedNamespace is just created.
+	//
+	// - "Ok": the namespace exists, is owned by this ManagedNamespace and has Spec.Parent as its
+	// parent. This is the only good state.
+	//
+	// - "Conflict": a namespace of the same name already exists but isn't owned by this
+	// ManagedNamespace, e.g. because it was created manually or by a SubnamespaceAnchor.
+	//
+	// - "Forbidden": Spec.Parent (or the requested namespace's name) is a namespace that HNC
+	// excludes from management, such as kube-system or hnc-system.
+	State ManagedNamespaceState `json:"state,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=managednamespaces,shortName=mns,scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// ManagedNamespace lets external systems request an HNC-managed namespace with a declared parent
+// without going through a SubnamespaceAnchor (which must live inside its parent, and so requires
+// access to that namespace) or hand-editing the target namespace's HierarchyConfiguration. The
+// name of the ManagedNamespace is the name of the namespace it manages.
+type ManagedNamespace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ManagedNamespaceSpec   `json:"spec,omitempty"`
+	Status ManagedNamespaceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ManagedNamespaceList contains a list of ManagedNamespace.
+type ManagedNamespaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ManagedNamespace `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ManagedNamespace{}, &ManagedNamespaceList{})
+}
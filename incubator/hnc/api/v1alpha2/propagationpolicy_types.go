@@ -0,0 +1,107 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Constants for the propagation policy resource type.
+const (
+	PropagationPolicies   = "propagationpolicies"
+	PropagationPolicyKind = "PropagationPolicy"
+)
+
+// PropagationRule names a set of source objects, in the namespace that owns the
+// PropagationPolicy, and the descendant namespaces they're allowed to propagate into. It's
+// deliberately similar in spirit to the propagate.hnc.x-k8s.io/select and treeSelect annotations
+// (see AnnotationSelector, AnnotationTreeSelector) but declared once, centrally, instead of being
+// repeated on every source object - which is what makes it auditable at scale.
+type PropagationRule struct {
+	// APIVersion is the API version (e.g. "v1", "rbac.authorization.k8s.io/v1") of the objects this
+	// rule applies to.
+	// +kubebuilder:validation:Required
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the Kind (e.g. "ConfigMap", "RoleBinding") of the objects this rule applies to.
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+
+	// NamePattern is a shell glob (as understood by path.Match) that a source object's name must
+	// match for this rule to apply to it. An empty pattern matches every name.
+	// +optional
+	NamePattern string `json:"namePattern,omitempty"`
+
+	// Selector, if set, restricts this rule to source objects whose labels match it. An unset
+	// selector matches every object.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Destinations lists the descendant namespaces that matching objects are allowed to propagate
+	// into. An empty list means every descendant namespace is allowed, which is equivalent to not
+	// setting a treeSelect annotation at all.
+	// +optional
+	Destinations []string `json:"destinations,omitempty"`
+}
+
+// PropagationPolicySpec defines the desired state of PropagationPolicy.
+type PropagationPolicySpec struct {
+	// Rules is the list of propagation rules that apply to objects created in this namespace. If a
+	// source object matches more than one rule, the union of their Destinations is used. A source
+	// object that doesn't match any rule here is unaffected - it's still governed only by its own
+	// annotations, and by the HNCConfiguration's mode for its type.
+	// +optional
+	Rules []PropagationRule `json:"rules,omitempty"`
+}
+
+// PropagationPolicyStatus defines the observed state of PropagationPolicy.
+type PropagationPolicyStatus struct {
+	// Conditions describes the errors, if any, found while reconciling this object - e.g. a rule
+	// whose Selector couldn't be parsed.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=propagationpolicies,shortName=prop,scope=Namespaced
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+
+// PropagationPolicy lets a namespace admin declare, in one auditable place, which objects (by
+// GVK, name pattern and label selector) are allowed to propagate to which descendant namespaces -
+// instead of relying solely on the propagate.hnc.x-k8s.io annotations scattered across individual
+// source objects. It's consumed by ObjectReconciler alongside those annotations; it doesn't
+// replace them, since an object still needs its own annotations validated the same way.
+type PropagationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PropagationPolicySpec   `json:"spec,omitempty"`
+	Status PropagationPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PropagationPolicyList contains a list of PropagationPolicy.
+type PropagationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PropagationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PropagationPolicy{}, &PropagationPolicyList{})
+}
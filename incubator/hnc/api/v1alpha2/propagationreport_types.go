@@ -0,0 +1,96 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Constants for the propagation report resource type.
+const (
+	PropagationReports    = "propagationreports"
+	PropagationReportKind = "PropagationReport"
+)
+
+// PropagationReportSpec defines the desired state of PropagationReport. It has no user-settable
+// fields yet - creating one in a namespace is enough to start tracking that namespace's subtree.
+type PropagationReportSpec struct {
+}
+
+// PropagationReportStatus defines the observed state of PropagationReport. It's an SLO-style
+// summary of how well HNC is keeping up with propagating objects from this namespace's subtree,
+// refreshed on every reconcile.
+type PropagationReportStatus struct {
+	// LastFullSyncTime is when this status was last computed. Since every field below is a
+	// snapshot, a status that hasn't been refreshed in a while is itself a sign something's wrong
+	// (e.g. the reconciler is stuck or its work queue is backed up).
+	// +optional
+	LastFullSyncTime *metav1.Time `json:"lastFullSyncTime,omitempty"`
+
+	// PendingWrites is the number of propagated copies that don't exist yet in the subtree, e.g.
+	// because a source object was created too recently for the object reconciler to have caught
+	// up, or because it's still retrying after a transient error.
+	PendingWrites int `json:"pendingWrites"`
+
+	// Failures is the number of propagated copies that exist but whose content no longer matches
+	// their source object. This is expected to be transient (a copy waiting for its own reconcile
+	// to catch up); a Failures count that never returns to zero across successive reports usually
+	// means something is stopping HNC from overwriting that copy, e.g. it's been claimed by another
+	// controller as unmanaged, or it's failing admission.
+	Failures int `json:"failures"`
+
+	// OldestOutOfDateCopy identifies the stalest entry counted in Failures, as "<namespace>/<name>
+	// (<Kind>)", or empty if Failures is 0.
+	// +optional
+	OldestOutOfDateCopy string `json:"oldestOutOfDateCopy,omitempty"`
+
+	// OldestOutOfDateCopyAge is how long OldestOutOfDateCopy has been out of date, measured from
+	// the last time HNC successfully wrote to it. It's omitted along with OldestOutOfDateCopy when
+	// Failures is 0.
+	// +optional
+	OldestOutOfDateCopyAge *metav1.Duration `json:"oldestOutOfDateCopyAge,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=propagationreports,shortName=prop,scope=Namespaced
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+
+// PropagationReport is an SLO-style health report for object propagation across a namespace's
+// subtree, refreshed periodically by PropagationReportReconciler. Tenant admins who don't have
+// RBAC to inspect the forest or the HNC manager's own logs can create one of these in the
+// namespace they own to get read access (via normal namespaced RBAC) to how well HNC is keeping
+// up with propagating objects into their descendants.
+type PropagationReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PropagationReportSpec   `json:"spec,omitempty"`
+	Status PropagationReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PropagationReportList contains a list of PropagationReport.
+type PropagationReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PropagationReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PropagationReport{}, &PropagationReportList{})
+}
@@ -16,6 +16,7 @@ limitations under the License.
 package v1alpha2
 
 import (
+	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,20 +26,106 @@ const (
 	AnchorKind       = "SubnamespaceAnchor"
 	AnchorAPIVersion = MetaGroup + "/v1alpha2"
 	SubnamespaceOf   = MetaGroup + "/subnamespace-of"
+
+	// SubnamespaceApprovedAnnotation, when set to "true" on a SubnamespaceAnchor, grants approval
+	// for HNC to create the subnamespace in a subtree that requires it (see
+	// HierarchyConfigurationSpec.RequireSubnamespaceApproval). Setting it is itself RBAC-checked: an
+	// update that flips this annotation to "true" is only allowed if the user has "update"
+	// permission on subnamespaceanchors/approval, so a cluster admin can let anyone create anchors
+	// while still restricting who can approve them.
+	SubnamespaceApprovedAnnotation = MetaGroup + "/approved"
+)
+
+// Condition types and reasons reported on a SubnamespaceAnchor's Status.Conditions when one of
+// its lifecycle hooks (see LifecycleHooks) doesn't behave as expected.
+const (
+	ConditionBadHookConfiguration string = "BadHookConfiguration"
+
+	ReasonHookInvalidConfiguration string = "HookInvalidConfiguration"
+	ReasonHookFailed               string = "HookFailed"
+	ReasonHookTimeout              string = "HookTimeout"
+)
+
+// HookFailurePolicy determines what happens if a lifecycle hook fails or times out.
+type HookFailurePolicy string
+
+const (
+	// HookFailurePolicyFail blocks the lifecycle event that the hook is attached to - e.g. a
+	// failing PreDelete hook prevents the subnamespace from being deleted. This is the default.
+	HookFailurePolicyFail HookFailurePolicy = "Fail"
+
+	// HookFailurePolicyIgnore lets the lifecycle event proceed even if the hook fails or times
+	// out. The failure is still recorded as a BadHookConfiguration condition.
+	HookFailurePolicyIgnore HookFailurePolicy = "Ignore"
 )
 
+// LifecycleHooks configures hooks that HNC runs at specific points in a subnamespace's lifecycle,
+// e.g. to provision or archive external resources that are tied to the subnamespace.
+type LifecycleHooks struct {
+	// PostCreate runs once, immediately after the subnamespace has been created.
+	// +optional
+	PostCreate *Hook `json:"postCreate,omitempty"`
+
+	// PreDelete runs before a subnamespace is cascading-deleted, e.g. to archive data or
+	// deregister external resources. It only runs when the subnamespace is actually about to be
+	// deleted as a result of its anchor being deleted or purged; it never runs when the anchor is
+	// merely being finalized without the underlying namespace being deleted (for example, because
+	// HNC itself is being uninstalled).
+	// +optional
+	PreDelete *Hook `json:"preDelete,omitempty"`
+}
+
+// Hook describes a single lifecycle hook, which is either a Kubernetes Job or an HTTP callback.
+// Exactly one of Job or HTTP must be set.
+type Hook struct {
+	// Job is the spec of a Job that HNC will create to run this hook. The Job is created in the
+	// subnamespace itself, and the hook is considered successful once the Job completes.
+	// +optional
+	Job *batchv1.JobSpec `json:"job,omitempty"`
+
+	// HTTP calls the given URL and treats any 2xx response as success.
+	// +optional
+	HTTP *HTTPHook `json:"http,omitempty"`
+
+	// TimeoutSeconds is how long to wait for the hook to complete before treating it as failed.
+	// If unset, defaults to 60 seconds.
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailurePolicy determines what happens if this hook fails or times out. Defaults to "Fail".
+	// +optional
+	// +kubebuilder:validation:Enum=Fail;Ignore
+	FailurePolicy HookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// HTTPHook describes an HTTP callback lifecycle hook.
+type HTTPHook struct {
+	// URL is the address to call. It must respond with a 2xx status code within the hook's
+	// TimeoutSeconds for the hook to be considered successful.
+	URL string `json:"url"`
+}
+
 // SubnamespaceAnchorState describes the state of the subnamespace. The state could be
-// "Missing", "Ok", "Conflict" or "Forbidden". The definitions will be described below.
+// "Missing", "Pending", "Ok", "Conflict" or "Forbidden". The definitions will be described below.
 type SubnamespaceAnchorState string
 
 // Anchor states, which are documented in the comment to SubnamespaceAnchorStatus.State.
 const (
 	Missing   SubnamespaceAnchorState = "Missing"
+	Pending   SubnamespaceAnchorState = "Pending"
 	Ok        SubnamespaceAnchorState = "Ok"
 	Conflict  SubnamespaceAnchorState = "Conflict"
 	Forbidden SubnamespaceAnchorState = "Forbidden"
 )
 
+// SubnamespaceAnchorSpec defines the desired state of SubnamespaceAnchor.
+type SubnamespaceAnchorSpec struct {
+	// Hooks configures lifecycle hooks that HNC runs for this subnamespace. If unset, no hooks
+	// are run.
+	// +optional
+	Hooks *LifecycleHooks `json:"hooks,omitempty"`
+}
+
 // SubnamespaceAnchorStatus defines the observed state of SubnamespaceAnchor.
 type SubnamespaceAnchorStatus struct {
 	// Describes the state of the subnamespace anchor.
@@ -48,6 +135,10 @@ type SubnamespaceAnchorStatus struct {
 	// - "Missing": the subnamespace has not been created yet. This should be the default state when
 	// the anchor is just created.
 	//
+	// - "Pending": the parent namespace requires approval before its subnamespaces are created (see
+	// HierarchyConfigurationSpec.RequireSubnamespaceApproval), and this anchor hasn't been approved
+	// yet. It will move to "Missing" once SubnamespaceApprovedAnnotation is set to "true".
+	//
 	// - "Ok": the subnamespace exists. This is the only good state of the anchor.
 	//
 	// - "Conflict": a namespace of the same name already exists. The admission controller will
@@ -56,6 +147,11 @@ type SubnamespaceAnchorStatus struct {
 	// - "Forbidden": the anchor was created in a namespace that doesn't allow children, such as
 	// kube-system or hnc-system. The admission controller will attempt to prevent this.
 	State SubnamespaceAnchorState `json:"status,omitempty"`
+
+	// Conditions describe the observed problems with running this anchor's lifecycle hooks. See
+	// Spec.Hooks.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -68,6 +164,7 @@ type SubnamespaceAnchor struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
+	Spec   SubnamespaceAnchorSpec   `json:"spec,omitempty"`
 	Status SubnamespaceAnchorStatus `json:"status,omitempty"`
 }
 
@@ -17,8 +17,11 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"contrib.go.opencensus.io/exporter/prometheus"
 	"contrib.go.opencensus.io/exporter/stackdriver"
@@ -39,10 +42,13 @@ import (
 	// +kubebuilder:scaffold:imports
 
 	v1a2 "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+	hncclient "sigs.k8s.io/multi-tenancy/incubator/hnc/internal/client"
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/config"
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/forest"
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/reconcilers"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/snapshot"
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/stats"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/subtreestats"
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/validators"
 )
 
@@ -56,6 +62,7 @@ var (
 	maxReconciles           int
 	enableLeaderElection    bool
 	leaderElectionId        string
+	managerIdentity         string
 	novalidation            bool
 	debugLogs               bool
 	testLog                 bool
@@ -65,6 +72,12 @@ var (
 	restartOnSecretRefresh  bool
 	unpropagatedAnnotations arrayArg
 	excludedNamespaces      arrayArg
+	trackedSubtreeRoots     arrayArg
+	logFormat               string
+	logSample               bool
+	maxTreeLabels           int
+	enableForestSnapshot    bool
+	enableSubtreeCounts     bool
 )
 
 func init() {
@@ -85,6 +98,8 @@ func main() {
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&leaderElectionId, "leader-election-id", "controller-leader-election-helper",
 		"Leader election id determines the name of the configmap that leader election will use for holding the leader lock.")
+	flag.StringVar(&managerIdentity, "manager-identity", "",
+		"An identity stamped onto every object this instance propagates, used to detect another HNC installation (e.g. one with a different --leader-election-id) also writing to this cluster instead of silently fighting over objects. Defaults to --leader-election-id, since that's already expected to be unique per installation.")
 	flag.BoolVar(&novalidation, "novalidation", false, "Disables validating webhook")
 	flag.BoolVar(&debugLogs, "debug-logs", false, "Shows verbose logs.")
 	flag.BoolVar(&testLog, "enable-test-log", false, "Enables test log.")
@@ -95,14 +110,31 @@ func main() {
 	flag.IntVar(&webhookServerPort, "webhook-server-port", 443, "The port that the webhook server serves at.")
 	flag.Var(&unpropagatedAnnotations, "unpropagated-annotation", "An annotation that, if present, will be stripped out of any propagated copies of an object. May be specified multiple times, with each instance specifying one annotation. See the user guide for more information.")
 	flag.Var(&excludedNamespaces, "excluded-namespace", "A namespace that, if present, will be excluded from HNC management. May be specified multiple times, with each instance specifying one namespace. See the user guide for more information.")
+	flag.Var(&trackedSubtreeRoots, "track-subtree-root", "A namespace that, if it's the root of a subtree, will get its own SubtreeRoot label value in propagation metrics instead of being folded into the shared bucket for untracked roots. May be specified multiple times, with each instance specifying one namespace. See the user guide for more information.")
 	flag.BoolVar(&restartOnSecretRefresh, "cert-restart-on-secret-refresh", false, "Kills the process when secrets are refreshed so that the pod can be restarted (secrets take up to 60s to be updated by running pods)")
+	flag.StringVar(&logFormat, "log-format", "console", "The log encoding format to use, either 'console' or 'json'. Use 'json' when shipping logs to a pipeline that expects structured input.")
+	flag.BoolVar(&logSample, "log-sample", true, "Enables log sampling so that repeated identical log messages - e.g. from a large propagation storm - don't overwhelm the logging pipeline.")
+	flag.IntVar(&maxTreeLabels, "max-tree-labels", 0, "The maximum number of tree labels HNC will allow a namespace to accumulate before rejecting hierarchy changes that would exceed it. 0 (the default) disables the check. See the user guide for more information.")
+	flag.BoolVar(&enableForestSnapshot, "enable-forest-snapshot", false, "Serves a read-only JSON dump of the forest on the metrics server, at "+snapshot.Path+", for consumption by external tooling such as cost-allocation or policy engines.")
+	flag.BoolVar(&enableSubtreeCounts, "enable-subtree-counts", false, "Serves a read-only JSON dump of the per-tenant namespace, propagated-object and source-object counts on the metrics server, at "+subtreestats.Path+", for consumption by cost-attribution and capacity tools.")
 	flag.Parse()
 	// Assign the array args to the configuration variables after the args are parsed.
 	config.UnpropagatedAnnotations = unpropagatedAnnotations
-	config.ExcludedNamespaces = make(map[string]bool)
+	excluded := make(map[string]bool, len(excludedNamespaces))
 	for _, exn := range excludedNamespaces {
-		config.ExcludedNamespaces[exn] = true
+		excluded[exn] = true
 	}
+	config.SetExcludedNamespaces(excluded)
+	config.SetMaxTreeLabels(maxTreeLabels)
+	tracked := make(map[string]bool, len(trackedSubtreeRoots))
+	for _, root := range trackedSubtreeRoots {
+		tracked[root] = true
+	}
+	config.SetTrackedSubtreeRoots(tracked)
+	if managerIdentity == "" {
+		managerIdentity = leaderElectionId
+	}
+	config.SetManagerIdentity(managerIdentity)
 
 	// Enable OpenCensus exporters to export metrics
 	// to Stackdriver Monitoring.
@@ -145,12 +177,32 @@ func main() {
 	if debugLogs {
 		logLevel = zapcore.DebugLevel
 	}
+	// Pick the encoder based on the --log-format flag. JSON is intended for production
+	// pipelines that ingest structured logs; console is easier to read when running locally.
+	zapOpts := []zap.Opts{zap.Level(logLevel), zap.StacktraceLevel(zapcore.PanicLevel)}
+	switch logFormat {
+	case "json":
+		zapOpts = append(zapOpts, zap.JSONEncoder())
+	case "console":
+		zapOpts = append(zapOpts, zap.ConsoleEncoder())
+	default:
+		setupLog.Error(fmt.Errorf("unknown log format %q", logFormat), "using console encoding instead")
+		zapOpts = append(zapOpts, zap.ConsoleEncoder())
+	}
+	// Propagation storms can cause the same message (e.g. "Reconciling") to be logged many
+	// times a second; sample those down so they don't swamp the logging pipeline, while still
+	// letting through the first few of each burst so the behaviour is still visible.
+	if logSample {
+		zapOpts = append(zapOpts, zap.RawZapOpts(stdzap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewSamplerWithOptions(core, time.Second, 100, 100)
+		})))
+	}
 	// Create a raw (upstream) zap logger that we can pass to both
 	// the zap stdlib log redirect and logr.Logger shim we use for controller-runtime.
 	// Stdlib is redirected at ErrorLevel since it should only log
 	// if it can't return an error, like in http.Server before a handler is invoked,
 	// and we expect other libraries to do the same.
-	rawlog := zap.NewRaw(zap.Level(logLevel), zap.StacktraceLevel(zapcore.PanicLevel))
+	rawlog := zap.NewRaw(zapOpts...)
 	stdzap.RedirectStdLogAt(rawlog, zapcore.ErrorLevel)
 	log := zapr.NewLogger(rawlog)
 	ctrl.SetLogger(log)
@@ -164,6 +216,12 @@ func main() {
 	// TODO: Better understand the behaviour of Burst, and consider making it equal to QPS if
 	// it turns out to be harmful.
 	cfg.Burst = int(cfg.QPS * 1.5)
+	// Wrap the transport so that if the apiserver's priority-and-fairness filter starts
+	// rejecting requests with 429s, HNC backs off its own rate instead of hammering a server
+	// that's already asking for less load.
+	cfg.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		return hncclient.NewAdaptivePacer(rt, float64(cfg.QPS))
+	}
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr,
@@ -220,6 +278,22 @@ func startControllers(mgr ctrl.Manager, certsCreated chan struct{}) {
 		os.Exit(1)
 	}
 
+	if enableForestSnapshot {
+		setupLog.Info("Registering forest snapshot endpoint", "path", snapshot.Path)
+		if err := snapshot.Create(mgr, f); err != nil {
+			setupLog.Error(err, "cannot register forest snapshot endpoint")
+			os.Exit(1)
+		}
+	}
+
+	if enableSubtreeCounts {
+		setupLog.Info("Registering subtree counts endpoint", "path", subtreestats.Path)
+		if err := subtreestats.Create(mgr); err != nil {
+			setupLog.Error(err, "cannot register subtree counts endpoint")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("All controllers started; setup complete")
 }
 
@@ -0,0 +1,121 @@
+// Package client provides transport-level helpers for HNC's connection to the apiserver.
+package client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/stats"
+)
+
+const (
+	// backoffFactor is how much AdaptivePacer cuts its QPS by every time it sees a 429, so a
+	// sustained storm of them backs off exponentially instead of limping along just above the
+	// threshold that's triggering them.
+	backoffFactor = 0.5
+
+	// recoverInterval is how often AdaptivePacer nudges its QPS back up towards maxQPS after a
+	// period with no 429s, so a transient overload doesn't permanently cripple HNC's throughput.
+	recoverInterval = 10 * time.Second
+
+	// recoverFactor is how much AdaptivePacer grows its QPS by on each recoverInterval tick.
+	recoverFactor = 1.1
+
+	// minQPSFraction bounds how far below maxQPS AdaptivePacer will ever throttle itself, so it
+	// always keeps making some forward progress even under continuous pressure.
+	minQPSFraction = 0.05
+)
+
+// AdaptivePacer wraps a RoundTripper and adaptively reduces the request rate it allows through
+// whenever the apiserver's priority-and-fairness filter starts responding with 429
+// (ClientRateLimiterExceeded), instead of retrying against a server that's already asking for
+// less load. It recovers back towards its configured ceiling once the 429s stop. The current
+// effective QPS is exported as a metric so operators can see when HNC is being throttled.
+type AdaptivePacer struct {
+	next    http.RoundTripper
+	maxQPS  float64
+	minQPS  float64
+	limiter *rate.Limiter
+
+	mu           sync.Mutex
+	lastRecovery time.Time
+}
+
+// NewAdaptivePacer wraps next in an AdaptivePacer that never exceeds maxQPS.
+func NewAdaptivePacer(next http.RoundTripper, maxQPS float64) *AdaptivePacer {
+	p := &AdaptivePacer{
+		next:         next,
+		maxQPS:       maxQPS,
+		minQPS:       maxQPS * minQPSFraction,
+		limiter:      rate.NewLimiter(rate.Limit(maxQPS), int(maxQPS)+1),
+		lastRecovery: time.Now(),
+	}
+	stats.RecordEffectiveQPS(maxQPS)
+	return p
+}
+
+// RoundTrip implements http.RoundTripper.
+func (p *AdaptivePacer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := p.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := p.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		p.backoff()
+	} else {
+		p.maybeRecover()
+	}
+	return resp, err
+}
+
+// backoff cuts the current QPS by backoffFactor, so a sustained 429 storm backs off
+// exponentially instead of limping along just above the threshold that's triggering them.
+func (p *AdaptivePacer) backoff() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	newQPS := p.limiter.Limit() * backoffFactor
+	if newQPS < rate.Limit(p.minQPS) {
+		newQPS = rate.Limit(p.minQPS)
+	}
+	p.setLimitLocked(newQPS)
+	p.lastRecovery = time.Now()
+}
+
+// maybeRecover grows the current QPS back towards maxQPS by recoverFactor, at most once per
+// recoverInterval, so a burst of successful requests right after a 429 doesn't immediately
+// undo the backoff.
+func (p *AdaptivePacer) maybeRecover() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if time.Since(p.lastRecovery) < recoverInterval {
+		return
+	}
+	p.lastRecovery = time.Now()
+
+	current := p.limiter.Limit()
+	if float64(current) >= p.maxQPS {
+		return
+	}
+	newQPS := float64(current) * recoverFactor
+	if newQPS > p.maxQPS {
+		newQPS = p.maxQPS
+	}
+	p.setLimitLocked(rate.Limit(newQPS))
+}
+
+// setLimitLocked updates the limiter's rate and records the new effective QPS as a metric. Callers
+// must hold p.mu.
+func (p *AdaptivePacer) setLimitLocked(qps rate.Limit) {
+	p.limiter.SetLimit(qps)
+	stats.RecordEffectiveQPS(float64(qps))
+}
@@ -8,10 +8,3 @@ package config
 // This value is controlled by the --unpropagated-annotation command line, which may be set multiple
 // times.
 var UnpropagatedAnnotations []string
-
-// ExcludedNamespaces is a list of namespaces used by reconcilers and validators
-// to exclude namespaces that shouldn't be reconciled or validated.
-//
-// This value is controlled by the --excluded-namespace command line, which may
-// be set multiple times.
-var ExcludedNamespaces map[string]bool
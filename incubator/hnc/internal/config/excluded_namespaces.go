@@ -0,0 +1,35 @@
+package config
+
+import "sync"
+
+// excludedNamespaces holds the set of namespaces that reconcilers and validators must not act on.
+// It's centralized here - instead of being read directly out of a package-level map from every
+// call site - so that the exclusion policy is applied consistently across HNC and can be updated
+// at runtime, not just once at startup.
+var excludedNamespaces struct {
+	sync.RWMutex
+	m map[string]bool
+}
+
+// SetExcludedNamespaces replaces the current set of excluded namespaces. It's called once at
+// startup with the namespaces named by the repeatable --excluded-namespace flag, but nothing
+// prevents it from being called again later to change the set while HNC is running.
+func SetExcludedNamespaces(nses map[string]bool) {
+	excludedNamespaces.Lock()
+	defer excludedNamespaces.Unlock()
+	excludedNamespaces.m = nses
+}
+
+// IsExcludedNamespace returns true if ns is excluded from HNC reconciliation and validation.
+// Every reconciler and validator that needs to skip excluded namespaces should call this instead
+// of consulting a namespace exclusion list of its own, so the policy stays consistent everywhere
+// it's enforced.
+//
+// This is also what the namespace validator consults to decide whether a namespace may carry
+// api.LabelExcludedNamespace: the label is only ever informational, since the set of excluded
+// namespaces is controlled solely by the --excluded-namespace flag, not by the label itself.
+func IsExcludedNamespace(ns string) bool {
+	excludedNamespaces.RLock()
+	defer excludedNamespaces.RUnlock()
+	return excludedNamespaces.m[ns]
+}
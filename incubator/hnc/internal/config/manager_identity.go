@@ -0,0 +1,31 @@
+package config
+
+import "sync"
+
+// managerIdentity holds the identity this HNC instance stamps onto propagated copies (see
+// api.AnnotationManagerIdentity), so a reconciler can recognize a copy last written by a
+// *different* HNC installation instead of its own. It's centralized here, rather than being a
+// plain package-level string, so it can be read and written safely if it's ever changed at
+// runtime instead of just once at startup.
+var managerIdentity struct {
+	sync.RWMutex
+	id string
+}
+
+// SetManagerIdentity sets the identity this HNC instance stamps onto propagated copies. It's
+// called once at startup with the value of the --manager-identity flag (falling back to the
+// --leader-election-id flag if unset, since that's already expected to be unique per
+// installation); nothing prevents it from being called again later to change the identity while
+// HNC is running.
+func SetManagerIdentity(id string) {
+	managerIdentity.Lock()
+	defer managerIdentity.Unlock()
+	managerIdentity.id = id
+}
+
+// ManagerIdentity returns this HNC instance's current identity.
+func ManagerIdentity() string {
+	managerIdentity.RLock()
+	defer managerIdentity.RUnlock()
+	return managerIdentity.id
+}
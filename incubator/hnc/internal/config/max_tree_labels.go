@@ -0,0 +1,30 @@
+package config
+
+import "sync"
+
+// maxTreeLabels holds the maximum number of tree labels (see api.LabelTreeDepthSuffix) that HNC
+// will allow a namespace to accumulate. It's centralized here, rather than being a plain
+// package-level int, so it can be read and written safely if it's ever changed at runtime instead
+// of just once at startup.
+var maxTreeLabels struct {
+	sync.RWMutex
+	n int
+}
+
+// SetMaxTreeLabels sets the maximum number of tree labels a namespace may have. It's called once
+// at startup with the value of the --max-tree-labels flag; a value of 0 (the default) disables the
+// check entirely. Nothing prevents it from being called again later to change the limit while HNC
+// is running.
+func SetMaxTreeLabels(n int) {
+	maxTreeLabels.Lock()
+	defer maxTreeLabels.Unlock()
+	maxTreeLabels.n = n
+}
+
+// MaxTreeLabels returns the current maximum number of tree labels a namespace may have, or 0 if
+// the check is disabled.
+func MaxTreeLabels() int {
+	maxTreeLabels.RLock()
+	defer maxTreeLabels.RUnlock()
+	return maxTreeLabels.n
+}
@@ -0,0 +1,45 @@
+package config
+
+import "sync"
+
+// trackedSubtreeRoots holds the set of subtree roots that should get their own label value in
+// per-tenant metrics. It's centralized here - instead of being read directly out of a
+// package-level map from every call site - so the cardinality budget is enforced consistently
+// wherever subtree-scoped metrics are recorded, and can be changed at runtime, not just once at
+// startup.
+var trackedSubtreeRoots struct {
+	sync.RWMutex
+	m map[string]bool
+}
+
+// SetTrackedSubtreeRoots replaces the current set of tracked subtree roots. It's called once at
+// startup with the roots named by the repeatable --track-subtree-root flag, but nothing prevents
+// it from being called again later to change the set while HNC is running.
+func SetTrackedSubtreeRoots(roots map[string]bool) {
+	trackedSubtreeRoots.Lock()
+	defer trackedSubtreeRoots.Unlock()
+	trackedSubtreeRoots.m = roots
+}
+
+// IsTrackedSubtreeRoot returns true if root is one of the subtree roots the operator has asked
+// to track individually. Callers that tag metrics by subtree root should fold untracked roots
+// into a shared bucket instead of using them as a label value, so a metric's cardinality stays
+// bounded by the configured budget rather than growing with the number of tenants.
+func IsTrackedSubtreeRoot(root string) bool {
+	trackedSubtreeRoots.RLock()
+	defer trackedSubtreeRoots.RUnlock()
+	return trackedSubtreeRoots.m[root]
+}
+
+// TrackedSubtreeRoots returns the current set of subtree roots the operator has asked to track
+// individually. Unlike IsTrackedSubtreeRoot, this is for callers that need to iterate the whole
+// set, e.g. to compute a per-root snapshot value instead of just tagging an incremental counter.
+func TrackedSubtreeRoots() []string {
+	trackedSubtreeRoots.RLock()
+	defer trackedSubtreeRoots.RUnlock()
+	roots := make([]string, 0, len(trackedSubtreeRoots.m))
+	for root := range trackedSubtreeRoots.m {
+		roots = append(roots, root)
+	}
+	return roots
+}
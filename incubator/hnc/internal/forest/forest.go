@@ -30,6 +30,24 @@ type Forest struct {
 	// We can also move the lock out of the forest and pass it to all reconcilers that need the lock.
 	// In that way, we don't need to put the list in the forest.
 	types []TypeSyncer
+
+	// librarySubscriptions records which subtree roots (see LibrarySpec) subscribe to which
+	// library namespaces, keyed by subtree root name. It's rebuilt wholesale by the ConfigReconciler
+	// every time the HNCConfiguration singleton is synced. Like `types`, access is guarded by the
+	// forest lock.
+	librarySubscriptions map[string][]string
+
+	// rootAllowlist records the set of namespaces allowed to act as the root of a multi-namespace
+	// tree (see api.HNCConfigurationSpec.RootAllowlist), or nil if the allowlist is disabled. It's
+	// replaced wholesale by the ConfigReconciler every time the HNCConfiguration singleton is
+	// synced, and consulted by the hierarchy webhook. Access is guarded by the forest lock.
+	rootAllowlist map[string]bool
+
+	// webhookExemptions records the identities exempted from the object validator's "cannot
+	// modify/delete propagated object" denials (see api.HNCConfigurationSpec.WebhookExemptions).
+	// It's replaced wholesale by the ConfigReconciler every time the HNCConfiguration singleton is
+	// synced, like rootAllowlist. Access is guarded by the forest lock.
+	webhookExemptions webhookExemptions
 }
 
 type namedNamespaces map[string]*Namespace
@@ -51,8 +69,38 @@ type TypeSyncer interface {
 	// GetMode gets the propagation mode of objects that are handled by the reconciler who implements the interface.
 	GetMode() api.SynchronizationMode
 
+	// SetTransform sets the field transform applied to propagated copies of objects handled by the
+	// reconciler who implements the interface, and re-syncs objects in the cluster if it changed.
+	SetTransform(context.Context, logr.Logger, *api.ObjectTransform) error
+
+	// SetLinkToSource sets whether propagated copies of objects handled by the reconciler who
+	// implements the interface get an owner-reference-like back-link and finalizer (see
+	// api.ResourceSpec.LinkToSource), and re-syncs objects in the cluster if it changed.
+	SetLinkToSource(context.Context, logr.Logger, bool) error
+
+	// GetLinkToSource gets whether objects handled by the reconciler who implements the interface
+	// get an owner-reference-like back-link and finalizer.
+	GetLinkToSource() bool
+
+	// SetIgnoreObjectWebhook sets whether the object validator skips this GVK entirely (see
+	// api.ResourceSpec.IgnoreObjectWebhook). Unlike the other Set* methods here, changing this never
+	// requires re-syncing any objects, since it only affects how future admission requests are
+	// validated, not what reconciliation writes.
+	SetIgnoreObjectWebhook(bool)
+
+	// GetIgnoreObjectWebhook gets whether the object validator skips this GVK entirely.
+	GetIgnoreObjectWebhook() bool
+
 	// GetNumPropagatedObjects returns the number of propagated objects on the apiserver.
 	GetNumPropagatedObjects() int
+
+	// GetNumPropagatedObjectsInNamespace returns the number of propagated objects on the apiserver
+	// that live in the given namespace.
+	GetNumPropagatedObjectsInNamespace(ns string) int
+
+	// GetNumDeferredObjects returns the number of propagated copies that are out of date because
+	// their source has a closed propagation window (see api.AnnotationPropagationWindow).
+	GetNumDeferredObjects() int
 }
 
 func NewForest() *Forest {
@@ -78,6 +126,17 @@ func (f *Forest) Get(nm string) *Namespace {
 		return nil
 	}
 	ns, ok := f.namespaces[nm]
+	if ok {
+		// A namespace that's still tombstoned is kept around so a delete/create race for the same
+		// name can reuse its entry, but once the tombstone expires without the namespace coming
+		// back, it's just as stale as if it had never been tombstoned - replace it with a fresh
+		// object rather than handing out one with leftover labels, anchors, etc. from the deleted
+		// incarnation.
+		if !ns.exists && len(ns.children) == 0 && !ns.IsTombstoned() && !ns.tombstonedAt.IsZero() {
+			delete(f.namespaces, nm)
+			ok = false
+		}
+	}
 	if ok {
 		return ns
 	}
@@ -0,0 +1,39 @@
+package forest
+
+// SetLibrarySubscriptions replaces the forest's whole set of subtree-root-to-library-namespace
+// subscriptions (see api.LibrarySpec) with subs, which is keyed by subtree root name. It's called
+// by the ConfigReconciler every time it resyncs the HNCConfiguration singleton; entries for
+// libraries or subtrees that turned out to be misconfigured should already have been dropped by
+// the caller.
+func (f *Forest) SetLibrarySubscriptions(subs map[string][]string) {
+	f.librarySubscriptions = subs
+}
+
+// librariesFor returns the names of the library namespaces subscribed to by ns's subtree - that
+// is, by ns itself or by any of its ancestors, since a subscription made at a subtree root is
+// inherited by the whole subtree.
+func (f *Forest) librariesFor(ns *Namespace) []string {
+	libs := []string{}
+	for _, anm := range ns.AncestryNames() {
+		libs = append(libs, f.librarySubscriptions[anm]...)
+	}
+	return libs
+}
+
+// LibrarySubscriberNamespaces returns the names of every namespace - subtree roots and all their
+// descendants - that subscribes, directly or by inheritance, to the library namespace libNs. It's
+// used to enqueue the right namespaces for reconciliation when a library's source objects change.
+func (f *Forest) LibrarySubscriberNamespaces(libNs string) []string {
+	nms := []string{}
+	for root, libs := range f.librarySubscriptions {
+		for _, l := range libs {
+			if l != libNs {
+				continue
+			}
+			nms = append(nms, root)
+			nms = append(nms, f.Get(root).DescendantNames()...)
+			break
+		}
+	}
+	return nms
+}
@@ -0,0 +1,35 @@
+package forest
+
+import (
+	"sort"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLibrarySubscriptions(t *testing.T) {
+	g := NewWithT(t)
+	f := NewForest()
+
+	// a -> b (subtree root), lib is a standalone library namespace.
+	a := f.Get("a")
+	a.SetExists()
+	b := f.Get("b")
+	b.SetExists()
+	b.SetParent(a)
+	lib := f.Get("lib")
+	lib.SetExists()
+
+	f.SetLibrarySubscriptions(map[string][]string{"a": {"lib"}})
+
+	g.Expect(f.librariesFor(a)).Should(Equal([]string{"lib"}))
+	// b inherits a's subscription since it's in a's subtree.
+	g.Expect(f.librariesFor(b)).Should(Equal([]string{"lib"}))
+	g.Expect(f.librariesFor(lib)).Should(BeEmpty())
+
+	subs := f.LibrarySubscriberNamespaces("lib")
+	sort.Strings(subs)
+	g.Expect(subs).Should(Equal([]string{"a", "b"}))
+
+	g.Expect(f.LibrarySubscriberNamespaces("nonexistent")).Should(BeEmpty())
+}
@@ -2,6 +2,7 @@ package forest
 
 import (
 	"reflect"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -10,6 +11,13 @@ import (
 	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
 )
 
+// tombstoneTTL is how long a deleted namespace's forest entry is kept around, instead of being
+// garbage collected immediately, so that a reconcile racing the deletion (e.g. one that was
+// already in flight, or one triggered by a stale informer cache) can tell "this namespace was
+// just deleted" apart from "this namespace was deleted a while ago and something's wrong" without
+// re-logging the same error on every retry. See Namespace.IsTombstoned.
+const tombstoneTTL = 5 * time.Second
+
 // While storing the V in GVK is not strictly necessary to match what's in the HNC type configuration,
 // as a client of the API server, HNC will be to be reading and writing versions of the API to communicate
 // with the API server. Since we need the V to work with the API server anyways anyways, we will choose to
@@ -26,6 +34,19 @@ type Namespace struct {
 	exists                 bool
 	allowCascadingDeletion bool
 
+	// tombstonedAt is the time at which this namespace was last found to be missing, or the zero
+	// value if it currently exists or was never known to exist. See IsTombstoned.
+	tombstonedAt time.Time
+
+	// requireSubnamespaceApproval indicates that new subnamespaces of this namespace (or any of
+	// its descendants) must be approved before HNC will create them. See
+	// api.HierarchyConfigurationSpec.RequireSubnamespaceApproval.
+	requireSubnamespaceApproval bool
+
+	// maxSubnamespaces is the maximum number of subnamespaces allowed directly under this namespace,
+	// or 0 for no limit. See api.HierarchyConfigurationSpec.MaxSubnamespaces.
+	maxSubnamespaces int
+
 	// labels store the original namespaces' labels
 	labels map[string]string
 
@@ -53,6 +74,25 @@ type Namespace struct {
 	// tree label of itself. The key is the tree label without ".tree.hnc.x-k8s.io/depth" suffix.
 	// The value is the depth.
 	ExternalTreeLabels map[string]int
+
+	// propagationPolicies stores the specs of the PropagationPolicy objects declared in this
+	// namespace, so ObjectReconciler can consult them without making its own apiserver calls. It's
+	// kept up to date by the PropagationPolicyReconciler.
+	propagationPolicies []api.PropagationPolicySpec
+
+	// ownedLabels and ownedAnnotations store this namespace's own HierarchyConfigurationSpec.Labels
+	// and .Annotations, i.e. the metadata it declares should be propagated to its descendants. Every
+	// namespace needs to remember its ancestors' declarations too when computing its own effective
+	// metadata, so these are read via Parent() rather than looked up in the apiserver.
+	ownedLabels      []api.MetaKVP
+	ownedAnnotations []api.MetaKVP
+
+	// propagatedLabels and propagatedAnnotations store the effective set of label/annotation
+	// key/values that HierarchyConfigReconciler last wrote onto this namespace because some
+	// ancestor (or this namespace itself) declared them. The Namespace validator uses these to
+	// reject changes that would remove or alter a propagated entry.
+	propagatedLabels      map[string]string
+	propagatedAnnotations map[string]string
 }
 
 // Name returns the name of the namespace, of "<none>" if the namespace is nil.
@@ -73,10 +113,13 @@ func (ns *Namespace) Exists() bool {
 	return ns.exists
 }
 
-// SetExists marks this namespace as existing, returning true if didn't previously exist.
+// SetExists marks this namespace as existing, returning true if didn't previously exist. Since
+// the namespace demonstrably exists again, any tombstone left behind by an earlier deletion no
+// longer applies.
 func (ns *Namespace) SetExists() bool {
 	changed := !ns.exists
 	ns.exists = true
+	ns.tombstonedAt = time.Time{}
 	return changed
 }
 
@@ -86,10 +129,22 @@ func (ns *Namespace) UnsetExists() bool {
 	changed := ns.exists
 	ns.SetParent(nil) // Unreconciled namespaces can't specify parents
 	ns.exists = false
+	if changed {
+		ns.tombstonedAt = time.Now()
+	}
 	ns.clean() // clean up if this is a useless data structure
 	return changed
 }
 
+// IsTombstoned returns true if this namespace was deleted recently enough (within tombstoneTTL)
+// that a reconciler encountering it as missing should treat that as an expected, still-settling
+// race rather than a persistent problem worth complaining about loudly. It returns false for a
+// namespace that currently exists, that was never known to have existed, or whose tombstone has
+// expired.
+func (ns *Namespace) IsTombstoned() bool {
+	return !ns.tombstonedAt.IsZero() && time.Since(ns.tombstonedAt) < tombstoneTTL
+}
+
 func (ns *Namespace) GetLabels() labels.Set {
 	return labels.Set(ns.labels)
 }
@@ -112,6 +167,13 @@ func (ns *Namespace) clean() {
 		return
 	}
 
+	// Don't clean up a namespace that was only just deleted - keep its entry, and whatever state
+	// reconcilers stashed on it, around until the tombstone expires so a delete/create race for the
+	// same name doesn't look like the namespace popping in and out of the forest.
+	if ns.IsTombstoned() {
+		return
+	}
+
 	// Remove from the forest.
 	delete(ns.forest.namespaces, ns.name)
 }
@@ -140,6 +202,46 @@ func (ns *Namespace) AllowsCascadingDeletion() bool {
 	return ns.parent.AllowsCascadingDeletion()
 }
 
+// UpdateRequireSubnamespaceApproval updates if this namespace requires approval before HNC will
+// create its subnamespaces. It returns true if the value has changed, false otherwise.
+func (ns *Namespace) UpdateRequireSubnamespaceApproval(rsa bool) bool {
+	if ns.requireSubnamespaceApproval == rsa {
+		return false
+	}
+	ns.requireSubnamespaceApproval = rsa
+	return true
+}
+
+// RequiresSubnamespaceApproval returns true if the namespace's or any of the ancestors'
+// requireSubnamespaceApproval field is set to true.
+func (ns *Namespace) RequiresSubnamespaceApproval() bool {
+	if ns.requireSubnamespaceApproval == true {
+		return true
+	}
+	if ns.parent == nil || ns.CycleNames() != nil {
+		return false
+	}
+
+	// This namespace is neither a root nor in a cycle, so this line can't cause a stack overflow.
+	return ns.parent.RequiresSubnamespaceApproval()
+}
+
+// UpdateMaxSubnamespaces updates the maximum number of subnamespaces allowed directly under this
+// namespace. It returns true if the value has changed, false otherwise.
+func (ns *Namespace) UpdateMaxSubnamespaces(max int) bool {
+	if ns.maxSubnamespaces == max {
+		return false
+	}
+	ns.maxSubnamespaces = max
+	return true
+}
+
+// MaxSubnamespaces returns the maximum number of subnamespaces allowed directly under this
+// namespace, or 0 if there's no limit.
+func (ns *Namespace) MaxSubnamespaces() int {
+	return ns.maxSubnamespaces
+}
+
 // SetAnchors updates the anchors and returns a difference between the new/old list.
 func (ns *Namespace) SetAnchors(anchors []string) (diff []string) {
 	add := make(map[string]bool)
@@ -174,6 +276,64 @@ func (ns *Namespace) HasAnchor(n string) bool {
 	return false
 }
 
+// SetPropagationPolicies replaces the set of PropagationPolicy specs declared in this namespace.
+func (ns *Namespace) SetPropagationPolicies(specs []api.PropagationPolicySpec) {
+	ns.propagationPolicies = specs
+}
+
+// PropagationPolicies returns the PropagationPolicy specs declared in this namespace.
+func (ns *Namespace) PropagationPolicies() []api.PropagationPolicySpec {
+	return ns.propagationPolicies
+}
+
+// SetOwnedLabels replaces this namespace's own declared HierarchyConfigurationSpec.Labels. It
+// returns true if the declared labels have changed, so the caller knows whether descendants need
+// to be re-reconciled to pick up the change.
+func (ns *Namespace) SetOwnedLabels(kvps []api.MetaKVP) bool {
+	updated := !reflect.DeepEqual(ns.ownedLabels, kvps)
+	ns.ownedLabels = kvps
+	return updated
+}
+
+// OwnedLabels returns this namespace's own declared HierarchyConfigurationSpec.Labels.
+func (ns *Namespace) OwnedLabels() []api.MetaKVP {
+	return ns.ownedLabels
+}
+
+// SetOwnedAnnotations replaces this namespace's own declared HierarchyConfigurationSpec.Annotations.
+// It returns true if the declared annotations have changed.
+func (ns *Namespace) SetOwnedAnnotations(kvps []api.MetaKVP) bool {
+	updated := !reflect.DeepEqual(ns.ownedAnnotations, kvps)
+	ns.ownedAnnotations = kvps
+	return updated
+}
+
+// OwnedAnnotations returns this namespace's own declared HierarchyConfigurationSpec.Annotations.
+func (ns *Namespace) OwnedAnnotations() []api.MetaKVP {
+	return ns.ownedAnnotations
+}
+
+// SetPropagatedMetadata records the effective set of propagated labels and annotations that were
+// just written onto this namespace, for the Namespace validator to check tampering against. It
+// returns true if either set has changed since the last call.
+func (ns *Namespace) SetPropagatedMetadata(labels, annotations map[string]string) bool {
+	updated := !reflect.DeepEqual(ns.propagatedLabels, labels) || !reflect.DeepEqual(ns.propagatedAnnotations, annotations)
+	ns.propagatedLabels = labels
+	ns.propagatedAnnotations = annotations
+	return updated
+}
+
+// PropagatedLabels returns the effective set of labels that some ancestor (or this namespace
+// itself) has declared should be propagated onto this namespace.
+func (ns *Namespace) PropagatedLabels() map[string]string {
+	return ns.propagatedLabels
+}
+
+// PropagatedAnnotations is the annotation equivalent of PropagatedLabels.
+func (ns *Namespace) PropagatedAnnotations() map[string]string {
+	return ns.propagatedAnnotations
+}
+
 // IsExternal returns true if the namespace is not managed by HNC.
 func (ns *Namespace) IsExternal() bool {
 	return len(ns.ExternalTreeLabels) > 0
@@ -11,6 +11,9 @@ func (ns *Namespace) HasLocalCritCondition() bool {
 		if cond.Type == api.ConditionActivitiesHalted && cond.Reason != api.ReasonAncestor {
 			return true
 		}
+		if cond.Type == api.ConditionSyncPaused {
+			return true
+		}
 	}
 	return false
 }
@@ -50,6 +50,19 @@ func (ns *Namespace) GetNumSourceObjects(gvk schema.GroupVersionKind) int {
 	return len(ns.sourceObjects[gvk])
 }
 
+// GetLibrarySourceObjects returns the source object with the given name, from each library
+// namespace subscribed to by ns's subtree (see LibrarySpec), in subscription order. Unlike
+// GetAncestorSourceObjects, library namespaces aren't ancestors of ns.
+func (ns *Namespace) GetLibrarySourceObjects(gvk schema.GroupVersionKind, name string) []*unstructured.Unstructured {
+	objs := []*unstructured.Unstructured{}
+	for _, lnm := range ns.forest.librariesFor(ns) {
+		if o := ns.forest.Get(lnm).GetSourceObject(gvk, name); o != nil {
+			objs = append(objs, o)
+		}
+	}
+	return objs
+}
+
 // GetAncestorSourceObjects returns all source objects with the specified name
 // in the ancestors (including itself) from top down. If the name is not
 // specified, all the source objects in the ancestors will be returned.
@@ -119,6 +119,12 @@ func (ns *Namespace) AncestryNames() []string {
 	return ancestors
 }
 
+// Root returns the name of the root of this namespace's tree. If the namespace has no ancestors,
+// it is its own root. This is cycle-safe since it's built on AncestryNames.
+func (ns *Namespace) Root() string {
+	return ns.AncestryNames()[0]
+}
+
 // CycleNames returns nil if the namespace is not in a cycle, or a list of names in the cycle if
 // it is. All namespaces in the cycle return the same list, which is the same as calling
 // ns.AncestryNames() on the namespaces with the lexicographically smallest name.
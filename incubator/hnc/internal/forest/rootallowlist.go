@@ -0,0 +1,27 @@
+package forest
+
+// SetRootAllowlist replaces the forest's set of namespaces allowed to act as the root of a
+// multi-namespace tree (see api.HNCConfigurationSpec.RootAllowlist). It's called by the
+// ConfigReconciler every time it resyncs the HNCConfiguration singleton. An empty or nil list
+// disables the allowlist, which is also the zero-value behavior of a freshly created Forest.
+func (f *Forest) SetRootAllowlist(allowed []string) {
+	if len(allowed) == 0 {
+		f.rootAllowlist = nil
+		return
+	}
+	m := make(map[string]bool, len(allowed))
+	for _, nm := range allowed {
+		m[nm] = true
+	}
+	f.rootAllowlist = m
+}
+
+// IsAllowedRoot returns true if nm is allowed to act as the root of a multi-namespace tree - that
+// is, to have descendants while having no parent of its own. It always returns true if the
+// allowlist is disabled (the default).
+func (f *Forest) IsAllowedRoot(nm string) bool {
+	if f.rootAllowlist == nil {
+		return true
+	}
+	return f.rootAllowlist[nm]
+}
@@ -0,0 +1,42 @@
+package forest
+
+// NamespaceSnapshot is a read-only, JSON-serializable view of a single namespace in a Forest,
+// for external tooling (e.g. cost-allocation or policy engines) that needs the whole hierarchy
+// without listing every HierarchyConfiguration object.
+type NamespaceSnapshot struct {
+	Name                   string   `json:"name"`
+	Parent                 string   `json:"parent,omitempty"`
+	Children               []string `json:"children,omitempty"`
+	IsSub                  bool     `json:"isSub,omitempty"`
+	AllowCascadingDeletion bool     `json:"allowCascadingDeletion,omitempty"`
+	Manager                string   `json:"manager,omitempty"`
+}
+
+// Snapshot returns a point-in-time copy of every existing namespace in the forest. It's safe to
+// call from any goroutine.
+func (f *Forest) Snapshot() []NamespaceSnapshot {
+	f.Lock()
+	defer f.Unlock()
+
+	nms := f.GetNamespaceNames()
+	snap := make([]NamespaceSnapshot, 0, len(nms))
+	for _, nm := range nms {
+		ns := f.Get(nm)
+		if !ns.Exists() {
+			continue
+		}
+		parent := ""
+		if p := ns.Parent(); p != nil {
+			parent = p.Name()
+		}
+		snap = append(snap, NamespaceSnapshot{
+			Name:                   ns.Name(),
+			Parent:                 parent,
+			Children:               ns.ChildNames(),
+			IsSub:                  ns.IsSub,
+			AllowCascadingDeletion: ns.AllowsCascadingDeletion(),
+			Manager:                ns.Manager,
+		})
+	}
+	return snap
+}
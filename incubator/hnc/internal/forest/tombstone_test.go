@@ -0,0 +1,75 @@
+package forest
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTombstoneDeleteCreateRace(t *testing.T) {
+	g := NewWithT(t)
+	f := NewForest()
+
+	// Simulate the namespace reconciler seeing "foo" for the first time.
+	foo := f.Get("foo")
+	g.Expect(foo.SetExists()).Should(BeTrue())
+
+	// The namespace gets deleted.
+	g.Expect(foo.UnsetExists()).Should(BeTrue())
+	g.Expect(foo.Exists()).Should(BeFalse())
+	g.Expect(foo.IsTombstoned()).Should(BeTrue())
+
+	// A reconcile that raced the deletion (e.g. queued beforehand, or triggered by a stale
+	// informer) fetches "foo" again before it's recreated. It should get back the same tombstoned
+	// entry rather than a namespace that looks like it's never been heard of, and the entry must
+	// survive being asked for more than once.
+	again := f.Get("foo")
+	g.Expect(again).Should(BeIdenticalTo(foo))
+	g.Expect(again.Exists()).Should(BeFalse())
+	g.Expect(again.IsTombstoned()).Should(BeTrue())
+
+	// The namespace is recreated under the same name before the tombstone expires. The same entry
+	// is reused and is no longer considered tombstoned.
+	recreated := f.Get("foo")
+	g.Expect(recreated.SetExists()).Should(BeTrue())
+	g.Expect(recreated.IsTombstoned()).Should(BeFalse())
+}
+
+func TestTombstoneExpiry(t *testing.T) {
+	g := NewWithT(t)
+	f := NewForest()
+
+	foo := f.Get("foo")
+	g.Expect(foo.SetExists()).Should(BeTrue())
+	g.Expect(foo.UnsetExists()).Should(BeTrue())
+
+	// Backdate the tombstone as though the deletion happened long ago, simulating the TTL expiring
+	// without the namespace ever coming back.
+	foo.tombstonedAt = time.Now().Add(-2 * tombstoneTTL)
+	g.Expect(foo.IsTombstoned()).Should(BeFalse())
+
+	// Once the tombstone has expired, a fresh Get shouldn't hand back the stale entry - any leftover
+	// state from the deleted namespace (labels, anchors, etc.) must not leak into whatever gets
+	// created under this name next.
+	foo.Anchors = []string{"leftover"}
+	again := f.Get("foo")
+	g.Expect(again).ShouldNot(BeIdenticalTo(foo))
+	g.Expect(again.Anchors).Should(BeEmpty())
+}
+
+func TestTombstoneKeepsNamespaceWithChildren(t *testing.T) {
+	g := NewWithT(t)
+	f := NewForest()
+
+	parent := f.Get("parent")
+	g.Expect(parent.SetExists()).Should(BeTrue())
+	child := f.Get("child")
+	g.Expect(child.SetExists()).Should(BeTrue())
+	child.SetParent(parent)
+
+	// Deleting the parent namespace clears its own parent pointer (it has none here) but must not
+	// be purged from the forest while it still has a child, tombstoned or not.
+	g.Expect(parent.UnsetExists()).Should(BeTrue())
+	g.Expect(f.Get("parent")).Should(BeIdenticalTo(parent))
+}
@@ -0,0 +1,59 @@
+package forest
+
+import (
+	"strings"
+
+	authnv1 "k8s.io/api/authentication/v1"
+)
+
+// webhookExemptions records the identities exempted from the object validator's "cannot
+// modify/delete propagated object" denials (see api.HNCConfigurationSpec.WebhookExemptions). It's
+// replaced wholesale by the ConfigReconciler every time the HNCConfiguration singleton is synced,
+// like rootAllowlist. Access is guarded by the forest lock.
+type webhookExemptions struct {
+	users  map[string]bool
+	groups map[string]bool
+}
+
+// SetWebhookExemptions replaces the forest's set of identities exempted from the object
+// validator's "cannot modify/delete propagated object" denials. serviceAccounts entries are
+// "<namespace>/<name>" pairs, which are translated into the equivalent
+// "system:serviceaccount:<namespace>:<name>" username so they can be matched against an admission
+// request's userInfo.username alongside users.
+func (f *Forest) SetWebhookExemptions(users, groups, serviceAccounts []string) {
+	we := webhookExemptions{
+		users:  make(map[string]bool, len(users)+len(serviceAccounts)),
+		groups: make(map[string]bool, len(groups)),
+	}
+	for _, u := range users {
+		we.users[u] = true
+	}
+	for _, sa := range serviceAccounts {
+		nsName := strings.SplitN(sa, "/", 2)
+		if len(nsName) != 2 {
+			continue
+		}
+		we.users["system:serviceaccount:"+nsName[0]+":"+nsName[1]] = true
+	}
+	for _, g := range groups {
+		we.groups[g] = true
+	}
+	f.webhookExemptions = we
+}
+
+// IsExemptWebhookUser returns true if user is listed in the forest's current webhook exemptions,
+// whether by username or by group membership.
+func (f *Forest) IsExemptWebhookUser(user *authnv1.UserInfo) bool {
+	if user == nil {
+		return false
+	}
+	if f.webhookExemptions.users[user.Username] {
+		return true
+	}
+	for _, g := range user.Groups {
+		if f.webhookExemptions.groups[g] {
+			return true
+		}
+	}
+	return false
+}
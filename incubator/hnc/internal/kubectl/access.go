@@ -0,0 +1,69 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/rbacresolver"
+)
+
+var accessCmd = &cobra.Command{
+	Use:   "access USER NAMESPACE",
+	Short: "Show the effective RBAC permissions a subject has in a namespace, and where each grant is inherited from",
+	Long: "Walks NAMESPACE and its ancestors, resolves any Roles/RoleBindings and ClusterRoles/ClusterRoleBindings " +
+		"that grant USER access, and prints the resulting permissions along with the namespace each one came from.",
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		user, nnm := args[0], args[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		grants, err := rbacresolver.Resolve(ctx, k8sClient, client.getHierarchy, user, nnm)
+		if err != nil {
+			fmt.Printf("Error resolving access: %s\n", err)
+			os.Exit(1)
+		}
+
+		if len(grants) == 0 {
+			fmt.Printf("%s has no RBAC grants in %q\n", user, nnm)
+			return
+		}
+
+		for _, g := range grants {
+			origin := "defined here"
+			if g.Inherited(nnm) {
+				origin = fmt.Sprintf("inherited from %q", g.Source)
+			} else if g.Source == "" {
+				origin = "cluster-wide"
+			}
+			fmt.Printf("RoleBinding %q (%s %q, %s):\n", g.RoleBinding, g.RoleKind, g.RoleName, origin)
+			for _, r := range g.Rules {
+				fmt.Printf("  - verbs=%v apiGroups=%v resources=%v\n", r.Verbs, r.APIGroups, r.Resources)
+			}
+		}
+	},
+}
+
+func newAccessCmd() *cobra.Command {
+	return accessCmd
+}
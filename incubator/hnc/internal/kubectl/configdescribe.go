@@ -35,6 +35,8 @@ var configDescribeCmd = &cobra.Command{
 			switch r.Mode {
 			case api.Propagate:
 				action = "Propagating"
+			case api.AllowPropagate:
+				action = "Propagating (opt-in)"
 			case api.Remove:
 				action = "Removing"
 			default:
@@ -26,8 +26,8 @@ import (
 )
 
 var setResourceCmd = &cobra.Command{
-	Use: fmt.Sprintf("set-resource RESOURCE [--group GROUP] [--force] --mode <%s|%s|%s>",
-		api.Propagate, api.Remove, api.Ignore),
+	Use: fmt.Sprintf("set-resource RESOURCE [--group GROUP] [--force] --mode <%s|%s|%s|%s>",
+		api.Propagate, api.AllowPropagate, api.Remove, api.Ignore),
 	Short: "Sets the HNC configuration of a specific resource",
 	Example: fmt.Sprintf("  # Set configuration of a core type\n" +
 		"  kubectl hns config set-resource secrets --mode Ignore\n\n" +
@@ -75,15 +75,20 @@ var setResourceCmd = &cobra.Command{
 	},
 }
 
-// normalizeModeString corrects format of input Synchronization Mode string
+// normalizeModeString corrects format of input Synchronization Mode string. It's special-cased
+// rather than just relying on strings.Title since that only capitalizes the first letter, which
+// isn't enough to turn "allowpropagate" back into "AllowPropagate".
 func normalizeModeString(modeStr string) string {
 	low := strings.ToLower(modeStr)
+	if low == strings.ToLower(string(api.AllowPropagate)) {
+		return string(api.AllowPropagate)
+	}
 	return strings.Title(low)
 }
 
 func newSetResourceCmd() *cobra.Command {
 	setResourceCmd.Flags().String("group", "", "The group of the resource; may be omitted for core resources (or explicitly set to the empty string)")
-	setResourceCmd.Flags().String("mode", "", "The synchronization mode: one of Propagate, Remove or Ignore")
+	setResourceCmd.Flags().String("mode", "", "The synchronization mode: one of Propagate, AllowPropagate, Remove or Ignore")
 	setResourceCmd.Flags().BoolP("force", "f", false, "Allow the synchronization mode to be changed directly from Ignore to Propagate despite the dangers of doing so")
 	return setResourceCmd
 }
@@ -17,16 +17,52 @@ package kubectl
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 )
 
+// anchorReadyTimeout is how long "create -f" waits for a newly created subnamespace anchor to
+// become ready before giving up on it and everything underneath it in the tree.
+const anchorReadyTimeout = 30 * time.Second
+
+// treeNode is the (recursive) shape of the file passed to "create -f": a namespace which must
+// already exist, and the subnamespaces to create underneath it, in order, along with any labels
+// or annotations to apply to each once it's ready.
+type treeNode struct {
+	Namespace   string            `json:"namespace"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Children    []treeNode        `json:"children,omitempty"`
+}
+
 var createCmd = &cobra.Command{
 	Use:   "create -n PARENT CHILD",
 	Short: "Creates a subnamespace under the given parent.",
-	Args:  cobra.ExactArgs(1),
+	Example: `	# Create 'child' as a subnamespace of 'parent'
+	kubectl hns create child -n parent
+
+	# Create a whole tree of subnamespaces, with labels and annotations, from a file
+	kubectl hns create -f tree.yaml`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("filename")
+		if file != "" {
+			if len(args) != 0 {
+				fmt.Println("Error: cannot pass a subnamespace name together with -f/--filename")
+				os.Exit(1)
+			}
+			createFromFile(file)
+			return
+		}
+
+		if len(args) != 1 {
+			fmt.Println("Error: must either pass a subnamespace name, or -f/--filename")
+			os.Exit(1)
+		}
 		parent, _ := cmd.Flags().GetString("namespace")
 		if parent == "" {
 			fmt.Println("Error: parent must be set via --namespace or -n")
@@ -37,7 +73,53 @@ var createCmd = &cobra.Command{
 	},
 }
 
+// createFromFile reads a tree of subnamespaces from a YAML file and creates it one namespace at
+// a time, in top-down order, waiting for each anchor to become ready (so its children, which
+// need it to exist, can be created next) before moving on.
+func createFromFile(path string) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		fmt.Printf("Error reading %s: %s\n", path, err)
+		os.Exit(1)
+	}
+
+	root := &treeNode{}
+	if err := yaml.UnmarshalStrict(data, root); err != nil {
+		fmt.Printf("Error parsing %s: %s\n", path, err)
+		os.Exit(1)
+	}
+	if root.Namespace == "" {
+		fmt.Println("Error: the root of the tree must set 'namespace' to the name of an existing namespace")
+		os.Exit(1)
+	}
+
+	createChildren(root.Namespace, root.Children)
+	fmt.Printf("Successfully created the subnamespace tree rooted at %q\n", root.Namespace)
+}
+
+func createChildren(parent string, children []treeNode) {
+	for _, c := range children {
+		if c.Namespace == "" {
+			fmt.Printf("Error: every node under %q must set 'namespace'\n", parent)
+			os.Exit(1)
+		}
+
+		client.createAnchor(parent, c.Namespace)
+		if err := client.waitForAnchor(parent, c.Namespace, anchorReadyTimeout); err != nil {
+			fmt.Printf("Error: %s\n", err)
+			os.Exit(1)
+		}
+		if len(c.Labels) > 0 || len(c.Annotations) > 0 {
+			client.updateNamespaceMeta(c.Namespace, c.Labels, c.Annotations)
+		}
+
+		// Recurse now that this namespace exists, so its own children can be anchored to it.
+		createChildren(c.Namespace, c.Children)
+	}
+}
+
 func newCreateCmd() *cobra.Command {
 	createCmd.Flags().StringP("namespace", "n", "", "The parent namespace for the new subnamespace")
+	createCmd.Flags().StringP("filename", "f", "", "A file describing a tree of subnamespaces to create")
 	return createCmd
 }
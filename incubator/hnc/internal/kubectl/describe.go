@@ -75,6 +75,11 @@ var describeCmd = &cobra.Command{
 			fmt.Printf("  No children\n")
 		}
 
+		// Cluster placement
+		if cp := hier.Spec.ClusterPlacement; cp != nil && len(cp.Clusters) > 0 {
+			fmt.Printf("  Cluster placement: %s\n", strings.Join(cp.Clusters, ", "))
+		}
+
 		// Conditions
 		describeConditions(hier.Status.Conditions)
 
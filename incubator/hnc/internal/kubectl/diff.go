@@ -0,0 +1,208 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff NAMESPACE",
+	Short: "Previews the propagation effects of a hypothetical change to a namespace's parent",
+	Long: `Computes, without making any changes, which objects would be added to, overwritten in, or
+removed from NAMESPACE if its parent were changed the way "kubectl hns set" would change it.
+
+This is a client-side simulation based on the types currently configured to propagate (see
+"kubectl hns config describe") and the source objects that exist today in each ancestor; it
+doesn't replicate every server-side rule (e.g. per-object selectors or propagation windows), so
+the actual result after the change may differ, especially if other objects change in the
+meantime.`,
+	Example: `	# Preview the effect of moving 'foo' under 'bar'
+	kubectl hns diff foo --parent bar
+
+	# Preview the effect of making 'foo' a root
+	kubectl hns diff foo --root`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		nnm := args[0]
+		flags := cmd.Flags()
+		newParent, _ := flags.GetString("parent")
+		root, _ := flags.GetBool("root")
+
+		if root && newParent != "" {
+			fmt.Println("Cannot give the namespace a parent and make it a root at the same time")
+			os.Exit(1)
+		}
+		if !root && newParent == "" {
+			fmt.Println("Must specify either --parent or --root")
+			os.Exit(1)
+		}
+
+		diffParent(nnm, newParent)
+	},
+}
+
+func newDiffCmd() *cobra.Command {
+	diffCmd.Flags().StringP("parent", "p", "", "The hypothetical new parent namespace")
+	diffCmd.Flags().BoolP("root", "r", false, "Hypothetically remove the current parent, making the namespace a root")
+	return diffCmd
+}
+
+// ancestorChain returns the chain of namespaces from start up to the root, nearest first,
+// stopping if it notices a cycle (already reported elsewhere via ReasonInCycle) instead of
+// looping forever. It returns nil if start is "".
+func ancestorChain(start string) []string {
+	chain := []string{}
+	seen := map[string]bool{}
+	for nnm := start; nnm != "" && !seen[nnm]; nnm = client.getHierarchy(nnm).Spec.Parent {
+		chain = append(chain, nnm)
+		seen[nnm] = true
+	}
+	return chain
+}
+
+func diffParent(nnm, newParent string) {
+	oldParent := client.getHierarchy(nnm).Spec.Parent
+	if oldParent == newParent {
+		fmt.Printf("Parent of %s is already %s; nothing would change\n", nnm, newParent)
+		return
+	}
+
+	oldAncestors := toSet(ancestorChain(oldParent))
+	newAncestors := toSet(ancestorChain(newParent))
+
+	removed := []string{}
+	for a := range oldAncestors {
+		if !newAncestors[a] {
+			removed = append(removed, a)
+		}
+	}
+	added := []string{}
+	for a := range newAncestors {
+		if !oldAncestors[a] {
+			added = append(added, a)
+		}
+	}
+	sort.Strings(removed)
+	sort.Strings(added)
+
+	cfg := client.getHNCConfig()
+	existing := listLocalObjects(nnm, cfg.Status.Resources)
+
+	anyChange := false
+	for _, rsrc := range cfg.Status.Resources {
+		if rsrc.Mode != api.Propagate && rsrc.Mode != api.AllowPropagate {
+			continue
+		}
+		gvr := schema.GroupVersionResource{Group: rsrc.Group, Version: rsrc.Version, Resource: rsrc.Resource}
+
+		lines := []string{}
+		for _, a := range removed {
+			for _, o := range existing[gvr] {
+				if o.GetLabels()[api.LabelInheritedFrom] == a {
+					lines = append(lines, fmt.Sprintf("- %s (no longer inherited from %s)", o.GetName(), a))
+				}
+			}
+		}
+		for _, a := range added {
+			for _, o := range listObjects(gvr, a) {
+				if rsrc.Mode == api.AllowPropagate && o.GetAnnotations()[api.AllowPropagateAnnotation] != "true" {
+					continue
+				}
+				if local, ok := existing[gvr][o.GetName()]; ok {
+					if local.GetLabels()[api.LabelInheritedFrom] == "" {
+						lines = append(lines, fmt.Sprintf("~ %s (would overwrite an object not managed by HNC, inherited from %s)", o.GetName(), a))
+					} else {
+						lines = append(lines, fmt.Sprintf("~ %s (would now be inherited from %s instead of %s)", o.GetName(), a, local.GetLabels()[api.LabelInheritedFrom]))
+					}
+				} else {
+					lines = append(lines, fmt.Sprintf("+ %s (inherited from %s)", o.GetName(), a))
+				}
+			}
+		}
+
+		if len(lines) == 0 {
+			continue
+		}
+		anyChange = true
+		kind := rsrc.Resource
+		if rsrc.Group != "" {
+			kind = kind + "." + rsrc.Group
+		}
+		fmt.Printf("%s:\n", kind)
+		sort.Strings(lines)
+		for _, l := range lines {
+			fmt.Printf("  %s\n", l)
+		}
+	}
+
+	if !anyChange {
+		fmt.Println("No propagated objects would change")
+	}
+}
+
+// toSet is a small helper to turn a namespace list into a membership set.
+func toSet(nnms []string) map[string]bool {
+	set := map[string]bool{}
+	for _, n := range nnms {
+		set[n] = true
+	}
+	return set
+}
+
+// listObjects lists every object of gvr in ns, or exits the process on error, matching the error
+// handling style of the rest of this plugin.
+func listObjects(gvr schema.GroupVersionResource, ns string) []unstructured.Unstructured {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	list, err := dynamicClient.Resource(gvr).Namespace(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		fmt.Printf("Could not list %s in namespace %s: %s\n", gvr.Resource, ns, err)
+		os.Exit(1)
+	}
+	return list.Items
+}
+
+// listLocalObjects lists, for every configured resource, the objects that currently exist in ns,
+// keyed by GVR and then by name, so diffParent can tell whether an incoming object would be new,
+// would overwrite a local object, or would simply be re-sourced from a different ancestor.
+func listLocalObjects(ns string, resources []api.ResourceStatus) map[schema.GroupVersionResource]map[string]unstructured.Unstructured {
+	out := map[schema.GroupVersionResource]map[string]unstructured.Unstructured{}
+	for _, rsrc := range resources {
+		if rsrc.Mode != api.Propagate && rsrc.Mode != api.AllowPropagate {
+			continue
+		}
+		gvr := schema.GroupVersionResource{Group: rsrc.Group, Version: rsrc.Version, Resource: rsrc.Resource}
+		byName := map[string]unstructured.Unstructured{}
+		for _, o := range listObjects(gvr, ns) {
+			byName[o.GetName()] = o
+		}
+		out[gvr] = byName
+	}
+	return out
+}
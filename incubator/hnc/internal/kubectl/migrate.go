@@ -0,0 +1,111 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+)
+
+// migrateGroupKeys are the well-known label/annotation keys HNC writes under api.MetaGroup
+// (excluding the per-ancestor tree depth labels, which are handled separately since their key
+// varies per namespace). A migration to a new API group would need to dual-write, and eventually
+// cut over, every namespace that carries one of these.
+var migrateGroupKeys = []string{
+	api.LabelInheritedFrom,
+	api.FinalizerHasSubnamespace,
+	api.AnnotationManagedBy,
+	api.AnnotationUnmanaged,
+	api.SubnamespaceOf,
+	api.SubnamespaceApprovedAnnotation,
+}
+
+var migrateToGroup string
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Tools for migrating off the " + api.MetaGroup + " API group",
+}
+
+// migrateCheckCmd doesn't perform any migration itself - HNC doesn't yet support dual-writing to,
+// or cutting over to, a different API group, since the destination group hasn't been finalized as
+// part of the project's move out of incubation. It exists so the scope of that future migration
+// (which namespaces and objects reference the current group) can be measured and verified for
+// completeness ahead of time.
+var migrateCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Reports the namespaces that reference the current API group, ahead of a future migration",
+	Run: func(cmd *cobra.Command, args []string) {
+		runMigrateCheck()
+	},
+}
+
+func runMigrateCheck() {
+	nsList := getAllNamespaces()
+
+	counts := map[string]int{}
+	depthLabels := 0
+	for _, nnm := range nsList {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ns, err := k8sClient.CoreV1().Namespaces().Get(ctx, nnm, metav1.GetOptions{})
+		cancel()
+		if err != nil {
+			fmt.Printf("Error reading namespace %s: %s\n", nnm, err)
+			os.Exit(1)
+		}
+		for k := range ns.Labels {
+			if strings.HasSuffix(k, api.LabelTreeDepthSuffix) {
+				depthLabels++
+			}
+		}
+		for _, key := range migrateGroupKeys {
+			if _, ok := ns.Labels[key]; ok {
+				counts[key]++
+			}
+			if _, ok := ns.Annotations[key]; ok {
+				counts[key]++
+			}
+		}
+	}
+
+	fmt.Printf("Scanned %d namespaces for %q references:\n", len(nsList), api.MetaGroup)
+	fmt.Printf("  %d tree depth labels (*%s)\n", depthLabels, api.LabelTreeDepthSuffix)
+	keys := append([]string{}, migrateGroupKeys...)
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Printf("  %d references to %s\n", counts[k], k)
+	}
+	if migrateToGroup != "" {
+		fmt.Printf("\nTo migrate to %q, all of the above must be dual-written and translated before cutover.\n", migrateToGroup)
+	}
+	fmt.Printf("\nNote: this command only reports what a migration would need to touch; HNC does not yet support dual-writing or cutting over to a different API group.\n")
+}
+
+func newMigrateCmd() *cobra.Command {
+	migrateCheckCmd.Flags().StringVar(&migrateToGroup, "to-group", "", "(optional) the destination API group, for informational purposes only")
+	migrateCmd.AddCommand(migrateCheckCmd)
+	migrateCmd.AddCommand(newMigrateApplyCmd())
+	return migrateCmd
+}
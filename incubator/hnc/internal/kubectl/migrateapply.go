@@ -0,0 +1,176 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// migrateNode is one namespace in the file passed to "migrate apply -f", along with the
+// namespaces that should be parented to it. Unlike treeNode (used by "create -f"), every
+// namespace here is expected to already exist as a flat namespace; migrate apply only sets its
+// HierarchyConfiguration, it never creates namespaces or subnamespace anchors.
+type migrateNode struct {
+	Namespace string        `json:"namespace"`
+	Children  []migrateNode `json:"children,omitempty"`
+}
+
+// migrateSpec is the top-level shape of the file: a forest of independent trees, since a bulk
+// adoption typically spans many pre-existing top-level namespaces rather than a single root.
+type migrateSpec struct {
+	Roots []migrateNode `json:"roots"`
+}
+
+// migrateStep is a single namespace's planned or applied parent change.
+type migrateStep struct {
+	namespace string
+	oldParent string
+	newParent string
+}
+
+var migrateApplyDryRun bool
+
+var migrateApplyCmd = &cobra.Command{
+	Use:   "apply -f FILENAME",
+	Short: "Bulk-adopts pre-existing flat namespaces into the hierarchy described by a file",
+	Example: `	# Preview the parent changes described by hierarchy.yaml, without applying them
+	kubectl hns migrate apply -f hierarchy.yaml --dry-run
+
+	# Apply them
+	kubectl hns migrate apply -f hierarchy.yaml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("filename")
+		if file == "" {
+			fmt.Println("Error: -f/--filename is required")
+			os.Exit(1)
+		}
+		runMigrateApply(file, migrateApplyDryRun)
+	},
+}
+
+func runMigrateApply(path string, dryRun bool) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		fmt.Printf("Error reading %s: %s\n", path, err)
+		os.Exit(1)
+	}
+
+	spec := &migrateSpec{}
+	if err := yaml.UnmarshalStrict(data, spec); err != nil {
+		fmt.Printf("Error parsing %s: %s\n", path, err)
+		os.Exit(1)
+	}
+
+	steps := planMigration(spec.Roots)
+	if len(steps) == 0 {
+		fmt.Println("No parent changes to make")
+		return
+	}
+
+	if dryRun {
+		fmt.Printf("Dry run: %d namespace(s) would be updated:\n", len(steps))
+		for _, s := range steps {
+			printMigrateStep(s)
+		}
+		return
+	}
+
+	applyMigration(steps)
+}
+
+// planMigration walks roots in dependency order - a namespace's step always comes before any of
+// its children's, since a child's new parent must be applied for the child to make sense - and
+// returns only the namespaces whose parent actually needs to change.
+func planMigration(roots []migrateNode) []migrateStep {
+	var steps []migrateStep
+	var walk func(nodes []migrateNode, parent string)
+	walk = func(nodes []migrateNode, parent string) {
+		for _, n := range nodes {
+			if n.Namespace == "" {
+				fmt.Println("Error: every node must set 'namespace'")
+				os.Exit(1)
+			}
+			hc := client.getHierarchy(n.Namespace)
+			if hc.Spec.Parent != parent {
+				steps = append(steps, migrateStep{namespace: n.Namespace, oldParent: hc.Spec.Parent, newParent: parent})
+			}
+			walk(n.Children, n.Namespace)
+		}
+	}
+	walk(roots, "")
+	return steps
+}
+
+func printMigrateStep(s migrateStep) {
+	if s.oldParent == "" {
+		fmt.Printf("  %s: set parent to %s\n", s.namespace, describeParent(s.newParent))
+		return
+	}
+	fmt.Printf("  %s: change parent from %s to %s\n", s.namespace, describeParent(s.oldParent), describeParent(s.newParent))
+}
+
+func describeParent(pnm string) string {
+	if pnm == "" {
+		return "<root>"
+	}
+	return pnm
+}
+
+// applyMigration applies steps in order, and if any step fails, rolls every already-applied step
+// in this run back to its previous parent, so a partial failure never leaves the tree in a mix of
+// old and new hierarchy.
+func applyMigration(steps []migrateStep) {
+	applied := []migrateStep{}
+	for _, s := range steps {
+		hc := client.getHierarchy(s.namespace)
+		hc.Spec.Parent = s.newParent
+		if err := client.updateHierarchyOrErr(hc); err != nil {
+			fmt.Printf("Error setting parent of %s to %s: %s\n", s.namespace, describeParent(s.newParent), err)
+			rollbackMigration(applied)
+			os.Exit(1)
+		}
+		fmt.Printf("Updated %s\n", s.namespace)
+		applied = append(applied, s)
+	}
+	fmt.Printf("Successfully migrated %d namespace(s)\n", len(applied))
+}
+
+// rollbackMigration reverts applied, in reverse order, back to each namespace's original parent.
+func rollbackMigration(applied []migrateStep) {
+	if len(applied) == 0 {
+		return
+	}
+	fmt.Printf("Rolling back %d already-applied change(s)...\n", len(applied))
+	for i := len(applied) - 1; i >= 0; i-- {
+		s := applied[i]
+		hc := client.getHierarchy(s.namespace)
+		hc.Spec.Parent = s.oldParent
+		if err := client.updateHierarchyOrErr(hc); err != nil {
+			fmt.Printf("Error rolling back %s to parent %s: %s\n", s.namespace, describeParent(s.oldParent), err)
+		}
+	}
+}
+
+func newMigrateApplyCmd() *cobra.Command {
+	migrateApplyCmd.Flags().StringP("filename", "f", "", "A file describing the target hierarchy to bulk-adopt existing namespaces into")
+	migrateApplyCmd.Flags().BoolVar(&migrateApplyDryRun, "dry-run", false, "Print the parent changes that would be made, without applying them")
+	return migrateApplyCmd
+}
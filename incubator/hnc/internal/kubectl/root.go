@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -38,6 +39,7 @@ import (
 
 var k8sClient *kubernetes.Clientset
 var hncClient *rest.RESTClient
+var dynamicClient dynamic.Interface
 var rootCmd *cobra.Command
 var client Client
 
@@ -47,8 +49,11 @@ type anchorStatus map[string]string
 type Client interface {
 	getHierarchy(nnm string) *api.HierarchyConfiguration
 	updateHierarchy(hier *api.HierarchyConfiguration, reason string)
+	updateHierarchyOrErr(hier *api.HierarchyConfiguration) error
 	createAnchor(nnm string, hnnm string)
 	getAnchorStatus(nnm string) anchorStatus
+	waitForAnchor(nnm, hnnm string, timeout time.Duration) error
+	updateNamespaceMeta(nnm string, labels, annotations map[string]string)
 	getHNCConfig() *api.HNCConfiguration
 	updateHNCConfig(*api.HNCConfiguration)
 }
@@ -91,6 +96,13 @@ func init() {
 				return err
 			}
 
+			// create a dynamic client, so commands can list arbitrary propagated resource types (see
+			// HNCConfiguration.Status.Resources) without HNC having to vendor a typed client for each one.
+			dynamicClient, err = dynamic.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+
 			return nil
 		},
 	}
@@ -102,6 +114,10 @@ func init() {
 	rootCmd.AddCommand(newCreateCmd())
 	rootCmd.AddCommand(newConfigCmd())
 	rootCmd.AddCommand(newVersionCmd())
+	rootCmd.AddCommand(newMigrateCmd())
+	rootCmd.AddCommand(newAccessCmd())
+	rootCmd.AddCommand(newDiffCmd())
+	rootCmd.AddCommand(newUninstallCmd())
 }
 
 func Execute() {
@@ -158,23 +174,73 @@ func (cl *realClient) getAnchorStatus(nnm string) anchorStatus {
 	return as
 }
 
-func (cl *realClient) updateHierarchy(hier *api.HierarchyConfiguration, reason string) {
+// waitForAnchor polls the given subnamespace anchor's status until it becomes "Ok" or the
+// timeout elapses, so that bulk creation can wait for a namespace to actually exist before
+// setting its labels/annotations or creating its own children.
+func (cl *realClient) waitForAnchor(nnm, hnnm string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		if state := cl.getAnchorStatus(nnm)[hnnm]; state == string(api.Ok) {
+			return nil
+		} else if state == string(api.Conflict) || state == string(api.Forbidden) {
+			return fmt.Errorf("subnamespace anchor %q in %q is in state %q", hnnm, nnm, state)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for subnamespace anchor %q in %q to become ready", hnnm, nnm)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// updateNamespaceMeta merges the given labels and annotations into an existing namespace.
+func (cl *realClient) updateNamespaceMeta(nnm string, labels, annotations map[string]string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	nnm := hier.Namespace
-	var err error
-	if hier.CreationTimestamp.IsZero() {
-		err = hncClient.Post().Resource(api.HierarchyConfigurations).Namespace(nnm).Name(api.Singleton).Body(hier).Do(ctx).Error()
-	} else {
-		err = hncClient.Put().Resource(api.HierarchyConfigurations).Namespace(nnm).Name(api.Singleton).Body(hier).Do(ctx).Error()
-	}
+	ns, err := k8sClient.CoreV1().Namespaces().Get(ctx, nnm, metav1.GetOptions{})
 	if err != nil {
+		fmt.Printf("Error reading namespace %s: %s\n", nnm, err)
+		os.Exit(1)
+	}
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	for k, v := range labels {
+		ns.Labels[k] = v
+	}
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
+	}
+	for k, v := range annotations {
+		ns.Annotations[k] = v
+	}
+	if _, err := k8sClient.CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{}); err != nil {
+		fmt.Printf("Error updating labels/annotations on namespace %s: %s\n", nnm, err)
+		os.Exit(1)
+	}
+}
+
+func (cl *realClient) updateHierarchy(hier *api.HierarchyConfiguration, reason string) {
+	if err := cl.updateHierarchyOrErr(hier); err != nil {
 		fmt.Printf("\nCould not %s.\nReason: %s\n", reason, err)
 		os.Exit(1)
 	}
 }
 
+// updateHierarchyOrErr is the same operation as updateHierarchy, but returns the error instead of
+// exiting the process, for callers such as "migrate apply" that need to roll back other changes
+// before giving up.
+func (cl *realClient) updateHierarchyOrErr(hier *api.HierarchyConfiguration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	nnm := hier.Namespace
+	if hier.CreationTimestamp.IsZero() {
+		return hncClient.Post().Resource(api.HierarchyConfigurations).Namespace(nnm).Name(api.Singleton).Body(hier).Do(ctx).Error()
+	}
+	return hncClient.Put().Resource(api.HierarchyConfigurations).Namespace(nnm).Name(api.Singleton).Body(hier).Do(ctx).Error()
+}
+
 func (cl *realClient) createAnchor(nnm string, hnnm string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -17,6 +17,7 @@ package kubectl
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
 
 	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
 )
@@ -53,6 +55,9 @@ var treeCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		outputFormat, _ := flags.GetString("output")
+
+		roots := []*TreeNode{}
 		for _, nnm := range nsList {
 			hier := client.getHierarchy(nnm)
 			// If we're showing the default list, skip all non-root namespaces since they'll be displayed
@@ -63,10 +68,21 @@ var treeCmd = &cobra.Command{
 			if defaultList && (!cycle && hier.Spec.Parent != "") {
 				continue
 			}
+
+			if outputFormat != "" {
+				roots = append(roots, buildTreeNode(hier, cycle))
+				continue
+			}
+
 			fmt.Println(txt)
 			printSubtree("", hier, cycle)
 		}
 
+		if outputFormat != "" {
+			printTreeNodes(outputFormat, roots)
+			return
+		}
+
 		if hasSubnamespace {
 			fmt.Printf("\n[s] indicates subnamespaces\n")
 		}
@@ -81,6 +97,151 @@ var treeCmd = &cobra.Command{
 	},
 }
 
+// TreeNode is the JSON/YAML representation of one namespace in a `kubectl hns tree` result. It's
+// emitted instead of the ASCII art when -o json or -o yaml is given, so CI pipelines and
+// dashboards can consume the hierarchy without having to parse the human-readable tree.
+type TreeNode struct {
+	Name           string          `json:"name"`
+	IsSubnamespace bool            `json:"isSubnamespace,omitempty"`
+	InCycle        bool            `json:"inCycle,omitempty"`
+	ChildCount     int             `json:"childCount"`
+	Conditions     []api.Condition `json:"conditions,omitempty"`
+	Children       []*TreeNode     `json:"children,omitempty"`
+}
+
+// buildTreeNode is the JSON/YAML equivalent of printSubtree: it walks the same hierarchy in the
+// same order, but builds a tree of TreeNodes instead of printing ASCII art.
+func buildTreeNode(hier *api.HierarchyConfiguration, inCycle bool) *TreeNode {
+	node := &TreeNode{
+		Name:       hier.Namespace,
+		InCycle:    inCycle,
+		ChildCount: len(hier.Status.Children),
+		Conditions: hier.Status.Conditions,
+	}
+
+	for _, cn := range hier.Status.Children {
+		ch := client.getHierarchy(cn)
+		_, cycle := nameAndFootnotes(ch)
+		if cycle && inCycle {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ns, err := k8sClient.CoreV1().Namespaces().Get(ctx, cn, metav1.GetOptions{})
+		cancel()
+		if err != nil {
+			fmt.Printf("Could not get namespaces: %s\n", err)
+			os.Exit(1)
+		}
+
+		childNode := buildTreeNode(ch, cycle)
+		if _, ok := ns.ObjectMeta.Annotations[api.SubnamespaceOf]; ok {
+			childNode.IsSubnamespace = true
+		}
+		node.Children = append(node.Children, childNode)
+	}
+
+	return node
+}
+
+// printTreeNodes renders roots in outputFormat and prints the result, exiting on an unsupported
+// output format.
+func printTreeNodes(outputFormat string, roots []*TreeNode) {
+	switch outputFormat {
+	case "json":
+		b, err := json.MarshalIndent(roots, "", "  ")
+		if err != nil {
+			fmt.Printf("Could not marshal tree: %s\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(b))
+	case "yaml":
+		b, err := yaml.Marshal(roots)
+		if err != nil {
+			fmt.Printf("Could not marshal tree: %s\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(b))
+	case "dot":
+		fmt.Println(renderDOT(roots))
+	case "mermaid":
+		fmt.Println(renderMermaid(roots))
+	default:
+		fmt.Printf("Error: unsupported output format %q; must be one of \"json\", \"yaml\", \"dot\", \"mermaid\"\n", outputFormat)
+		os.Exit(1)
+	}
+}
+
+// nodeLabel returns the multi-line label to show for a node in a diagram: its name, its child
+// count, and (if any) the number of conditions currently affecting it.
+func nodeLabel(n *TreeNode) string {
+	label := fmt.Sprintf("%s\n(%d children)", n.Name, n.ChildCount)
+	if len(n.Conditions) > 0 {
+		label += fmt.Sprintf("\n%d condition(s)", len(n.Conditions))
+	}
+	return label
+}
+
+// nodeColor returns the fill color to use for a node in a diagram: red if it's part of a cycle,
+// orange if it has any other condition, and green otherwise.
+func nodeColor(n *TreeNode) string {
+	switch {
+	case n.InCycle:
+		return "red"
+	case len(n.Conditions) > 0:
+		return "orange"
+	default:
+		return "palegreen"
+	}
+}
+
+// renderDOT renders roots as a Graphviz DOT digraph, colored by condition status and labeled
+// with each namespace's child count, for pasting into documentation or incident reviews of large
+// hierarchies.
+func renderDOT(roots []*TreeNode) string {
+	var b strings.Builder
+	b.WriteString("digraph hierarchy {\n")
+	var walk func(n *TreeNode)
+	walk = func(n *TreeNode) {
+		fmt.Fprintf(&b, "  %q [label=%q, style=filled, fillcolor=%q];\n", n.Name, nodeLabel(n), nodeColor(n))
+		for _, c := range n.Children {
+			fmt.Fprintf(&b, "  %q -> %q;\n", n.Name, c.Name)
+			walk(c)
+		}
+	}
+	for _, r := range roots {
+		walk(r)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// renderMermaid renders roots as a Mermaid flowchart, colored by condition status and labeled
+// with each namespace's child count, for embedding directly in Markdown documentation.
+func renderMermaid(roots []*TreeNode) string {
+	var b strings.Builder
+	b.WriteString("graph TD\n")
+	var walk func(n *TreeNode)
+	walk = func(n *TreeNode) {
+		fmt.Fprintf(&b, "  %s[%q]\n", mermaidID(n.Name), nodeLabel(n))
+		fmt.Fprintf(&b, "  style %s fill:%s\n", mermaidID(n.Name), nodeColor(n))
+		for _, c := range n.Children {
+			fmt.Fprintf(&b, "  %s --> %s\n", mermaidID(n.Name), mermaidID(c.Name))
+			walk(c)
+		}
+	}
+	for _, r := range roots {
+		walk(r)
+	}
+	return b.String()
+}
+
+// mermaidID turns a namespace name into a Mermaid-safe node identifier. Mermaid node IDs can't
+// contain the characters that are otherwise valid in a Kubernetes namespace name, such as "-".
+func mermaidID(name string) string {
+	return "ns_" + strings.ReplaceAll(name, "-", "_")
+}
+
 func printSubtree(prefix string, hier *api.HierarchyConfiguration, inCycle bool) {
 	for i, cn := range hier.Status.Children {
 		ch := client.getHierarchy(cn)
@@ -148,6 +309,7 @@ func nameAndFootnotes(hier *api.HierarchyConfiguration) (string, bool) {
 
 func newTreeCmd() *cobra.Command {
 	treeCmd.Flags().BoolP("all-namespaces", "A", false, "Displays all trees on the cluster")
+	treeCmd.Flags().StringP("output", "o", "", "Output format. One of: json, yaml, dot, mermaid. Defaults to the ASCII tree if unset")
 	return treeCmd
 }
 
@@ -0,0 +1,283 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+)
+
+// validatingWebhookConfigurationName is the name of the single ValidatingWebhookConfiguration
+// HNC installs (see config/webhook/manifests.yaml); all of HNC's webhooks live in this one object.
+const validatingWebhookConfigurationName = "validating-webhook-configuration"
+
+// hncCRDNames are the CustomResourceDefinitions HNC installs. They must be removed last, since
+// deleting a CRD deletes every instance of it, and any instance still carrying a finalizer HNC
+// would normally remove (e.g. FinalizerHasSubnamespace) would otherwise wedge its deletion
+// forever once HNC itself is gone.
+var hncCRDNames = []string{
+	api.HierarchyConfigurations + "." + api.MetaGroup,
+	api.Anchors + "." + api.MetaGroup,
+	api.HNCConfigSingletons + "." + api.MetaGroup,
+}
+
+// crdGVR identifies CustomResourceDefinition objects so they can be deleted through the dynamic
+// client, without HNC having to vendor the apiextensions clientset just for this one command.
+var crdGVR = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+var (
+	uninstallYes       bool
+	uninstallDryRun    bool
+	uninstallStripMeta bool
+)
+
+var uninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Removes HNC from the cluster in a safe order",
+	Long: `Orchestrates a full removal of HNC, in the order that avoids orphaned objects and wedged
+namespaces that a manual "kubectl delete -f" of HNC's manifests tends to leave behind:
+
+  1. Sets every configured type's synchronization mode to Ignore, so the object reconcilers stop
+     propagating and deleting objects while the rest of the uninstall proceeds.
+  2. Deletes HNC's ValidatingWebhookConfiguration, so the apiserver stops consulting a webhook
+     that's about to disappear.
+  3. (optional, --strip-metadata) Strips the labels, annotations and finalizers HNC wrote onto
+     namespaces and propagated objects, so they're left as ordinary, unmanaged objects instead of
+     objects a controller that no longer exists was responsible for cleaning up.
+  4. Deletes HNC's CustomResourceDefinitions, which also deletes every HierarchyConfiguration,
+     SubnamespaceAnchor and the HNCConfiguration singleton.
+
+Run with --dry-run first to see what would be touched.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runUninstall()
+	},
+}
+
+func newUninstallCmd() *cobra.Command {
+	uninstallCmd.Flags().BoolVarP(&uninstallYes, "yes", "y", false, "Actually perform the uninstall. Without this flag, only --dry-run is allowed")
+	uninstallCmd.Flags().BoolVar(&uninstallDryRun, "dry-run", false, "Print the steps that would be taken, without making any changes")
+	uninstallCmd.Flags().BoolVar(&uninstallStripMeta, "strip-metadata", false, "Also strip HNC's labels, annotations and finalizers from namespaces and propagated objects")
+	return uninstallCmd
+}
+
+func runUninstall() {
+	if !uninstallDryRun && !uninstallYes {
+		fmt.Println("This will remove HNC and all of its CRDs (HierarchyConfigurations, SubnamespaceAnchors and")
+		fmt.Println("the HNCConfiguration singleton) from the cluster.")
+		fmt.Println("Re-run with --dry-run to preview the steps, or --yes to proceed.")
+		os.Exit(1)
+	}
+
+	cfg := client.getHNCConfig()
+
+	fmt.Println("Step 1/4: setting all configured types to Ignore mode")
+	ignoreAllModes(cfg)
+
+	fmt.Println("Step 2/4: removing HNC's webhooks")
+	deleteValidatingWebhook()
+
+	if uninstallStripMeta {
+		fmt.Println("Step 3/4: stripping HNC labels, annotations and finalizers")
+		stripHNCMetadata(cfg)
+	} else {
+		fmt.Println("Step 3/4: skipped (pass --strip-metadata to also clean up namespaces and propagated objects)")
+	}
+
+	fmt.Println("Step 4/4: removing HNC's CRDs")
+	deleteHNCCRDs()
+
+	if uninstallDryRun {
+		fmt.Println("\nDry run complete; no changes were made.")
+		return
+	}
+	fmt.Println("\nHNC has been uninstalled.")
+}
+
+// ignoreAllModes sets every user-configured type (not HNC's enforced RBAC types, which the
+// apiserver won't let leave Propagate mode anyway) to Ignore, so propagation and deletion of
+// managed objects stops before anything else changes.
+func ignoreAllModes(cfg *api.HNCConfiguration) {
+	changed := false
+	for i := range cfg.Spec.Resources {
+		r := &cfg.Spec.Resources[i]
+		if r.Mode == api.Ignore {
+			continue
+		}
+		fmt.Printf("  %s: %s -> %s\n", describeResource(r.Group, r.Resource), r.Mode, api.Ignore)
+		if uninstallDryRun {
+			continue
+		}
+		r.Mode = api.Ignore
+		changed = true
+	}
+	if !uninstallDryRun && changed {
+		client.updateHNCConfig(cfg)
+	}
+}
+
+func describeResource(group, resource string) string {
+	if group == "" {
+		return resource
+	}
+	return resource + "." + group
+}
+
+// deleteValidatingWebhook removes HNC's single ValidatingWebhookConfiguration. It's not an error
+// if it's already gone.
+func deleteValidatingWebhook() {
+	fmt.Printf("  validatingwebhookconfiguration/%s\n", validatingWebhookConfigurationName)
+	if uninstallDryRun {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := k8sClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Delete(ctx, validatingWebhookConfigurationName, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		fmt.Printf("Error deleting validatingwebhookconfiguration/%s: %s\n", validatingWebhookConfigurationName, err)
+		os.Exit(1)
+	}
+}
+
+// stripHNCMetadata removes the labels, annotations and finalizers HNC wrote onto namespaces (see
+// migrateGroupKeys and api.LabelTreeDepthSuffix) and onto propagated copies of objects (see
+// api.LabelInheritedFrom, api.AnnotationSourceUID and api.FinalizerPropagatedCopy), so they're
+// left behind as ordinary objects once HNC's controllers stop running, instead of being wedged
+// waiting for a finalizer that will never be removed.
+func stripHNCMetadata(cfg *api.HNCConfiguration) {
+	for _, nnm := range getAllNamespaces() {
+		stripNamespaceMetadata(nnm)
+	}
+	for _, rsrc := range cfg.Status.Resources {
+		if rsrc.Mode != api.Propagate && rsrc.Mode != api.AllowPropagate {
+			continue
+		}
+		gvr := schema.GroupVersionResource{Group: rsrc.Group, Version: rsrc.Version, Resource: rsrc.Resource}
+		stripPropagatedObjects(gvr)
+	}
+}
+
+func stripNamespaceMetadata(nnm string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ns, err := k8sClient.CoreV1().Namespaces().Get(ctx, nnm, metav1.GetOptions{})
+	if err != nil {
+		fmt.Printf("Error reading namespace %s: %s\n", nnm, err)
+		os.Exit(1)
+	}
+
+	changed := false
+	for _, key := range migrateGroupKeys {
+		if _, ok := ns.Labels[key]; ok {
+			changed = true
+			delete(ns.Labels, key)
+		}
+		if _, ok := ns.Annotations[key]; ok {
+			changed = true
+			delete(ns.Annotations, key)
+		}
+	}
+	for k := range ns.Labels {
+		if strings.HasSuffix(k, api.LabelTreeDepthSuffix) {
+			changed = true
+			delete(ns.Labels, k)
+		}
+	}
+	if controllerutil.ContainsFinalizer(ns, api.FinalizerHasSubnamespace) {
+		changed = true
+		controllerutil.RemoveFinalizer(ns, api.FinalizerHasSubnamespace)
+	}
+
+	if !changed {
+		return
+	}
+	fmt.Printf("  namespace/%s\n", nnm)
+	if uninstallDryRun {
+		return
+	}
+	if _, err := k8sClient.CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{}); err != nil {
+		fmt.Printf("Error stripping HNC metadata from namespace %s: %s\n", nnm, err)
+		os.Exit(1)
+	}
+}
+
+func stripPropagatedObjects(gvr schema.GroupVersionResource) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	list, err := dynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		fmt.Printf("Error listing %s: %s\n", gvr.Resource, err)
+		os.Exit(1)
+	}
+
+	for i := range list.Items {
+		o := &list.Items[i]
+		if _, inherited := o.GetLabels()[api.LabelInheritedFrom]; !inherited {
+			continue
+		}
+		fmt.Printf("  %s/%s/%s\n", gvr.Resource, o.GetNamespace(), o.GetName())
+		if uninstallDryRun {
+			continue
+		}
+
+		labels := o.GetLabels()
+		delete(labels, api.LabelInheritedFrom)
+		o.SetLabels(labels)
+		annots := o.GetAnnotations()
+		delete(annots, api.AnnotationSourceUID)
+		o.SetAnnotations(annots)
+		if controllerutil.ContainsFinalizer(o, api.FinalizerPropagatedCopy) {
+			controllerutil.RemoveFinalizer(o, api.FinalizerPropagatedCopy)
+		}
+
+		if _, err := dynamicClient.Resource(gvr).Namespace(o.GetNamespace()).Update(ctx, o, metav1.UpdateOptions{}); err != nil {
+			fmt.Printf("Error stripping HNC metadata from %s/%s/%s: %s\n", gvr.Resource, o.GetNamespace(), o.GetName(), err)
+			os.Exit(1)
+		}
+	}
+}
+
+// deleteHNCCRDs removes HNC's CRDs. This implicitly deletes every HierarchyConfiguration,
+// SubnamespaceAnchor and the HNCConfiguration singleton, so it must run last.
+func deleteHNCCRDs() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, name := range hncCRDNames {
+		fmt.Printf("  customresourcedefinition/%s\n", name)
+		if uninstallDryRun {
+			continue
+		}
+		if err := dynamicClient.Resource(crdGVR).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			fmt.Printf("Error deleting customresourcedefinition/%s: %s\n", name, err)
+			os.Exit(1)
+		}
+	}
+}
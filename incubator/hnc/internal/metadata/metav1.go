@@ -22,6 +22,15 @@ func SetLabel(inst metav1.Object, label string, value string) {
 	inst.SetLabels(labels)
 }
 
+func GetAnnotation(inst metav1.Object, annotation string) (string, bool) {
+	annotations := inst.GetAnnotations()
+	if annotations == nil {
+		return "", false
+	}
+	value, ok := annotations[annotation]
+	return value, ok
+}
+
 func SetAnnotation(inst metav1.Object, annotation string, value string) {
 	annotations := inst.GetAnnotations()
 	if annotations == nil {
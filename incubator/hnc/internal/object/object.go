@@ -1,6 +1,9 @@
 package object
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -19,16 +22,25 @@ func metaPrefix(s string) string {
 }
 
 // Canonical returns a canonicalized version of the object - that is, one that has the same name,
-// spec and non-HNC labels and annotations, but with the status and all other metadata cleared
-// (including, notably, the namespace). The resulting object is suitable to be copied into a new
-// namespace, or two canonicalized objects are suitable for being compared via reflect.DeepEqual.
+// spec and non-HNC labels and annotations, but with the status, apiVersion and all other metadata
+// cleared (including, notably, the namespace). The resulting object is suitable to be copied into
+// a new namespace, or two canonicalized objects are suitable for being compared via
+// reflect.DeepEqual.
+//
+// apiVersion is cleared because it identifies which version of a CRD an object was read through,
+// not its content - a source object and its propagated copies are always read through the same
+// ObjectReconciler and therefore the same GVK, but if a synced CRD's storage version changes
+// while objects of it are being propagated, the source and destination may transiently be read
+// through different versions, and the resulting apiVersion mismatch must not be reported as a
+// content difference.
 //
 // As a side effect, the label and annotation maps are always initialized in the returned value.
 func Canonical(inst *unstructured.Unstructured) *unstructured.Unstructured {
-	// Start with a copy and clear the status and metadata
+	// Start with a copy and clear the status, apiVersion and metadata
 	c := inst.DeepCopy()
 	delete(c.Object, "status")
 	delete(c.Object, "metadata")
+	delete(c.Object, "apiVersion")
 
 	// Restore the whitelisted metadata. Name:
 	c.SetName(inst.GetName())
@@ -51,5 +63,22 @@ func Canonical(inst *unstructured.Unstructured) *unstructured.Unstructured {
 	}
 	c.SetLabels(newLabels)
 
+	// Apply any Kind-specific rules, e.g. stripping fields Kubernetes populates after creation.
+	if r, ok := gvkRules[inst.GroupVersionKind().GroupKind()]; ok {
+		r(c)
+	}
+
 	return c
 }
+
+// Digest returns a content hash of the canonical form of the object (see Canonical). Two objects
+// with the same digest can be treated as equal without holding onto, or deep-comparing, full
+// copies of both - this matters for types like ConfigMaps, which are often used to propagate
+// large, mostly-static blobs (e.g. CA bundles) to every namespace in a subtree.
+func Digest(inst *unstructured.Unstructured) string {
+	// Object content is always JSON-serializable since it was decoded from JSON (or deep-copied from
+	// something that was), so this can't fail in practice.
+	b, _ := json.Marshal(Canonical(inst).Object)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
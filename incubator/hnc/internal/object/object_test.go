@@ -83,3 +83,90 @@ func TestCanonical(t *testing.T) {
 		})
 	}
 }
+
+// TestCanonicalServiceAccount is a golden-fixture regression test for a real propagation-skew bug:
+// the ServiceAccount token controller populates "secrets" and "imagePullSecrets" independently in
+// every namespace, so a naive Canonical() that only stripped metadata and status would report a
+// freshly-propagated ServiceAccount as different from its source forever.
+func TestCanonicalServiceAccount(t *testing.T) {
+	g := NewWithT(t)
+
+	src := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ServiceAccount",
+			"metadata":   map[string]interface{}{"name": "default"},
+			"secrets": []interface{}{
+				map[string]interface{}{"name": "default-token-abcde"},
+			},
+		},
+	}
+	descendantCopy := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ServiceAccount",
+			"metadata":   map[string]interface{}{"name": "default"},
+			"secrets": []interface{}{
+				map[string]interface{}{"name": "default-token-vwxyz"},
+			},
+			"imagePullSecrets": []interface{}{
+				map[string]interface{}{"name": "default-token-vwxyz"},
+			},
+		},
+	}
+
+	g.Expect(Canonical(src)).Should(Equal(Canonical(descendantCopy)))
+}
+
+// TestCanonicalCRDVersionUpgrade is a golden-fixture regression test for a propagation-skew bug
+// that would otherwise surface when a synced CRD's storage version changes mid-propagation: the
+// source and a descendant's copy can transiently be read back through different served versions
+// of the same CRD, and a naive Canonical() that kept apiVersion would report that purely
+// version-driven difference as a real content change forever.
+func TestCanonicalCRDVersionUpgrade(t *testing.T) {
+	g := NewWithT(t)
+
+	v1beta1 := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "example.com/v1beta1",
+			"kind":       "Widget",
+			"metadata":   map[string]interface{}{"name": "gadget"},
+			"spec":       map[string]interface{}{"color": "red"},
+		},
+	}
+	v1 := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       "Widget",
+			"metadata":   map[string]interface{}{"name": "gadget"},
+			"spec":       map[string]interface{}{"color": "red"},
+		},
+	}
+
+	g.Expect(Canonical(v1beta1)).Should(Equal(Canonical(v1)))
+	g.Expect(Digest(v1beta1)).Should(Equal(Digest(v1)), "digest should ignore apiVersion")
+}
+
+func TestDigest(t *testing.T) {
+	g := NewWithT(t)
+
+	a := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "cm"},
+		"data":     map[string]interface{}{"key": "value"},
+	}}
+	// Same content, but with HNC-managed metadata that Canonical strips out - should be ignored.
+	b := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "cm",
+			"labels": map[string]interface{}{api.LabelInheritedFrom: "some-ns"},
+		},
+		"data": map[string]interface{}{"key": "value"},
+	}}
+	c := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "cm"},
+		"data":     map[string]interface{}{"key": "different"},
+	}}
+
+	g.Expect(Digest(a)).Should(Equal(Digest(b)), "digest should ignore HNC-managed metadata")
+	g.Expect(Digest(a)).ShouldNot(Equal(Digest(c)), "digest should change when the data changes")
+}
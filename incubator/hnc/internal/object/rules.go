@@ -0,0 +1,31 @@
+package object
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// gvkRule adjusts an object after the generic metadata/status normalization done by Canonical, to
+// strip fields that a specific Kind gets populated with server-side, after creation, in a way
+// that's expected to differ between a source object and its propagated copies (or between two
+// reconciles of the same copy) even though nothing about the object's own configuration changed.
+// Unlike the generic normalization above, these rules are necessarily Kind-specific, since the
+// fields in question aren't part of any common schema.
+type gvkRule func(*unstructured.Unstructured)
+
+// gvkRules is keyed by GroupKind, not GroupVersionKind, since the fields these rules strip don't
+// vary between API versions of the same Kind.
+var gvkRules = map[schema.GroupKind]gvkRule{
+	{Kind: "ServiceAccount"}: stripServiceAccountTokens,
+}
+
+// stripServiceAccountTokens removes the "secrets" and "imagePullSecrets" fields that the
+// ServiceAccount token controller adds to every ServiceAccount shortly after it's created. Since
+// every namespace's own token controller populates these independently, a propagated
+// ServiceAccount and its source will always disagree on them, and HNC has no way to make them
+// converge - comparing them (or overwriting them) would just make HNC and the token controller
+// fight forever.
+func stripServiceAccountTokens(u *unstructured.Unstructured) {
+	unstructured.RemoveNestedField(u.Object, "secrets")
+	unstructured.RemoveNestedField(u.Object, "imagePullSecrets")
+}
@@ -0,0 +1,27 @@
+package object
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+)
+
+// ApplyTransform mutates inst in place, replacing every occurrence of api.NamespacePlaceholder in
+// the string fields named by t.Fields with ns. It's meant to be called on a copy that's about to
+// be propagated to namespace ns, after Canonical has already been applied.
+func ApplyTransform(inst *unstructured.Unstructured, t *api.ObjectTransform, ns string) {
+	if t == nil {
+		return
+	}
+	for _, field := range t.Fields {
+		path := strings.Split(field, ".")
+		val, found, err := unstructured.NestedString(inst.Object, path...)
+		if err != nil || !found {
+			continue
+		}
+		val = strings.ReplaceAll(val, api.NamespacePlaceholder, ns)
+		_ = unstructured.SetNestedField(inst.Object, val, path...)
+	}
+}
@@ -0,0 +1,43 @@
+package object
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+)
+
+func TestApplyTransform(t *testing.T) {
+	g := NewWithT(t)
+
+	inst := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "cm"},
+		"data": map[string]interface{}{
+			"host":       "svc." + api.NamespacePlaceholder + ".svc.cluster.local",
+			"unaffected": "value",
+		},
+	}}
+
+	ApplyTransform(inst, &api.ObjectTransform{Fields: []string{"data.host", "data.missing"}}, "child")
+
+	host, _, _ := unstructured.NestedString(inst.Object, "data", "host")
+	g.Expect(host).Should(Equal("svc.child.svc.cluster.local"))
+
+	unaffected, _, _ := unstructured.NestedString(inst.Object, "data", "unaffected")
+	g.Expect(unaffected).Should(Equal("value"))
+}
+
+func TestApplyTransformNilTransform(t *testing.T) {
+	g := NewWithT(t)
+
+	inst := &unstructured.Unstructured{Object: map[string]interface{}{
+		"data": map[string]interface{}{"host": api.NamespacePlaceholder},
+	}}
+
+	ApplyTransform(inst, nil, "child")
+
+	host, _, _ := unstructured.NestedString(inst.Object, "data", "host")
+	g.Expect(host).Should(Equal(api.NamespacePlaceholder))
+}
@@ -0,0 +1,76 @@
+// Package policy evaluates PropagationPolicy objects, which let a namespace admin declare, in one
+// auditable place, which source objects (by GVK, name pattern and label selector) are allowed to
+// propagate into which descendant namespaces. It's meant to be consulted alongside - not instead
+// of - the propagate.hnc.x-k8s.io annotations that selectors.ShouldPropagate already checks.
+package policy
+
+import (
+	"fmt"
+	"path"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+)
+
+// ShouldPropagate returns whether an object of the given apiVersion/kind/name/labels, declared in
+// a namespace with the given PropagationPolicy specs, is allowed to propagate into dst.
+//
+// An object that doesn't match any rule in any of the specs is unaffected - that is, this
+// returns true - since PropagationPolicy is meant to add restrictions on top of the existing
+// annotations, not require every object to be explicitly enumerated. If one or more rules match,
+// the object may propagate into dst if any matching rule either omits Destinations (meaning every
+// descendant is allowed) or explicitly lists dst.
+func ShouldPropagate(specs []api.PropagationPolicySpec, apiVersion, kind, name string, objLabels labels.Set, dst string) (bool, error) {
+	matched := false
+	for _, spec := range specs {
+		for _, rule := range spec.Rules {
+			ok, err := ruleMatches(rule, apiVersion, kind, name, objLabels)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				continue
+			}
+			matched = true
+			if len(rule.Destinations) == 0 {
+				return true, nil
+			}
+			for _, d := range rule.Destinations {
+				if d == dst {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	// No rule restricted this destination. If at least one rule matched the object but none allowed
+	// this destination, it's disallowed; otherwise, it was never in scope of any policy.
+	return !matched, nil
+}
+
+func ruleMatches(rule api.PropagationRule, apiVersion, kind, name string, objLabels labels.Set) (bool, error) {
+	if rule.APIVersion != apiVersion || rule.Kind != kind {
+		return false, nil
+	}
+	if rule.NamePattern != "" {
+		ok, err := path.Match(rule.NamePattern, name)
+		if err != nil {
+			return false, fmt.Errorf("invalid namePattern %q: %w", rule.NamePattern, err)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	if rule.Selector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(rule.Selector)
+		if err != nil {
+			return false, fmt.Errorf("invalid selector: %w", err)
+		}
+		if !sel.Matches(objLabels) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,103 @@
+// Package window parses and evaluates the propagation windows declared via
+// api.AnnotationPropagationWindow, so ObjectReconciler can decide whether a source object's
+// changes should be written now or deferred until the window opens.
+package window
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const dayLayout = "15:04"
+
+// Spec is a parsed propagation window. It's either a one-off interval between two instants, or a
+// daily UTC time-of-day range that repeats every day.
+type Spec struct {
+	start, end time.Time     // set if this is a one-off interval
+	daily      bool          // true if this is a recurring daily window
+	dayStart   time.Duration // offset from midnight UTC; set if daily
+	dayEnd     time.Duration // offset from midnight UTC; set if daily
+}
+
+// Parse parses a propagation window spec in one of two forms:
+//   - a one-off RFC3339 interval, "<start>/<end>", e.g. "2021-01-01T00:00:00Z/2021-01-02T00:00:00Z"
+//   - a recurring daily UTC time-of-day range, "HH:MM-HH:MM", e.g. "02:00-04:00"; if the end is
+//     earlier than the start, the window wraps past midnight (e.g. "22:00-02:00")
+func Parse(spec string) (Spec, error) {
+	if s, e, ok := strings.Cut(spec, "/"); ok {
+		start, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return Spec{}, fmt.Errorf("invalid interval start %q: %w", s, err)
+		}
+		end, err := time.Parse(time.RFC3339, e)
+		if err != nil {
+			return Spec{}, fmt.Errorf("invalid interval end %q: %w", e, err)
+		}
+		if !end.After(start) {
+			return Spec{}, fmt.Errorf("interval end %q must be after start %q", e, s)
+		}
+		return Spec{start: start, end: end}, nil
+	}
+
+	if s, e, ok := strings.Cut(spec, "-"); ok {
+		start, err := time.Parse(dayLayout, s)
+		if err != nil {
+			return Spec{}, fmt.Errorf("invalid daily window start %q: %w", s, err)
+		}
+		end, err := time.Parse(dayLayout, e)
+		if err != nil {
+			return Spec{}, fmt.Errorf("invalid daily window end %q: %w", e, err)
+		}
+		dayStart := start.Sub(start.Truncate(24 * time.Hour))
+		dayEnd := end.Sub(end.Truncate(24 * time.Hour))
+		if dayStart == dayEnd {
+			return Spec{}, fmt.Errorf("daily window start and end %q can't be equal", s)
+		}
+		return Spec{daily: true, dayStart: dayStart, dayEnd: dayEnd}, nil
+	}
+
+	return Spec{}, fmt.Errorf(`invalid propagation window %q: must be "<RFC3339>/<RFC3339>" or "HH:MM-HH:MM"`, spec)
+}
+
+// IsOpen returns whether the window is open at the given instant.
+func (s Spec) IsOpen(now time.Time) bool {
+	if !s.daily {
+		return !now.Before(s.start) && now.Before(s.end)
+	}
+	tod := timeOfDay(now)
+	if s.dayStart <= s.dayEnd {
+		return tod >= s.dayStart && tod < s.dayEnd
+	}
+	// The window wraps past midnight, e.g. 22:00-02:00.
+	return tod >= s.dayStart || tod < s.dayEnd
+}
+
+// NextOpen returns how long until the window will next be open, relative to now. It returns 0 if
+// the window is already open, or if it's a one-off interval that has permanently closed - in that
+// case, there's no future time to wake up for, and it's up to whoever set the annotation to update
+// or remove it.
+func (s Spec) NextOpen(now time.Time) time.Duration {
+	if s.IsOpen(now) {
+		return 0
+	}
+	if !s.daily {
+		if now.Before(s.start) {
+			return s.start.Sub(now)
+		}
+		return 0
+	}
+	tod := timeOfDay(now)
+	wait := s.dayStart - tod
+	if wait <= 0 {
+		wait += 24 * time.Hour
+	}
+	return wait
+}
+
+// timeOfDay returns the offset of t from the most recent UTC midnight.
+func timeOfDay(t time.Time) time.Duration {
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return t.Sub(midnight)
+}
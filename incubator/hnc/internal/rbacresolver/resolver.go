@@ -0,0 +1,163 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rbacresolver computes the effective RBAC permissions a subject has in a namespace given
+// HNC's hierarchy: a RoleBinding created in a namespace is propagated to every one of that
+// namespace's descendants, so working out who can do what in ns means walking ns and all of its
+// ancestors, not just ns itself.
+package rbacresolver
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+)
+
+// Grant is one RoleBinding or ClusterRoleBinding that grants access to the resolved subject in
+// the target namespace, either because it lives there directly, because it lives in one of the
+// target's ancestors and is (or will be) propagated down by HNC, or because it's a
+// ClusterRoleBinding, which applies everywhere regardless of hierarchy.
+type Grant struct {
+	RoleBinding string
+	RoleKind    string // "Role" or "ClusterRole"
+	RoleName    string
+	Rules       []rbacv1.PolicyRule
+	// Source is the namespace the RoleBinding is defined in, or "" for a ClusterRoleBinding. Equal
+	// to the target namespace if the grant isn't inherited.
+	Source string
+}
+
+// Inherited reports whether the grant comes from an ancestor of the target namespace rather than
+// the target namespace itself.
+func (g Grant) Inherited(target string) bool {
+	return g.Source != "" && g.Source != target
+}
+
+// GetHierarchy fetches the HierarchyConfiguration singleton for a namespace. It's satisfied by
+// kubectl-hns's own Client.getHierarchy; it's a function type here so this package doesn't need to
+// know about kubectl-hns's REST plumbing.
+type GetHierarchy func(nnm string) *api.HierarchyConfiguration
+
+// Resolve returns every RoleBinding, in ns or any of its ancestors, and every ClusterRoleBinding,
+// whose subjects include user, along with the rules of the Role/ClusterRole each one references.
+func Resolve(ctx context.Context, k8sClient kubernetes.Interface, getHierarchy GetHierarchy, user, ns string) ([]Grant, error) {
+	var grants []Grant
+
+	for _, nnm := range ancestry(getHierarchy, ns) {
+		rbs, err := k8sClient.RbacV1().RoleBindings(nnm).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list rolebindings in %q: %w", nnm, err)
+		}
+		for _, rb := range rbs.Items {
+			// A RoleBinding that HNC has already propagated into this namespace from one of its
+			// ancestors is a copy of a grant we'll independently pick up when we visit that ancestor
+			// ourselves; counting it again here would double-report the same permission and attribute
+			// it to the wrong namespace.
+			if _, ok := rb.Labels[api.LabelInheritedFrom]; ok {
+				continue
+			}
+			if !hasSubject(rb.Subjects, user) {
+				continue
+			}
+			rules, err := rulesFor(ctx, k8sClient, nnm, rb.RoleRef)
+			if err != nil {
+				return nil, err
+			}
+			grants = append(grants, Grant{
+				RoleBinding: rb.Name,
+				RoleKind:    rb.RoleRef.Kind,
+				RoleName:    rb.RoleRef.Name,
+				Rules:       rules,
+				Source:      nnm,
+			})
+		}
+	}
+
+	crbs, err := k8sClient.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clusterrolebindings: %w", err)
+	}
+	for _, crb := range crbs.Items {
+		if !hasSubject(crb.Subjects, user) {
+			continue
+		}
+		cr, err := k8sClient.RbacV1().ClusterRoles().Get(ctx, crb.RoleRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get clusterrole %q: %w", crb.RoleRef.Name, err)
+		}
+		grants = append(grants, Grant{
+			RoleBinding: crb.Name,
+			RoleKind:    "ClusterRole",
+			RoleName:    cr.Name,
+			Rules:       cr.Rules,
+		})
+	}
+
+	return grants, nil
+}
+
+// ancestry returns ns and every one of its ancestors, in that order, from most to least specific.
+// It bails out at a cycle rather than looping forever - HNC will flag the cycle itself elsewhere,
+// but an access query shouldn't hang because of it.
+func ancestry(getHierarchy GetHierarchy, ns string) []string {
+	seen := map[string]bool{}
+	var chain []string
+	for nnm := ns; nnm != "" && !seen[nnm]; {
+		seen[nnm] = true
+		chain = append(chain, nnm)
+		nnm = getHierarchy(nnm).Spec.Parent
+	}
+	return chain
+}
+
+func rulesFor(ctx context.Context, k8sClient kubernetes.Interface, ns string, ref rbacv1.RoleRef) ([]rbacv1.PolicyRule, error) {
+	switch ref.Kind {
+	case "Role":
+		r, err := k8sClient.RbacV1().Roles(ns).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get role %q in %q: %w", ref.Name, ns, err)
+		}
+		return r.Rules, nil
+	case "ClusterRole":
+		r, err := k8sClient.RbacV1().ClusterRoles().Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get clusterrole %q: %w", ref.Name, err)
+		}
+		return r.Rules, nil
+	default:
+		return nil, fmt.Errorf("unknown roleRef kind %q", ref.Kind)
+	}
+}
+
+func hasSubject(subjects []rbacv1.Subject, user string) bool {
+	for _, s := range subjects {
+		switch s.Kind {
+		case rbacv1.UserKind:
+			if s.Name == user {
+				return true
+			}
+		case rbacv1.ServiceAccountKind:
+			if fmt.Sprintf("system:serviceaccount:%s:%s", s.Namespace, s.Name) == user {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbacresolver
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+)
+
+// parents maps a namespace to its HNC parent, used to fake out getHierarchy for these tests.
+type parents map[string]string
+
+func (p parents) get(nnm string) *api.HierarchyConfiguration {
+	hier := &api.HierarchyConfiguration{}
+	hier.Namespace = nnm
+	hier.Spec.Parent = p[nnm]
+	return hier
+}
+
+func TestResolve(t *testing.T) {
+	g := NewWithT(t)
+
+	// grandparent -> parent -> child, mirroring a typical HNC subtree.
+	hier := parents{"child": "parent", "parent": "grandparent"}
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "viewer", Namespace: "grandparent"},
+		Rules:      []rbacv1.PolicyRule{{Verbs: []string{"get", "list"}, Resources: []string{"pods"}}},
+	}
+	rb := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-viewer", Namespace: "grandparent"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "viewer"},
+	}
+	// A propagated copy of alice-viewer that HNC has already synced down into "child". It must not
+	// be double-counted alongside the original in "grandparent".
+	propagatedRB := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "alice-viewer", Namespace: "child",
+			Labels: map[string]string{api.LabelInheritedFrom: "grandparent"},
+		},
+		Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		RoleRef:  rbacv1.RoleRef{Kind: "Role", Name: "viewer"},
+	}
+
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-viewer"},
+		Rules:      []rbacv1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"nodes"}}},
+	}
+	crb := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-cluster-viewer"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-viewer"},
+	}
+
+	client := fake.NewSimpleClientset(role, rb, propagatedRB, clusterRole, crb)
+
+	grants, err := Resolve(context.Background(), client, hier.get, "alice", "child")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(grants).To(HaveLen(2))
+
+	var roleBindingGrant, clusterGrant Grant
+	for _, gr := range grants {
+		if gr.RoleBinding == "alice-viewer" {
+			roleBindingGrant = gr
+		} else {
+			clusterGrant = gr
+		}
+	}
+
+	g.Expect(roleBindingGrant.Source).To(Equal("grandparent"))
+	g.Expect(roleBindingGrant.Inherited("child")).To(BeTrue())
+	g.Expect(roleBindingGrant.Rules).To(Equal(role.Rules))
+
+	g.Expect(clusterGrant.RoleBinding).To(Equal("alice-cluster-viewer"))
+	g.Expect(clusterGrant.Source).To(BeEmpty())
+	g.Expect(clusterGrant.Inherited("child")).To(BeFalse())
+	g.Expect(clusterGrant.Rules).To(Equal(clusterRole.Rules))
+}
+
+func TestResolveNoAccess(t *testing.T) {
+	g := NewWithT(t)
+	hier := parents{"child": "parent"}
+	client := fake.NewSimpleClientset()
+
+	grants, err := Resolve(context.Background(), client, hier.get, "bob", "child")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(grants).To(BeEmpty())
+}
@@ -19,11 +19,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -37,6 +41,9 @@ import (
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/metadata"
 )
 
+// defaultHookTimeout is used for a Hook whose TimeoutSeconds is unset.
+const defaultHookTimeout = 60 * time.Second
+
 // AnchorReconciler reconciles SubnamespaceAnchor CRs to make sure all the subnamespaces are
 // properly maintained.
 type AnchorReconciler struct {
@@ -49,6 +56,10 @@ type AnchorReconciler struct {
 	// https://book-v1.book.kubebuilder.io/beyond_basics/controller_watches.html) that is used to
 	// enqueue additional objects that need updating.
 	Affected chan event.GenericEvent
+
+	// httpClient is used to call HTTP lifecycle hooks. It's a field, rather than a call to
+	// http.DefaultClient, so that it can be stubbed out in unit tests.
+	httpClient *http.Client
 }
 
 // Reconcile sets up some basic variables and then calls the business logic. It currently
@@ -75,7 +86,7 @@ func (r *AnchorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 
 	// Always delete anchor (and any other HNC CRs) in the excluded namespaces and
 	// early exit.
-	if config.ExcludedNamespaces[pnm] {
+	if config.IsExcludedNamespace(pnm) {
 		// Since the anchors in the excluded namespaces are never synced by HNC,
 		// there are no finalizers on the anchors that we can delete them without
 		// removing the finalizers first.
@@ -87,7 +98,7 @@ func (r *AnchorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	// namespace that should not be created as a subnamespace, but the webhook has
 	// been bypassed and the anchor has been successfully created. Forbidden
 	// anchors won't have finalizers.
-	if config.ExcludedNamespaces[nm] {
+	if config.IsExcludedNamespace(nm) {
 		inst.Status.State = api.Forbidden
 		return ctrl.Result{}, r.writeInstance(ctx, log, inst)
 	}
@@ -106,8 +117,13 @@ func (r *AnchorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, err
 	}
 
-	// If the subnamespace doesn't exist, create it.
-	if inst.Status.State == api.Missing {
+	// If the subnamespace doesn't exist, create it - unless the parent requires approval and this
+	// anchor hasn't received it yet, in which case just record that we're waiting.
+	if inst.Status.State == api.Missing && r.requiresApproval(pnm) && inst.Annotations[api.SubnamespaceApprovedAnnotation] != "true" {
+		inst.Status.State = api.Pending
+		return ctrl.Result{}, r.writeInstance(ctx, log, inst)
+	}
+	if inst.Status.State == api.Missing || inst.Status.State == api.Pending {
 		if err := r.writeNamespace(ctx, log, nm, pnm); err != nil {
 			// Write the "Missing" state to the anchor status if the subnamespace
 			// cannot be created for some reason. Without it, the anchor status will
@@ -117,6 +133,9 @@ func (r *AnchorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			}
 			return ctrl.Result{}, err
 		}
+		// The subnamespace was just created; run its PostCreate hook, if any. Since the namespace
+		// already exists by this point, a hook failure can only be reported, not undone.
+		r.runPostCreateHook(ctx, log, inst)
 	}
 
 	// Add finalizers on all non-forbidden anchors to ensure it's not deleted until
@@ -125,6 +144,15 @@ func (r *AnchorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	return ctrl.Result{}, r.writeInstance(ctx, log, inst)
 }
 
+// requiresApproval returns true if the given parent namespace, or any of its ancestors, requires
+// approval before its subnamespaces are created. See
+// api.HierarchyConfigurationSpec.RequireSubnamespaceApproval.
+func (r *AnchorReconciler) requiresApproval(pnm string) bool {
+	r.forest.Lock()
+	defer r.forest.Unlock()
+	return r.forest.Get(pnm).RequiresSubnamespaceApproval()
+}
+
 // onDeleting returns true if the anchor is in the process of being deleted, and handles all
 // necessary steps to allow it to be deleted. This typically amounts to two steps:
 //
@@ -154,6 +182,17 @@ func (r *AnchorReconciler) onDeleting(ctx context.Context, log logr.Logger, inst
 	// something to happen. See method-level comments for details.
 	switch {
 	case r.shouldDeleteSubns(log, inst, snsInst, deletingCRD):
+		proceed, err := r.runPreDeleteHook(ctx, log, inst)
+		if werr := r.writeInstance(ctx, log, inst); werr != nil {
+			log.Error(werr, "while persisting anchor after running its PreDelete hook")
+		}
+		if err != nil {
+			return true, err
+		}
+		if !proceed {
+			log.Info("Not deleting subnamespace because its PreDelete hook failed and FailurePolicy is Fail")
+			return true, nil
+		}
 		log.Info("Deleting subnamespace due to anchor being deleted")
 		return true, r.deleteNamespace(ctx, log, snsInst)
 	case r.shouldFinalizeAnchor(log, inst, snsInst):
@@ -308,17 +347,16 @@ func (r *AnchorReconciler) updateState(log logr.Logger, inst *api.SubnamespaceAn
 	}
 }
 
-// It enqueues a subnamespace anchor for later reconciliation. This occurs in a goroutine
-// so the caller doesn't block; since the reconciler is never garbage-collected, this is safe.
+// It enqueues a subnamespace anchor for later reconciliation. Since Affected is a bounded,
+// non-blocking channel (see enqueueEvent), this never blocks the caller, so there's no need to do
+// this in a goroutine.
 func (r *AnchorReconciler) enqueue(log logr.Logger, nm, pnm, reason string) {
-	go func() {
-		// The watch handler doesn't care about anything except the metadata.
-		inst := &api.SubnamespaceAnchor{}
-		inst.ObjectMeta.Name = nm
-		inst.ObjectMeta.Namespace = pnm
-		log.V(1).Info("Enqueuing for reconciliation", "affected", pnm+"/"+nm, "reason", reason)
-		r.Affected <- event.GenericEvent{Object: inst}
-	}()
+	// The watch handler doesn't care about anything except the metadata.
+	inst := &api.SubnamespaceAnchor{}
+	inst.ObjectMeta.Name = nm
+	inst.ObjectMeta.Namespace = pnm
+	log.V(1).Info("Enqueuing for reconciliation", "affected", pnm+"/"+nm, "reason", reason)
+	enqueueEvent(log, r.Affected, "anchor-affected", event.GenericEvent{Object: inst})
 }
 
 func (r *AnchorReconciler) getInstance(ctx context.Context, pnm, nm string) (*api.SubnamespaceAnchor, error) {
@@ -394,6 +432,121 @@ func (r *AnchorReconciler) deleteNamespace(ctx context.Context, log logr.Logger,
 	return nil
 }
 
+// runPostCreateHook runs the anchor's PostCreate hook, if any, immediately after the
+// subnamespace has been created. Since the subnamespace already exists by the time this runs, a
+// failure can only be reported as a condition; it can never undo the creation.
+func (r *AnchorReconciler) runPostCreateHook(ctx context.Context, log logr.Logger, inst *api.SubnamespaceAnchor) {
+	if inst.Spec.Hooks == nil || inst.Spec.Hooks.PostCreate == nil {
+		return
+	}
+	if err := r.runHook(ctx, inst.Name, inst.Spec.Hooks.PostCreate); err != nil {
+		log.Error(err, "PostCreate hook failed")
+		r.writeHookCondition(inst, api.ReasonHookFailed, fmt.Sprintf("PostCreate hook failed: %s", err))
+		return
+	}
+	log.Info("PostCreate hook succeeded")
+}
+
+// runPreDeleteHook runs the anchor's PreDelete hook, if any, and reports whether it's safe to
+// proceed with deleting the subnamespace. It's safe to proceed if there's no hook configured, if
+// the hook succeeds, or if the hook fails but its FailurePolicy is "Ignore". The returned error is
+// always nil: any error while running the hook, including a client error creating its Job, is
+// treated as a hook failure.
+func (r *AnchorReconciler) runPreDeleteHook(ctx context.Context, log logr.Logger, inst *api.SubnamespaceAnchor) (bool, error) {
+	if inst.Spec.Hooks == nil || inst.Spec.Hooks.PreDelete == nil {
+		return true, nil
+	}
+	hook := inst.Spec.Hooks.PreDelete
+	if err := r.runHook(ctx, inst.Name, hook); err != nil {
+		log.Error(err, "PreDelete hook failed")
+		r.writeHookCondition(inst, api.ReasonHookFailed, fmt.Sprintf("PreDelete hook failed: %s", err))
+		return hook.FailurePolicy == api.HookFailurePolicyIgnore, nil
+	}
+	log.Info("PreDelete hook succeeded")
+	return true, nil
+}
+
+func (r *AnchorReconciler) writeHookCondition(inst *api.SubnamespaceAnchor, reason, msg string) {
+	inst.Status.Conditions = append(inst.Status.Conditions, api.NewCondition(api.ConditionBadHookConfiguration, reason, msg))
+}
+
+// runHook runs a single lifecycle hook - either an HTTP callback or a Job created in namespace ns
+// - and waits up to hook.TimeoutSeconds (or defaultHookTimeout, if unset) for it to complete.
+func (r *AnchorReconciler) runHook(ctx context.Context, ns string, hook *api.Hook) error {
+	timeout := defaultHookTimeout
+	if hook.TimeoutSeconds > 0 {
+		timeout = time.Duration(hook.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	switch {
+	case hook.HTTP != nil:
+		return r.runHTTPHook(ctx, hook.HTTP)
+	case hook.Job != nil:
+		return r.runJobHook(ctx, ns, hook.Job)
+	default:
+		return errors.New("hook has neither http nor job configured")
+	}
+}
+
+func (r *AnchorReconciler) runHTTPHook(ctx context.Context, hook *api.HTTPHook) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, nil)
+	if err != nil {
+		return fmt.Errorf("while building request for %s: %w", hook.URL, err)
+	}
+	hc := r.httpClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("while calling %s: %w", hook.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned status %d", hook.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// runJobHook creates a Job in namespace ns from spec, and blocks until it either completes,
+// fails, or ctx is done.
+func (r *AnchorReconciler) runJobHook(ctx context.Context, ns string, spec *batchv1.JobSpec) error {
+	job := &batchv1.Job{}
+	job.GenerateName = "hnc-hook-"
+	job.Namespace = ns
+	job.Spec = *spec
+	if err := r.Create(ctx, job); err != nil {
+		return fmt.Errorf("while creating hook job: %w", err)
+	}
+
+	timeout := defaultHookTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	key := types.NamespacedName{Namespace: job.Namespace, Name: job.Name}
+	err := wait.PollImmediate(2*time.Second, timeout, func() (bool, error) {
+		cur := &batchv1.Job{}
+		if err := r.Get(ctx, key, cur); err != nil {
+			return false, err
+		}
+		for _, c := range cur.Status.Conditions {
+			if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+				return false, fmt.Errorf("hook job %q failed: %s", job.Name, c.Message)
+			}
+			if c.Type == batchv1.JobComplete && c.Status == corev1.ConditionTrue {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("while waiting for hook job %q: %w", job.Name, err)
+	}
+	return nil
+}
+
 func (r *AnchorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Maps an subnamespace to its anchor in the parent namespace.
 	nsMapFn := func(obj client.Object) []reconcile.Request {
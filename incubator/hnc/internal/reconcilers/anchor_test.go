@@ -2,6 +2,8 @@ package reconcilers_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -22,7 +24,7 @@ var _ = Describe("Anchor", func() {
 	BeforeEach(func() {
 		fooName = createNS(ctx, "foo")
 		barName = createNSName("bar")
-		config.ExcludedNamespaces = nil
+		config.SetExcludedNamespaces(nil)
 	})
 
 	It("should create an subnamespace and update the hierarchy according to the anchor", func() {
@@ -52,14 +54,14 @@ var _ = Describe("Anchor", func() {
 	})
 
 	It("should remove the anchor in an excluded namespace", func() {
-		config.ExcludedNamespaces = map[string]bool{"kube-system": true}
+		config.SetExcludedNamespaces(map[string]bool{"kube-system": true})
 		kube_system_anchor_bar := newAnchor(barName, "kube-system")
 		updateAnchor(ctx, kube_system_anchor_bar)
 		Eventually(canGetAnchor(ctx, barName, "kube-system")).Should(Equal(false))
 	})
 
 	It("should set the anchor.status.state to Forbidden if the subnamespace is an excluded namespace", func() {
-		config.ExcludedNamespaces = map[string]bool{"kube-system": true}
+		config.SetExcludedNamespaces(map[string]bool{"kube-system": true})
 		foo_anchor_kube_system := newAnchor("kube-system", fooName)
 		updateAnchor(ctx, foo_anchor_kube_system)
 		Eventually(getAnchorState(ctx, fooName, "kube-system")).Should(Equal(api.Forbidden))
@@ -102,6 +104,48 @@ var _ = Describe("Anchor", func() {
 			return barHier.Spec.Parent
 		}).Should(Equal(fooName))
 	})
+	It("should run a PostCreate hook after creating the subnamespace", func() {
+		called := make(chan struct{}, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called <- struct{}{}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		anchor := newAnchor(barName, fooName)
+		anchor.Spec.Hooks = &api.LifecycleHooks{
+			PostCreate: &api.Hook{HTTP: &api.HTTPHook{URL: srv.URL}},
+		}
+		updateAnchor(ctx, anchor)
+
+		Eventually(called).Should(Receive())
+	})
+
+	It("should not delete the subnamespace if its PreDelete hook fails and FailurePolicy is Fail", func() {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		anchor := newAnchor(barName, fooName)
+		anchor.Spec.Hooks = &api.LifecycleHooks{
+			PreDelete: &api.Hook{HTTP: &api.HTTPHook{URL: srv.URL}, FailurePolicy: api.HookFailurePolicyFail},
+		}
+		updateAnchor(ctx, anchor)
+		Eventually(getAnchorState(ctx, fooName, barName)).Should(Equal(api.Ok))
+
+		anchor = getAnchor(ctx, fooName, barName)
+		Expect(k8sClient.Delete(ctx, anchor)).Should(Succeed())
+
+		// The PreDelete hook keeps failing, so the anchor should keep its finalizer and record the
+		// failure as a condition instead of being allowed to finish deleting.
+		Eventually(func() []api.Condition {
+			return getAnchor(ctx, fooName, barName).Status.Conditions
+		}).ShouldNot(BeEmpty())
+		Consistently(func() []string {
+			return getAnchor(ctx, fooName, barName).ObjectMeta.Finalizers
+		}).ShouldNot(BeEmpty())
+	})
 })
 
 func getAnchorState(ctx context.Context, pnm, nm string) func() api.SubnamespaceAnchorState {
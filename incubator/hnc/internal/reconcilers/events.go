@@ -0,0 +1,94 @@
+package reconcilers
+
+import (
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/stats"
+)
+
+// affectedChannelSize bounds the buffer of every "Affected"-style event.GenericEvent channel used
+// to enqueue additional objects or namespaces for reconciliation. It's large enough to absorb a
+// typical burst of enqueues - e.g. propagating one changed source object to every descendant of a
+// large subtree - without blocking the sender, while still bounded so a consumer that's stalled
+// or fallen behind can't make HNC's memory usage grow without limit.
+const affectedChannelSize = 1024
+
+// newAffectedChannel creates a new bounded event.GenericEvent channel suitable for use as an
+// "Affected"-style reconciler field.
+func newAffectedChannel() chan event.GenericEvent {
+	return make(chan event.GenericEvent, affectedChannelSize)
+}
+
+// enqueueEvent sends evt on ch without blocking the caller and reports whether it was actually
+// sent. If ch's buffer is full - meaning its consumer has fallen far behind - the event is
+// dropped instead of blocking, so callers no longer need to spawn a goroutine per enqueue just to
+// avoid stalling on a slow consumer. Both the outcome and (on success) the channel's resulting
+// length are reported via the stats package so operators can see when a channel is close to full
+// or actively dropping events.
+func enqueueEvent(log logr.Logger, ch chan event.GenericEvent, name string, evt event.GenericEvent) bool {
+	select {
+	case ch <- evt:
+		stats.RecordEventEnqueued(name, len(ch))
+		return true
+	default:
+		log.Info("Dropping enqueued event because the channel's buffer is full", "channel", name)
+		stats.RecordEventDropped(name)
+		return false
+	}
+}
+
+// affectedCoalescer dedupes and batches enqueues to an Affected channel by NamespacedName, so
+// that many enqueues for overlapping objects - e.g. propagating a change to every descendant of a
+// large subtree - only ever have one event pending per object at a time, instead of flooding the
+// channel (and the workqueue behind it) with duplicates that would all reconcile to the same
+// thing anyway.
+type affectedCoalescer struct {
+	mu      sync.Mutex
+	pending namespacedNameSet
+}
+
+// newAffectedCoalescer creates a new, empty affectedCoalescer.
+func newAffectedCoalescer() *affectedCoalescer {
+	return &affectedCoalescer{pending: namespacedNameSet{}}
+}
+
+// enqueueBatch sends one event on ch for every NamespacedName in nnms that isn't already pending -
+// including duplicates within nnms itself - and marks each of them pending until the reconciler
+// that owns ch calls reconciling for it. mk builds the event.GenericEvent to send for a given
+// NamespacedName.
+func (c *affectedCoalescer) enqueueBatch(log logr.Logger, ch chan event.GenericEvent, chName string, nnms []types.NamespacedName, mk func(types.NamespacedName) event.GenericEvent) {
+	c.mu.Lock()
+	var toSend []types.NamespacedName
+	for _, nnm := range nnms {
+		if c.pending[nnm] {
+			continue
+		}
+		c.pending[nnm] = true
+		toSend = append(toSend, nnm)
+	}
+	c.mu.Unlock()
+
+	for _, nnm := range toSend {
+		if !enqueueEvent(log, ch, chName, mk(nnm)) {
+			// The channel was full and the event was dropped, so nothing will ever call reconciling
+			// for nnm on this account. Clear its pending marker now so a later enqueueBatch call can
+			// retry it instead of treating it as permanently in flight.
+			c.mu.Lock()
+			delete(c.pending, nnm)
+			c.mu.Unlock()
+		}
+	}
+}
+
+// reconciling clears nnm's pending marker, so a future change to it can be coalesced again instead
+// of being dropped as a stale duplicate. Reconcilers should call this as they start reconciling
+// nnm, before they could miss a change that arrives mid-reconcile.
+func (c *affectedCoalescer) reconciling(nnm types.NamespacedName) {
+	c.mu.Lock()
+	delete(c.pending, nnm)
+	c.mu.Unlock()
+}
@@ -0,0 +1,172 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcilers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/forest"
+)
+
+// hrqManagedBy is the value HierarchicalResourceQuotaReconciler puts in the "app.kubernetes.io/managed-by"
+// label of every per-namespace ResourceQuota it writes, so its own writes can be told apart from
+// a ResourceQuota that a user or another controller created by hand.
+const hrqManagedBy = "hnc-hierarchicalresourcequota"
+
+// hrqQuotaName is the name of the ResourceQuota that HierarchicalResourceQuotaReconciler writes
+// into every namespace in a HierarchicalResourceQuota's subtree.
+func hrqQuotaName(hrqName string) string {
+	return "hnc-hrq-" + hrqName
+}
+
+// HierarchicalResourceQuotaReconciler reconciles a HierarchicalResourceQuota by dividing its
+// budget evenly across every namespace in its subtree (the namespace it's created in, plus all
+// descendants in the forest) and writing a plain ResourceQuota with that share into each one.
+//
+// Kubernetes has no native way to enforce a single quota across multiple namespaces atomically,
+// so this is necessarily a best-effort, eventually-consistent approximation: usage is aggregated
+// from each namespace's own ResourceQuota.Status.Used on every reconcile, but a burst of
+// concurrent creations spread across several namespaces between reconciles could still push the
+// subtree's real total slightly over Spec.Hard. Reconciling more often narrows that window; it
+// can't close it.
+type HierarchicalResourceQuotaReconciler struct {
+	client.Client
+	Log logr.Logger
+
+	Forest *forest.Forest
+}
+
+// +kubebuilder:rbac:groups=hnc.x-k8s.io,resources=hierarchicalresourcequotas,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=hnc.x-k8s.io,resources=hierarchicalresourcequotas/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=resourcequotas,verbs=get;list;watch;create;update;patch;delete
+
+func (r *HierarchicalResourceQuotaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := loggerWithRID(r.Log).WithValues("trigger", req.NamespacedName)
+
+	inst := &api.HierarchicalResourceQuota{}
+	if err := r.Get(ctx, req.NamespacedName, inst); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.V(1).Info("HierarchicalResourceQuota has been deleted; its per-namespace ResourceQuotas are left in place")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	namespaces := r.subtreeNamespaces(req.Namespace)
+	sort.Strings(namespaces)
+	shares := shareQuota(inst.Spec.Hard, len(namespaces))
+
+	used := corev1.ResourceList{}
+	for _, ns := range namespaces {
+		rq, err := r.writeShare(ctx, log, ns, inst.Name, shares)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		addResourceList(used, rq.Status.Used)
+	}
+
+	inst.Status.Hard = inst.Spec.Hard
+	inst.Status.Used = used
+	inst.Status.Namespaces = namespaces
+	if err := r.Status().Update(ctx, inst); err != nil {
+		log.Error(err, "while updating status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// subtreeNamespaces returns ns and every one of its descendants in the forest, or just ns if it's
+// not (yet) known to the forest.
+func (r *HierarchicalResourceQuotaReconciler) subtreeNamespaces(ns string) []string {
+	r.Forest.Lock()
+	defer r.Forest.Unlock()
+	nsInst := r.Forest.Get(ns)
+	if nsInst == nil {
+		return []string{ns}
+	}
+	return append([]string{ns}, nsInst.DescendantNames()...)
+}
+
+// writeShare creates or updates the ResourceQuota that carries hrqName's share of the budget in
+// namespace ns, and returns it as last observed on the apiserver (i.e. including Status.Used).
+func (r *HierarchicalResourceQuotaReconciler) writeShare(ctx context.Context, log logr.Logger, ns, hrqName string, hard corev1.ResourceList) (*corev1.ResourceQuota, error) {
+	rq := &corev1.ResourceQuota{}
+	rq.Name = hrqQuotaName(hrqName)
+	rq.Namespace = ns
+
+	res, err := controllerutil.CreateOrUpdate(ctx, r.Client, rq, func() error {
+		if rq.Labels == nil {
+			rq.Labels = map[string]string{}
+		}
+		rq.Labels["app.kubernetes.io/managed-by"] = hrqManagedBy
+		rq.Spec.Hard = hard
+		return nil
+	})
+	if err != nil {
+		log.Error(err, "while writing per-namespace share of hierarchical resource quota", "namespace", ns)
+		return nil, err
+	}
+	if res != controllerutil.OperationResultNone {
+		log.V(1).Info("Wrote per-namespace share of hierarchical resource quota", "namespace", ns, "op", res)
+	}
+
+	// CreateOrUpdate doesn't refresh Status, so re-fetch to pick up the latest Status.Used.
+	if err := r.Get(ctx, types.NamespacedName{Namespace: ns, Name: rq.Name}, rq); err != nil {
+		return nil, err
+	}
+	return rq, nil
+}
+
+// shareQuota divides each quantity in hard evenly across n namespaces, rounding down. n is
+// assumed to be at least 1 (the source namespace is always included).
+func shareQuota(hard corev1.ResourceList, n int) corev1.ResourceList {
+	share := corev1.ResourceList{}
+	for name, qty := range hard {
+		q := qty.DeepCopy()
+		q.SetMilli(q.MilliValue() / int64(n))
+		share[name] = q
+	}
+	return share
+}
+
+// addResourceList adds every quantity in src into dst, in place.
+func addResourceList(dst, src corev1.ResourceList) {
+	for name, qty := range src {
+		if cur, ok := dst[name]; ok {
+			cur.Add(qty)
+			dst[name] = cur
+		} else {
+			dst[name] = qty.DeepCopy()
+		}
+	}
+}
+
+func (r *HierarchicalResourceQuotaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&api.HierarchicalResourceQuota{}).
+		Complete(r)
+}
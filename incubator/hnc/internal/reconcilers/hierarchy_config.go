@@ -54,7 +54,7 @@ var nextReconcileID int64
 // loggerWithRID adds a reconcile ID (rid) to the given logger.
 func loggerWithRID(log logr.Logger) logr.Logger {
 	rid := atomic.AddInt64(&nextReconcileID, 1)
-	return log.WithValues("rid", rid)
+	return log.WithValues(keyReconcileID, rid)
 }
 
 // HierarchyConfigReconciler is responsible for determining the forest structure from the Hierarchy CRs,
@@ -77,6 +77,11 @@ type HierarchyConfigReconciler struct {
 
 	// sar is the Subnamespace Anchor Reconciler
 	sar *AnchorReconciler
+
+	// affectedCoalescer dedupes and batches enqueueAffected's sends on Affected, so that a change
+	// affecting many overlapping namespaces - e.g. a subtree root changing - doesn't flood Affected
+	// with duplicate events for the same namespace.
+	affectedCoalescer *affectedCoalescer
 }
 
 // +kubebuilder:rbac:groups=hnc.x-k8s.io,resources=hierarchies,verbs=get;list;watch;create;update;patch;delete
@@ -86,11 +91,15 @@ type HierarchyConfigReconciler struct {
 // Reconcile sets up some basic variables and then calls the business logic.
 func (r *HierarchyConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	ns := req.NamespacedName.Namespace
-	log := loggerWithRID(r.Log).WithValues("ns", ns)
+	log := loggerWithRID(r.Log).WithValues(keyNamespace, ns)
+
+	// Allow ns to be coalesced into a future enqueueAffected call again, now that we're about to
+	// reconcile whatever state caused it to be enqueued.
+	r.affectedCoalescer.reconciling(req.NamespacedName)
 
 	// Always delete hierarchyconfiguration (and any other HNC CRs) in the
 	// excluded namespaces and early exit.
-	if config.ExcludedNamespaces[ns] {
+	if config.IsExcludedNamespace(ns) {
 		// Since singletons in the excluded namespaces are never synced by HNC, there
 		// are no finalizers on the singletons that we can delete them without
 		// removing the finalizers first.
@@ -175,6 +184,15 @@ func (r *HierarchyConfigReconciler) onMissingNamespace(log logr.Logger, nm strin
 		r.enqueueAffected(log, "relative of deleted namespace", ns.RelativesNames()...)
 		ns.UnsetExists()
 		log.Info("Namespace has been deleted")
+		return
+	}
+
+	// The namespace was already missing last time we looked. If it's still within its tombstone
+	// window, this is expected - e.g. a reconcile queued just before the deletion is only now being
+	// processed, or the namespace is about to be recreated under the same name - so don't re-log it
+	// as though something new happened.
+	if ns.IsTombstoned() {
+		log.V(1).Info("Namespace is still missing, but was recently deleted; ignoring")
 	}
 }
 
@@ -239,11 +257,19 @@ func (r *HierarchyConfigReconciler) syncWithForest(log logr.Logger, nsInst *core
 	// Set external tree labels in the forest if this is an external namespace.
 	r.syncExternalNamespace(log, nsInst, ns)
 
+	// Halt propagation into and out of this namespace if it's opted out of HNC.
+	r.syncUnmanagedNamespace(log, nsInst, ns)
+
+	// Pause propagation and deletion for this subtree if it's been suspended, e.g. during an
+	// incident or migration.
+	r.syncSuspendedNamespace(log, inst, ns)
+
 	// If this is a subnamespace, make sure .spec.parent is set correctly. Then sync the parent to the
 	// forest, and finally notify any relatives (including the parent) that might have been waiting
 	// for this namespace to be synced.
 	r.syncSubnamespaceParent(log, inst, nsInst, ns)
 	r.syncParent(log, inst, ns)
+	r.syncMaxTreeLabels(log, ns)
 	initial := r.markExisting(log, ns)
 
 	// Sync other spec and spec-like info
@@ -252,6 +278,16 @@ func (r *HierarchyConfigReconciler) syncWithForest(log logr.Logger, nsInst *core
 		// Added to help debug #1155 if it ever reoccurs
 		log.Info("Updated allowCascadingDeletion", "newValue", inst.Spec.AllowCascadingDeletion)
 	}
+	if ns.UpdateRequireSubnamespaceApproval(inst.Spec.RequireSubnamespaceApproval) {
+		log.Info("Updated requireSubnamespaceApproval", "newValue", inst.Spec.RequireSubnamespaceApproval)
+	}
+	ns.UpdateMaxSubnamespaces(inst.Spec.MaxSubnamespaces)
+	r.syncMaxSubnamespaces(log, ns)
+	labelsChanged := ns.SetOwnedLabels(inst.Spec.Labels)
+	annotationsChanged := ns.SetOwnedAnnotations(inst.Spec.Annotations)
+	if labelsChanged || annotationsChanged {
+		r.enqueueAffected(log, "propagated labels/annotations changed", ns.DescendantNames()...)
+	}
 
 	// Sync the status
 	inst.Status.Children = ns.ChildNames()
@@ -260,7 +296,11 @@ func (r *HierarchyConfigReconciler) syncWithForest(log logr.Logger, nsInst *core
 	// Sync the tree labels. This should go last since it can depend on the conditions.
 	nsCustomerLabelUpdated := r.syncLabel(log, nsInst, ns)
 
-	return initial || nsCustomerLabelUpdated
+	// Sync labels/annotations declared by this namespace or an ancestor via
+	// HierarchyConfigurationSpec.Labels/Annotations.
+	nsMetadataUpdated := r.syncPropagatedMetadata(log, nsInst, ns)
+
+	return initial || nsCustomerLabelUpdated || nsMetadataUpdated
 }
 
 // syncExternalNamespace sets external tree labels to the namespace in the forest
@@ -297,6 +337,36 @@ func (r *HierarchyConfigReconciler) syncExternalNamespace(log logr.Logger, nsIns
 	ns.ExternalTreeLabels = etls
 }
 
+// syncUnmanagedNamespace sets the ActivitiesHalted/UnmanagedNamespace condition on the namespace
+// if the user has opted it out of HNC via the unmanaged annotation. This is checked on every
+// reconcile (unlike the --excluded-namespace flag), so it can be turned on or off at any time
+// without restarting the manager. Setting the condition reuses the same mechanism that halts
+// propagation for excluded parents and cycles (see GetCritAncestor), so neither this namespace nor
+// its descendants get the tree labels of its ancestors (they still get this namespace's own tree
+// label), and it shows up as a footnote in `kubectl hns tree`.
+func (r *HierarchyConfigReconciler) syncUnmanagedNamespace(log logr.Logger, nsInst *corev1.Namespace, ns *forest.Namespace) {
+	if nsInst.Annotations[api.AnnotationUnmanaged] != "true" {
+		return
+	}
+	log.V(1).Info("Namespace is unmanaged; halting propagation")
+	msg := fmt.Sprintf("This namespace is unmanaged: it opted out of HNC via the %q annotation", api.AnnotationUnmanaged)
+	ns.SetCondition(api.ConditionActivitiesHalted, api.ReasonUnmanaged, msg)
+}
+
+// syncSuspendedNamespace sets the SyncPaused condition on the namespace if its HierarchyConfiguration
+// has been suspended via the suspend annotation. Like syncUnmanagedNamespace, this is checked on
+// every reconcile so it can be toggled at any time, and it's a local crit condition (see
+// HasLocalCritCondition), so ObjectReconciler will refuse to propagate into or delete objects out
+// of this namespace or any of its descendants until it's cleared.
+func (r *HierarchyConfigReconciler) syncSuspendedNamespace(log logr.Logger, inst *api.HierarchyConfiguration, ns *forest.Namespace) {
+	if inst.Annotations[api.AnnotationSuspend] != "true" {
+		return
+	}
+	log.V(1).Info("Namespace is suspended; pausing sync")
+	msg := fmt.Sprintf("Sync is paused for this subtree: its HierarchyConfiguration has the %q annotation", api.AnnotationSuspend)
+	ns.SetCondition(api.ConditionSyncPaused, api.ReasonSuspended, msg)
+}
+
 // syncSubnamespaceParent sets the parent to the owner and updates the SubnamespaceAnchorMissing
 // condition if the anchor is missing in the parent namespace according to the forest. The
 // subnamespace-of annotation is the source of truth of the ownership (e.g. being a subnamespace),
@@ -337,6 +407,7 @@ func (r *HierarchyConfigReconciler) syncSubnamespaceParent(log logr.Logger, inst
 			log.Info("Inserting newly created subnamespace into the hierarchy", "parent", pnm)
 		} else {
 			log.Info("The parent doesn't match the subnamespace annotation; overwriting parent", "oldParent", inst.Spec.Parent, "parent", pnm)
+			backupPreviousParent(log, inst, inst.Spec.Parent)
 		}
 		inst.Spec.Parent = pnm
 	}
@@ -378,7 +449,7 @@ func (r *HierarchyConfigReconciler) syncParent(log logr.Logger, inst *api.Hierar
 
 	// Sync this namespace with its current parent.
 	curParent := r.Forest.Get(inst.Spec.Parent)
-	if config.ExcludedNamespaces[inst.Spec.Parent] {
+	if config.IsExcludedNamespace(inst.Spec.Parent) {
 		log.Info("Setting ConditionActivitiesHalted: excluded namespace set as parent", "parent", inst.Spec.Parent)
 		ns.SetCondition(api.ConditionActivitiesHalted, api.ReasonIllegalParent, fmt.Sprintf("Parent %q is an excluded namespace", inst.Spec.Parent))
 	} else if curParent != nil && !curParent.Exists() {
@@ -411,6 +482,39 @@ func (r *HierarchyConfigReconciler) syncParent(log logr.Logger, inst *api.Hierar
 	r.enqueueAffected(log, "subtree root has changed", ns.DescendantNames()...)
 }
 
+// syncMaxTreeLabels sets ReasonTreeLabelsExceeded on ns if it already has more tree labels (see
+// api.LabelTreeDepthSuffix) than config.MaxTreeLabels allows. Unlike checkMaxTreeLabels in the
+// hierarchy validator, which only blocks a hierarchy change from creating a new namespace that
+// exceeds the limit, this also catches legacy trees that predate the limit being set, or that were
+// created while it was set to a larger value - those namespaces keep working normally, but the
+// condition gives an admin something to alert on.
+func (r *HierarchyConfigReconciler) syncMaxTreeLabels(log logr.Logger, ns *forest.Namespace) {
+	max := config.MaxTreeLabels()
+	if max <= 0 {
+		return
+	}
+	if depth := len(ns.AncestryNames()); depth > max {
+		msg := fmt.Sprintf("This namespace has %d tree labels, which exceeds the configured maximum of %d", depth, max)
+		ns.SetCondition(api.ConditionBadConfiguration, api.ReasonTreeLabelsExceeded, msg)
+	}
+}
+
+// syncMaxSubnamespaces sets ReasonMaxSubnamespacesExceeded on ns if it already has more
+// subnamespaces than its own spec.maxSubnamespaces allows, e.g. because the limit was lowered
+// after some of them were created. Unlike the anchor validator, which only stops the count from
+// growing further, this also catches namespaces that are already over the limit, so an admin has
+// something to notice and act on.
+func (r *HierarchyConfigReconciler) syncMaxSubnamespaces(log logr.Logger, ns *forest.Namespace) {
+	max := ns.MaxSubnamespaces()
+	if max <= 0 {
+		return
+	}
+	if n := len(ns.Anchors); n > max {
+		msg := fmt.Sprintf("This namespace has %d subnamespaces, which exceeds the configured maximum of %d", n, max)
+		ns.SetCondition(api.ConditionBadConfiguration, api.ReasonMaxSubnamespacesExceeded, msg)
+	}
+}
+
 // syncAnchors updates the anchor list. If any anchor is created/deleted, it will enqueue
 // the child to update its SubnamespaceAnchorMissing condition. A modified anchor will appear
 // twice in the change list (one in deleted, one in created), both subnamespaces
@@ -470,6 +574,52 @@ func (r *HierarchyConfigReconciler) syncLabel(log logr.Logger, nsInst *corev1.Na
 	return false
 }
 
+// syncPropagatedMetadata writes the labels/annotations declared via HierarchyConfigurationSpec.Labels
+// and .Annotations on this namespace and all its ancestors onto nsInst, removing any that were
+// propagated by a previous reconcile but are no longer declared anywhere in the chain. If two
+// ancestors declare the same key, the one closer to ns wins. Return true if anything changed.
+func (r *HierarchyConfigReconciler) syncPropagatedMetadata(log logr.Logger, nsInst *corev1.Namespace, ns *forest.Namespace) bool {
+	newLabels := map[string]string{}
+	newAnnotations := map[string]string{}
+
+	// Walk from the root down to ns, so that a closer ancestor's value overwrites a farther one's.
+	chain := []*forest.Namespace{}
+	for anc := ns; anc != nil; anc = anc.Parent() {
+		chain = append(chain, anc)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		for _, kvp := range chain[i].OwnedLabels() {
+			newLabels[kvp.Key] = kvp.Value
+		}
+		for _, kvp := range chain[i].OwnedAnnotations() {
+			newAnnotations[kvp.Key] = kvp.Value
+		}
+	}
+
+	for k := range ns.PropagatedLabels() {
+		if _, ok := newLabels[k]; !ok {
+			delete(nsInst.Labels, k)
+		}
+	}
+	for k := range ns.PropagatedAnnotations() {
+		if _, ok := newAnnotations[k]; !ok {
+			delete(nsInst.Annotations, k)
+		}
+	}
+	for k, v := range newLabels {
+		metadata.SetLabel(nsInst, k, v)
+	}
+	for k, v := range newAnnotations {
+		metadata.SetAnnotation(nsInst, k, v)
+	}
+
+	if ns.SetPropagatedMetadata(newLabels, newAnnotations) {
+		log.Info("Namespace propagated labels/annotations have been updated.")
+		return true
+	}
+	return false
+}
+
 func (r *HierarchyConfigReconciler) syncConditions(log logr.Logger, inst *api.HierarchyConfiguration, ns *forest.Namespace, deletingCRD, hadCrit bool) {
 	// Sync critical conditions after all locally-set conditions are updated.
 	r.syncCritConditions(log, ns, deletingCRD, hadCrit)
@@ -527,26 +677,30 @@ func setCritAncestorCondition(log logr.Logger, inst *api.HierarchyConfiguration,
 	}
 }
 
-// enqueueAffected enqueues all affected namespaces for later reconciliation. This occurs in a
-// goroutine so the caller doesn't block; since the reconciler is never garbage-collected, this is
-// safe.
+// enqueueAffected enqueues all affected namespaces for later reconciliation. Since Affected is a
+// bounded, non-blocking channel (see enqueueEvent), this never blocks the caller, so there's no
+// need to do this in a goroutine. Namespaces that already have an enqueue pending - whether from
+// this call or an earlier one that hasn't been reconciled yet - are coalesced into that single
+// pending event instead of adding another one (see affectedCoalescer).
 //
 // It's fine to call this function with `foo.Name()` even if `foo` is nil; it will just be ignored.
 func (r *HierarchyConfigReconciler) enqueueAffected(log logr.Logger, reason string, affected ...string) {
-	go func() {
-		for _, nm := range affected {
-			// Ignore any nil namespaces (lets callers skip a nil check)
-			if nm == (*forest.Namespace)(nil).Name() {
-				continue
-			}
-			log.V(1).Info("Enqueuing for reconcilation", "affected", nm, "reason", reason)
-			// The watch handler doesn't care about anything except the metadata.
-			inst := &api.HierarchyConfiguration{}
-			inst.ObjectMeta.Name = api.Singleton
-			inst.ObjectMeta.Namespace = nm
-			r.Affected <- event.GenericEvent{Object: inst}
+	nnms := make([]types.NamespacedName, 0, len(affected))
+	for _, nm := range affected {
+		// Ignore any nil namespaces (lets callers skip a nil check)
+		if nm == (*forest.Namespace)(nil).Name() {
+			continue
 		}
-	}()
+		log.V(1).Info("Enqueuing for reconcilation", "affected", nm, "reason", reason)
+		nnms = append(nnms, types.NamespacedName{Namespace: nm, Name: api.Singleton})
+	}
+	r.affectedCoalescer.enqueueBatch(log, r.Affected, "hierarchyconfig-affected", nnms, func(nnm types.NamespacedName) event.GenericEvent {
+		// The watch handler doesn't care about anything except the metadata.
+		inst := &api.HierarchyConfiguration{}
+		inst.ObjectMeta.Name = nnm.Name
+		inst.ObjectMeta.Namespace = nnm.Namespace
+		return event.GenericEvent{Object: inst}
+	})
 }
 
 func (r *HierarchyConfigReconciler) writeInstances(ctx context.Context, log logr.Logger, oldHC, newHC *api.HierarchyConfiguration, oldNS, newNS *corev1.Namespace) (bool, error) {
@@ -22,7 +22,7 @@ var _ = Describe("Hierarchy", func() {
 	BeforeEach(func() {
 		fooName = createNS(ctx, "foo")
 		barName = createNS(ctx, "bar")
-		config.ExcludedNamespaces = nil
+		config.SetExcludedNamespaces(nil)
 	})
 
 	It("should set a child on the parent", func() {
@@ -34,7 +34,7 @@ var _ = Describe("Hierarchy", func() {
 
 	It("should remove the hierarchyconfiguration singleton in an excluded namespacee", func() {
 		// Set the excluded-namespace "kube-system"'s parent to "bar".
-		config.ExcludedNamespaces = map[string]bool{"kube-system": true}
+		config.SetExcludedNamespaces(map[string]bool{"kube-system": true})
 		exHier := newHierarchy("kube-system")
 		exHier.Spec.Parent = barName
 		updateHierarchy(ctx, exHier)
@@ -45,7 +45,7 @@ var _ = Describe("Hierarchy", func() {
 
 	It("should set IllegalParent condition if the parent is an excluded namespace", func() {
 		// Set bar's parent to the excluded-namespace "kube-system".
-		config.ExcludedNamespaces = map[string]bool{"kube-system": true}
+		config.SetExcludedNamespaces(map[string]bool{"kube-system": true})
 		barHier := newHierarchy(barName)
 		barHier.Spec.Parent = "kube-system"
 		updateHierarchy(ctx, barHier)
@@ -149,6 +149,22 @@ var _ = Describe("Hierarchy", func() {
 		Eventually(hasCondition(ctx, barName, api.ConditionActivitiesHalted, api.ReasonInCycle)).Should(Equal(false))
 	})
 
+	It("should set MaxTreeLabelsExceeded condition on a namespace with too many tree labels", func() {
+		// This simulates a legacy tree that predates the limit - the admission-time check in the
+		// validator can't catch this case since the namespace already exists, so it's the
+		// reconciler's job to flag it.
+		config.SetMaxTreeLabels(1)
+		defer config.SetMaxTreeLabels(0)
+
+		// Make bar a child of foo - 2 tree labels, which exceeds the limit of 1.
+		setParent(ctx, barName, fooName)
+		Eventually(hasCondition(ctx, barName, api.ConditionBadConfiguration, api.ReasonTreeLabelsExceeded)).Should(Equal(true))
+
+		// Remove the parent - back down to 1 tree label, so the condition should clear.
+		setParent(ctx, barName, "")
+		Eventually(hasCondition(ctx, barName, api.ConditionBadConfiguration, api.ReasonTreeLabelsExceeded)).Should(Equal(false))
+	})
+
 	It("should have a tree label", func() {
 		// Make bar a child of foo
 		setParent(ctx, barName, fooName)
@@ -382,7 +398,7 @@ var _ = Describe("Hierarchy", func() {
 	})
 
 	It("should remove excluded namespace labels from non-excluded namespaces", func() {
-		config.ExcludedNamespaces = map[string]bool{"kube-system": true}
+		config.SetExcludedNamespaces(map[string]bool{"kube-system": true})
 		l := map[string]string{api.LabelExcludedNamespace: "true"}
 
 		// Set excluded namespace labels on foo and bar. We are not verifying the
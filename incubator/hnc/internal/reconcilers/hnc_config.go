@@ -2,7 +2,9 @@ package reconcilers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 	"sync"
@@ -25,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/config"
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/forest"
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/stats"
 )
@@ -68,8 +71,11 @@ type ConfigReconciler struct {
 }
 
 type gvkMode struct {
-	gvk  schema.GroupVersionKind
-	mode api.SynchronizationMode
+	gvk                 schema.GroupVersionKind
+	mode                api.SynchronizationMode
+	transform           *api.ObjectTransform
+	linkToSource        bool
+	ignoreObjectWebhook bool
 }
 
 // gr2gvkMode keeps track of a group of unique GRs and the mapping GVKs and modes.
@@ -90,6 +96,7 @@ func (r *ConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		r.Log.Error(err, "Couldn't read singleton")
 		return ctrl.Result{}, err
 	}
+	oldStatus := inst.Status.DeepCopy()
 	inst.Status.Conditions = nil
 
 	if err := r.reconcileTypes(inst); err != nil {
@@ -99,14 +106,35 @@ func (r *ConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	// Create or sync corresponding ObjectReconcilers, if needed.
 	syncErr := r.syncObjectReconcilers(ctx, inst)
 
+	// Rebuild the forest's library subscriptions so ObjectReconcilers can propagate library
+	// objects into the subtrees that subscribe to them.
+	r.reconcileLibraries(inst)
+
+	// Refresh the forest's copy of the root allowlist so the hierarchy webhook can enforce it.
+	r.reconcileRootAllowlist(inst)
+
+	// Refresh the forest's copy of the webhook exemptions so the object webhook can enforce them.
+	r.reconcileWebhookExemptions(inst)
+
 	// Set the status for each type.
 	r.setTypeStatuses(inst)
 
 	// Load all conditions
 	r.loadNamespaceConditions(inst)
 
-	// Write back to the apiserver.
-	if err := r.writeSingleton(ctx, inst); err != nil {
+	// Refresh the per-tenant object-count metrics used by cost-attribution tooling.
+	r.recordSubtreeObjectCounts()
+
+	// Report the health of the webhook serving cert, since HNC's webhooks silently reject every
+	// request once it's expired or was never generated in time.
+	if ok, reason, msg := stats.CertHealth(); !ok {
+		r.writeCondition(inst, api.ConditionBadWebhookCert, reason, msg)
+	}
+
+	// Write back to the apiserver, but only if something actually changed - the status can grow
+	// large in clusters with many types and namespace conditions, and this reconciler runs far
+	// more often (every checkPeriod) than the status typically changes.
+	if err := r.writeSingleton(ctx, inst, oldStatus); err != nil {
 		r.Log.Error(err, "Couldn't write singleton")
 		return ctrl.Result{}, err
 	}
@@ -154,7 +182,7 @@ func (r *ConfigReconciler) ensureEnforcedTypes(inst *api.HNCConfiguration, allRe
 			r.writeCondition(inst, api.ConditionBadTypeConfiguration, api.ReasonResourceNotFound, err.Error())
 			return err
 		}
-		r.activeGVKMode[gr] = gvkMode{gvk, t.Mode}
+		r.activeGVKMode[gr] = gvkMode{gvk, t.Mode, nil, false, false}
 		r.activeGR[gvk] = gr
 	}
 	return nil
@@ -168,6 +196,18 @@ func (r *ConfigReconciler) reconcileConfigTypes(inst *api.HNCConfiguration, allR
 	// Get valid settings in the spec.resources of the `config` singleton.
 	for _, rsc := range inst.Spec.Resources {
 		gr := schema.GroupResource{Group: rsc.Group, Resource: rsc.Resource}
+
+		// Secrets require an explicit acknowledgment of the blast radius of propagating them before
+		// they can be set to "Propagate". Without it, silently fall back to "Ignore" rather than
+		// rejecting the whole config, since the rest of Resources may still be valid.
+		if rsc.Resource == api.SecretResource && rsc.Group == "" && rsc.Mode == api.Propagate && !inst.Spec.AllowSecretPropagation {
+			msg := fmt.Sprintf("Ignoring %q mode for %q because spec.allowSecretPropagation is not set to true; "+
+				"propagating Secrets copies their contents into every descendant namespace", api.Propagate, gr)
+			r.Log.Info("Refusing to propagate secrets without spec.allowSecretPropagation")
+			r.writeCondition(inst, api.ConditionSecretPropagationNotAllowed, api.ReasonSecretPropagationNotAllowed, msg)
+			rsc.Mode = api.Ignore
+		}
+
 		// If there are multiple configurations of the same type, we will follow the
 		// first configuration and ignore the rest.
 		if gvkMode, exist := r.activeGVKMode[gr]; exist {
@@ -194,11 +234,78 @@ func (r *ConfigReconciler) reconcileConfigTypes(inst *api.HNCConfiguration, allR
 			r.writeCondition(inst, api.ConditionBadTypeConfiguration, api.ReasonResourceNotFound, err.Error())
 			continue
 		}
-		r.activeGVKMode[gr] = gvkMode{gvk, rsc.Mode}
+		r.activeGVKMode[gr] = gvkMode{gvk, rsc.Mode, rsc.Transform, rsc.LinkToSource, rsc.IgnoreObjectWebhook}
 		r.activeGR[gvk] = gr
 	}
 }
 
+// reconcileLibraries validates inst.Spec.Libraries against the current forest and rebuilds the
+// forest's subtree-root-to-library-namespace subscriptions accordingly. Unlike reconcileTypes, a
+// misconfigured entry never blocks the rest of the config from being applied - e.g. a namespace
+// referenced by a library or subtree that's been deleted (or never created) is simply skipped and
+// reported via ConditionBadLibraryConfiguration, so admins can also see the problem reflected
+// here if it develops after the config was originally accepted by the webhook.
+func (r *ConfigReconciler) reconcileLibraries(inst *api.HNCConfiguration) {
+	r.Forest.Lock()
+	defer r.Forest.Unlock()
+
+	subs := map[string][]string{}
+	seen := map[string]bool{}
+	for _, lib := range inst.Spec.Libraries {
+		if lib.Namespace == "" || seen[lib.Namespace] {
+			msg := fmt.Sprintf("Ignoring duplicate or empty library namespace entry in spec.libraries: %q", lib.Namespace)
+			r.writeCondition(inst, api.ConditionBadLibraryConfiguration, api.ReasonLibraryNamespaceNotFound, msg)
+			continue
+		}
+		seen[lib.Namespace] = true
+
+		libNs := r.Forest.Get(lib.Namespace)
+		if !libNs.Exists() {
+			msg := fmt.Sprintf("Library namespace %q does not exist", lib.Namespace)
+			r.writeCondition(inst, api.ConditionBadLibraryConfiguration, api.ReasonLibraryNamespaceNotFound, msg)
+			continue
+		}
+
+		for _, subtree := range lib.Subtrees {
+			subtreeNs := r.Forest.Get(subtree)
+			if !subtreeNs.Exists() {
+				msg := fmt.Sprintf("Subtree root %q, which subscribes to library %q, does not exist", subtree, lib.Namespace)
+				r.writeCondition(inst, api.ConditionBadLibraryConfiguration, api.ReasonLibrarySubtreeNotFound, msg)
+				continue
+			}
+			if subtree == lib.Namespace || libNs.IsAncestor(subtreeNs) {
+				msg := fmt.Sprintf("Subtree root %q cannot subscribe to library %q because the library is the subtree root itself, or one of its ancestors", subtree, lib.Namespace)
+				r.writeCondition(inst, api.ConditionBadLibraryConfiguration, api.ReasonLibrarySelfSubscription, msg)
+				continue
+			}
+			subs[subtree] = append(subs[subtree], lib.Namespace)
+		}
+	}
+
+	r.Forest.SetLibrarySubscriptions(subs)
+}
+
+// reconcileRootAllowlist copies inst.Spec.RootAllowlist into the forest so the hierarchy webhook
+// can enforce it without needing its own copy of the HNCConfiguration singleton. Unlike
+// reconcileLibraries, there's nothing here to validate against the forest - the allowlist is just
+// a list of names - so this can't produce any conditions.
+func (r *ConfigReconciler) reconcileRootAllowlist(inst *api.HNCConfiguration) {
+	r.Forest.Lock()
+	defer r.Forest.Unlock()
+	r.Forest.SetRootAllowlist(inst.Spec.RootAllowlist)
+}
+
+// reconcileWebhookExemptions copies inst.Spec.WebhookExemptions into the forest so the object
+// webhook can enforce them without needing its own copy of the HNCConfiguration singleton. Like
+// reconcileRootAllowlist, there's nothing here to validate against the forest, so this can't
+// produce any conditions.
+func (r *ConfigReconciler) reconcileWebhookExemptions(inst *api.HNCConfiguration) {
+	r.Forest.Lock()
+	defer r.Forest.Unlock()
+	we := inst.Spec.WebhookExemptions
+	r.Forest.SetWebhookExemptions(we.Users, we.Groups, we.ServiceAccounts)
+}
+
 // getSingleton returns the singleton if it exists, or creates a default one if it doesn't.
 func (r *ConfigReconciler) getSingleton(ctx context.Context) (*api.HNCConfiguration, error) {
 	nnm := types.NamespacedName{Name: api.HNCConfigSingleton}
@@ -223,11 +330,14 @@ func (r *ConfigReconciler) validateSingleton(inst *api.HNCConfiguration) {
 	}
 }
 
-// writeSingleton creates a singleton on the apiserver if it does not exist.
-// Otherwise, it updates existing singleton on the apiserver.
-// We will write the singleton to apiserver even it is not changed because we assume this
-// reconciler is called very infrequently and is not performance critical.
-func (r *ConfigReconciler) writeSingleton(ctx context.Context, inst *api.HNCConfiguration) error {
+// writeSingleton creates a singleton on the apiserver if it does not exist. Otherwise, it updates
+// the existing singleton on the apiserver, unless oldStatus - the status as it was read from the
+// apiserver at the start of this reconciliation - is identical to inst.Status, in which case the
+// update is skipped entirely to avoid needlessly rewriting a status that can grow large in
+// clusters with many configured types and namespace conditions.
+func (r *ConfigReconciler) writeSingleton(ctx context.Context, inst *api.HNCConfiguration, oldStatus *api.HNCConfigurationStatus) error {
+	stats.RecordHNCConfigStatusSize(statusSizeBytes(inst))
+
 	if inst.CreationTimestamp.IsZero() {
 		// No point creating it if the CRD's being deleted
 		if isDeleted, err := isDeletingCRD(ctx, api.HNCConfigSingletons); isDeleted || err != nil {
@@ -239,17 +349,33 @@ func (r *ConfigReconciler) writeSingleton(ctx context.Context, inst *api.HNCConf
 			r.Log.Error(err, "Could not create HNCConfiguration object")
 			return err
 		}
-	} else {
+	} else if !reflect.DeepEqual(oldStatus, &inst.Status) {
 		r.Log.V(1).Info("Updating the singleton on apiserver")
 		if err := r.Update(ctx, inst); err != nil {
 			r.Log.Error(err, "Could not update HNCConfiguration object")
 			return err
 		}
+	} else {
+		r.Log.V(1).Info("Status unchanged; skipping update")
 	}
 
 	return nil
 }
 
+// statusSizeBytes returns the approximate size, in bytes, that inst.Status will occupy once
+// marshalled to JSON for storage in etcd. It's only an approximation - the actual stored
+// representation is protobuf, not JSON - but it's good enough to catch a status that's
+// approaching etcd's per-object size limit well before it gets there.
+func statusSizeBytes(inst *api.HNCConfiguration) int {
+	b, err := json.Marshal(inst.Status)
+	if err != nil {
+		// Practically unreachable - HNCConfigurationStatus contains no unmarshallable fields (e.g.
+		// channels or functions) - but report zero rather than panicking if it ever does happen.
+		return 0
+	}
+	return len(b)
+}
+
 // syncObjectReconcilers creates or syncs ObjectReconcilers.
 //
 // For newly added types in the HNC configuration, the method will create corresponding ObjectReconcilers and
@@ -301,8 +427,15 @@ func (r *ConfigReconciler) syncActiveReconcilers(ctx context.Context, inst *api.
 			if err := ts.SetMode(ctx, r.Log, gvkMode.mode); err != nil {
 				return err // retry the reconciliation
 			}
+			if err := ts.SetTransform(ctx, r.Log, gvkMode.transform); err != nil {
+				return err // retry the reconciliation
+			}
+			if err := ts.SetLinkToSource(ctx, r.Log, gvkMode.linkToSource); err != nil {
+				return err // retry the reconciliation
+			}
+			ts.SetIgnoreObjectWebhook(gvkMode.ignoreObjectWebhook)
 		} else {
-			r.createObjectReconciler(gvkMode.gvk, gvkMode.mode, inst)
+			r.createObjectReconciler(gvkMode.gvk, gvkMode.mode, gvkMode.transform, gvkMode.linkToSource, gvkMode.ignoreObjectWebhook, inst)
 		}
 	}
 	return nil
@@ -344,20 +477,27 @@ func (r *ConfigReconciler) syncRemovedReconcilers(ctx context.Context) error {
 // create reconciler successfully even when the resource does not exist in the
 // cluster. Therefore, the caller should check if the resource exists before
 // creating the reconciler.
-func (r *ConfigReconciler) createObjectReconciler(gvk schema.GroupVersionKind, mode api.SynchronizationMode, inst *api.HNCConfiguration) {
+func (r *ConfigReconciler) createObjectReconciler(gvk schema.GroupVersionKind, mode api.SynchronizationMode, transform *api.ObjectTransform, linkToSource bool, ignoreObjectWebhook bool, inst *api.HNCConfiguration) {
 	r.Log.Info("Starting to sync objects", "gvk", gvk, "mode", mode)
 
 	or := &ObjectReconciler{
 		Client: r.Client,
 		// This field will be shown as source.component=hnc.x-k8s.io in events.
-		EventRecorder:     r.Manager.GetEventRecorderFor(api.MetaGroup),
-		Log:               ctrl.Log.WithName("reconcilers").WithName(gvk.Kind),
-		Forest:            r.Forest,
-		GVK:               gvk,
-		Mode:              GetValidateMode(mode, r.Log),
-		Affected:          make(chan event.GenericEvent),
-		AffectedNamespace: r.HierarchyConfigUpdates,
-		propagatedObjects: namespacedNameSet{},
+		EventRecorder:       r.Manager.GetEventRecorderFor(api.MetaGroup),
+		Log:                 ctrl.Log.WithName("reconcilers").WithName(gvk.Kind),
+		Forest:              r.Forest,
+		GVK:                 gvk,
+		Mode:                GetValidateMode(mode, r.Log),
+		Transform:           transform,
+		LinkToSource:        linkToSource,
+		IgnoreObjectWebhook: ignoreObjectWebhook,
+		Affected:            newAffectedChannel(),
+		AffectedNamespace:   r.HierarchyConfigUpdates,
+		affectedCoalescer:   newAffectedCoalescer(),
+		propagatedObjects:   namespacedNameSet{},
+		propagatedSizes:     map[types.NamespacedName]int{},
+		srcDigestCache:      map[types.NamespacedName]srcDigest{},
+		deferredObjects:     namespacedNameSet{},
 	}
 
 	// TODO: figure out MaxConcurrentReconciles option - https://github.com/kubernetes-sigs/multi-tenancy/issues/291
@@ -386,6 +526,7 @@ func (r *ConfigReconciler) setTypeStatuses(inst *api.HNCConfiguration) {
 	defer r.Forest.Unlock()
 
 	statuses := []api.ResourceStatus{}
+	totalDeferred := 0
 	for _, ts := range r.Forest.GetTypeSyncers() {
 		// Don't output a status for any reconciler that isn't explicitly listed in
 		// the Spec
@@ -407,10 +548,14 @@ func (r *ConfigReconciler) setTypeStatuses(inst *api.HNCConfiguration) {
 		if ts.GetMode() != api.Ignore {
 			numProp := ts.GetNumPropagatedObjects()
 			status.NumPropagatedObjects = &numProp
+
+			numDeferred := ts.GetNumDeferredObjects()
+			status.NumDeferredObjects = &numDeferred
+			totalDeferred += numDeferred
 		}
 
 		// Only add NumSourceObjects if we are propagating objects of this type.
-		if ts.GetMode() == api.Propagate {
+		if ts.GetMode() == api.Propagate || ts.GetMode() == api.AllowPropagate {
 			numSrc := 0
 			nms := r.Forest.GetNamespaceNames()
 			for _, nm := range nms {
@@ -434,6 +579,13 @@ func (r *ConfigReconciler) setTypeStatuses(inst *api.HNCConfiguration) {
 
 	// Record the final list
 	inst.Status.Resources = statuses
+
+	// Surface any pending deferred changes cluster-wide, so an admin doesn't have to scan every
+	// resource's status to notice that a change freeze is holding propagation back.
+	if totalDeferred > 0 {
+		msg := fmt.Sprintf("%d propagated object(s) are out of date because their source has a closed propagation window", totalDeferred)
+		r.writeCondition(inst, api.ConditionPropagationDeferred, api.ReasonOutsideWindow, msg)
+	}
 }
 
 // loadNamespaceConditions collects every condition on every namespace in the forest. With an
@@ -443,9 +595,12 @@ func (r *ConfigReconciler) loadNamespaceConditions(inst *api.HNCConfiguration) {
 	r.Forest.Lock()
 	defer r.Forest.Unlock()
 
+	nsnms := r.Forest.GetNamespaceNames()
+	stats.SetForestNamespaceCount(len(nsnms))
+
 	// Get namespace conditions by type and reason.
 	conds := map[string]map[string][]string{}
-	for _, nsnm := range r.Forest.GetNamespaceNames() {
+	for _, nsnm := range nsnms {
 		for _, cond := range r.Forest.Get(nsnm).Conditions() {
 			if _, ok := conds[cond.Type]; !ok {
 				conds[cond.Type] = map[string][]string{}
@@ -485,6 +640,39 @@ func (r *ConfigReconciler) loadNamespaceConditions(inst *api.HNCConfiguration) {
 	}
 }
 
+// recordSubtreeObjectCounts records, for every tracked subtree root (see
+// config.SetTrackedSubtreeRoots), the number of namespaces, propagated objects and source objects
+// in its subtree. This is what backs the subtree_namespaces_total, subtree_propagated_objects_total
+// and subtree_source_objects_total metrics, and the equivalent JSON endpoint (see
+// cmd/manager/main.go), which cost-attribution and capacity tools use to charge tenants for their
+// etcd/object footprint.
+func (r *ConfigReconciler) recordSubtreeObjectCounts() {
+	r.Forest.Lock()
+	defer r.Forest.Unlock()
+
+	tss := r.Forest.GetTypeSyncers()
+	for _, root := range config.TrackedSubtreeRoots() {
+		rootNS := r.Forest.Get(root)
+		if !rootNS.Exists() {
+			continue
+		}
+		nsnms := append([]string{root}, rootNS.DescendantNames()...)
+
+		numSrc, numProp := 0, 0
+		for _, ts := range tss {
+			gvk := ts.GetGVK()
+			for _, nsnm := range nsnms {
+				numSrc += r.Forest.Get(nsnm).GetNumSourceObjects(gvk)
+				numProp += ts.GetNumPropagatedObjectsInNamespace(nsnm)
+			}
+		}
+
+		stats.SetSubtreeNamespaceCount(root, len(nsnms))
+		stats.SetSubtreeSourceObjectCount(root, numSrc)
+		stats.SetSubtreePropagatedObjectCount(root, numProp)
+	}
+}
+
 // requestReconcile records that the reconciler needs to be reinvoked.
 func (r *ConfigReconciler) requestReconcile(reason string) {
 	if r == nil { // for unit testing
@@ -525,11 +713,9 @@ func (r *ConfigReconciler) triggerReconcileIfNeeded() {
 
 	// Clear the flag and actually trigger the reconcile.
 	r.enqueueReasons = nil
-	go func() {
-		inst := &api.HNCConfiguration{}
-		inst.ObjectMeta.Name = api.HNCConfigSingleton
-		r.Trigger <- event.GenericEvent{Object: inst}
-	}()
+	inst := &api.HNCConfiguration{}
+	inst.ObjectMeta.Name = api.HNCConfigSingleton
+	enqueueEvent(r.Log, r.Trigger, "hncconfig-trigger", event.GenericEvent{Object: inst})
 }
 
 // SetupWithManager builds a controller with the reconciler.
@@ -163,6 +163,44 @@ var _ = Describe("HNCConfiguration", func() {
 		Expect(objectInheritedFrom(ctx, "secrets", barName, "foo-sec")).Should(Equal(fooName))
 	})
 
+	It("should propagate objects from a library namespace into a subscribed subtree", func() {
+		addToHNCConfig(ctx, "", "secrets", api.Propagate)
+
+		libName := createNS(ctx, "lib")
+		makeObject(ctx, "secrets", libName, "shared-sec")
+		addLibraryToHNCConfig(ctx, libName, []string{fooName})
+
+		// fooName isn't a descendant of libName, so this can only come from the library subscription.
+		Eventually(hasObject(ctx, "secrets", fooName, "shared-sec")).Should(BeTrue())
+		Expect(objectInheritedFrom(ctx, "secrets", fooName, "shared-sec")).Should(Equal(libName))
+
+		// barName is a descendant of the subscribed subtree root, so it should inherit the
+		// subscription too.
+		setParent(ctx, barName, fooName)
+		Eventually(hasObject(ctx, "secrets", barName, "shared-sec")).Should(BeTrue())
+	})
+
+	It("should let an ancestor's source object take precedence over a subscribed library's", func() {
+		addToHNCConfig(ctx, "", "secrets", api.Propagate)
+
+		libName := createNS(ctx, "lib")
+		makeObject(ctx, "secrets", libName, "conflict-sec")
+		addLibraryToHNCConfig(ctx, libName, []string{fooName})
+		setParent(ctx, barName, fooName)
+		makeObject(ctx, "secrets", fooName, "conflict-sec")
+
+		// bar should get foo's copy, not the library's, even though both apply.
+		Eventually(hasObject(ctx, "secrets", barName, "conflict-sec")).Should(BeTrue())
+		Expect(objectInheritedFrom(ctx, "secrets", barName, "conflict-sec")).Should(Equal(fooName))
+	})
+
+	It("should set BadLibraryConfiguration if a library namespace doesn't exist", func() {
+		addLibraryToHNCConfig(ctx, "no-such-library-ns", []string{fooName})
+
+		Eventually(getHNCConfigCondition(ctx, api.ConditionBadLibraryConfiguration, api.ReasonLibraryNamespaceNotFound)).
+			Should(ContainSubstring("no-such-library-ns"))
+	})
+
 	It("should stop propagating objects if the mode of a type is changed to ignore", func() {
 		addToHNCConfig(ctx, "", "secrets", api.Propagate)
 
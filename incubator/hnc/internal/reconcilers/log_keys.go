@@ -0,0 +1,44 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcilers
+
+// Structured log keys used across the reconcilers in this package. Reconcilers propagate a lot of
+// objects between a lot of namespaces, so during a large propagation storm it's the consistency of
+// these keys - not the log message itself - that lets an ingestion pipeline group and filter the
+// resulting flood of messages. New log calls in this package should prefer these constants over
+// ad-hoc key strings.
+const (
+	// keyNamespace is the namespace being reconciled, or otherwise the namespace an object or event
+	// pertains to.
+	keyNamespace = "ns"
+
+	// keyGVK is the group/version/kind of the object being reconciled.
+	keyGVK = "gvk"
+
+	// keySource is the namespace an propagated object was (or would be) propagated from.
+	keySource = "source"
+
+	// keyDestination is the namespace an object was (or would be) propagated to.
+	keyDestination = "dst"
+
+	// keyAction is the syncAction (write, remove, no-op, etc) taken or being considered for an
+	// object.
+	keyAction = "action"
+
+	// keyReconcileID (rid) ties together every log message emitted as part of a single
+	// reconciliation attempt. It's set once per Reconcile call by loggerWithRID.
+	keyReconcileID = "rid"
+)
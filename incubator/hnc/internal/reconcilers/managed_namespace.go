@@ -0,0 +1,178 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcilers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/config"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/metadata"
+)
+
+// ManagedNamespaceReconciler reconciles ManagedNamespace CRs, creating and maintaining the
+// namespace each one names as a child of its declared parent. It plays the same role as
+// AnchorReconciler, but for namespaces requested via a cluster-scoped CR instead of a
+// SubnamespaceAnchor living in the parent namespace, and so is ownership-based (via an owner
+// reference from the namespace back to the ManagedNamespace) rather than finalizer-based, since a
+// cluster-scoped owner can safely own a namespace without the two-step anchor/subns dance anchors
+// need to coordinate cross-namespace deletion.
+type ManagedNamespaceReconciler struct {
+	client.Client
+	Log logr.Logger
+}
+
+// +kubebuilder:rbac:groups=hnc.x-k8s.io,resources=managednamespaces,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=hnc.x-k8s.io,resources=managednamespaces/status,verbs=get;update;patch
+
+func (r *ManagedNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := loggerWithRID(r.Log).WithValues("trigger", req.NamespacedName)
+	log.V(1).Info("Reconciling managed namespace")
+
+	inst := &api.ManagedNamespace{}
+	if err := r.Get(ctx, req.NamespacedName, inst); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("Managed namespace has been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Refuse to manage namespaces HNC always excludes from management, such as kube-system or
+	// hnc-system, whether they're the requested namespace or its declared parent.
+	if config.IsExcludedNamespace(inst.Name) || config.IsExcludedNamespace(inst.Spec.Parent) {
+		inst.Status.State = api.MNSForbidden
+		return ctrl.Result{}, r.writeInstance(ctx, log, inst)
+	}
+
+	nsInst, err := r.getNamespace(ctx, inst.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.updateState(log, inst, nsInst)
+
+	if inst.Status.State == api.MNSMissing {
+		if err := r.writeNamespace(ctx, log, inst); err != nil {
+			if werr := r.writeInstance(ctx, log, inst); werr != nil {
+				log.Error(werr, "while setting managed namespace state", "state", api.MNSMissing, "reason", err)
+			}
+			return ctrl.Result{}, err
+		}
+		inst.Status.State = api.MNSOk
+	}
+
+	return ctrl.Result{}, r.writeInstance(ctx, log, inst)
+}
+
+// updateState sets inst's status to reflect whether nsInst exists, and whether it's owned by inst.
+// Ownership - not the SubnamespaceOf annotation checked by AnchorReconciler.updateState - is what
+// distinguishes a namespace this ManagedNamespace controls from one that merely happens to have
+// the same name, since a ManagedNamespace's name need not match its Spec.Parent the way an
+// anchor's does.
+func (r *ManagedNamespaceReconciler) updateState(log logr.Logger, inst *api.ManagedNamespace, nsInst *corev1.Namespace) {
+	switch {
+	case nsInst.Name == "":
+		log.Info("Namespace does not (yet) exist; setting managed namespace state to Missing")
+		inst.Status.State = api.MNSMissing
+	case !metav1.IsControlledBy(nsInst, inst):
+		log.Info("A namespace with this name already exists but isn't owned by this managed namespace; setting state to Conflict")
+		inst.Status.State = api.MNSConflict
+	default:
+		if inst.Status.State != api.MNSOk {
+			log.Info("The namespace and its managed namespace are correctly synchronized", "prevState", inst.Status.State)
+		}
+		inst.Status.State = api.MNSOk
+	}
+}
+
+func (r *ManagedNamespaceReconciler) getNamespace(ctx context.Context, nm string) (*corev1.Namespace, error) {
+	ns := &corev1.Namespace{}
+	nnm := types.NamespacedName{Name: nm}
+	if err := r.Get(ctx, nnm, ns); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		return &corev1.Namespace{}, nil
+	}
+	return ns, nil
+}
+
+// writeNamespace creates the namespace named by inst, setting the SubnamespaceOf annotation to
+// inst.Spec.Parent so that HierarchyConfigReconciler picks up the parent-child relationship in
+// exactly the same way it would for a subnamespace created via an anchor, and an owner reference
+// back to inst so a later conflicting namespace can be told apart from one this controller made.
+func (r *ManagedNamespaceReconciler) writeNamespace(ctx context.Context, log logr.Logger, inst *api.ManagedNamespace) error {
+	ns := &corev1.Namespace{}
+	ns.ObjectMeta.Name = inst.Name
+	metadata.SetAnnotation(ns, api.SubnamespaceOf, inst.Spec.Parent)
+	if err := controllerutil.SetControllerReference(inst, ns, r.Scheme()); err != nil {
+		return err
+	}
+
+	// As with AnchorReconciler.writeNamespace, it's safe to use Create here: if someone else creates
+	// the namespace first, the error triggers a retry, and the reconciler will set the Conflict
+	// state instead of trying to adopt or recreate it.
+	log.Info("Creating managed namespace")
+	if err := r.Create(ctx, ns); err != nil {
+		log.Error(err, "While creating managed namespace")
+		return err
+	}
+	return nil
+}
+
+func (r *ManagedNamespaceReconciler) writeInstance(ctx context.Context, log logr.Logger, inst *api.ManagedNamespace) error {
+	if inst.CreationTimestamp.IsZero() {
+		if err := r.Create(ctx, inst); err != nil {
+			log.Error(err, "while creating on apiserver")
+			return err
+		}
+	} else {
+		if err := r.Update(ctx, inst); err != nil {
+			log.Error(err, "while updating on apiserver")
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ManagedNamespaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Maps a namespace back to the ManagedNamespace that owns it, if any, so that changes made
+	// directly to the namespace (e.g. someone removing the SubnamespaceOf annotation) get noticed.
+	nsMapFn := func(obj client.Object) []reconcile.Request {
+		owner := metav1.GetControllerOf(obj)
+		if owner == nil || owner.Kind != api.ManagedNamespaceKind {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: owner.Name}}}
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&api.ManagedNamespace{}).
+		Watches(&source.Kind{Type: &corev1.Namespace{}}, handler.EnqueueRequestsFromMapFunc(nsMapFn)).
+		Complete(r)
+}
@@ -0,0 +1,75 @@
+package reconcilers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+)
+
+var _ = Describe("ManagedNamespace", func() {
+	ctx := context.Background()
+
+	var fooName string
+
+	BeforeEach(func() {
+		fooName = createNS(ctx, "foo")
+	})
+
+	It("should create a namespace with the declared parent set via the subnamespace-of annotation", func() {
+		barName := createNSName("bar")
+		mn := newManagedNamespace(barName, fooName)
+		updateManagedNamespace(ctx, mn)
+
+		Eventually(func() string {
+			return getNamespace(ctx, barName).GetAnnotations()[api.SubnamespaceOf]
+		}).Should(Equal(fooName))
+
+		Eventually(func() string {
+			return getHierarchy(ctx, barName).Spec.Parent
+		}).Should(Equal(fooName))
+
+		Eventually(getManagedNamespaceState(ctx, barName)).Should(Equal(api.MNSOk))
+	})
+
+	It("should set the Conflict state if the namespace already exists and isn't owned by it", func() {
+		barName := createNS(ctx, "bar")
+		mn := newManagedNamespace(barName, fooName)
+		updateManagedNamespace(ctx, mn)
+
+		Eventually(getManagedNamespaceState(ctx, barName)).Should(Equal(api.MNSConflict))
+	})
+})
+
+func getManagedNamespaceState(ctx context.Context, nm string) func() api.ManagedNamespaceState {
+	return func() api.ManagedNamespaceState {
+		return getManagedNamespace(ctx, nm).Status.State
+	}
+}
+
+func newManagedNamespace(nm, pnm string) *api.ManagedNamespace {
+	mn := &api.ManagedNamespace{}
+	mn.ObjectMeta.Name = nm
+	mn.Spec.Parent = pnm
+	return mn
+}
+
+func getManagedNamespace(ctx context.Context, nm string) *api.ManagedNamespace {
+	nsn := types.NamespacedName{Name: nm}
+	mn := &api.ManagedNamespace{}
+	EventuallyWithOffset(1, func() error {
+		return k8sClient.Get(ctx, nsn, mn)
+	}).Should(Succeed())
+	return mn
+}
+
+func updateManagedNamespace(ctx context.Context, mn *api.ManagedNamespace) {
+	if mn.CreationTimestamp.IsZero() {
+		ExpectWithOffset(1, k8sClient.Create(ctx, mn)).Should(Succeed())
+	} else {
+		ExpectWithOffset(1, k8sClient.Update(ctx, mn)).Should(Succeed())
+	}
+}
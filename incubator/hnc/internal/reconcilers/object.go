@@ -17,6 +17,7 @@ package reconcilers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
@@ -34,6 +35,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -43,8 +45,11 @@ import (
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/forest"
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/metadata"
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/object"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/pkg/policy"
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/pkg/selectors"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/pkg/window"
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/stats"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/version"
 )
 
 // syncAction is the action to take after Reconcile syncs with the in-memory forest.
@@ -81,6 +86,22 @@ type ObjectReconciler struct {
 	// See more details in the comments of api.SynchronizationMode.
 	Mode api.SynchronizationMode
 
+	// Transform, if set, is applied to every propagated copy of the source object before it's
+	// written, substituting the destination namespace's name into the fields it names. See
+	// api.ObjectTransform.
+	Transform *api.ObjectTransform
+
+	// LinkToSource mirrors api.ResourceSpec.LinkToSource: if true, every propagated copy gets an
+	// owner-reference-like back-link (api.AnnotationSourceUID) and a finalizer
+	// (api.FinalizerPropagatedCopy).
+	LinkToSource bool
+
+	// IgnoreObjectWebhook mirrors api.ResourceSpec.IgnoreObjectWebhook: if true, the object
+	// validator allows every admission request for this GVK without running its "cannot
+	// modify/delete propagated object" checks, trading drift protection for apiserver write
+	// latency.
+	IgnoreObjectWebhook bool
+
 	// Affected is a channel of event.GenericEvent (see "Watching Channels" in
 	// https://book-v1.book.kubebuilder.io/beyond_basics/controller_watches.html) that is used to
 	// enqueue additional objects that need updating.
@@ -89,12 +110,46 @@ type ObjectReconciler struct {
 	// AffectedNamespace is a channel of events used to update namespaces.
 	AffectedNamespace chan event.GenericEvent
 
+	// affectedCoalescer dedupes and batches enqueueDescendants' sends on Affected, so that
+	// propagating one changed source object to every descendant of a large subtree doesn't flood
+	// Affected with a duplicate event for any descendant that already has one pending.
+	affectedCoalescer *affectedCoalescer
+
 	// propagatedObjectsLock is used to prevent the race condition between concurrent reconciliation threads
-	// trying to update propagatedObjects at the same time.
+	// trying to update propagatedObjects and propagatedSizes at the same time.
 	propagatedObjectsLock sync.Mutex
 
 	// propagatedObjects contains all propagated objects of the GVK handled by this reconciler.
 	propagatedObjects namespacedNameSet
+
+	// propagatedSizes contains the approximate serialized size, in bytes, of every object in
+	// propagatedObjects, so we can report the aggregate storage HNC's propagation of this GVK is
+	// responsible for (see stats.SetPropagatedBytes) without re-serializing every object every time.
+	propagatedSizes map[types.NamespacedName]int
+
+	// srcDigestLock guards srcDigestCache.
+	srcDigestLock sync.Mutex
+
+	// srcDigestCache memoizes the content digest (see object.Digest) of the last-seen source object
+	// for each name, keyed by the source's identity as well as its name. Since a source object in the
+	// forest is only ever replaced - never mutated - by syncSource, an unchanged pointer means the
+	// digest is still valid, so we can avoid recomputing it once per descendant every time a
+	// namespace with many descendants is reconciled.
+	srcDigestCache map[types.NamespacedName]srcDigest
+
+	// deferredObjectsLock guards deferredObjects.
+	deferredObjectsLock sync.Mutex
+
+	// deferredObjects contains the propagated copies of this GVK that are currently out of date
+	// because their source has a closed api.AnnotationPropagationWindow (see deferredByWindow).
+	deferredObjects namespacedNameSet
+}
+
+// srcDigest is a memoized object.Digest, along with the object it was computed from so we can tell
+// whether it's stale.
+type srcDigest struct {
+	obj    *unstructured.Unstructured
+	digest string
 }
 
 // HNC doesn't actually need all these permissions, but we *do* need to have them to be able to
@@ -107,7 +162,7 @@ type ObjectReconciler struct {
 // It enqueues all the current objects in the namespace and local copies of the original objects
 // in the ancestors.
 func (r *ObjectReconciler) SyncNamespace(ctx context.Context, log logr.Logger, ns string) error {
-	log = log.WithValues("gvk", r.GVK)
+	log = log.WithValues(keyGVK, r.GVK)
 
 	// Enqueue all the current objects in the namespace because some of them may have been deleted.
 	if err := r.enqueueLocalObjects(ctx, log, ns); err != nil {
@@ -125,6 +180,13 @@ func (r *ObjectReconciler) GetGVK() schema.GroupVersionKind {
 	return r.GVK
 }
 
+// affectedChannelName identifies this reconciler's Affected channel in exported metrics. There's
+// one ObjectReconciler (and therefore one Affected channel) per GVK, so the GVK is enough to
+// distinguish them from each other.
+func (r *ObjectReconciler) affectedChannelName() string {
+	return "object-affected-" + r.GVK.String()
+}
+
 // GetMode provides the mode of objects that are handled by this reconciler.
 func (r *ObjectReconciler) GetMode() api.SynchronizationMode {
 	return r.Mode
@@ -136,7 +198,7 @@ func (r *ObjectReconciler) GetMode() api.SynchronizationMode {
 // treated as api.Ignore.
 func GetValidateMode(mode api.SynchronizationMode, log logr.Logger) api.SynchronizationMode {
 	switch mode {
-	case api.Propagate, api.Ignore, api.Remove:
+	case api.Propagate, api.AllowPropagate, api.Ignore, api.Remove:
 		return mode
 	case "":
 		log.Info("Sync mode is unset; using default 'Propagate'")
@@ -150,7 +212,7 @@ func GetValidateMode(mode api.SynchronizationMode, log logr.Logger) api.Synchron
 // SetMode sets the Mode field of an object reconciler and syncs objects in the cluster if needed.
 // The method will return an error if syncs fail.
 func (r *ObjectReconciler) SetMode(ctx context.Context, log logr.Logger, mode api.SynchronizationMode) error {
-	log = log.WithValues("gvk", r.GVK)
+	log = log.WithValues(keyGVK, r.GVK)
 	newMode := GetValidateMode(mode, log)
 	oldMode := r.Mode
 	if newMode == oldMode {
@@ -169,6 +231,65 @@ func (r *ObjectReconciler) SetMode(ctx context.Context, log logr.Logger, mode ap
 	return nil
 }
 
+// SetTransform sets the Transform field of an object reconciler and re-syncs objects in the
+// cluster if it changed, so already-propagated copies pick up the new (or removed) substitution.
+// The method will return an error if syncs fail.
+func (r *ObjectReconciler) SetTransform(ctx context.Context, log logr.Logger, transform *api.ObjectTransform) error {
+	log = log.WithValues(keyGVK, r.GVK)
+	if reflect.DeepEqual(r.Transform, transform) {
+		return nil
+	}
+	log.Info("Changing field transform of the object reconciler", "oldTransform", r.Transform, "newTransform", transform)
+	r.Transform = transform
+	if r.Mode != api.Ignore {
+		if err := r.enqueueAllObjects(ctx, r.Log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetLinkToSource provides whether propagated copies of the GVK handled by this reconciler get an
+// owner-reference-like back-link and finalizer.
+func (r *ObjectReconciler) GetLinkToSource() bool {
+	return r.LinkToSource
+}
+
+// SetLinkToSource sets the LinkToSource field of an object reconciler and re-syncs objects in the
+// cluster if it changed, so already-propagated copies pick up (or drop) the back-link and
+// finalizer.
+func (r *ObjectReconciler) SetLinkToSource(ctx context.Context, log logr.Logger, linkToSource bool) error {
+	log = log.WithValues(keyGVK, r.GVK)
+	if r.LinkToSource == linkToSource {
+		return nil
+	}
+	log.Info("Changing LinkToSource of the object reconciler", "oldLinkToSource", r.LinkToSource, "newLinkToSource", linkToSource)
+	r.LinkToSource = linkToSource
+	if r.Mode != api.Ignore {
+		if err := r.enqueueAllObjects(ctx, r.Log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetIgnoreObjectWebhook provides whether the object validator skips this reconciler's GVK
+// entirely.
+func (r *ObjectReconciler) GetIgnoreObjectWebhook() bool {
+	return r.IgnoreObjectWebhook
+}
+
+// SetIgnoreObjectWebhook sets the IgnoreObjectWebhook field of an object reconciler. Unlike
+// SetMode, SetTransform and SetLinkToSource, this never needs to re-sync any objects, since it
+// only affects how future admission requests are validated.
+func (r *ObjectReconciler) SetIgnoreObjectWebhook(ignore bool) {
+	if r.IgnoreObjectWebhook == ignore {
+		return
+	}
+	r.Log.Info("Changing IgnoreObjectWebhook of the object reconciler", "gvk", r.GVK, "oldIgnoreObjectWebhook", r.IgnoreObjectWebhook, "newIgnoreObjectWebhook", ignore)
+	r.IgnoreObjectWebhook = ignore
+}
+
 // GetNumPropagatedObjects returns the number of propagated objects of the GVK handled by this object reconciler.
 func (r *ObjectReconciler) GetNumPropagatedObjects() int {
 	r.propagatedObjectsLock.Lock()
@@ -177,6 +298,30 @@ func (r *ObjectReconciler) GetNumPropagatedObjects() int {
 	return len(r.propagatedObjects)
 }
 
+// GetNumPropagatedObjectsInNamespace returns the number of propagated objects of the GVK handled
+// by this object reconciler that live in ns.
+func (r *ObjectReconciler) GetNumPropagatedObjectsInNamespace(ns string) int {
+	r.propagatedObjectsLock.Lock()
+	defer r.propagatedObjectsLock.Unlock()
+
+	n := 0
+	for nnm := range r.propagatedObjects {
+		if nnm.Namespace == ns {
+			n++
+		}
+	}
+	return n
+}
+
+// GetNumDeferredObjects returns the number of propagated copies of the GVK handled by this object
+// reconciler that are currently out of date because their source has a closed propagation window.
+func (r *ObjectReconciler) GetNumDeferredObjects() int {
+	r.deferredObjectsLock.Lock()
+	defer r.deferredObjectsLock.Unlock()
+
+	return len(r.deferredObjects)
+}
+
 // enqueueAllObjects enqueues all the current objects in all namespaces.
 func (r *ObjectReconciler) enqueueAllObjects(ctx context.Context, log logr.Logger) error {
 	keys := r.Forest.GetNamespaceNames()
@@ -194,7 +339,11 @@ func (r *ObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	resp := ctrl.Result{}
 	log := loggerWithRID(r.Log).WithValues("trigger", req.NamespacedName)
 
-	if config.ExcludedNamespaces[req.Namespace] {
+	// Allow req.NamespacedName to be coalesced into a future enqueueDescendants call again, now
+	// that we're about to reconcile whatever state caused it to be enqueued.
+	r.affectedCoalescer.reconciling(req.NamespacedName)
+
+	if config.IsExcludedNamespace(req.Namespace) {
 		return resp, nil
 	}
 
@@ -205,6 +354,10 @@ func (r *ObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	stats.StartObjReconcile(r.GVK)
 	defer stats.StopObjReconcile(r.GVK)
 
+	// Remember when this reconcile started so we can report how long it took from noticing the
+	// change to actually writing it, if we end up propagating anything (see stats.RecordPropagationLatency).
+	start := time.Now()
+
 	// Read the object.
 	inst := &unstructured.Unstructured{}
 	inst.SetGroupVersionKind(r.GVK)
@@ -218,14 +371,17 @@ func (r *ObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	}
 
 	// Sync with the forest and perform any required actions.
-	actions, srcInst := r.syncWithForest(ctx, log, inst)
-	return resp, r.operate(ctx, log, actions, inst, srcInst)
+	actions, srcInst, requeueAfter := r.syncWithForest(ctx, log, inst)
+	resp.RequeueAfter = requeueAfter
+	return resp, r.operate(ctx, log, actions, inst, srcInst, start)
 }
 
 // syncWithForest syncs the object instance with the in-memory forest. It returns the action to take on
-// the object (delete, write or do nothing) and a source object if the action is to write it. It can
-// also update the forest if a source object is added or removed.
-func (r *ObjectReconciler) syncWithForest(ctx context.Context, log logr.Logger, inst *unstructured.Unstructured) (syncAction, *unstructured.Unstructured) {
+// the object (delete, write or do nothing), a source object if the action is to write it, and how
+// long to wait before reconciling this object again - nonzero only if the action was suppressed
+// because the source has a closed propagation window (see deferredByWindow). It can also update
+// the forest if a source object is added or removed.
+func (r *ObjectReconciler) syncWithForest(ctx context.Context, log logr.Logger, inst *unstructured.Unstructured) (syncAction, *unstructured.Unstructured, time.Duration) {
 	// This is the only place we should lock the forest in each Reconcile, so this fn needs to return
 	// everything relevant for the rest of the Reconcile. This fn shouldn't contact the apiserver since
 	// that's a slow operation and everything will block on the lock being held.
@@ -235,27 +391,27 @@ func (r *ObjectReconciler) syncWithForest(ctx context.Context, log logr.Logger,
 	// If this namespace isn't ready to be synced (or is never synced), early exit. We'll be called
 	// again if this changes.
 	if r.skipNamespace(ctx, log, inst) {
-		return actionNop, nil
+		return actionNop, nil, 0
 	}
 
 	// If the object's missing and we know how to handle it, return early.
 	if missingAction := r.syncMissingObject(ctx, log, inst); missingAction != actionUnknown {
-		return missingAction, nil
+		return missingAction, nil, 0
 	}
 
 	// Update the forest and get the intended action.
-	action, srcInst := r.syncObject(ctx, log, inst)
+	action, srcInst, requeueAfter := r.syncObject(ctx, log, inst)
 
 	// If the namespace has a critical condition, we shouldn't actually take any action, regardless of
 	// what we'd _like_ to do. We still needed to sync the forest since we want to know when objects
 	// are added and removed, so we can sync them properly if the critical condition is resolved, but
 	// don't do anything else for now.
 	if ca := r.Forest.Get(inst.GetNamespace()).GetCritAncestor(); ca != "" {
-		log.Info("Namespace has 'ActivitiesHalted' condition; will not touch propagated object", "affectedNamespace", ca, "suppressedAction", action)
-		return actionNop, nil
+		log.Info("Namespace has a critical condition; will not touch propagated object", "affectedNamespace", ca, "suppressedAction", action)
+		return actionNop, nil, 0
 	}
 
-	return action, srcInst
+	return action, srcInst, requeueAfter
 }
 
 func (r *ObjectReconciler) skipNamespace(ctx context.Context, log logr.Logger, inst *unstructured.Unstructured) bool {
@@ -322,14 +478,14 @@ func (r *ObjectReconciler) syncMissingObject(ctx context.Context, log logr.Logge
 }
 
 // syncObject determines if this object is a source or propagated copy and handles it accordingly.
-func (r *ObjectReconciler) syncObject(ctx context.Context, log logr.Logger, inst *unstructured.Unstructured) (syncAction, *unstructured.Unstructured) {
+func (r *ObjectReconciler) syncObject(ctx context.Context, log logr.Logger, inst *unstructured.Unstructured) (syncAction, *unstructured.Unstructured, time.Duration) {
 	// If for some reason this has been called on an object that isn't namespaced, let's generate some
 	// logspam!
 	if inst.GetNamespace() == "" {
 		for i := 0; i < 100; i++ {
 			log.Info("Non-namespaced object!!!")
 		}
-		return actionNop, nil
+		return actionNop, nil, 0
 	}
 
 	// If the object should be propagated, we will sync it as an propagated object.
@@ -339,7 +495,7 @@ func (r *ObjectReconciler) syncObject(ctx context.Context, log logr.Logger, inst
 
 	r.syncSource(ctx, log, inst)
 	// No action needs to take on source objects.
-	return actionNop, nil
+	return actionNop, nil, 0
 }
 
 // shouldSyncAsPropagated returns true and the source object if this object
@@ -357,10 +513,11 @@ func (r *ObjectReconciler) shouldSyncAsPropagated(log logr.Logger, inst *unstruc
 		return true, srcInst
 	}
 
-	// If there's a conflicting source in the ancestors (excluding itself) and the
-	// the type has 'Propagate' mode, the object will be overwritten.
+	// If there's a conflicting source in the ancestors (excluding itself) and the type has
+	// 'Propagate' or 'AllowPropagate' mode (and, for the latter, srcInst has already opted in - see
+	// shouldPropagateSource), the object will be overwritten.
 	mode := r.Forest.GetTypeSyncer(r.GVK).GetMode()
-	if mode == api.Propagate && srcInst != nil {
+	if (mode == api.Propagate || mode == api.AllowPropagate) && srcInst != nil {
 		log.Info("Conflicting object found in ancestors namespace; will overwrite this object", "conflictingAncestor", srcInst.GetNamespace())
 		return true, srcInst
 	}
@@ -370,11 +527,16 @@ func (r *ObjectReconciler) shouldSyncAsPropagated(log logr.Logger, inst *unstruc
 
 // getTopSourceToPropagate returns the top source in the ancestors (excluding
 // itself) that can propagate. Otherwise, return nil.
+//
+// Ordinary ancestor propagation always takes precedence over library propagation (see
+// api.LibrarySpec): a subscribed library only ever fills in an object that no ancestor already
+// provides, and among subscribed libraries, the first one that declares the object wins.
 func (r *ObjectReconciler) getTopSourceToPropagate(log logr.Logger, inst *unstructured.Unstructured) *unstructured.Unstructured {
 	ns := r.Forest.Get(inst.GetNamespace())
 	// Get all the source objects with the same name in the ancestors excluding
 	// itself from top down.
 	objs := ns.Parent().GetAncestorSourceObjects(r.GVK, inst.GetName())
+	objs = append(objs, ns.GetLibrarySourceObjects(r.GVK, inst.GetName())...)
 	for _, obj := range objs {
 		// If the source cannot propagate, ignore it.
 		// TODO: add a webhook rule to prevent e.g. removing a source finalizer that
@@ -389,40 +551,80 @@ func (r *ObjectReconciler) getTopSourceToPropagate(log logr.Logger, inst *unstru
 }
 
 // syncPropagated will determine whether to delete the obsolete copy or overwrite it with the source.
-// Or do nothing if it remains the same as the source object.
-func (r *ObjectReconciler) syncPropagated(log logr.Logger, inst, srcInst *unstructured.Unstructured) (syncAction, *unstructured.Unstructured) {
+// Or do nothing if it remains the same as the source object. It also returns how long to wait
+// before reconciling this object again, which is nonzero only if a write was needed but deferred
+// because the source has a closed propagation window (see deferredByWindow).
+func (r *ObjectReconciler) syncPropagated(log logr.Logger, inst, srcInst *unstructured.Unstructured) (syncAction, *unstructured.Unstructured, time.Duration) {
 	ns := r.Forest.Get(inst.GetNamespace())
 	// Delete this local source object from the forest if it exists. (This could
 	// only happen when we are trying to overwrite a conflicting source).
 	ns.DeleteSourceObject(r.GVK, inst.GetName())
 	stats.OverwriteObject(r.GVK)
+	stats.RecordSubtreePropagation(ns.Root())
 
 	// If no source object exists, delete this object. This can happen when the source was deleted by
 	// users or the admin decided this type should no longer be propagated.
 	if srcInst == nil {
-		return actionRemove, nil
+		r.recordUndeferredObject(inst)
+		return actionRemove, nil, 0
 	}
 
 	// If an object doesn't exist, assume it's been deleted or not yet created.
 	exists := inst.GetCreationTimestamp() != v1.Time{}
 
 	// If the copy does not exist, or is different from the source, return the write action and the
-	// source instance. Note that DeepEqual could return `true` even if the object doesn't exist if
-	// the source object is trivial (e.g. a completely empty ConfigMap).
+	// source instance. We compare content digests rather than deep-comparing the two canonicalized
+	// objects directly - when there's no Transform, the source's digest is cached (see
+	// digestOfSource), so reconciling many descendants of the same unchanged source (e.g. a CA
+	// bundle ConfigMap propagated to hundreds of namespaces) doesn't require re-walking the whole
+	// object once per descendant. Note that two equal digests could in principle be a hash
+	// collision, and equal-but-nonexistent objects could still have equal digests, so we still check
+	// `exists` explicitly.
 	if !exists ||
-		!reflect.DeepEqual(object.Canonical(inst), object.Canonical(srcInst)) ||
+		object.Digest(inst) != r.digestOfPropagated(srcInst, inst.GetNamespace()) ||
 		inst.GetLabels()[api.LabelInheritedFrom] != srcInst.GetNamespace() {
+		if deferred, requeueAfter := r.deferredByWindow(log, inst, srcInst); deferred {
+			return actionNop, nil, requeueAfter
+		}
+		r.recordUndeferredObject(inst)
 		metadata.SetLabel(inst, api.LabelInheritedFrom, srcInst.GetNamespace())
-		return actionWrite, srcInst
+		return actionWrite, srcInst, 0
 	}
 
 	// The object already exists and doesn't need to be updated. This will typically happen when HNC
 	// is restarted - all the propagated objects already exist on the apiserver. Record that it exists
 	// for our statistics.
-	r.recordPropagatedObject(log, inst.GetNamespace(), inst.GetName())
+	r.recordPropagatedObject(log, inst)
+	r.recordUndeferredObject(inst)
 
 	// Nothing more needs to be done.
-	return actionNop, nil
+	return actionNop, nil, 0
+}
+
+// deferredByWindow returns whether inst's propagation must be deferred because srcInst declares a
+// propagation window (see api.AnnotationPropagationWindow) that's currently closed, along with how
+// long until it next opens. If srcInst's window annotation is missing or unparseable, this always
+// returns false, so propagation proceeds as if it weren't set.
+func (r *ObjectReconciler) deferredByWindow(log logr.Logger, inst, srcInst *unstructured.Unstructured) (bool, time.Duration) {
+	spec := srcInst.GetAnnotations()[api.AnnotationPropagationWindow]
+	if spec == "" {
+		return false, 0
+	}
+	w, err := window.Parse(spec)
+	if err != nil {
+		log.Info("Ignoring unparseable propagation window", "annotation", api.AnnotationPropagationWindow, "value", spec, "error", err.Error())
+		r.EventRecorder.Event(srcInst, "Warning", api.EventCannotParsePropagationWindow, err.Error())
+		return false, 0
+	}
+
+	now := time.Now()
+	if w.IsOpen(now) {
+		return false, 0
+	}
+
+	log.V(1).Info("Deferring propagation: source has a closed propagation window", "window", spec)
+	r.recordDeferredObject(inst)
+	return true, w.NextOpen(now)
 }
 
 // syncSource updates the copy in the forest with the current source object. We
@@ -478,13 +680,22 @@ func (r *ObjectReconciler) enqueueDescendants(ctx context.Context, log logr.Logg
 		log.V(1).Info("Will not enqueue descendants due to crit ancestor", "critAncestor", ca, "oldReason", reason)
 		return
 	}
+	// If this is a library namespace, also enqueue its subscribers' subtrees, since they aren't
+	// descendants of the source namespace but still need to react to changes to its source objects.
+	targets := sns.DescendantNames()
+	targets = append(targets, r.Forest.LibrarySubscriberNamespaces(src.GetNamespace())...)
+
 	log.V(1).Info("Enqueuing descendant objects", "reason", reason)
-	for _, ns := range sns.DescendantNames() {
-		dc := object.Canonical(src)
-		dc.SetNamespace(ns)
-		log.V(1).Info("... enqueuing descendant copy", "affected", ns+"/"+src.GetName(), "reason", reason)
-		r.Affected <- event.GenericEvent{Object: dc}
+	nnms := make([]types.NamespacedName, len(targets))
+	for i, ns := range targets {
+		nnms[i] = types.NamespacedName{Namespace: ns, Name: src.GetName()}
 	}
+	r.affectedCoalescer.enqueueBatch(log, r.Affected, r.affectedChannelName(), nnms, func(nnm types.NamespacedName) event.GenericEvent {
+		log.V(1).Info("... enqueuing descendant copy", keyDestination, nnm.Namespace+"/"+nnm.Name, "reason", reason)
+		dc := object.Canonical(src)
+		dc.SetNamespace(nnm.Namespace)
+		return event.GenericEvent{Object: dc}
+	})
 }
 
 // enqueueLocalObjects enqueues all the objects (with the same GVK) in the namespace.
@@ -501,7 +712,7 @@ func (r *ObjectReconciler) enqueueLocalObjects(ctx context.Context, log logr.Log
 		co := object.Canonical(&inst)
 		co.SetNamespace(inst.GetNamespace())
 		log.V(1).Info("Enqueuing existing object for reconciliation", "affected", co.GetName())
-		r.Affected <- event.GenericEvent{Object: co}
+		enqueueEvent(log, r.Affected, r.affectedChannelName(), event.GenericEvent{Object: co})
 	}
 
 	return nil
@@ -520,13 +731,14 @@ func (r *ObjectReconciler) enqueuePropagatedObjects(ctx context.Context, log log
 		lc := object.Canonical(obj)
 		lc.SetNamespace(ns)
 		log.V(1).Info("Enqueuing local copy of the ancestor original for reconciliation", "affected", lc.GetName())
-		r.Affected <- event.GenericEvent{Object: lc}
+		enqueueEvent(log, r.Affected, r.affectedChannelName(), event.GenericEvent{Object: lc})
 	}
 }
 
 // operate operates the action generated from syncing the object with the forest.
-func (r *ObjectReconciler) operate(ctx context.Context, log logr.Logger, act syncAction, inst, srcInst *unstructured.Unstructured) error {
+func (r *ObjectReconciler) operate(ctx context.Context, log logr.Logger, act syncAction, inst, srcInst *unstructured.Unstructured, start time.Time) error {
 	var err error
+	log = log.WithValues(keyAction, act)
 
 	switch act {
 	case actionNop:
@@ -534,7 +746,7 @@ func (r *ObjectReconciler) operate(ctx context.Context, log logr.Logger, act syn
 	case actionRemove:
 		err = r.deleteObject(ctx, log, inst)
 	case actionWrite:
-		err = r.writeObject(ctx, log, inst, srcInst)
+		err = r.writeObject(ctx, log, inst, srcInst, start)
 	default: // this should never, ever happen. But if it does, try to make a very obvious error message.
 		if act == "" {
 			act = actionUnknown
@@ -549,6 +761,17 @@ func (r *ObjectReconciler) operate(ctx context.Context, log logr.Logger, act syn
 func (r *ObjectReconciler) deleteObject(ctx context.Context, log logr.Logger, inst *unstructured.Unstructured) error {
 	log.Info("Deleted propagated object")
 	stats.WriteObject(r.GVK)
+	stats.RecordSubtreePropagation(r.Forest.Get(inst.GetNamespace()).Root())
+
+	// A copy carrying FinalizerPropagatedCopy is only allowed to disappear via HNC removing the
+	// finalizer itself; otherwise Delete below would just leave it stuck in "Terminating" forever.
+	if controllerutil.ContainsFinalizer(inst, api.FinalizerPropagatedCopy) {
+		controllerutil.RemoveFinalizer(inst, api.FinalizerPropagatedCopy)
+		if err := r.Update(ctx, inst); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
 	err := r.Delete(ctx, inst)
 	if errors.IsNotFound(err) {
 		log.V(1).Info("The obsolete copy doesn't exist, no more action needed")
@@ -562,69 +785,131 @@ func (r *ObjectReconciler) deleteObject(ctx context.Context, log logr.Logger, in
 
 	// Remove the propagated object from the map because we are confident that the object was successfully deleted
 	// on the apiserver.
-	r.recordRemovedObject(log, inst.GetNamespace(), inst.GetName())
+	r.recordRemovedObject(log, inst)
 	return nil
 }
 
-func (r *ObjectReconciler) writeObject(ctx context.Context, log logr.Logger, inst, srcInst *unstructured.Unstructured) error {
-	// The object exists if CreationTimestamp is set. This flag enables us to have only 1 API call.
+// objectFieldManager is the field manager HNC uses when it server-side-applies propagated
+// objects, so it only ever claims ownership of the fields it actually sets (name, canonicalized
+// spec, and the inherited-from label). Other controllers - e.g. one that injects an annotation
+// into a ConfigMap after it's propagated - keep ownership of their own fields instead of having
+// them clobbered on HNC's next reconcile, the way a whole-object Update would.
+const objectFieldManager = api.MetaGroup + "/hnc-object-reconciler"
+
+func (r *ObjectReconciler) writeObject(ctx context.Context, log logr.Logger, inst, srcInst *unstructured.Unstructured, start time.Time) error {
+	// The object exists if CreationTimestamp is set. This flag is only used for logging below, since
+	// server-side apply creates or updates in a single call either way.
 	exist := inst.GetCreationTimestamp() != v1.Time{}
+
+	// If the existing copy was last written by a different HNC instance, another installation is
+	// also propagating into this cluster - e.g. an accidental double install, where each
+	// installation's own leader election doesn't stop the other one. Forcibly re-applying our copy
+	// every reconcile would just mean the two instances fight over the object forever, so skip the
+	// write instead and surface it loudly.
+	if exist {
+		if foreign := inst.GetAnnotations()[api.AnnotationManagerIdentity]; foreign != "" && foreign != config.ManagerIdentity() {
+			stats.RecordManagerConflict(r.GVK)
+			msg := fmt.Sprintf("Found a copy of this object last written by a different HNC instance (%q); skipping to avoid fighting over it", foreign)
+			r.EventRecorder.Event(inst, "Warning", api.EventManagerConflict, msg)
+			return nil
+		}
+	}
+
 	ns := inst.GetNamespace()
-	inst = object.Canonical(srcInst)
-	inst.SetNamespace(ns)
+	inst = r.propagatedCopy(srcInst, ns)
+	// propagatedCopy clears apiVersion (see object.Canonical) so that a CRD version change mid-
+	// propagation can't be mistaken for a content change; always write at this reconciler's own
+	// GVK rather than trusting srcInst to have been read through the same version.
+	inst.SetGroupVersionKind(r.GVK)
 	metadata.SetLabel(inst, api.LabelInheritedFrom, srcInst.GetNamespace())
-	log.V(1).Info("Writing", "dst", inst.GetNamespace(), "origin", srcInst.GetNamespace())
 
-	var err error = nil
-	stats.WriteObject(r.GVK)
+	// Stamp the copy with enough detail to let an incident responder correlate it with the exact
+	// source change and HNC build that produced it, without having to guess from timing alone.
+	metadata.SetAnnotation(inst, api.AnnotationSourceResourceVersion, srcInst.GetResourceVersion())
+	metadata.SetAnnotation(inst, api.AnnotationPropagatedAt, time.Now().UTC().Format(time.RFC3339))
+	metadata.SetAnnotation(inst, api.AnnotationPropagatedByVersion, version.Version)
+	metadata.SetAnnotation(inst, api.AnnotationManagerIdentity, config.ManagerIdentity())
+
+	if r.LinkToSource {
+		metadata.SetAnnotation(inst, api.AnnotationSourceUID, string(srcInst.GetUID()))
+		controllerutil.AddFinalizer(inst, api.FinalizerPropagatedCopy)
+	}
+
+	log = log.WithValues(keyDestination, inst.GetNamespace(), keySource, srcInst.GetNamespace())
+	log.V(1).Info("Writing")
+
+	if depth := r.depthBelowSource(srcInst.GetNamespace(), ns); depth > 0 {
+		scaleQuotaObject(inst, srcInst, depth)
+	}
+
 	if exist {
 		log.Info("Updating propagated object")
-		err = r.Update(ctx, inst)
-		// RoleBindings can't have their Roles changed after they're created
-		// (see  https://github.com/kubernetes-sigs/multi-tenancy/issues/798).
-		// If an RB was quickly delete and re-created in an ancestor namespace
-		// - fast enough that by the time that HNC notices, the new RB exists; or
-		// if there's a change to the RBs when HNC isn't running - HNC could see
-		// it as an update (not a delete + create) and attempt to update the RBs in
-		// all descendant namespaces, and this will fail. In order to handle this
-		// case, we try to delete and re-create the rolebinding here
-
-		// We don't apply this logic to other objects because if another object has an
-		// ownerReference pointing to the object we're deleting, it could be deleted as
-		// well, which is undesirable.
-
-		// The error type is 'Invalid' after I tested it out with different error types
-		// from https://godoc.org/k8s.io/apimachinery/pkg/api/errors
-		api := strings.Split(inst.GetAPIVersion(), "/")[0]
-		if err != nil && errors.IsInvalid(err) && inst.GetKind() == "RoleBinding" && api == "rbac.authorization.k8s.io" {
-			// Log this error because we're about to throw it away.
-			log.Error(err, "Couldn't update propagated object; will try to delete and recreate instead")
-			if err = r.Delete(ctx, inst); err == nil {
-				err = r.Create(ctx, inst)
-				if err != nil {
-					log.Info("Couldn't recreate propagated object after deleting it") // error is handles below
-				} else {
-					log.Info("Successfully recreated propagated object")
-				}
+	} else {
+		log.Info("Propagating object")
+	}
+	stats.WriteObject(r.GVK)
+	root := r.Forest.Get(ns).Root()
+	stats.RecordSubtreePropagation(root)
+	err := r.Patch(ctx, inst, client.Apply, client.FieldOwner(objectFieldManager), client.ForceOwnership)
+
+	// RoleBindings can't have their Roles changed after they're created
+	// (see  https://github.com/kubernetes-sigs/multi-tenancy/issues/798).
+	// If an RB was quickly delete and re-created in an ancestor namespace
+	// - fast enough that by the time that HNC notices, the new RB exists; or
+	// if there's a change to the RBs when HNC isn't running - HNC could see
+	// it as an update (not a delete + create) and attempt to update the RBs in
+	// all descendant namespaces, and this will fail. In order to handle this
+	// case, we try to delete and re-create the rolebinding here
+
+	// We don't apply this logic to other objects because if another object has an
+	// ownerReference pointing to the object we're deleting, it could be deleted as
+	// well, which is undesirable.
+
+	// The error type is 'Invalid' after I tested it out with different error types
+	// from https://godoc.org/k8s.io/apimachinery/pkg/api/errors
+	apiGroup := strings.Split(inst.GetAPIVersion(), "/")[0]
+	if exist && err != nil && errors.IsInvalid(err) && inst.GetKind() == "RoleBinding" && apiGroup == "rbac.authorization.k8s.io" {
+		// Log this error because we're about to throw it away.
+		log.Error(err, "Couldn't apply propagated object; will try to delete and recreate instead")
+		if err = r.Delete(ctx, inst); err == nil {
+			err = r.Patch(ctx, inst, client.Apply, client.FieldOwner(objectFieldManager), client.ForceOwnership)
+			if err != nil {
+				log.Info("Couldn't recreate propagated object after deleting it") // error is handles below
 			} else {
-				log.Info("Couldn't delete propagated object that we couldn't update") // error is handles below
+				log.Info("Successfully recreated propagated object")
 			}
+		} else {
+			log.Info("Couldn't delete propagated object that we couldn't update") // error is handles below
 		}
-	} else {
-		log.Info("Propagating object")
-		err = r.Create(ctx, inst)
 	}
 	if err != nil {
+		stats.RecordSubtreePropagationError(root)
+		stats.WriteObjectFailure(r.GVK)
 		// Don't log the error since controller-runtime will do it for us
 		return err
 	}
 
+	stats.RecordPropagationLatency(r.GVK, time.Since(start).Seconds())
+
 	// Add the object to the map if it does not exist because we are confident that the object was updated/created
 	// successfully on the apiserver.
-	r.recordPropagatedObject(log, inst.GetNamespace(), inst.GetName())
+	r.recordPropagatedObject(log, inst)
 	return nil
 }
 
+// depthBelowSource returns the number of tree levels between srcNS and dstNS - e.g. 1 if dstNS is
+// a direct child of srcNS, 2 if it's a grandchild, and so on - or 0 if either namespace isn't in
+// the forest (e.g. during a race with namespace deletion).
+func (r *ObjectReconciler) depthBelowSource(srcNS, dstNS string) int {
+	r.Forest.Lock()
+	defer r.Forest.Unlock()
+	src, dst := r.Forest.Get(srcNS), r.Forest.Get(dstNS)
+	if !src.Exists() || !dst.Exists() {
+		return 0
+	}
+	return len(dst.AncestryNames()) - len(src.AncestryNames())
+}
+
 // generateEvents is called when the reconciler has performed all necessary
 // actions and knows if they've succeeded or failed. If a source should not be
 // propagated or there was a failure, generate "Warning" events.
@@ -675,8 +960,10 @@ func (r *ObjectReconciler) syncPropagation(ctx context.Context, log logr.Logger,
 // shouldPropagateSource returns true if the object should be propagated by the HNC. The following
 // objects are not propagated:
 // - Objects of a type whose mode is set to "remove" in the HNCConfiguration singleton
+// - Objects of a type whose mode is set to "AllowPropagate" that don't carry AllowPropagateAnnotation
 // - Objects with nonempty finalizer list
 // - Objects have a selector that doesn't match the destination namespace
+// - Objects excluded from dst by a PropagationPolicy declared in the source namespace
 // - Service Account token secrets
 func (r *ObjectReconciler) shouldPropagateSource(log logr.Logger, inst *unstructured.Unstructured, dst string) bool {
 	nsLabels := r.Forest.Get(dst).GetLabels()
@@ -688,12 +975,26 @@ func (r *ObjectReconciler) shouldPropagateSource(log logr.Logger, inst *unstruct
 		return false
 	}
 
+	srcNS := r.Forest.Get(inst.GetNamespace())
+	specs := srcNS.PropagationPolicies()
+	if ok, err := policy.ShouldPropagate(specs, inst.GetAPIVersion(), inst.GetKind(), inst.GetName(), inst.GetLabels(), dst); err != nil {
+		log.Error(err, "Cannot evaluate PropagationPolicy")
+		r.EventRecorder.Event(inst, "Warning", api.EventCannotParseSelector, err.Error())
+		return false
+	} else if !ok {
+		return false
+	}
+
 	switch {
 	// Users can set the mode of a type to "remove" to exclude objects of the type
 	// from being handled by HNC.
 	case r.Mode == api.Remove:
 		return false
 
+	// AllowPropagate only propagates the source objects that have explicitly opted in.
+	case r.Mode == api.AllowPropagate && inst.GetAnnotations()[api.AllowPropagateAnnotation] != "true":
+		return false
+
 	// Object with nonempty finalizer list is not propagated
 	case hasFinalizers(inst):
 		return false
@@ -718,35 +1019,125 @@ func (r *ObjectReconciler) shouldPropagateSource(log logr.Logger, inst *unstruct
 }
 
 // recordPropagatedObject records the fact that this object has been propagated, so we can report
-// statistics in the HNC Config.
-func (r *ObjectReconciler) recordPropagatedObject(log logr.Logger, namespace, name string) {
+// statistics in the HNC Config, and updates the aggregate storage size HNC is reporting for this
+// GVK (see stats.SetPropagatedBytes).
+func (r *ObjectReconciler) recordPropagatedObject(log logr.Logger, inst *unstructured.Unstructured) {
 	r.propagatedObjectsLock.Lock()
 	defer r.propagatedObjectsLock.Unlock()
 
 	nnm := types.NamespacedName{
-		Namespace: namespace,
-		Name:      name,
+		Namespace: inst.GetNamespace(),
+		Name:      inst.GetName(),
 	}
 	if !r.propagatedObjects[nnm] {
 		r.propagatedObjects[nnm] = true
 		hnccrSingleton.requestReconcile("newly propagated object")
 	}
+
+	b, _ := json.Marshal(inst.Object)
+	r.propagatedSizes[nnm] = len(b)
+	stats.SetPropagatedBytes(r.GVK, r.totalPropagatedSizeLocked())
+	stats.SetPropagatedObjectsCount(r.GVK, len(r.propagatedObjects))
 }
 
 // recordRemovedObject records the fact that this (possibly) previously propagated object no longer
 // exists.
-func (r *ObjectReconciler) recordRemovedObject(log logr.Logger, namespace, name string) {
+func (r *ObjectReconciler) recordRemovedObject(log logr.Logger, inst *unstructured.Unstructured) {
 	r.propagatedObjectsLock.Lock()
 	defer r.propagatedObjectsLock.Unlock()
 
 	nnm := types.NamespacedName{
-		Namespace: namespace,
-		Name:      name,
+		Namespace: inst.GetNamespace(),
+		Name:      inst.GetName(),
 	}
 	if r.propagatedObjects[nnm] {
 		delete(r.propagatedObjects, nnm)
 		hnccrSingleton.requestReconcile("newly unpropagated object")
 	}
+
+	delete(r.propagatedSizes, nnm)
+	stats.SetPropagatedBytes(r.GVK, r.totalPropagatedSizeLocked())
+	stats.SetPropagatedObjectsCount(r.GVK, len(r.propagatedObjects))
+}
+
+// recordDeferredObject records the fact that this propagated copy is currently out of date
+// because its source has a closed propagation window (see deferredByWindow).
+func (r *ObjectReconciler) recordDeferredObject(inst *unstructured.Unstructured) {
+	r.deferredObjectsLock.Lock()
+	defer r.deferredObjectsLock.Unlock()
+
+	nnm := types.NamespacedName{
+		Namespace: inst.GetNamespace(),
+		Name:      inst.GetName(),
+	}
+	if !r.deferredObjects[nnm] {
+		r.deferredObjects[nnm] = true
+		hnccrSingleton.requestReconcile("newly deferred object")
+	}
+}
+
+// recordUndeferredObject records the fact that this object is no longer deferred - either because
+// it's been brought up to date with its source, or because its source is gone.
+func (r *ObjectReconciler) recordUndeferredObject(inst *unstructured.Unstructured) {
+	r.deferredObjectsLock.Lock()
+	defer r.deferredObjectsLock.Unlock()
+
+	nnm := types.NamespacedName{
+		Namespace: inst.GetNamespace(),
+		Name:      inst.GetName(),
+	}
+	if r.deferredObjects[nnm] {
+		delete(r.deferredObjects, nnm)
+		hnccrSingleton.requestReconcile("newly undeferred object")
+	}
+}
+
+// totalPropagatedSizeLocked sums the sizes of all currently-known propagated objects of this GVK.
+// Callers must hold propagatedObjectsLock.
+func (r *ObjectReconciler) totalPropagatedSizeLocked() int {
+	total := 0
+	for _, sz := range r.propagatedSizes {
+		total += sz
+	}
+	return total
+}
+
+// propagatedCopy returns the canonicalized copy of src that should be written to namespace dstNS,
+// with r.Transform (if any) applied. writeObject and digestOfPropagated both build on this so a
+// change to how transforms are applied only has to be made in one place.
+func (r *ObjectReconciler) propagatedCopy(src *unstructured.Unstructured, dstNS string) *unstructured.Unstructured {
+	c := object.Canonical(src)
+	c.SetNamespace(dstNS)
+	object.ApplyTransform(c, r.Transform, dstNS)
+	return c
+}
+
+// digestOfPropagated returns the content digest (see object.Digest) that the copy of src written
+// to dstNS should have. If this type has no Transform, every destination gets the same content, so
+// this defers to the cached digestOfSource; otherwise the digest depends on dstNS as well, so it's
+// recomputed every time.
+func (r *ObjectReconciler) digestOfPropagated(src *unstructured.Unstructured, dstNS string) string {
+	if r.Transform == nil {
+		return r.digestOfSource(src)
+	}
+	return object.Digest(r.propagatedCopy(src, dstNS))
+}
+
+// digestOfSource returns the content digest (see object.Digest) of the given source object,
+// reusing the last-computed digest if this exact object (by identity, not just by name) was the
+// last one digested under this name - see srcDigestCache.
+func (r *ObjectReconciler) digestOfSource(src *unstructured.Unstructured) string {
+	nnm := types.NamespacedName{Namespace: src.GetNamespace(), Name: src.GetName()}
+
+	r.srcDigestLock.Lock()
+	defer r.srcDigestLock.Unlock()
+
+	if cached, ok := r.srcDigestCache[nnm]; ok && cached.obj == src {
+		return cached.digest
+	}
+	digest := object.Digest(src)
+	r.srcDigestCache[nnm] = srcDigest{obj: src, digest: digest}
+	return digest
 }
 
 func hasFinalizers(inst *unstructured.Unstructured) bool {
@@ -684,6 +684,46 @@ var _ = Describe("Basic propagation", func() {
 	})
 })
 
+var _ = Describe("AllowPropagate mode", func() {
+	ctx := context.Background()
+
+	var (
+		fooName string
+		barName string
+	)
+
+	BeforeEach(func() {
+		fooName = createNS(ctx, "foo")
+		barName = createNS(ctx, "bar")
+		cleanupObjects(ctx)
+		addToHNCConfig(ctx, "", "secrets", api.AllowPropagate)
+	})
+
+	AfterEach(func() {
+		resetHNCConfigToDefault(ctx)
+		cleanupObjects(ctx)
+	})
+
+	It("should propagate a source object that carries the allow-propagate annotation", func() {
+		setParent(ctx, barName, fooName)
+		makeObjectWithAnnotation(ctx, "secrets", fooName, "foo-sec", map[string]string{
+			api.AllowPropagateAnnotation: "true",
+		})
+
+		Eventually(hasObject(ctx, "secrets", barName, "foo-sec")).Should(BeTrue())
+		Expect(objectInheritedFrom(ctx, "secrets", barName, "foo-sec")).Should(Equal(fooName))
+	})
+
+	It("should not propagate a source object without the allow-propagate annotation", func() {
+		setParent(ctx, barName, fooName)
+		makeObject(ctx, "secrets", fooName, "foo-sec")
+
+		// Give the reconciler some time to propagate the object if it's going to.
+		time.Sleep(500 * time.Millisecond)
+		Eventually(hasObject(ctx, "secrets", barName, "foo-sec")).Should(BeFalse())
+	})
+})
+
 func newOrGetHierarchy(ctx context.Context, nm string) *api.HierarchyConfiguration {
 	hier := &api.HierarchyConfiguration{}
 	hier.ObjectMeta.Namespace = nm
@@ -0,0 +1,62 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcilers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/metadata"
+)
+
+// maxPreviousParents is the number of prior automatic parent overwrites kept in
+// api.AnnotationPreviousParents before the oldest entries are dropped.
+const maxPreviousParents = 5
+
+// previousParent is a single entry in the api.AnnotationPreviousParents history.
+type previousParent struct {
+	Parent string    `json:"parent"`
+	Time   time.Time `json:"time"`
+}
+
+// backupPreviousParent records oldParent into api.AnnotationPreviousParents on inst before it's
+// overwritten, so the change can be diagnosed or manually undone later. It's a best-effort record:
+// if the existing annotation can't be parsed (e.g. it was hand-edited), it's simply replaced
+// rather than blocking the reconcile.
+func backupPreviousParent(log logr.Logger, inst *api.HierarchyConfiguration, oldParent string) {
+	var history []previousParent
+	if raw, ok := metadata.GetAnnotation(inst, api.AnnotationPreviousParents); ok {
+		if err := json.Unmarshal([]byte(raw), &history); err != nil {
+			log.V(1).Info("Couldn't parse existing parent backup history; discarding it", "error", err)
+			history = nil
+		}
+	}
+
+	history = append([]previousParent{{Parent: oldParent, Time: time.Now().UTC()}}, history...)
+	if len(history) > maxPreviousParents {
+		history = history[:maxPreviousParents]
+	}
+
+	raw, err := json.Marshal(history)
+	if err != nil {
+		log.Error(err, "Couldn't serialize parent backup history; skipping it")
+		return
+	}
+	metadata.SetAnnotation(inst, api.AnnotationPreviousParents, string(raw))
+}
@@ -0,0 +1,70 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcilers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/forest"
+)
+
+// PropagationPolicyReconciler keeps the forest's copy of each namespace's PropagationPolicy specs
+// up to date, so ObjectReconciler can consult them without making its own apiserver calls.
+type PropagationPolicyReconciler struct {
+	client.Client
+	Log logr.Logger
+
+	Forest *forest.Forest
+}
+
+// +kubebuilder:rbac:groups=hnc.x-k8s.io,resources=propagationpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=hnc.x-k8s.io,resources=propagationpolicies/status,verbs=get;update;patch
+
+func (r *PropagationPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := loggerWithRID(r.Log).WithValues("trigger", req.NamespacedName)
+
+	ppl := &api.PropagationPolicyList{}
+	if err := r.List(ctx, ppl, client.InNamespace(req.Namespace)); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "while listing PropagationPolicies")
+			return ctrl.Result{}, err
+		}
+	}
+
+	specs := make([]api.PropagationPolicySpec, len(ppl.Items))
+	for i, pp := range ppl.Items {
+		specs[i] = pp.Spec
+	}
+
+	r.Forest.Lock()
+	defer r.Forest.Unlock()
+	ns := r.Forest.Get(req.Namespace)
+	ns.SetPropagationPolicies(specs)
+
+	return ctrl.Result{}, nil
+}
+
+func (r *PropagationPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&api.PropagationPolicy{}).
+		Complete(r)
+}
@@ -0,0 +1,198 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/forest"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/metadata"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/object"
+)
+
+// propagationReportRefreshInterval is how often PropagationReportReconciler recomputes a
+// PropagationReport's status. There's no event that reliably signals "a propagated copy just
+// went stale" - that's exactly the kind of divergence this report exists to surface - so it's
+// refreshed on a timer instead of purely in response to watches.
+const propagationReportRefreshInterval = 30 * time.Second
+
+// PropagationReportReconciler keeps a PropagationReport's status up to date with a summary of
+// how well HNC is keeping up with propagating objects into its namespace's subtree.
+//
+// It cross-checks the forest's record of source objects (of types in "Propagate" mode) against
+// what's actually readable on the apiserver in each descendant namespace: a missing copy counts as
+// a pending write, and a copy whose content digest (object.Digest) differs from its source's
+// counts as a failure, aged by the api.AnnotationPropagatedAt annotation ObjectReconciler writes.
+// It compares against the untransformed source and doesn't check selectors, PropagationPolicies,
+// finalizers or library subscriptions, so a copy rewritten by a Transform counts as a failure and
+// a descendant that ObjectReconciler deliberately skips counts as a pending write.
+type PropagationReportReconciler struct {
+	client.Client
+	Log logr.Logger
+
+	Forest *forest.Forest
+}
+
+// +kubebuilder:rbac:groups=hnc.x-k8s.io,resources=propagationreports,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=hnc.x-k8s.io,resources=propagationreports/status,verbs=get;update;patch
+
+func (r *PropagationReportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := loggerWithRID(r.Log).WithValues("trigger", req.NamespacedName)
+
+	inst := &api.PropagationReport{}
+	if err := r.Get(ctx, req.NamespacedName, inst); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.V(1).Info("PropagationReport has been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	summary, err := r.summarizeSubtree(ctx, log, req.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := metav1.Now()
+	inst.Status.LastFullSyncTime = &now
+	inst.Status.PendingWrites = summary.pendingWrites
+	inst.Status.Failures = summary.failures
+	inst.Status.OldestOutOfDateCopy = summary.oldestOutOfDate
+	inst.Status.OldestOutOfDateCopyAge = summary.oldestOutOfDateAge
+	if err := r.Status().Update(ctx, inst); err != nil {
+		log.Error(err, "while updating status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: propagationReportRefreshInterval}, nil
+}
+
+// propagationSummary is the result of walking a subtree's expected propagated copies.
+type propagationSummary struct {
+	pendingWrites      int
+	failures           int
+	oldestOutOfDate    string
+	oldestOutOfDateAge *metav1.Duration
+}
+
+// summarizeSubtree walks every source object in root's subtree and checks whether each of its
+// expected propagated copies exists on the apiserver and matches its source's content.
+func (r *PropagationReportReconciler) summarizeSubtree(ctx context.Context, log logr.Logger, root string) (propagationSummary, error) {
+	r.Forest.Lock()
+	namespaces := append([]string{root}, r.namespaceOrEmpty(root).DescendantNames()...)
+	var typeSyncers []forest.TypeSyncer
+	for _, t := range r.Forest.GetTypeSyncers() {
+		if t.GetMode() == api.Propagate {
+			typeSyncers = append(typeSyncers, t)
+		}
+	}
+	// Copy out everything we need from the forest before releasing the lock, since the apiserver
+	// calls below shouldn't be made while holding it.
+	type sourceObject struct {
+		obj         *unstructured.Unstructured
+		descendants []string
+	}
+	var sources []sourceObject
+	for _, t := range typeSyncers {
+		gvk := t.GetGVK()
+		for _, nsnm := range namespaces {
+			ns := r.Forest.Get(nsnm)
+			if ns == nil {
+				continue
+			}
+			for _, src := range ns.GetSourceObjects(gvk) {
+				sources = append(sources, sourceObject{obj: src.DeepCopy(), descendants: ns.DescendantNames()})
+			}
+		}
+	}
+	r.Forest.Unlock()
+
+	summary := propagationSummary{}
+	var oldestAge time.Duration
+	for _, s := range sources {
+		gvk := s.obj.GroupVersionKind()
+		for _, descNs := range s.descendants {
+			copyInst := &unstructured.Unstructured{}
+			copyInst.SetGroupVersionKind(gvk)
+			nsn := types.NamespacedName{Namespace: descNs, Name: s.obj.GetName()}
+			if err := r.Get(ctx, nsn, copyInst); err != nil {
+				if apierrors.IsNotFound(err) {
+					summary.pendingWrites++
+					continue
+				}
+				return propagationSummary{}, err
+			}
+
+			if object.Digest(s.obj) == object.Digest(copyInst) {
+				continue
+			}
+			summary.failures++
+
+			age := r.staleness(log, copyInst)
+			if summary.oldestOutOfDate == "" || age > oldestAge {
+				oldestAge = age
+				summary.oldestOutOfDate = fmt.Sprintf("%s/%s (%s)", descNs, copyInst.GetName(), gvk.Kind)
+			}
+		}
+	}
+	if summary.oldestOutOfDate != "" {
+		summary.oldestOutOfDateAge = &metav1.Duration{Duration: oldestAge}
+	}
+
+	return summary, nil
+}
+
+// staleness returns how long it's been since HNC last successfully wrote copyInst, based on
+// api.AnnotationPropagatedAt. It returns 0 if the annotation is missing or unparseable, which
+// undercounts staleness rather than crashing on an object HNC didn't write.
+func (r *PropagationReportReconciler) staleness(log logr.Logger, copyInst *unstructured.Unstructured) time.Duration {
+	raw, ok := metadata.GetAnnotation(copyInst, api.AnnotationPropagatedAt)
+	if !ok {
+		return 0
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		log.V(1).Info("Couldn't parse propagated-at annotation", "value", raw, "error", err)
+		return 0
+	}
+	return time.Since(t)
+}
+
+// namespaceOrEmpty returns the forest.Namespace for nm, or a freshly-zeroed one (with no
+// descendants) if the forest doesn't know about it yet. Caller must hold the forest lock.
+func (r *PropagationReportReconciler) namespaceOrEmpty(nm string) *forest.Namespace {
+	if ns := r.Forest.Get(nm); ns != nil {
+		return ns
+	}
+	return &forest.Namespace{}
+}
+
+func (r *PropagationReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&api.PropagationReport{}).
+		Complete(r)
+}
@@ -0,0 +1,108 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcilers
+
+import (
+	"math"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+)
+
+// scaleQuotaObject shrinks the numeric quantities in a propagated ResourceQuota or LimitRange by
+// api.AnnotationScaleQuotaFactor, raised to the power of depth - the number of tree levels between
+// the source namespace and the descendant inst is being propagated into. This turns a plain copy
+// into a progressively smaller budget the deeper it's inherited, without requiring the full
+// HierarchicalResourceQuota type. It's a no-op for any other kind, or if the source doesn't carry
+// a valid factor.
+func scaleQuotaObject(inst, srcInst *unstructured.Unstructured, depth int) {
+	factor, ok := scaleQuotaFactor(srcInst)
+	if !ok {
+		return
+	}
+	mult := math.Pow(factor, float64(depth))
+
+	switch inst.GetKind() {
+	case "ResourceQuota":
+		scaleQuantityMap(inst.Object, mult, "spec", "hard")
+	case "LimitRange":
+		scaleLimitRangeItems(inst, mult)
+	}
+}
+
+// scaleQuotaFactor returns the value of api.AnnotationScaleQuotaFactor on inst, if it's set and
+// parses as a float64 in the range (0, 1].
+func scaleQuotaFactor(inst *unstructured.Unstructured) (float64, bool) {
+	s, ok := inst.GetAnnotations()[api.AnnotationScaleQuotaFactor]
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f <= 0 || f > 1 {
+		return 0, false
+	}
+	return f, true
+}
+
+// scaleQuantityMap scales every value of the map of quantity strings found at the given field
+// path (e.g. spec.hard) by mult, in place.
+func scaleQuantityMap(obj map[string]interface{}, mult float64, fields ...string) {
+	m, found, err := unstructured.NestedStringMap(obj, fields...)
+	if err != nil || !found {
+		return
+	}
+	for k, v := range m {
+		if scaled, ok := scaleQuantityString(v, mult); ok {
+			m[k] = scaled
+		}
+	}
+	_ = unstructured.SetNestedStringMap(obj, m, fields...)
+}
+
+// scaleLimitRangeItems scales the max, min, default and defaultRequest quantities of every item in
+// a LimitRange's spec.limits by mult. maxLimitRequestRatio is left alone since it's already a
+// ratio rather than an absolute budget.
+func scaleLimitRangeItems(inst *unstructured.Unstructured, mult float64) {
+	items, found, err := unstructured.NestedSlice(inst.Object, "spec", "limits")
+	if err != nil || !found {
+		return
+	}
+	for _, item := range items {
+		limit, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, field := range []string{"max", "min", "default", "defaultRequest"} {
+			scaleQuantityMap(limit, mult, field)
+		}
+	}
+	_ = unstructured.SetNestedSlice(inst.Object, items, "spec", "limits")
+}
+
+// scaleQuantityString parses s as a resource.Quantity, multiplies it by mult, and returns its
+// canonical string form. It returns false if s doesn't parse as a quantity.
+func scaleQuantityString(s string, mult float64) (string, bool) {
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return "", false
+	}
+	scaled := int64(math.Round(float64(q.MilliValue()) * mult))
+	nq := resource.NewMilliQuantity(scaled, q.Format)
+	return nq.String(), true
+}
@@ -10,7 +10,6 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
-	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/forest"
@@ -42,8 +41,8 @@ var deleteCRDClient deleteCRDClientType
 //
 // This function is called both from main.go as well as from the integ tests.
 func Create(mgr ctrl.Manager, f *forest.Forest, maxReconciles int, useFakeClient bool) error {
-	hcChan := make(chan event.GenericEvent)
-	anchorChan := make(chan event.GenericEvent)
+	hcChan := newAffectedChannel()
+	anchorChan := newAffectedChannel()
 
 	// Create uncached client for CRD deletion check
 	if !useFakeClient {
@@ -72,11 +71,12 @@ func Create(mgr ctrl.Manager, f *forest.Forest, maxReconciles int, useFakeClient
 
 	// Create the HierarchyConfigReconciler with a pointer to the Anchor reconciler.
 	hcr := &HierarchyConfigReconciler{
-		Client:   mgr.GetClient(),
-		Log:      ctrl.Log.WithName("reconcilers").WithName("Hierarchy"),
-		Forest:   f,
-		sar:      sar,
-		Affected: hcChan,
+		Client:            mgr.GetClient(),
+		Log:               ctrl.Log.WithName("reconcilers").WithName("Hierarchy"),
+		Forest:            f,
+		sar:               sar,
+		Affected:          hcChan,
+		affectedCoalescer: newAffectedCoalescer(),
 	}
 	if err := hcr.SetupWithManager(mgr, maxReconciles); err != nil {
 		return fmt.Errorf("cannot create Hierarchy reconciler: %s", err.Error())
@@ -88,13 +88,52 @@ func Create(mgr ctrl.Manager, f *forest.Forest, maxReconciles int, useFakeClient
 		Log:                    ctrl.Log.WithName("reconcilers").WithName("HNCConfiguration"),
 		Manager:                mgr,
 		Forest:                 f,
-		Trigger:                make(chan event.GenericEvent),
+		Trigger:                newAffectedChannel(),
 		HierarchyConfigUpdates: hcChan,
 	}
 	if err := hnccrSingleton.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("cannot create Config reconciler: %s", err.Error())
 	}
 
+	// Create the HierarchicalResourceQuotaReconciler.
+	hrqr := &HierarchicalResourceQuotaReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("reconcilers").WithName("HierarchicalResourceQuota"),
+		Forest: f,
+	}
+	if err := hrqr.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("cannot create HierarchicalResourceQuota reconciler: %s", err.Error())
+	}
+
+	// Create the PropagationPolicyReconciler.
+	ppr := &PropagationPolicyReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("reconcilers").WithName("PropagationPolicy"),
+		Forest: f,
+	}
+	if err := ppr.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("cannot create PropagationPolicy reconciler: %s", err.Error())
+	}
+
+	// Create the PropagationReportReconciler.
+	prr := &PropagationReportReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("reconcilers").WithName("PropagationReport"),
+		Forest: f,
+	}
+	if err := prr.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("cannot create PropagationReport reconciler: %s", err.Error())
+	}
+
+	// Create the ManagedNamespaceReconciler.
+	mnr := &ManagedNamespaceReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("reconcilers").WithName("ManagedNamespace"),
+	}
+	if err := mnr.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("cannot create ManagedNamespace reconciler: %s", err.Error())
+	}
+
 	return nil
 }
 
@@ -232,7 +232,9 @@ func resetHNCConfigToDefault(ctx context.Context) {
 		if err != nil {
 			return err
 		}
-		c.Spec = api.HNCConfigurationSpec{}
+		// AllowSecretPropagation is set because this suite uses "secrets" as its default example of a
+		// non-enforced, non-RBAC type throughout.
+		c.Spec = api.HNCConfigurationSpec{AllowSecretPropagation: true}
 		c.Status = api.HNCConfigurationStatus{}
 		return k8sClient.Update(ctx, c)
 	}).Should(Succeed(), "While resetting HNC config")
@@ -263,6 +265,17 @@ func addToHNCConfig(ctx context.Context, group, resource string, mode api.Synchr
 	}).Should(Succeed(), "While adding %s/%s=%s to HNC config", group, resource, mode)
 }
 
+func addLibraryToHNCConfig(ctx context.Context, namespace string, subtrees []string) {
+	EventuallyWithOffset(1, func() error {
+		c, err := getHNCConfig(ctx)
+		if err != nil {
+			return err
+		}
+		c.Spec.Libraries = append(c.Spec.Libraries, api.LibrarySpec{Namespace: namespace, Subtrees: subtrees})
+		return updateHNCConfig(ctx, c)
+	}).Should(Succeed(), "While adding library %s (subtrees %v) to HNC config", namespace, subtrees)
+}
+
 // hasObject returns true if a namespace contains a specific object of the given kind.
 //  The kind and its corresponding GVK should be included in the GVKs map.
 func hasObject(ctx context.Context, resource string, nsName, name string) func() bool {
@@ -0,0 +1,46 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package snapshot serves a read-only HTTP/JSON dump of the forest, so external tooling (e.g.
+// cost-allocation or policy engines) can consume the whole hierarchy without listing every
+// HierarchyConfiguration object.
+package snapshot
+
+import (
+	"encoding/json"
+	"net/http"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/forest"
+)
+
+// Path is where the snapshot is served, relative to the metrics server (see --metrics-addr).
+const Path = "/api/v1/forest"
+
+// Create registers the forest snapshot handler on mgr's metrics server. This function is called
+// from main.go.
+func Create(mgr ctrl.Manager, f *forest.Forest) error {
+	return mgr.AddMetricsExtraHandler(Path, newHandler(f))
+}
+
+func newHandler(f *forest.Forest) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(f.Snapshot()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	ocstats "go.opencensus.io/stats"
+)
+
+// certExpiryWarningWindow is how far ahead of the webhook serving cert's expiry HNC starts
+// warning about it, giving operators time to notice and investigate before webhooks start
+// rejecting every request.
+const certExpiryWarningWindow = 7 * 24 * time.Hour
+
+// certHealth tracks the health of the webhook serving cert, so it can be reported both as metrics
+// (see RecordCertSetupDuration/RecordCertExpiry) and as a condition on the HNCConfiguration
+// singleton (see CertHealth, used by the ConfigReconciler).
+var certHealth struct {
+	sync.Mutex
+	setupDone   bool
+	setupSlow   bool
+	setupTook   time.Duration
+	expiryKnown bool
+	expiry      time.Time
+}
+
+// RecordCertSetupDuration records how long it took the webhook serving cert to become ready at
+// startup, and whether that exceeded the "slow setup" threshold used to decide whether to warn.
+func RecordCertSetupDuration(took time.Duration, slow bool) {
+	certHealth.Lock()
+	certHealth.setupDone = true
+	certHealth.setupSlow = slow
+	certHealth.setupTook = took
+	certHealth.Unlock()
+
+	ocstats.Record(context.Background(), certSetupSeconds.M(int64(took.Seconds())))
+}
+
+// RecordCertExpiry records the expiry time of the webhook serving cert currently in use.
+func RecordCertExpiry(notAfter time.Time) {
+	certHealth.Lock()
+	certHealth.expiryKnown = true
+	certHealth.expiry = notAfter
+	certHealth.Unlock()
+
+	ocstats.Record(context.Background(), certExpirySeconds.M(int64(time.Until(notAfter).Seconds())))
+}
+
+// CertHealth reports whether the webhook serving cert is currently healthy. If it isn't, reason
+// and msg describe why, in the same style as the reason/message pair on an api.Condition.
+func CertHealth() (ok bool, reason, msg string) {
+	certHealth.Lock()
+	defer certHealth.Unlock()
+
+	if certHealth.setupDone && certHealth.setupSlow {
+		return false, "SlowCertSetup", fmt.Sprintf("Webhook serving cert took %s to become ready at startup", certHealth.setupTook.Round(time.Second))
+	}
+	if certHealth.expiryKnown && time.Until(certHealth.expiry) < certExpiryWarningWindow {
+		return false, "CertExpiringSoon", fmt.Sprintf("Webhook serving cert expires at %s", certHealth.expiry.Format(time.RFC3339))
+	}
+	return true, "", ""
+}
@@ -15,3 +15,7 @@ func (c *counter) decr() {
 	atomic.AddInt32(&i, -1)
 	*c = counter(i)
 }
+
+func (c *counter) set(v int) {
+	atomic.StoreInt32((*int32)(c), int32(v))
+}
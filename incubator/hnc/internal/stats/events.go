@@ -0,0 +1,49 @@
+package stats
+
+import "sync"
+
+// eventChannel tracks how a single bounded event.GenericEvent channel - used to enqueue
+// additional objects or namespaces for reconciliation - is holding up under load.
+type eventChannel struct {
+	enqueued counter
+	dropped  counter
+	length   counter
+}
+
+var (
+	eventChannelsLock sync.Mutex
+	eventChannels     = map[string]*eventChannel{}
+)
+
+// RecordEventEnqueued records that an event was successfully enqueued onto the named channel,
+// along with the channel's length immediately afterwards, so operators can see how close it is to
+// filling up before events start being dropped.
+func RecordEventEnqueued(name string, length int) {
+	ec := getEventChannel(name)
+	ec.enqueued.incr()
+	ec.length.set(length)
+
+	recordChannelMetric(ec.enqueued, eventsEnqueuedTotal, name)
+	recordChannelMetric(ec.length, eventsChannelLength, name)
+}
+
+// RecordEventDropped records that an event was dropped because the named channel's buffer was
+// full - i.e. its consumer fell far enough behind that HNC gave up waiting rather than block the
+// sender or leak a goroutine to wait on its behalf.
+func RecordEventDropped(name string) {
+	ec := getEventChannel(name)
+	ec.dropped.incr()
+
+	recordChannelMetric(ec.dropped, eventsDroppedTotal, name)
+}
+
+func getEventChannel(name string) *eventChannel {
+	eventChannelsLock.Lock()
+	defer eventChannelsLock.Unlock()
+	ec, ok := eventChannels[name]
+	if !ok {
+		ec = &eventChannel{}
+		eventChannels[name] = ec
+	}
+	return ec
+}
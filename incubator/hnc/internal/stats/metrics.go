@@ -23,15 +23,33 @@ var (
 
 // Create Measures. A measure represents a metric type to be recorded.
 var (
-	hierConfigReconcileTotal      = ocstats.Int64("hierconfig_reconcile_total", "The total number of HierConfig reconciliations happened", "reconciliations")
-	hierConfigReconcileConcurrent = ocstats.Int64("hierconfig_reconcile_concurrent_peak", "The peak concurrent HierConfig reconciliations happened in the last reporting period", "reconciliations")
-	hierConfigWritesTotal         = ocstats.Int64("hierconfig_writes_total", "The number of HierConfig writes happened during HierConfig reconciliations", "writes")
-	namespaceWritesTotal          = ocstats.Int64("namespace_writes_total", "The number of namespace writes happened during HierConfig reconciliations", "writes")
-	objectReconcileTotal          = ocstats.Int64("object_reconcile_total", "The total number of object reconciliations happened", "reconciliations")
-	objectReconcileConcurrent     = ocstats.Int64("object_reconcile_concurrent_peak", "The peak concurrent object reconciliations happened in the last reporting period", "reconciliations")
-	objectWritesTotal             = ocstats.Int64("object_writes_total", "The number of object writes happened during object reconciliations", "writes")
-	namespaceConditions           = ocstats.Int64("namespace_conditions", "The number of namespaces with conditions", "conditions")
-	objectOverwritesTotal         = ocstats.Int64("object_overwrites_total", "The number of overwritten objects", "overwrites")
+	hierConfigReconcileTotal        = ocstats.Int64("hierconfig_reconcile_total", "The total number of HierConfig reconciliations happened", "reconciliations")
+	hierConfigReconcileConcurrent   = ocstats.Int64("hierconfig_reconcile_concurrent_peak", "The peak concurrent HierConfig reconciliations happened in the last reporting period", "reconciliations")
+	hierConfigWritesTotal           = ocstats.Int64("hierconfig_writes_total", "The number of HierConfig writes happened during HierConfig reconciliations", "writes")
+	namespaceWritesTotal            = ocstats.Int64("namespace_writes_total", "The number of namespace writes happened during HierConfig reconciliations", "writes")
+	objectReconcileTotal            = ocstats.Int64("object_reconcile_total", "The total number of object reconciliations happened", "reconciliations")
+	objectReconcileConcurrent       = ocstats.Int64("object_reconcile_concurrent_peak", "The peak concurrent object reconciliations happened in the last reporting period", "reconciliations")
+	objectWritesTotal               = ocstats.Int64("object_writes_total", "The number of object writes happened during object reconciliations", "writes")
+	namespaceConditions             = ocstats.Int64("namespace_conditions", "The number of namespaces with conditions", "conditions")
+	objectOverwritesTotal           = ocstats.Int64("object_overwrites_total", "The number of overwritten objects", "overwrites")
+	objectPropagatedBytes           = ocstats.Int64("object_propagated_bytes", "The approximate aggregate size of all objects propagated by HNC", "bytes")
+	eventsEnqueuedTotal             = ocstats.Int64("events_enqueued_total", "The number of events successfully enqueued onto an internal reconciler channel", "events")
+	eventsDroppedTotal              = ocstats.Int64("events_dropped_total", "The number of events dropped because an internal reconciler channel's buffer was full", "events")
+	eventsChannelLength             = ocstats.Int64("events_channel_length", "The number of events currently buffered in an internal reconciler channel", "events")
+	certSetupSeconds                = ocstats.Int64("cert_setup_seconds", "How long it took the webhook serving cert to become ready at startup", "s")
+	certExpirySeconds               = ocstats.Int64("cert_expiry_seconds", "The number of seconds until the webhook serving cert expires; negative if it's already expired", "s")
+	effectiveQPS                    = ocstats.Float64("effective_qps", "The current QPS AdaptivePacer is allowing to the apiserver, after any throttling-driven backoff", "qps")
+	subtreePropagationTotal         = ocstats.Int64("subtree_propagation_total", "The number of objects propagated into namespaces under a subtree root", "propagations")
+	subtreePropagationErrorsTotal   = ocstats.Int64("subtree_propagation_errors_total", "The number of errors propagating objects into namespaces under a subtree root", "errors")
+	hncConfigStatusBytes            = ocstats.Int64("hncconfig_status_bytes", "The approximate size of the HNCConfiguration singleton's status", "bytes")
+	objectWriteFailuresTotal        = ocstats.Int64("object_write_failures_total", "The number of failed writes while propagating objects", "failures")
+	objectPropagationLatencySeconds = ocstats.Float64("object_propagation_latency_seconds", "How long it took from noticing a source change to successfully writing the propagated copy", "s")
+	objectPropagatedCount           = ocstats.Int64("object_propagated_count", "The current number of objects of a GroupKind that are propagated somewhere in the forest", "objects")
+	forestNamespacesTotal           = ocstats.Int64("forest_namespaces_total", "The current number of namespaces in the in-memory forest", "namespaces")
+	objectManagerConflictsTotal     = ocstats.Int64("object_manager_conflicts_total", "The number of times a propagated copy was found to have last been written by a different HNC instance", "conflicts")
+	subtreeNamespacesTotal          = ocstats.Int64("subtree_namespaces_total", "The current number of namespaces in the subtree rooted at a tracked subtree root", "namespaces")
+	subtreePropagatedObjectsTotal   = ocstats.Int64("subtree_propagated_objects_total", "The current number of propagated objects in the subtree rooted at a tracked subtree root", "objects")
+	subtreeSourceObjectsTotal       = ocstats.Int64("subtree_source_objects_total", "The current number of source objects in the subtree rooted at a tracked subtree root", "objects")
 )
 
 // Create Tags. Tags are used to group and filter collected metrics later on.
@@ -46,6 +64,15 @@ var KeyNamespaceConditionType, _ = tag.NewKey("Condition")
 // The values could be "InCycle", "ParentMissing", etc.
 var KeyNamespaceConditionReason, _ = tag.NewKey("Reason")
 
+// KeyChannel identifies which internal reconciler channel (e.g. "hierarchyconfig-affected") an
+// event metric is about.
+var KeyChannel, _ = tag.NewKey("Channel")
+
+// KeySubtreeRoot identifies the subtree root a propagated object's namespace belongs to. Only
+// roots named by the --track-subtree-root flag get their own value here; every other root is
+// folded into otherSubtreeRoot so this tag's cardinality stays bounded by that configured budget.
+var KeySubtreeRoot, _ = tag.NewKey("SubtreeRoot")
+
 // Create Views. Views are the coupling of an Aggregation applied to a Measure and
 // optionally Tags. Views are the connection to Metric exporters.
 var (
@@ -116,6 +143,145 @@ var (
 		Aggregation: ocview.LastValue(),
 		TagKeys:     []tag.Key{KeyGroupKind},
 	}
+
+	objectPropagatedBytesView = &ocview.View{
+		Name:        "hnc/reconcilers/object/propagated_bytes",
+		Measure:     objectPropagatedBytes,
+		Description: "The approximate aggregate size of all objects propagated by HNC",
+		Aggregation: ocview.LastValue(),
+		TagKeys:     []tag.Key{KeyGroupKind},
+	}
+
+	eventsEnqueuedTotalView = &ocview.View{
+		Name:        "hnc/reconcilers/events/enqueued_total",
+		Measure:     eventsEnqueuedTotal,
+		Description: "The number of events successfully enqueued onto an internal reconciler channel",
+		Aggregation: ocview.LastValue(),
+		TagKeys:     []tag.Key{KeyChannel},
+	}
+
+	eventsDroppedTotalView = &ocview.View{
+		Name:        "hnc/reconcilers/events/dropped_total",
+		Measure:     eventsDroppedTotal,
+		Description: "The number of events dropped because an internal reconciler channel's buffer was full",
+		Aggregation: ocview.LastValue(),
+		TagKeys:     []tag.Key{KeyChannel},
+	}
+
+	eventsChannelLengthView = &ocview.View{
+		Name:        "hnc/reconcilers/events/channel_length",
+		Measure:     eventsChannelLength,
+		Description: "The number of events currently buffered in an internal reconciler channel",
+		Aggregation: ocview.LastValue(),
+		TagKeys:     []tag.Key{KeyChannel},
+	}
+
+	certSetupSecondsView = &ocview.View{
+		Name:        "hnc/certs/setup_seconds",
+		Measure:     certSetupSeconds,
+		Description: "How long it took the webhook serving cert to become ready at startup",
+		Aggregation: ocview.LastValue(),
+	}
+
+	certExpirySecondsView = &ocview.View{
+		Name:        "hnc/certs/expiry_seconds",
+		Measure:     certExpirySeconds,
+		Description: "The number of seconds until the webhook serving cert expires; negative if it's already expired",
+		Aggregation: ocview.LastValue(),
+	}
+
+	effectiveQPSView = &ocview.View{
+		Name:        "hnc/apiserver/effective_qps",
+		Measure:     effectiveQPS,
+		Description: "The current QPS AdaptivePacer is allowing to the apiserver, after any throttling-driven backoff",
+		Aggregation: ocview.LastValue(),
+	}
+
+	subtreePropagationTotalView = &ocview.View{
+		Name:        "hnc/reconcilers/object/subtree_propagation_total",
+		Measure:     subtreePropagationTotal,
+		Description: "The number of objects propagated into namespaces under a subtree root",
+		Aggregation: ocview.LastValue(),
+		TagKeys:     []tag.Key{KeySubtreeRoot},
+	}
+
+	subtreePropagationErrorsTotalView = &ocview.View{
+		Name:        "hnc/reconcilers/object/subtree_propagation_errors_total",
+		Measure:     subtreePropagationErrorsTotal,
+		Description: "The number of errors propagating objects into namespaces under a subtree root",
+		Aggregation: ocview.LastValue(),
+		TagKeys:     []tag.Key{KeySubtreeRoot},
+	}
+
+	hncConfigStatusBytesView = &ocview.View{
+		Name:        "hnc/reconcilers/hncconfig/status_bytes",
+		Measure:     hncConfigStatusBytes,
+		Description: "The approximate size of the HNCConfiguration singleton's status",
+		Aggregation: ocview.LastValue(),
+	}
+
+	objectWriteFailuresTotalView = &ocview.View{
+		Name:        "hnc/reconcilers/object/write_failures_total",
+		Measure:     objectWriteFailuresTotal,
+		Description: "The number of failed writes while propagating objects",
+		Aggregation: ocview.LastValue(),
+		TagKeys:     []tag.Key{KeyGroupKind},
+	}
+
+	objectPropagationLatencySecondsView = &ocview.View{
+		Name:        "hnc/reconcilers/object/propagation_latency_seconds",
+		Measure:     objectPropagationLatencySeconds,
+		Description: "How long it took from noticing a source change to successfully writing the propagated copy",
+		Aggregation: ocview.Distribution(0, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120, 300),
+		TagKeys:     []tag.Key{KeyGroupKind},
+	}
+
+	objectPropagatedCountView = &ocview.View{
+		Name:        "hnc/reconcilers/object/propagated_count",
+		Measure:     objectPropagatedCount,
+		Description: "The current number of objects of a GroupKind that are propagated somewhere in the forest",
+		Aggregation: ocview.LastValue(),
+		TagKeys:     []tag.Key{KeyGroupKind},
+	}
+
+	forestNamespacesTotalView = &ocview.View{
+		Name:        "hnc/forest/namespaces_total",
+		Measure:     forestNamespacesTotal,
+		Description: "The current number of namespaces in the in-memory forest",
+		Aggregation: ocview.LastValue(),
+	}
+
+	objectManagerConflictsTotalView = &ocview.View{
+		Name:        "hnc/reconcilers/object/manager_conflicts_total",
+		Measure:     objectManagerConflictsTotal,
+		Description: "The number of times a propagated copy was found to have last been written by a different HNC instance",
+		Aggregation: ocview.LastValue(),
+		TagKeys:     []tag.Key{KeyGroupKind},
+	}
+
+	subtreeNamespacesTotalView = &ocview.View{
+		Name:        "hnc/forest/subtree_namespaces_total",
+		Measure:     subtreeNamespacesTotal,
+		Description: "The current number of namespaces in the subtree rooted at a tracked subtree root",
+		Aggregation: ocview.LastValue(),
+		TagKeys:     []tag.Key{KeySubtreeRoot},
+	}
+
+	subtreePropagatedObjectsTotalView = &ocview.View{
+		Name:        "hnc/reconcilers/object/subtree_propagated_objects_total",
+		Measure:     subtreePropagatedObjectsTotal,
+		Description: "The current number of propagated objects in the subtree rooted at a tracked subtree root",
+		Aggregation: ocview.LastValue(),
+		TagKeys:     []tag.Key{KeySubtreeRoot},
+	}
+
+	subtreeSourceObjectsTotalView = &ocview.View{
+		Name:        "hnc/reconcilers/object/subtree_source_objects_total",
+		Measure:     subtreeSourceObjectsTotal,
+		Description: "The current number of source objects in the subtree rooted at a tracked subtree root",
+		Aggregation: ocview.LastValue(),
+		TagKeys:     []tag.Key{KeySubtreeRoot},
+	}
 )
 
 // periodicPeak contains periodic peaks for concurrent reconciliations.
@@ -143,6 +309,24 @@ func startRecordingMetrics() {
 		objectWritesView,
 		namespaceConditionsView,
 		objectOverwritesTotalView,
+		objectPropagatedBytesView,
+		eventsEnqueuedTotalView,
+		eventsDroppedTotalView,
+		eventsChannelLengthView,
+		certSetupSecondsView,
+		certExpirySecondsView,
+		effectiveQPSView,
+		subtreePropagationTotalView,
+		subtreePropagationErrorsTotalView,
+		hncConfigStatusBytesView,
+		objectWriteFailuresTotalView,
+		objectPropagationLatencySecondsView,
+		objectPropagatedCountView,
+		forestNamespacesTotalView,
+		objectManagerConflictsTotalView,
+		subtreeNamespacesTotalView,
+		subtreePropagatedObjectsTotalView,
+		subtreeSourceObjectsTotalView,
 	); err != nil {
 		log.Error(err, "Failed to register the views")
 	}
@@ -166,12 +350,40 @@ func recordObjectMetric(m counter, ms *ocstats.Int64Measure, gk schema.GroupKind
 	ocstats.Record(ctx, ms.M(int64(m)))
 }
 
+// recordObjectLatencyMetric records a float measurement associated with an object's GroupKind. If
+// SuppressObjectTags isn't set, it also tags the measurement with the provided GroupKind.
+func recordObjectLatencyMetric(seconds float64, ms *ocstats.Float64Measure, gk schema.GroupKind) {
+	ctx := context.Background()
+	if !SuppressObjectTags {
+		ctx, _ = tag.New(ctx, tag.Insert(KeyGroupKind, gk.String()))
+	}
+	ocstats.Record(ctx, ms.M(seconds))
+}
+
+// recordChannelMetric records a measurement associated with a named event channel.
+func recordChannelMetric(m counter, ms *ocstats.Int64Measure, name string) {
+	ctx, _ := tag.New(context.Background(), tag.Insert(KeyChannel, name))
+	ocstats.Record(ctx, ms.M(int64(m)))
+}
+
+// recordSubtreeMetric records a measurement associated with a subtree root label, as computed by
+// subtreeRootLabel.
+func recordSubtreeMetric(m counter, ms *ocstats.Int64Measure, label string) {
+	ctx, _ := tag.New(context.Background(), tag.Insert(KeySubtreeRoot, label))
+	ocstats.Record(ctx, ms.M(int64(m)))
+}
+
 func RecordNamespaceCondition(tp, reason string, num int) {
 	ctx, _ := tag.New(context.Background(), tag.Insert(KeyNamespaceConditionType, tp))
 	ctx, _ = tag.New(ctx, tag.Insert(KeyNamespaceConditionReason, reason))
 	ocstats.Record(ctx, namespaceConditions.M(int64(num)))
 }
 
+// RecordEffectiveQPS records the QPS AdaptivePacer is currently allowing to the apiserver.
+func RecordEffectiveQPS(qps float64) {
+	ocstats.Record(context.Background(), effectiveQPS.M(qps))
+}
+
 func recordPeakConcurrentReconciles() {
 	for {
 		// This runs forever. It records and resets the peakConcurrent_ values every 1 minute,
@@ -8,17 +8,45 @@ import (
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/config"
 )
 
 type object struct {
-	totalReconciles counter
-	curReconciles   counter
-	apiWrites       counter
-	totalOverwrites counter
+	totalReconciles   counter
+	curReconciles     counter
+	apiWrites         counter
+	writeFailures     counter
+	totalOverwrites   counter
+	propagatedBytes   counter
+	propagatedObjects counter
+	managerConflicts  counter
 }
 
 type objects map[schema.GroupKind]*object
 
+// subtree holds the propagation stats for a single subtree root label, i.e. either the name of a
+// tracked subtree root or otherSubtreeRoot.
+type subtree struct {
+	propagations counter
+	errors       counter
+
+	// namespaces, propagatedObjects and sourceObjects are snapshot gauges of the subtree's current
+	// footprint, recorded periodically by ConfigReconciler. Unlike propagations/errors, they're only
+	// ever recorded for an actual tracked root, never for otherSubtreeRoot - there's no way to sum a
+	// snapshot across an unbounded set of untracked tenants without walking all of them anyway.
+	namespaces        counter
+	propagatedObjects counter
+	sourceObjects     counter
+}
+
+type subtrees map[string]*subtree
+
+// otherSubtreeRoot is the label used in place of any subtree root that the operator hasn't asked
+// to track individually (see config.IsTrackedSubtreeRoot). It keeps the SubtreeRoot tag's
+// cardinality bounded by the configured tracking budget instead of growing with every tenant.
+const otherSubtreeRoot = "<other>"
+
 type stat struct {
 	// actionID is the number of controller actions devided by idles
 	actionID counter
@@ -35,11 +63,26 @@ type stat struct {
 	// namespaceWrites is the total number of Namespace writes.
 	namespaceWrites counter
 
+	// hncConfigStatusBytes is the approximate size, in bytes, of the HNCConfiguration singleton's
+	// status as of the most recent ConfigReconciler reconciliation.
+	hncConfigStatusBytes counter
+
 	objects objects
+
+	subtrees subtrees
+
+	// forestNamespaces is the current number of namespaces in the in-memory forest.
+	forestNamespaces counter
 }
 
 var stats stat
 
+// subtreesMu guards stats.subtrees. Every other stat field is only ever read by this package's
+// own logging/metrics goroutines, but stats.subtrees is also read by SubtreeSnapshot on behalf of
+// the /api/v1/subtree-counts HTTP handler (see internal/subtreestats), so unlike the rest of this
+// file, its reads and writes need to be synchronized against each other.
+var subtreesMu sync.Mutex
+
 // StartHierConfigReconcile updates stats when hierarchyConfig
 // reconciliation starts.
 func StartHierConfigReconcile() {
@@ -107,6 +150,45 @@ func WriteObject(gvk schema.GroupVersionKind) {
 	recordObjectMetric(stats.objects[gk].apiWrites, objectWritesTotal, gk)
 }
 
+// WriteObjectFailure updates the object stats by GK when a write to propagate the object fails.
+func WriteObjectFailure(gvk schema.GroupVersionKind) {
+	gk := gvk.GroupKind()
+	stats.objects[gk].writeFailures.incr()
+
+	recordObjectMetric(stats.objects[gk].writeFailures, objectWriteFailuresTotal, gk)
+}
+
+// RecordPropagationLatency records how long it took, in seconds, from this reconcile noticing a
+// source change to successfully writing the resulting propagated copy.
+func RecordPropagationLatency(gvk schema.GroupVersionKind, seconds float64) {
+	recordObjectLatencyMetric(seconds, objectPropagationLatencySeconds, gvk.GroupKind())
+}
+
+// SetPropagatedObjectsCount records the current number of objects of this GK that this reconciler
+// has propagated somewhere in the forest.
+func SetPropagatedObjectsCount(gvk schema.GroupVersionKind, n int) {
+	gk := gvk.GroupKind()
+	stats.objects[gk].propagatedObjects.set(n)
+
+	recordObjectMetric(stats.objects[gk].propagatedObjects, objectPropagatedCount, gk)
+}
+
+// SetForestNamespaceCount records the current number of namespaces in the in-memory forest.
+func SetForestNamespaceCount(n int) {
+	stats.forestNamespaces.set(n)
+
+	recordMetric(stats.forestNamespaces, forestNamespacesTotal)
+}
+
+// RecordManagerConflict updates the object stats by GK when a propagated copy is found to have
+// last been written by a different HNC instance (see api.AnnotationManagerIdentity).
+func RecordManagerConflict(gvk schema.GroupVersionKind) {
+	gk := gvk.GroupKind()
+	stats.objects[gk].managerConflicts.incr()
+
+	recordObjectMetric(stats.objects[gk].managerConflicts, objectManagerConflictsTotal, gk)
+}
+
 // OverwriteObject updates the object stats by GK when writing the object.
 func OverwriteObject(gvk schema.GroupVersionKind) {
 	gk := gvk.GroupKind()
@@ -115,6 +197,143 @@ func OverwriteObject(gvk schema.GroupVersionKind) {
 	recordObjectMetric(stats.objects[gk].totalOverwrites, objectOverwritesTotal, gk)
 }
 
+// SetPropagatedBytes records the current aggregate size, in bytes, of all objects of this GK that
+// HNC is propagating across the cluster. It's an approximation of the apiserver/etcd storage that's
+// attributable to HNC's propagation of this type, which is most useful for large objects (e.g. CA
+// bundle ConfigMaps) that are propagated to many namespaces.
+func SetPropagatedBytes(gvk schema.GroupVersionKind, bytes int) {
+	gk := gvk.GroupKind()
+	stats.objects[gk].propagatedBytes.set(bytes)
+
+	recordObjectMetric(stats.objects[gk].propagatedBytes, objectPropagatedBytes, gk)
+}
+
+// RecordHNCConfigStatusSize records the approximate size, in bytes, of the HNCConfiguration
+// singleton's status, so operators can be warned before it grows large enough to threaten etcd's
+// per-object size limit.
+func RecordHNCConfigStatusSize(bytes int) {
+	stats.hncConfigStatusBytes.set(bytes)
+
+	recordMetric(stats.hncConfigStatusBytes, hncConfigStatusBytes)
+}
+
+// subtreeRootLabel maps a subtree root to the label it should be recorded under: itself, if the
+// operator has asked to track it via --track-subtree-root, or otherSubtreeRoot otherwise.
+func subtreeRootLabel(root string) string {
+	if config.IsTrackedSubtreeRoot(root) {
+		return root
+	}
+	return otherSubtreeRoot
+}
+
+// RecordSubtreePropagation updates stats when an object is propagated into a namespace under the
+// subtree rooted at root. It's used alongside WriteObject and OverwriteObject to give operators
+// per-tenant propagation volume without letting the metric's cardinality grow with every tenant;
+// see subtreeRootLabel.
+func RecordSubtreePropagation(root string) {
+	label := subtreeRootLabel(root)
+
+	subtreesMu.Lock()
+	if _, ok := stats.subtrees[label]; !ok {
+		stats.subtrees[label] = &subtree{}
+	}
+	stats.subtrees[label].propagations.incr()
+	propagations := stats.subtrees[label].propagations
+	subtreesMu.Unlock()
+
+	recordSubtreeMetric(propagations, subtreePropagationTotal, label)
+}
+
+// RecordSubtreePropagationError updates stats when propagating an object into a namespace under
+// the subtree rooted at root fails. See RecordSubtreePropagation for how root is mapped to a
+// label.
+func RecordSubtreePropagationError(root string) {
+	label := subtreeRootLabel(root)
+
+	subtreesMu.Lock()
+	if _, ok := stats.subtrees[label]; !ok {
+		stats.subtrees[label] = &subtree{}
+	}
+	stats.subtrees[label].errors.incr()
+	errors := stats.subtrees[label].errors
+	subtreesMu.Unlock()
+
+	recordSubtreeMetric(errors, subtreePropagationErrorsTotal, label)
+}
+
+// SetSubtreeNamespaceCount records the current number of namespaces - the root plus all its
+// descendants - in the subtree rooted at root. Unlike RecordSubtreePropagation, root must already
+// be a tracked subtree root (see config.TrackedSubtreeRoots); this is a per-tenant snapshot, not
+// an incremental counter that needs to fold untracked roots into a shared bucket.
+func SetSubtreeNamespaceCount(root string, n int) {
+	subtreesMu.Lock()
+	if _, ok := stats.subtrees[root]; !ok {
+		stats.subtrees[root] = &subtree{}
+	}
+	stats.subtrees[root].namespaces.set(n)
+	namespaces := stats.subtrees[root].namespaces
+	subtreesMu.Unlock()
+
+	recordSubtreeMetric(namespaces, subtreeNamespacesTotal, root)
+}
+
+// SetSubtreePropagatedObjectCount records the current number of propagated objects, of every
+// type, in the subtree rooted at root. See SetSubtreeNamespaceCount for how root is handled.
+func SetSubtreePropagatedObjectCount(root string, n int) {
+	subtreesMu.Lock()
+	if _, ok := stats.subtrees[root]; !ok {
+		stats.subtrees[root] = &subtree{}
+	}
+	stats.subtrees[root].propagatedObjects.set(n)
+	propagatedObjects := stats.subtrees[root].propagatedObjects
+	subtreesMu.Unlock()
+
+	recordSubtreeMetric(propagatedObjects, subtreePropagatedObjectsTotal, root)
+}
+
+// SetSubtreeSourceObjectCount records the current number of source objects, of every type, in the
+// subtree rooted at root. See SetSubtreeNamespaceCount for how root is handled.
+func SetSubtreeSourceObjectCount(root string, n int) {
+	subtreesMu.Lock()
+	if _, ok := stats.subtrees[root]; !ok {
+		stats.subtrees[root] = &subtree{}
+	}
+	stats.subtrees[root].sourceObjects.set(n)
+	sourceObjects := stats.subtrees[root].sourceObjects
+	subtreesMu.Unlock()
+
+	recordSubtreeMetric(sourceObjects, subtreeSourceObjectsTotal, root)
+}
+
+// SubtreeCounts holds a snapshot of a tracked subtree root's object footprint, for tools that
+// want it as JSON instead of scraping the equivalent OpenMetrics gauges (subtree_namespaces_total,
+// subtree_propagated_objects_total and subtree_source_objects_total).
+type SubtreeCounts struct {
+	Namespaces        int `json:"namespaces"`
+	PropagatedObjects int `json:"propagatedObjects"`
+	SourceObjects     int `json:"sourceObjects"`
+}
+
+// SubtreeSnapshot returns the most recently recorded SubtreeCounts for every tracked subtree
+// root, keyed by root name.
+func SubtreeSnapshot() map[string]SubtreeCounts {
+	subtreesMu.Lock()
+	defer subtreesMu.Unlock()
+
+	snap := make(map[string]SubtreeCounts, len(stats.subtrees))
+	for root, st := range stats.subtrees {
+		if root == otherSubtreeRoot {
+			continue
+		}
+		snap[root] = SubtreeCounts{
+			Namespaces:        int(st.namespaces),
+			PropagatedObjects: int(st.propagatedObjects),
+			SourceObjects:     int(st.sourceObjects),
+		}
+	}
+	return snap
+}
+
 func init() {
 	objects := make(map[schema.GroupKind]*object)
 	peak = periodicPeak{
@@ -125,6 +344,7 @@ func init() {
 	stats = stat{
 		actionID: 1,
 		objects:  objects,
+		subtrees: make(subtrees),
 	}
 	startRecordingMetrics()
 }
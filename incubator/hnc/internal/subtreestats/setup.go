@@ -0,0 +1,47 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package subtreestats serves a read-only HTTP/JSON dump of the same per-tenant namespace,
+// propagated-object and source-object counts as the subtree_*_total metrics, so cost-attribution
+// and capacity tools that would rather not scrape Prometheus can still get at the numbers.
+package subtreestats
+
+import (
+	"encoding/json"
+	"net/http"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/stats"
+)
+
+// Path is where the subtree counts are served, relative to the metrics server (see
+// --metrics-addr).
+const Path = "/api/v1/subtree-counts"
+
+// Create registers the subtree counts handler on mgr's metrics server. This function is called
+// from main.go.
+func Create(mgr ctrl.Manager) error {
+	return mgr.AddMetricsExtraHandler(Path, newHandler())
+}
+
+func newHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(stats.SubtreeSnapshot()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/go-logr/logr"
 	k8sadm "k8s.io/api/admission/v1"
+	authnv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
@@ -23,18 +25,27 @@ const (
 // Note: the validating webhook FAILS CLOSE. This means that if the webhook goes down, all further
 // changes are forbidden.
 //
-// +kubebuilder:webhook:admissionReviewVersions=v1;v1beta1,path=/validate-hnc-x-k8s-io-v1alpha2-subnamespaceanchors,mutating=false,failurePolicy=fail,groups="hnc.x-k8s.io",resources=subnamespaceanchors,sideEffects=None,verbs=create;delete,versions=v1alpha2,name=subnamespaceanchors.hnc.x-k8s.io
+// +kubebuilder:webhook:admissionReviewVersions=v1;v1beta1,path=/validate-hnc-x-k8s-io-v1alpha2-subnamespaceanchors,mutating=false,failurePolicy=fail,groups="hnc.x-k8s.io",resources=subnamespaceanchors,sideEffects=None,verbs=create;update;delete,versions=v1alpha2,name=subnamespaceanchors.hnc.x-k8s.io
 
 type Anchor struct {
-	Log     logr.Logger
-	Forest  *forest.Forest
+	Log    logr.Logger
+	Forest *forest.Forest
+	// server is used to check whether a user is allowed to approve a subnamespace. It's normally
+	// backed by a real apiserver connection, injected via InjectClient, but can be stubbed out for
+	// unit tests.
+	server  serverClient
 	decoder *admission.Decoder
 }
 
 // req defines the aspects of the admission.Request that we care about.
 type anchorRequest struct {
 	anchor *api.SubnamespaceAnchor
-	op     k8sadm.Operation
+	// oldAnchor is only set for Update requests. It's used to tell whether this update is the one
+	// granting SubnamespaceApprovedAnnotation, as opposed to some unrelated change to an
+	// already-approved anchor.
+	oldAnchor *api.SubnamespaceAnchor
+	op        k8sadm.Operation
+	ui        *authnv1.UserInfo
 }
 
 // Handle implements the validation webhook.
@@ -56,6 +67,19 @@ func (v *Anchor) Handle(ctx context.Context, req admission.Request) admission.Re
 		return allow("")
 	}
 
+	if isGrantingApproval(decoded) {
+		allowed, err := v.canApprove(ctx, decoded)
+		if err != nil {
+			log.Error(err, "Couldn't check subnamespace approval permission")
+			return deny(metav1.StatusReasonInternalError, err.Error())
+		}
+		if !allowed {
+			msg := fmt.Sprintf("%s requires 'update' permission on subnamespaceanchors/approval in namespace %q",
+				api.SubnamespaceApprovedAnnotation, decoded.anchor.Namespace)
+			return deny(metav1.StatusReasonForbidden, msg)
+		}
+	}
+
 	resp := v.handle(decoded)
 	if !resp.Allowed {
 		log.Info("Denied", "code", resp.Result.Code, "reason", resp.Result.Reason, "message", resp.Result.Message)
@@ -74,17 +98,24 @@ func (v *Anchor) handle(req *anchorRequest) admission.Response {
 
 	pnm := req.anchor.Namespace
 	cnm := req.anchor.Name
+	pns := v.Forest.Get(pnm)
 	cns := v.Forest.Get(cnm)
 
+	if req.op == k8sadm.Create || req.op == k8sadm.Update {
+		if resp := validateHooks(req.anchor.Spec.Hooks); !resp.Allowed {
+			return resp
+		}
+	}
+
 	switch req.op {
 	case k8sadm.Create:
 		// Can't create subnamespaces in excluded namespaces
-		if config.ExcludedNamespaces[pnm] {
+		if config.IsExcludedNamespace(pnm) {
 			msg := fmt.Sprintf("Cannot create a subnamespace in the excluded namespace %q", pnm)
 			return deny(metav1.StatusReasonForbidden, msg)
 		}
 		// Can't create subnamespaces using excluded namespace names
-		if config.ExcludedNamespaces[cnm] {
+		if config.IsExcludedNamespace(cnm) {
 			msg := fmt.Sprintf("Cannot create a subnamespace using the excluded namespace name %q", cnm)
 			return deny(metav1.StatusReasonForbidden, msg)
 		}
@@ -99,6 +130,14 @@ func (v *Anchor) handle(req *anchorRequest) admission.Response {
 			}
 		}
 
+		// Can't create more subnamespaces than the parent's own spec.maxSubnamespaces allows. This
+		// doesn't apply to recreating a missing anchor for an existing subnamespace, since that isn't
+		// growing the count.
+		if max := pns.MaxSubnamespaces(); max > 0 && !pns.HasAnchor(cnm) && len(pns.Anchors) >= max {
+			msg := fmt.Sprintf("Cannot create subnamespace %q: namespace %q already has the maximum of %d subnamespaces allowed by spec.maxSubnamespaces", cnm, pnm, max)
+			return deny(metav1.StatusReasonForbidden, msg)
+		}
+
 	case k8sadm.Delete:
 		// Don't allow the anchor to be deleted if it's in a good state and has descendants of its own,
 		// unless allowCascadingDeletion is set.
@@ -114,6 +153,33 @@ func (v *Anchor) handle(req *anchorRequest) admission.Response {
 	return allow("")
 }
 
+// validateHooks checks that each configured lifecycle hook makes sense on its own - namely, that
+// it specifies exactly one of Job or HTTP, and that its HTTP URL (if any) is non-empty. It doesn't
+// need the forest since it only looks at the anchor's own spec.
+func validateHooks(hooks *api.LifecycleHooks) admission.Response {
+	if hooks == nil {
+		return allow("")
+	}
+	for nm, hook := range map[string]*api.Hook{"postCreate": hooks.PostCreate, "preDelete": hooks.PreDelete} {
+		if hook == nil {
+			continue
+		}
+		if (hook.Job == nil) == (hook.HTTP == nil) {
+			msg := fmt.Sprintf("spec.hooks.%s must set exactly one of job or http", nm)
+			return deny(metav1.StatusReasonInvalid, msg)
+		}
+		if hook.HTTP != nil && hook.HTTP.URL == "" {
+			msg := fmt.Sprintf("spec.hooks.%s.http.url must be set", nm)
+			return deny(metav1.StatusReasonInvalid, msg)
+		}
+		if hook.TimeoutSeconds < 0 {
+			msg := fmt.Sprintf("spec.hooks.%s.timeoutSeconds must not be negative", nm)
+			return deny(metav1.StatusReasonInvalid, msg)
+		}
+	}
+	return allow("")
+}
+
 // decodeRequest gets the information we care about into a simple struct that's easy to both a) use
 // and b) factor out in unit tests.
 func (v *Anchor) decodeRequest(log logr.Logger, in admission.Request) (*anchorRequest, error) {
@@ -135,10 +201,47 @@ func (v *Anchor) decodeRequest(log logr.Logger, in admission.Request) (*anchorRe
 		return nil, err
 	}
 
-	return &anchorRequest{
+	req := &anchorRequest{
 		anchor: anchor,
 		op:     in.Operation,
-	}, nil
+		ui:     &in.UserInfo,
+	}
+
+	if in.Operation == k8sadm.Update {
+		oldAnchor := &api.SubnamespaceAnchor{}
+		if err := v.decoder.DecodeRaw(in.OldObject, oldAnchor); err != nil {
+			return nil, err
+		}
+		req.oldAnchor = oldAnchor
+	}
+
+	return req, nil
+}
+
+// isGrantingApproval returns true if this update is the one setting SubnamespaceApprovedAnnotation
+// to "true" - as opposed to a create, delete, or some unrelated update to an anchor that's already
+// approved (or was never gated behind approval in the first place).
+func isGrantingApproval(req *anchorRequest) bool {
+	if req.op != k8sadm.Update || req.oldAnchor == nil {
+		return false
+	}
+	return req.anchor.Annotations[api.SubnamespaceApprovedAnnotation] == "true" &&
+		req.oldAnchor.Annotations[api.SubnamespaceApprovedAnnotation] != "true"
+}
+
+// canApprove checks whether the user making this request is allowed to approve the subnamespace.
+// It fails closed - like the rest of this webhook - if there's no apiserver connection to check
+// against, e.g. because it's being called from a unit test.
+func (v *Anchor) canApprove(ctx context.Context, req *anchorRequest) (bool, error) {
+	if v.server == nil {
+		return false, nil
+	}
+	return v.server.CanApproveSubnamespace(ctx, req.ui, req.anchor.Namespace, req.anchor.Name)
+}
+
+func (v *Anchor) InjectClient(c client.Client) error {
+	v.server = &realClient{client: c}
+	return nil
 }
 
 func (v *Anchor) InjectDecoder(d *admission.Decoder) error {
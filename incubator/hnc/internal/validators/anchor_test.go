@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	k8sadm "k8s.io/api/admission/v1"
+	batchv1 "k8s.io/api/batch/v1"
 
 	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/config"
@@ -16,7 +17,7 @@ func TestCreateSubnamespaces(t *testing.T) {
 	// namespace "c".
 	f := foresttest.Create("-Aa")
 	h := &Anchor{Forest: f}
-	config.ExcludedNamespaces = map[string]bool{"kube-system": true}
+	config.SetExcludedNamespaces(map[string]bool{"kube-system": true})
 
 	tests := []struct {
 		name string
@@ -53,6 +54,44 @@ func TestCreateSubnamespaces(t *testing.T) {
 	}
 }
 
+func TestValidateHooks(t *testing.T) {
+	tests := []struct {
+		name  string
+		hooks *api.LifecycleHooks
+		fail  bool
+	}{
+		{name: "no hooks configured"},
+		{name: "valid http postCreate hook", hooks: &api.LifecycleHooks{
+			PostCreate: &api.Hook{HTTP: &api.HTTPHook{URL: "https://example.com/hook"}},
+		}},
+		{name: "valid job preDelete hook", hooks: &api.LifecycleHooks{
+			PreDelete: &api.Hook{Job: &batchv1.JobSpec{}},
+		}},
+		{name: "neither job nor http set", hooks: &api.LifecycleHooks{
+			PostCreate: &api.Hook{},
+		}, fail: true},
+		{name: "both job and http set", hooks: &api.LifecycleHooks{
+			PostCreate: &api.Hook{Job: &batchv1.JobSpec{}, HTTP: &api.HTTPHook{URL: "https://example.com"}},
+		}, fail: true},
+		{name: "http hook with empty url", hooks: &api.LifecycleHooks{
+			PreDelete: &api.Hook{HTTP: &api.HTTPHook{}},
+		}, fail: true},
+		{name: "negative timeout", hooks: &api.LifecycleHooks{
+			PostCreate: &api.Hook{HTTP: &api.HTTPHook{URL: "https://example.com"}, TimeoutSeconds: -1},
+		}, fail: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got := validateHooks(tc.hooks)
+
+			logResult(t, got.AdmissionResponse.Result)
+			g.Expect(got.AdmissionResponse.Allowed).ShouldNot(Equal(tc.fail))
+		})
+	}
+}
+
 func TestAllowCascadingDeleteSubnamespaces(t *testing.T) {
 	// Create a chain of namespaces from "a" to "e", with "a" as the root. Among them,
 	// "b", "d" and "e" are subnamespaces. This is set up in a long chain to test that
@@ -107,3 +146,76 @@ func TestAllowCascadingDeleteSubnamespaces(t *testing.T) {
 		})
 	}
 }
+
+func TestMaxSubnamespaces(t *testing.T) {
+	// Create namespace "a" as the root with two subnamespaces, "b" and "c".
+	f := foresttest.Create("-AA")
+	h := &Anchor{Forest: f}
+	f.Get("a").SetAnchors([]string{"b", "c"})
+
+	tests := []struct {
+		name string
+		max  int
+		cnm  string
+		fail bool
+	}{
+		{name: "no limit set", max: 0, cnm: "brumpf"},
+		{name: "under the limit", max: 3, cnm: "brumpf"},
+		{name: "at the limit", max: 2, cnm: "brumpf", fail: true},
+		{name: "at the limit but recreating a missing anchor for an existing subns", max: 2, cnm: "b"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f.Get("a").UpdateMaxSubnamespaces(tc.max)
+			defer f.Get("a").UpdateMaxSubnamespaces(0)
+
+			// Setup
+			g := NewWithT(t)
+			anchor := &api.SubnamespaceAnchor{}
+			anchor.ObjectMeta.Namespace = "a"
+			anchor.ObjectMeta.Name = tc.cnm
+			req := &anchorRequest{
+				anchor: anchor,
+				op:     k8sadm.Create,
+			}
+
+			// Test
+			got := h.handle(req)
+
+			// Report
+			logResult(t, got.AdmissionResponse.Result)
+			g.Expect(got.AdmissionResponse.Allowed).ShouldNot(Equal(tc.fail))
+		})
+	}
+}
+
+func TestIsGrantingApproval(t *testing.T) {
+	tests := []struct {
+		name      string
+		op        k8sadm.Operation
+		old, new  string
+		wantGrant bool
+	}{
+		{name: "create", op: k8sadm.Create, new: "true", wantGrant: false},
+		{name: "unset to true", op: k8sadm.Update, old: "", new: "true", wantGrant: true},
+		{name: "false to true", op: k8sadm.Update, old: "false", new: "true", wantGrant: true},
+		{name: "true to true", op: k8sadm.Update, old: "true", new: "true", wantGrant: false},
+		{name: "true to false", op: k8sadm.Update, old: "true", new: "false", wantGrant: false},
+		{name: "unrelated update", op: k8sadm.Update, old: "", new: "", wantGrant: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			newAnchor := &api.SubnamespaceAnchor{}
+			newAnchor.Annotations = map[string]string{api.SubnamespaceApprovedAnnotation: tc.new}
+			req := &anchorRequest{anchor: newAnchor, op: tc.op}
+			if tc.op == k8sadm.Update {
+				oldAnchor := &api.SubnamespaceAnchor{}
+				oldAnchor.Annotations = map[string]string{api.SubnamespaceApprovedAnnotation: tc.old}
+				req.oldAnchor = oldAnchor
+			}
+
+			g.Expect(isGrantingApproval(req)).Should(Equal(tc.wantGrant))
+		})
+	}
+}
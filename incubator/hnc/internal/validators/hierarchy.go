@@ -15,6 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -52,6 +53,10 @@ type serverClient interface {
 	// IsAdmin takes a UserInfo and the name of a namespace, and returns true if the user is an admin
 	// of that namespace (ie, can update the hierarchical config).
 	IsAdmin(ctx context.Context, ui *authnv1.UserInfo, nnm string) (bool, error)
+
+	// CanApproveSubnamespace returns true if ui is allowed to approve subnamespace anchor cnm in
+	// namespace pnm - that is, to set SubnamespaceApprovedAnnotation to "true" on it.
+	CanApproveSubnamespace(ctx context.Context, ui *authnv1.UserInfo, pnm, cnm string) (bool, error)
 }
 
 // request defines the aspects of the admission.Request that we care about.
@@ -119,14 +124,17 @@ func (v *Hierarchy) handle(ctx context.Context, log logr.Logger, req *request) a
 		return allow("HNC SA")
 	}
 
-	if config.ExcludedNamespaces[req.hc.Namespace] {
+	if config.IsExcludedNamespace(req.hc.Namespace) {
 		reason := fmt.Sprintf("Cannot set the excluded namespace %q as a child of another namespace", req.hc.Namespace)
 		return deny(metav1.StatusReasonForbidden, reason)
 	}
-	if config.ExcludedNamespaces[req.hc.Spec.Parent] {
+	if config.IsExcludedNamespace(req.hc.Spec.Parent) {
 		reason := fmt.Sprintf("Cannot set the parent to the excluded namespace %q", req.hc.Spec.Parent)
 		return deny(metav1.StatusReasonForbidden, reason)
 	}
+	if resp := checkClusterPlacement(req.hc.Spec.ClusterPlacement); !resp.Allowed {
+		return resp
+	}
 
 	// Do all checks that require holding the in-memory lock. Generate a list of server checks we
 	// should perform once the lock is released.
@@ -216,6 +224,20 @@ func (v *Hierarchy) checkParent(ns, curParent, newParent *forest.Namespace) admi
 		return deny(metav1.StatusReasonConflict, "Illegal parent: "+reason)
 	}
 
+	// Enforce the root allowlist (spec.rootAllowlist in HNCConfiguration), if configured. It
+	// restricts which namespaces may act as the root of a multi-namespace tree, i.e. have
+	// descendants while having no parent of their own - whether that's because a parentless
+	// namespace is about to gain a child, or because a namespace with existing children is about to
+	// lose its own parent.
+	if newParent != nil && newParent.Parent() == nil && !v.Forest.IsAllowedRoot(newParent.Name()) {
+		msg := fmt.Sprintf("Namespace %q is not on the root allowlist, so it cannot become the root of a multi-namespace tree", newParent.Name())
+		return deny(metav1.StatusReasonForbidden, msg)
+	}
+	if newParent == nil && ns.ChildNames() != nil && !v.Forest.IsAllowedRoot(ns.Name()) {
+		msg := fmt.Sprintf("Namespace %q is not on the root allowlist, so it cannot become the root of a multi-namespace tree", ns.Name())
+		return deny(metav1.StatusReasonForbidden, msg)
+	}
+
 	// Prevent overwriting source objects in the descendants after the hierarchy change.
 	if co := v.getConflictingObjects(newParent, ns); len(co) != 0 {
 		msg := "Cannot update hierarchy because it would overwrite the following object(s):\n"
@@ -224,6 +246,62 @@ func (v *Hierarchy) checkParent(ns, curParent, newParent *forest.Namespace) admi
 		return deny(metav1.StatusReasonConflict, msg)
 	}
 
+	// Prevent the reparented namespace, or any of its descendants, from ending up with more tree
+	// labels than the configured guardrail allows.
+	if resp := checkMaxTreeLabels(v.Forest, ns, newParent); !resp.Allowed {
+		return resp
+	}
+
+	return allow("")
+}
+
+// checkMaxTreeLabels denies the request if reparenting ns under newParent would give ns, or any of
+// its descendants, more tree labels (see api.LabelTreeDepthSuffix) than config.MaxTreeLabels
+// allows. It's a no-op if the check is disabled (the default).
+func checkMaxTreeLabels(f *forest.Forest, ns, newParent *forest.Namespace) admission.Response {
+	max := config.MaxTreeLabels()
+	if max <= 0 {
+		return allow("")
+	}
+
+	newDepth := len(newParent.AncestryNames()) + 1
+	if newDepth > max {
+		msg := fmt.Sprintf("Setting the parent of %q to %q would give it %d tree labels, which exceeds the configured maximum of %d", ns.Name(), newParent.Name(), newDepth, max)
+		return deny(metav1.StatusReasonForbidden, msg)
+	}
+
+	curDepth := len(ns.AncestryNames())
+	for _, dnm := range ns.DescendantNames() {
+		d := f.Get(dnm)
+		relDepth := len(d.AncestryNames()) - curDepth
+		if newDepth+relDepth > max {
+			msg := fmt.Sprintf("Setting the parent of %q to %q would give its descendant %q %d tree labels, which exceeds the configured maximum of %d", ns.Name(), newParent.Name(), dnm, newDepth+relDepth, max)
+			return deny(metav1.StatusReasonForbidden, msg)
+		}
+	}
+
+	return allow("")
+}
+
+// checkClusterPlacement validates the (optional) fleet-placement hint. HNC doesn't act on this
+// field itself, so all it can do is make sure it's well-formed enough that fleet tools consuming
+// it don't have to defend against garbage: no duplicate or malformed cluster names.
+func checkClusterPlacement(cp *api.ClusterPlacement) admission.Response {
+	if cp == nil {
+		return allow("")
+	}
+	seen := map[string]bool{}
+	for _, c := range cp.Clusters {
+		if errs := validation.IsDNS1123Label(c); len(errs) > 0 {
+			msg := fmt.Sprintf("Invalid cluster name %q in clusterPlacement.clusters: %s", c, strings.Join(errs, "; "))
+			return deny(metav1.StatusReasonInvalid, msg)
+		}
+		if seen[c] {
+			msg := fmt.Sprintf("Duplicate cluster name %q in clusterPlacement.clusters", c)
+			return deny(metav1.StatusReasonInvalid, msg)
+		}
+		seen[c] = true
+	}
 	return allow("")
 }
 
@@ -506,6 +584,37 @@ func (r *realClient) IsAdmin(ctx context.Context, ui *authnv1.UserInfo, nnm stri
 	return sar.Status.Allowed, err
 }
 
+// CanApproveSubnamespace implements serverClient. It checks a virtual "approval" subresource
+// rather than "update" on the anchor itself, so that a cluster admin can grant broad self-service
+// access to create anchors while still restricting who can approve them.
+func (r *realClient) CanApproveSubnamespace(ctx context.Context, ui *authnv1.UserInfo, pnm, cnm string) (bool, error) {
+	authzExtra := map[string]authzv1.ExtraValue{}
+	for k, v := range ui.Extra {
+		authzExtra[k] = (authzv1.ExtraValue)(v)
+	}
+
+	sar := &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Namespace:   pnm,
+				Verb:        "update",
+				Group:       "hnc.x-k8s.io",
+				Version:     "*",
+				Resource:    "subnamespaceanchors",
+				Subresource: "approval",
+				Name:        cnm,
+			},
+			User:   ui.Username,
+			Groups: ui.Groups,
+			UID:    ui.UID,
+			Extra:  authzExtra,
+		},
+	}
+
+	err := r.client.Create(ctx, sar)
+	return sar.Status.Allowed, err
+}
+
 // allow is a replacement for controller-runtime's admission.Allowed() that allows you to set the
 // message (human-readable) as opposed to the reason (machine-readable).
 func allow(msg string) admission.Response {
@@ -21,7 +21,7 @@ func TestStructure(t *testing.T) {
 	h := &Hierarchy{Forest: f}
 	l := zap.New()
 	// For this unit test, we only set `kube-system` as an excluded namespace.
-	config.ExcludedNamespaces = map[string]bool{"kube-system": true}
+	config.SetExcludedNamespaces(map[string]bool{"kube-system": true})
 
 	tests := []struct {
 		name        string
@@ -63,6 +63,83 @@ func TestStructure(t *testing.T) {
 	}
 }
 
+func TestMaxTreeLabels(t *testing.T) {
+	f := foresttest.Create("-a-") // a <- b; c
+	h := &Hierarchy{Forest: f}
+	l := zap.New()
+	config.SetExcludedNamespaces(map[string]bool{})
+	defer config.SetMaxTreeLabels(0)
+
+	tests := []struct {
+		name string
+		max  int
+		fail bool
+	}{
+		{name: "disabled", max: 0},
+		{name: "just enough", max: 3},
+		{name: "not enough", max: 2, fail: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			g := NewWithT(t)
+			config.SetMaxTreeLabels(tc.max)
+			hc := &api.HierarchyConfiguration{Spec: api.HierarchyConfigurationSpec{Parent: "b"}}
+			hc.ObjectMeta.Name = api.Singleton
+			hc.ObjectMeta.Namespace = "c"
+			req := &request{hc: hc}
+
+			// Test - reparenting "c" under "b" (itself a child of "a") gives "c" 3 tree labels.
+			got := h.handle(context.Background(), l, req)
+
+			// Report
+			logResult(t, got.AdmissionResponse.Result)
+			g.Expect(got.AdmissionResponse.Allowed).ShouldNot(Equal(tc.fail))
+		})
+	}
+}
+
+func TestRootAllowlist(t *testing.T) {
+	l := zap.New()
+	config.SetExcludedNamespaces(map[string]bool{})
+
+	tests := []struct {
+		name      string
+		desc      string // see foresttest.Create
+		nnm       string
+		pnm       string
+		allowlist []string
+		fail      bool
+	}{
+		{name: "disabled, new root gains a child", desc: "--", nnm: "b", pnm: "a"},
+		{name: "new root not on allowlist", desc: "--", nnm: "b", pnm: "a", allowlist: []string{"z"}, fail: true},
+		{name: "new root on allowlist", desc: "--", nnm: "b", pnm: "a", allowlist: []string{"a"}},
+		{name: "orphaning a namespace with children, not on allowlist", desc: "-ab", nnm: "b", pnm: "", allowlist: []string{"a"}, fail: true}, // a <- b <- c
+		{name: "orphaning a namespace with children, on allowlist", desc: "-ab", nnm: "b", pnm: "", allowlist: []string{"a", "b"}},
+		{name: "orphaning a childless namespace ignores allowlist", desc: "-a-", nnm: "b", pnm: "", allowlist: []string{"a"}}, // a <- b; c
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			g := NewWithT(t)
+			f := foresttest.Create(tc.desc)
+			f.SetRootAllowlist(tc.allowlist)
+			h := &Hierarchy{Forest: f}
+			hc := &api.HierarchyConfiguration{Spec: api.HierarchyConfigurationSpec{Parent: tc.pnm}}
+			hc.ObjectMeta.Name = api.Singleton
+			hc.ObjectMeta.Namespace = tc.nnm
+			req := &request{hc: hc}
+
+			// Test
+			got := h.handle(context.Background(), l, req)
+
+			// Report
+			logResult(t, got.AdmissionResponse.Result)
+			g.Expect(got.AdmissionResponse.Allowed).ShouldNot(Equal(tc.fail))
+		})
+	}
+}
+
 func TestChangeParentOnManagedBy(t *testing.T) {
 	f := foresttest.Create("-a-c") // a <- b; c <- d
 	h := &Hierarchy{Forest: f}
@@ -211,6 +288,34 @@ func logResult(t *testing.T, result *metav1.Status) {
 	t.Logf("Got reason %q, code %d, msg %q", result.Reason, result.Code, result.Message)
 }
 
+func TestClusterPlacement(t *testing.T) {
+	tests := []struct {
+		name        string
+		clusters    []string
+		fail        bool
+		msgContains string
+	}{
+		{name: "nil", clusters: nil},
+		{name: "ok", clusters: []string{"cluster-a", "cluster-b"}},
+		{name: "invalid name", clusters: []string{"Cluster_A"}, fail: true, msgContains: "Invalid cluster name"},
+		{name: "duplicate", clusters: []string{"cluster-a", "cluster-a"}, fail: true, msgContains: "Duplicate cluster name"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var cp *api.ClusterPlacement
+			if tc.clusters != nil {
+				cp = &api.ClusterPlacement{Clusters: tc.clusters}
+			}
+
+			got := checkClusterPlacement(cp)
+
+			g.Expect(got.Allowed).ShouldNot(Equal(tc.fail))
+			g.Expect(got.Result.Message).Should(ContainSubstring(tc.msgContains))
+		})
+	}
+}
+
 // fakeServer implements serverClient. It's implemented as a string separated by a colon (":") with
 // the following meanings:
 // * Anything *before* the colon passes the IsAdmin check
@@ -230,6 +335,12 @@ func (f fakeServer) IsAdmin(_ context.Context, _ *authn.UserInfo, nnm string) (b
 	return false, nil
 }
 
+// CanApproveSubnamespace isn't exercised by any test in this file; it's only here to satisfy
+// serverClient.
+func (f fakeServer) CanApproveSubnamespace(_ context.Context, _ *authn.UserInfo, _, _ string) (bool, error) {
+	return false, nil
+}
+
 func (f fakeServer) Exists(_ context.Context, nnm string) (bool, error) {
 	foundColon := false
 	for _, n := range f {
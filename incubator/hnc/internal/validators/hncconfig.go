@@ -90,9 +90,58 @@ func (c *HNCConfig) handle(ctx context.Context, inst *api.HNCConfiguration) admi
 		return rp
 	}
 
-	// Lastly, check if changing a type to "Propagate" mode would cause
-	// overwriting user-created objects.
-	return c.checkForest(inst, ts)
+	// Validate the library namespaces and their subscribing subtrees.
+	if rp := c.validateLibraries(inst); !rp.Allowed {
+		return rp
+	}
+
+	// Check if changing a type to "Propagate" mode would cause overwriting user-created objects.
+	if rp := c.checkForest(inst, ts); !rp.Allowed {
+		return rp
+	}
+
+	// Lastly, estimate how many objects this change would create or delete across the cluster, and
+	// require an explicit confirmation for high-impact changes.
+	return c.checkImpact(inst, ts)
+}
+
+// validateLibraries checks inst.Spec.Libraries against the current forest: every library
+// namespace and every subtree that subscribes to it must exist, each library namespace may only
+// be declared once, and a subtree can't subscribe to a library that's nested inside itself.
+func (c *HNCConfig) validateLibraries(inst *api.HNCConfiguration) admission.Response {
+	c.Forest.Lock()
+	defer c.Forest.Unlock()
+
+	seen := map[string]bool{}
+	for i, lib := range inst.Spec.Libraries {
+		fld := field.NewPath("spec", "libraries").Index(i).Child("namespace")
+		if lib.Namespace == "" {
+			return denyInvalid(fld, "namespace must be set")
+		}
+		if seen[lib.Namespace] {
+			return denyInvalid(fld, fmt.Sprintf("duplicate configuration for library namespace %q", lib.Namespace))
+		}
+		seen[lib.Namespace] = true
+
+		libNs := c.Forest.Get(lib.Namespace)
+		if !libNs.Exists() {
+			return denyInvalid(fld, fmt.Sprintf("namespace %q does not exist", lib.Namespace))
+		}
+
+		for j, subtree := range lib.Subtrees {
+			sfld := field.NewPath("spec", "libraries").Index(i).Child("subtrees").Index(j)
+			subtreeNs := c.Forest.Get(subtree)
+			if !subtreeNs.Exists() {
+				return denyInvalid(sfld, fmt.Sprintf("namespace %q does not exist", subtree))
+			}
+			if subtree == lib.Namespace || libNs.IsAncestor(subtreeNs) {
+				return denyInvalid(sfld, fmt.Sprintf(
+					"subtree %q cannot subscribe to library %q because the subtree root itself is the library, or is one of its ancestors",
+					subtree, lib.Namespace))
+			}
+		}
+	}
+	return allow("")
 }
 
 func (c *HNCConfig) validateTypes(inst *api.HNCConfiguration, ts gvkSet) admission.Response {
@@ -105,6 +154,15 @@ func (c *HNCConfig) validateTypes(inst *api.HNCConfiguration, ts gvkSet) admissi
 				"with 'Propagate' mode. Please remove it from the spec.", gr))
 		}
 
+		// Propagating Secrets copies their contents into every descendant namespace, which widens
+		// the set of principals who can read them. Require the admin to acknowledge that blast
+		// radius via spec.allowSecretPropagation before allowing it.
+		if r.Resource == api.SecretResource && r.Group == "" && r.Mode == api.Propagate && !inst.Spec.AllowSecretPropagation {
+			return denyInvalid(field, fmt.Sprintf("Cannot set %s to 'Propagate' mode because spec.allowSecretPropagation "+
+				"is not set to true. Propagating Secrets copies their contents into every descendant namespace; "+
+				"set spec.allowSecretPropagation to true to acknowledge this before enabling it.", gr))
+		}
+
 		// Validate the type exists in the apiserver. If yes, convert GR to GVK. We
 		// use GVK because we will need to checkForest() later to avoid source
 		// overwriting conflict (forest uses GVK as the key for object reconcilers).
@@ -204,6 +262,62 @@ func (c *HNCConfig) getNewPropagateTypes(ts gvkSet) gvkSet {
 	return newPts
 }
 
+// checkImpact estimates how many objects switching types into or out of "Propagate" mode would
+// create or delete across the cluster, and denies the change if it exceeds
+// api.LargeConfigChangeThreshold and inst doesn't carry api.ConfirmLargeConfigChangeAnnotation.
+func (c *HNCConfig) checkImpact(inst *api.HNCConfiguration, ts gvkSet) admission.Response {
+	c.Forest.Lock()
+	defer c.Forest.Unlock()
+
+	impact := c.estimateImpact(c.getNewPropagateTypes(ts)) + c.estimateImpact(c.getRemovedPropagateTypes(ts))
+	if impact <= api.LargeConfigChangeThreshold {
+		return allow("")
+	}
+	if _, ok := inst.Annotations[api.ConfirmLargeConfigChangeAnnotation]; ok {
+		return allow("")
+	}
+
+	return deny(metav1.StatusReasonForbidden, fmt.Sprintf(
+		"This change is estimated to create or remove approximately %d object(s) across the cluster, "+
+			"which exceeds the safety threshold of %d. If you're sure you want to proceed, add the %q "+
+			"annotation to this object (to any value) and re-apply.",
+		impact, api.LargeConfigChangeThreshold, api.ConfirmLargeConfigChangeAnnotation))
+}
+
+// estimateImpact returns the approximate number of propagated copies that would be created (for
+// types newly configured with "Propagate" mode) or deleted (for types moving away from it) if
+// gvks were applied, by counting each namespace's own source objects of that type against the
+// number of its descendants.
+func (c *HNCConfig) estimateImpact(gvks gvkSet) int {
+	total := 0
+	for gvk := range gvks {
+		for _, nnm := range c.Forest.GetNamespaceNames() {
+			ns := c.Forest.Get(nnm)
+			total += ns.GetNumSourceObjects(gvk) * len(ns.DescendantNames())
+		}
+	}
+	return total
+}
+
+// getRemovedPropagateTypes returns the set of types that are currently in "Propagate" mode in the
+// forest, but wouldn't be after inst's configuration were applied.
+func (c *HNCConfig) getRemovedPropagateTypes(ts gvkSet) gvkSet {
+	removed := gvkSet{}
+	for _, t := range c.Forest.GetTypeSyncers() {
+		if t.GetMode() != api.Propagate {
+			continue
+		}
+		// Enforced types can't appear in the spec, but they stay in "Propagate" mode regardless.
+		if gvk := t.GetGVK(); gvk.Group == api.RBACGroup && (gvk.Kind == api.RoleKind || gvk.Kind == api.RoleBindingKind) {
+			continue
+		}
+		if newMode, stillPropagate := ts[t.GetGVK()]; !stillPropagate || newMode != api.Propagate {
+			removed[t.GetGVK()] = api.Remove
+		}
+	}
+	return removed
+}
+
 // ancestorObjects maps an object name to the ancestor namespace(s) in which
 // it's defined.
 type ancestorObjects map[string][]string
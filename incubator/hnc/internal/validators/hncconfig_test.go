@@ -204,7 +204,7 @@ func TestPropagateConflict(t *testing.T) {
 			g := NewWithT(t)
 			configs := []api.ResourceSpec{
 				{Group: "", Resource: "secrets", Mode: "Propagate"}}
-			c := &api.HNCConfiguration{Spec: api.HNCConfigurationSpec{Resources: configs}}
+			c := &api.HNCConfiguration{Spec: api.HNCConfigurationSpec{Resources: configs, AllowSecretPropagation: true}}
 			c.Name = api.HNCConfigSingleton
 			f := foresttest.Create(tc.forest)
 			config := &HNCConfig{
@@ -234,6 +234,139 @@ func TestPropagateConflict(t *testing.T) {
 	}
 }
 
+func TestCheckImpact(t *testing.T) {
+	newSecretConfig := func(annotations map[string]string) *api.HNCConfiguration {
+		c := &api.HNCConfiguration{Spec: api.HNCConfigurationSpec{
+			Resources:              []api.ResourceSpec{{Group: "", Resource: "secrets", Mode: "Propagate"}},
+			AllowSecretPropagation: true,
+		}}
+		c.Name = api.HNCConfigSingleton
+		c.Annotations = annotations
+		return c
+	}
+
+	t.Run("Allows a low-impact change with no confirmation", func(t *testing.T) {
+		g := NewWithT(t)
+		// "-a" -> a is the root, with one child b: at most one object would be propagated.
+		f := foresttest.Create("-a")
+		f.Get("a").SetSourceObject(newSecret("my-creds"))
+		config := &HNCConfig{translator: fakeGRTranslator{}, Forest: f, Log: zap.New()}
+
+		got := config.handle(context.Background(), newSecretConfig(nil))
+
+		logResult(t, got.AdmissionResponse.Result)
+		g.Expect(got.AdmissionResponse.Allowed).Should(BeTrue())
+	})
+
+	t.Run("Denies a high-impact change without confirmation", func(t *testing.T) {
+		g := NewWithT(t)
+		// "-aaaa" -> a is the root with four children: each source object in a would be propagated
+		// to all four, so 30 source objects push the estimated impact well past the threshold.
+		f := foresttest.Create("-aaaa")
+		a := f.Get("a")
+		for i := 0; i < 30; i++ {
+			a.SetSourceObject(newSecret(fmt.Sprintf("my-creds-%d", i)))
+		}
+		config := &HNCConfig{translator: fakeGRTranslator{}, Forest: f, Log: zap.New()}
+
+		got := config.handle(context.Background(), newSecretConfig(nil))
+
+		logResult(t, got.AdmissionResponse.Result)
+		g.Expect(got.AdmissionResponse.Allowed).Should(BeFalse())
+		g.Expect(got.AdmissionResponse.Result.Message).Should(ContainSubstring(api.ConfirmLargeConfigChangeAnnotation))
+	})
+
+	t.Run("Allows a high-impact change once confirmed", func(t *testing.T) {
+		g := NewWithT(t)
+		f := foresttest.Create("-aaaa")
+		a := f.Get("a")
+		for i := 0; i < 30; i++ {
+			a.SetSourceObject(newSecret(fmt.Sprintf("my-creds-%d", i)))
+		}
+		config := &HNCConfig{translator: fakeGRTranslator{}, Forest: f, Log: zap.New()}
+
+		got := config.handle(context.Background(), newSecretConfig(map[string]string{api.ConfirmLargeConfigChangeAnnotation: "true"}))
+
+		logResult(t, got.AdmissionResponse.Result)
+		g.Expect(got.AdmissionResponse.Allowed).Should(BeTrue())
+	})
+}
+
+func newSecret(name string) *unstructured.Unstructured {
+	inst := &unstructured.Unstructured{}
+	inst.SetGroupVersionKind(schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Secret"})
+	inst.SetName(name)
+	return inst
+}
+
+func TestValidateLibraries(t *testing.T) {
+	tests := []struct {
+		name       string
+		libraries  []api.LibrarySpec
+		allow      bool
+		errContain string
+	}{{
+		name:      "valid library and subtree",
+		libraries: []api.LibrarySpec{{Namespace: "b", Subtrees: []string{"c"}}},
+		allow:     true,
+	}, {
+		name:       "empty namespace",
+		libraries:  []api.LibrarySpec{{Namespace: ""}},
+		allow:      false,
+		errContain: "namespace must be set",
+	}, {
+		name: "duplicate library namespace",
+		libraries: []api.LibrarySpec{
+			{Namespace: "b", Subtrees: []string{"c"}},
+			{Namespace: "b", Subtrees: []string{"d"}},
+		},
+		allow:      false,
+		errContain: "duplicate configuration",
+	}, {
+		name:       "library namespace doesn't exist",
+		libraries:  []api.LibrarySpec{{Namespace: "nonexistent"}},
+		allow:      false,
+		errContain: "does not exist",
+	}, {
+		name:       "subtree doesn't exist",
+		libraries:  []api.LibrarySpec{{Namespace: "b", Subtrees: []string{"nonexistent"}}},
+		allow:      false,
+		errContain: "does not exist",
+	}, {
+		name:       "subtree can't be the library itself",
+		libraries:  []api.LibrarySpec{{Namespace: "b", Subtrees: []string{"b"}}},
+		allow:      false,
+		errContain: "subtree root itself",
+	}, {
+		name:       "subtree can't be an ancestor of the library",
+		libraries:  []api.LibrarySpec{{Namespace: "c", Subtrees: []string{"b"}}},
+		allow:      false,
+		errContain: "one of its ancestors",
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := &api.HNCConfiguration{Spec: api.HNCConfigurationSpec{Libraries: tc.libraries}}
+			c.Name = api.HNCConfigSingleton
+			// "--b" makes both a and b roots, and c a child of b, so c is a descendant of b.
+			config := &HNCConfig{
+				translator: fakeGRTranslator{},
+				Forest:     foresttest.Create("--b"),
+				Log:        zap.New(),
+			}
+
+			got := config.handle(context.Background(), c)
+
+			logResult(t, got.AdmissionResponse.Result)
+			g.Expect(got.AdmissionResponse.Allowed).Should(Equal(tc.allow))
+			if tc.errContain != "" {
+				g.Expect(got.AdmissionResponse.Result.Message).Should(ContainSubstring(tc.errContain))
+			}
+		})
+	}
+}
+
 // fakeGRTranslator implements grTranslator. Any kind that are in the slice are
 // denied; anything else are translated.
 type fakeGRTranslator []string
@@ -72,11 +72,28 @@ func (v *Namespace) handle(req *nsRequest) admission.Response {
 	v.Forest.Lock()
 	defer v.Forest.Unlock()
 
+	// The excluded-namespace label must be checked regardless of whether the namespace is
+	// currently excluded, since this is the only way a user could try to (mis)apply the label to a
+	// namespace that config says isn't actually excluded. It's a no-op for namespaces that are
+	// already excluded.
+	if req.op != k8sadm.Delete {
+		if rsp := v.illegalExcludedNamespaceLabel(req); !rsp.Allowed {
+			return rsp
+		}
+	}
+
+	// Excluded namespaces are never reconciled and have no forest state, so none of the checks
+	// below apply to them - skip them here to stay consistent with how the reconcilers and the
+	// other validators treat excluded namespaces.
+	if config.IsExcludedNamespace(req.ns.Name) {
+		return allow("")
+	}
+
 	ns := v.Forest.Get(req.ns.Name)
 
 	switch req.op {
 	case k8sadm.Create:
-		if rsp := v.illegalExcludedNamespaceLabel(req); !rsp.Allowed {
+		if rsp := v.illegalUnmanagedAnnotationValue(req); !rsp.Allowed {
 			return rsp
 		}
 		// This check only applies to the Create operation since namespace name
@@ -85,7 +102,7 @@ func (v *Namespace) handle(req *nsRequest) admission.Response {
 			return rsp
 		}
 	case k8sadm.Update:
-		if rsp := v.illegalExcludedNamespaceLabel(req); !rsp.Allowed {
+		if rsp := v.illegalUnmanagedAnnotationValue(req); !rsp.Allowed {
 			return rsp
 		}
 		// This check only applies to the Update operation. Creating a namespace
@@ -94,6 +111,9 @@ func (v *Namespace) handle(req *nsRequest) admission.Response {
 		if rsp := v.conflictBetweenParentAndExternalManager(req, ns); !rsp.Allowed {
 			return rsp
 		}
+		if rsp := v.illegalPropagatedMetadataChange(req, ns); !rsp.Allowed {
+			return rsp
+		}
 	case k8sadm.Delete:
 		if rsp := v.cannotDeleteSubnamespace(req); !rsp.Allowed {
 			return rsp
@@ -108,7 +128,7 @@ func (v *Namespace) handle(req *nsRequest) admission.Response {
 
 func (v *Namespace) illegalExcludedNamespaceLabel(req *nsRequest) admission.Response {
 	for l := range req.ns.Labels {
-		if l == api.LabelExcludedNamespace && !config.ExcludedNamespaces[req.ns.Name] {
+		if l == api.LabelExcludedNamespace && !config.IsExcludedNamespace(req.ns.Name) {
 			// Note: this only blocks the request if it has a newly added illegal
 			// excluded-namespace label because existing illegal excluded-namespace
 			// label should have already been removed by our reconciler. For example,
@@ -123,6 +143,39 @@ func (v *Namespace) illegalExcludedNamespaceLabel(req *nsRequest) admission.Resp
 	return allow("")
 }
 
+// illegalPropagatedMetadataChange rejects an update that would remove or change the value of a
+// label or annotation that some ancestor (or this namespace itself) has declared via
+// HierarchyConfigurationSpec.Labels/Annotations, so a tenant can't tamper with metadata that isn't
+// theirs to control. HierarchyConfigReconciler will always win this race and write the correct
+// value straight back, but denying the request here gives the user immediate feedback instead of
+// silently reverting their change.
+func (v *Namespace) illegalPropagatedMetadataChange(req *nsRequest, ns *forest.Namespace) admission.Response {
+	for k, want := range ns.PropagatedLabels() {
+		if got := req.ns.Labels[k]; got != want {
+			msg := fmt.Sprintf("Cannot change or remove the %q label: it's propagated from an ancestor namespace via spec.labels", k)
+			return deny(metav1.StatusReasonForbidden, msg)
+		}
+	}
+	for k, want := range ns.PropagatedAnnotations() {
+		if got := req.ns.Annotations[k]; got != want {
+			msg := fmt.Sprintf("Cannot change or remove the %q annotation: it's propagated from an ancestor namespace via spec.annotations", k)
+			return deny(metav1.StatusReasonForbidden, msg)
+		}
+	}
+	return allow("")
+}
+
+// illegalUnmanagedAnnotationValue ensures that the unmanaged annotation, if present, is either
+// "true" or "false" so the reconciler doesn't have to guess what an unrecognized value means.
+func (v *Namespace) illegalUnmanagedAnnotationValue(req *nsRequest) admission.Response {
+	val, ok := req.ns.Annotations[api.AnnotationUnmanaged]
+	if !ok || val == "true" || val == "false" {
+		return allow("")
+	}
+	msg := fmt.Sprintf("The %q annotation must be set to \"true\" or \"false\", not %q.", api.AnnotationUnmanaged, val)
+	return deny(metav1.StatusReasonBadRequest, msg)
+}
+
 func (v *Namespace) nameExistsInExternalHierarchy(req *nsRequest) admission.Response {
 	for _, nm := range v.Forest.GetNamespaceNames() {
 		if _, ok := v.Forest.Get(nm).ExternalTreeLabels[req.ns.Name]; ok {
@@ -233,7 +233,7 @@ func TestIllegalExcludedNamespace(t *testing.T) {
 	legalInst := &corev1.Namespace{}
 	legalInst.Name = "legal"
 	legalInst.SetLabels(map[string]string{api.LabelExcludedNamespace: "true"})
-	config.ExcludedNamespaces["legal"] = true
+	config.SetExcludedNamespaces(map[string]bool{"kube-system": true, "legal": true})
 
 	tests := []struct {
 		name   string
@@ -266,3 +266,102 @@ func TestIllegalExcludedNamespace(t *testing.T) {
 		})
 	}
 }
+
+// TestExcludedNamespaceSkipsOtherChecks verifies that, once a namespace is excluded, none of the
+// other namespace invariants are enforced against it - matching how the reconcilers and the other
+// validators already treat excluded namespaces.
+func TestExcludedNamespaceSkipsOtherChecks(t *testing.T) {
+	g := NewWithT(t)
+
+	f := foresttest.Create("c--") // c (has anchor a)
+	f.Get("c").SetAnchors([]string{"a"})
+	vns := &Namespace{Forest: f}
+	config.SetExcludedNamespaces(map[string]bool{"kube-system": true, "a": true})
+	t.Cleanup(func() { config.SetExcludedNamespaces(map[string]bool{"kube-system": true}) })
+
+	// "a" is a subnamespace of "c" with a matching anchor, so deleting it would normally be
+	// forbidden by cannotDeleteSubnamespace - but it's excluded, so the check should be skipped.
+	sub := &corev1.Namespace{}
+	sub.Name = "a"
+	setSubAnnotation(sub, "c")
+
+	req := &nsRequest{ns: sub, op: k8sadm.Delete}
+	got := vns.handle(req)
+
+	logResult(t, got.AdmissionResponse.Result)
+	g.Expect(got.AdmissionResponse.Allowed).Should(BeTrue())
+}
+
+func TestIllegalUnmanagedAnnotationValue(t *testing.T) {
+	f := foresttest.Create("-")
+	vns := &Namespace{Forest: f}
+
+	tests := []struct {
+		name  string
+		value string
+		unset bool
+		fail  bool
+	}{
+		{name: "no annotation", unset: true},
+		{name: "true", value: "true"},
+		{name: "false", value: "false"},
+		{name: "garbage value", value: "yes", fail: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			nsInst := &corev1.Namespace{}
+			nsInst.Name = "a"
+			if !tc.unset {
+				nsInst.SetAnnotations(map[string]string{api.AnnotationUnmanaged: tc.value})
+			}
+			req := &nsRequest{ns: nsInst, op: k8sadm.Update}
+
+			// Test
+			got := vns.handle(req)
+
+			// Report
+			logResult(t, got.AdmissionResponse.Result)
+			g.Expect(got.AdmissionResponse.Allowed).ShouldNot(Equal(tc.fail))
+		})
+	}
+}
+
+func TestIllegalPropagatedMetadataChange(t *testing.T) {
+	f := foresttest.Create("-")
+	vns := &Namespace{Forest: f}
+	f.Get("a").SetPropagatedMetadata(map[string]string{"team": "payments"}, map[string]string{"owner": "acme"})
+
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		fail        bool
+	}{
+		{name: "unchanged", labels: map[string]string{"team": "payments"}, annotations: map[string]string{"owner": "acme"}},
+		{name: "extra label untouched", labels: map[string]string{"team": "payments", "extra": "x"}, annotations: map[string]string{"owner": "acme"}},
+		{name: "changed label value", labels: map[string]string{"team": "other"}, annotations: map[string]string{"owner": "acme"}, fail: true},
+		{name: "removed label", annotations: map[string]string{"owner": "acme"}, fail: true},
+		{name: "changed annotation value", labels: map[string]string{"team": "payments"}, annotations: map[string]string{"owner": "other"}, fail: true},
+		{name: "removed annotation", labels: map[string]string{"team": "payments"}, fail: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			nsInst := &corev1.Namespace{}
+			nsInst.Name = "a"
+			nsInst.SetLabels(tc.labels)
+			nsInst.SetAnnotations(tc.annotations)
+			req := &nsRequest{ns: nsInst, op: k8sadm.Update}
+
+			// Test
+			got := vns.handle(req)
+
+			// Report
+			logResult(t, got.AdmissionResponse.Result)
+			g.Expect(got.AdmissionResponse.Allowed).ShouldNot(Equal(tc.fail))
+		})
+	}
+}
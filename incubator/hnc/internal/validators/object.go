@@ -8,12 +8,14 @@ import (
 
 	"github.com/go-logr/logr"
 	k8sadm "k8s.io/api/admission/v1"
+	authnv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -43,10 +45,11 @@ const (
 // +kubebuilder:webhook:admissionReviewVersions=v1;v1beta1,path=/validate-objects,mutating=false,failurePolicy=fail,groups="*",resources="*",sideEffects=None,verbs=create;update;delete,versions="*",name=objects.hnc.x-k8s.io
 
 type Object struct {
-	Log     logr.Logger
-	Forest  *forest.Forest
-	client  client.Client
-	decoder *admission.Decoder
+	Log           logr.Logger
+	Forest        *forest.Forest
+	EventRecorder record.EventRecorder
+	client        client.Client
+	decoder       *admission.Decoder
 }
 
 func (o *Object) Handle(ctx context.Context, req admission.Request) admission.Response {
@@ -59,7 +62,7 @@ func (o *Object) Handle(ctx context.Context, req admission.Request) admission.Re
 	// Note: This is added just in case the "hnc.x-k8s.io/excluded-namespace=true"
 	// label is not added on the excluded namespaces. VWHConfiguration of this VWH
 	// already has a `namespaceSelector` to exclude namespaces with the label.
-	if config.ExcludedNamespaces[req.Namespace] {
+	if config.IsExcludedNamespace(req.Namespace) {
 		return allow("excluded namespace " + req.Namespace)
 	}
 	// Allow changes to the types that are not in propagate mode. This is to dynamically enable/disable
@@ -68,6 +71,12 @@ func (o *Object) Handle(ctx context.Context, req admission.Request) admission.Re
 	if !o.isPropagateType(req.Kind) {
 		return allow("Non-propagate-mode types")
 	}
+	// Skip types that are configured to opt out of this webhook entirely (see
+	// api.ResourceSpec.IgnoreObjectWebhook). Reconciliation still keeps propagated copies of these
+	// types in sync; only the real-time admission checks below are skipped.
+	if o.ignoresObjectWebhook(req.Kind) {
+		return allow("excluded from object webhook by resource config")
+	}
 	// Finally, let the HNC SA do whatever it wants.
 	if isHNCServiceAccount(&req.AdmissionRequest.UserInfo) {
 		log.V(1).Info("Allowed change by HNC SA")
@@ -96,7 +105,8 @@ func (o *Object) Handle(ctx context.Context, req admission.Request) admission.Re
 	}
 
 	// Run the actual logic.
-	resp := o.handle(ctx, log, req.Operation, inst, oldInst)
+	exempt := o.isExemptWebhookUser(&req.AdmissionRequest.UserInfo)
+	resp := o.handle(ctx, log, req.Operation, inst, oldInst, exempt)
 	if !resp.Allowed {
 		log.Info("Denied", "code", resp.Result.Code, "reason", resp.Result.Reason, "message", resp.Result.Message)
 	} else {
@@ -105,17 +115,40 @@ func (o *Object) Handle(ctx context.Context, req admission.Request) admission.Re
 	return resp
 }
 
+// isExemptWebhookUser returns true if user is listed in HNCConfigurationSpec.WebhookExemptions,
+// and so is trusted to modify or delete propagated objects without going through HNC.
+func (o *Object) isExemptWebhookUser(user *authnv1.UserInfo) bool {
+	o.Forest.Lock()
+	defer o.Forest.Unlock()
+	return o.Forest.IsExemptWebhookUser(user)
+}
+
 func (o *Object) isPropagateType(gvk metav1.GroupVersionKind) bool {
 	o.Forest.Lock()
 	defer o.Forest.Unlock()
 
 	ts := o.Forest.GetTypeSyncerFromGroupKind(schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind})
-	return ts != nil && ts.GetMode() == api.Propagate
+	if ts == nil {
+		return false
+	}
+	mode := ts.GetMode()
+	return mode == api.Propagate || mode == api.AllowPropagate
+}
+
+// ignoresObjectWebhook returns true if gvk is configured with
+// api.ResourceSpec.IgnoreObjectWebhook, meaning admission requests for it should always be
+// allowed without running the "cannot modify/delete propagated object" checks below.
+func (o *Object) ignoresObjectWebhook(gvk metav1.GroupVersionKind) bool {
+	o.Forest.Lock()
+	defer o.Forest.Unlock()
+
+	ts := o.Forest.GetTypeSyncerFromGroupKind(schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind})
+	return ts != nil && ts.GetIgnoreObjectWebhook()
 }
 
 // handle implements the non-webhook-y businesss logic of this validator, allowing it to be more
 // easily unit tested (ie without constructing an admission.Request, setting up user infos, etc).
-func (o *Object) handle(ctx context.Context, log logr.Logger, op k8sadm.Operation, inst, oldInst *unstructured.Unstructured) admission.Response {
+func (o *Object) handle(ctx context.Context, log logr.Logger, op k8sadm.Operation, inst, oldInst *unstructured.Unstructured, exempt bool) admission.Response {
 	// Find out if the object was/is inherited, and where it's inherited from.
 	oldSource, oldInherited := metadata.GetLabel(oldInst, api.LabelInheritedFrom)
 	newSource, newInherited := metadata.GetLabel(inst, api.LabelInheritedFrom)
@@ -152,7 +185,7 @@ func (o *Object) handle(ctx context.Context, log logr.Logger, op k8sadm.Operatio
 		return allow("source object")
 	}
 	// This is a propagated object.
-	return o.handleInherited(ctx, op, newSource, oldSource, inst, oldInst)
+	return o.handleInherited(ctx, op, newSource, oldSource, inst, oldInst, exempt)
 }
 
 func validateSelectorAnnot(inst *unstructured.Unstructured) string {
@@ -242,7 +275,7 @@ func validateNoneSelectorChange(inst, oldInst *unstructured.Unstructured) error
 	return err
 }
 
-func (o *Object) handleInherited(ctx context.Context, op k8sadm.Operation, newSource, oldSource string, inst, oldInst *unstructured.Unstructured) admission.Response {
+func (o *Object) handleInherited(ctx context.Context, op k8sadm.Operation, newSource, oldSource string, inst, oldInst *unstructured.Unstructured, exempt bool) admission.Response {
 	// Propagated objects cannot be created or deleted (except by the HNC SA, but the HNC SA
 	// never gets this far in the validation). They *can* have their statuses updated, so
 	// if this is an update, make sure that the canonical form of the object hasn't changed.
@@ -260,6 +293,10 @@ func (o *Object) handleInherited(ctx context.Context, op k8sadm.Operation, newSo
 		}
 
 		if !isDeleting {
+			if exempt {
+				o.recordWebhookExemption(oldInst, "deletion")
+				return allow("exempted identity deleting propagated object")
+			}
 			return deny(metav1.StatusReasonForbidden, "Cannot delete object propagated from namespace \""+oldSource+"\"")
 		}
 
@@ -268,7 +305,9 @@ func (o *Object) handleInherited(ctx context.Context, op k8sadm.Operation, newSo
 	case k8sadm.Update:
 		// If the values have changed, that's an illegal modification. This includes if the label is
 		// added or deleted. Note that this label is *not* included in object.Canonical(), below, so we
-		// need to check it manually.
+		// need to check it manually. This is enforced even for exempted identities, since an exemption
+		// is only meant to let trusted automation reconcile a propagated object's contents, not
+		// reassign which source it's propagated from.
 		if newSource != oldSource {
 			return deny(metav1.StatusReasonForbidden, "Cannot modify the label \""+api.LabelInheritedFrom+"\"")
 		}
@@ -277,6 +316,10 @@ func (o *Object) handleInherited(ctx context.Context, op k8sadm.Operation, newSo
 		// should be rejected. Note that object.Canonical does *not* compare any HNC labels or
 		// annotations.
 		if !reflect.DeepEqual(object.Canonical(inst), object.Canonical(oldInst)) {
+			if exempt {
+				o.recordWebhookExemption(inst, "modification")
+				return allow("exempted identity modifying propagated object")
+			}
 			return deny(metav1.StatusReasonForbidden,
 				"Cannot modify object propagated from namespace \""+oldSource+"\"")
 		}
@@ -289,6 +332,18 @@ func (o *Object) handleInherited(ctx context.Context, op k8sadm.Operation, newSo
 	return deny(metav1.StatusReasonInternalError, "unknown operation: "+string(op))
 }
 
+// recordWebhookExemption fires an event on inst recording that a request which would normally
+// have been denied by the "cannot modify/delete propagated object" checks above was allowed
+// instead, because the requester is listed in HNCConfigurationSpec.WebhookExemptions. This is the
+// only trace of the exemption having been used, so every use is recorded, not just the first.
+func (o *Object) recordWebhookExemption(inst *unstructured.Unstructured, verb string) {
+	if o.EventRecorder == nil {
+		return
+	}
+	o.EventRecorder.Eventf(inst, corev1.EventTypeNormal, api.EventWebhookExemption,
+		"Allowed %s of this propagated object because the requester is exempted by HNCConfiguration's webhookExemptions", verb)
+}
+
 // validateDeletingNS validates if the namespace of the object is already being deleted
 func (o *Object) isDeletingNS(ctx context.Context, ns string) (bool, error) {
 	nsObj := &corev1.Namespace{}
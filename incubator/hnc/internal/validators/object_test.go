@@ -33,8 +33,14 @@ func TestType(t *testing.T) {
 		GVK:  schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Secret"},
 		Mode: api.Propagate,
 	}
+	ignored := &reconcilers.ObjectReconciler{
+		GVK:                 schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"},
+		Mode:                api.Propagate,
+		IgnoreObjectWebhook: true,
+	}
 	f := forest.NewForest()
 	f.AddTypeSyncer(or)
+	f.AddTypeSyncer(ignored)
 	l := zap.New()
 	o := &Object{Forest: f, Log: l}
 
@@ -62,6 +68,10 @@ func TestType(t *testing.T) {
 		version: "v1",
 		kind:    "Secret",
 		ns:      "kube-system",
+	}, {
+		name:    "Always allow request with GroupKind configured to ignore the object webhook",
+		version: "v1",
+		kind:    "ConfigMap",
 	}}
 
 	for _, tc := range tests {
@@ -135,7 +145,7 @@ func TestInheritedFromLabel(t *testing.T) {
 			metadata.SetLabel(inst, tc.newLabel, tc.newValue)
 
 			// Test
-			got := o.handle(context.Background(), l, k8sadm.Update, inst, oldInst)
+			got := o.handle(context.Background(), l, k8sadm.Update, inst, oldInst, false)
 
 			// Report
 			code := got.AdmissionResponse.Result.Code
@@ -158,6 +168,7 @@ func TestUserChanges(t *testing.T) {
 		inst       *unstructured.Unstructured
 		fail       bool
 		isDeleting bool
+		exempt     bool
 	}{{
 		name: "Allow changes to original objects",
 		oldInst: &unstructured.Unstructured{
@@ -292,6 +303,51 @@ func TestUserChanges(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name:   "Allow spec changes to propagated objects by an exempted identity",
+		exempt: true,
+		oldInst: &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{
+						api.LabelInheritedFrom: "foo",
+					},
+				},
+				"spec": map[string]interface{}{
+					"hostname": "hello.com",
+				},
+			},
+		},
+		inst: &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{
+						api.LabelInheritedFrom: "foo",
+					},
+				},
+				"spec": map[string]interface{}{
+					"hostname": "world.com",
+				},
+			},
+		},
+	}, {
+		name:   "Allow deletions of propagated objects by an exempted identity when namespace is not being deleted",
+		exempt: true,
+		oldInst: &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{
+						api.LabelInheritedFrom: "foo",
+					},
+				},
+			},
+		},
 	}, {
 		name:       "Allow deletions of propagated objects when namespace is being deleted",
 		isDeleting: true,
@@ -575,7 +631,7 @@ func TestUserChanges(t *testing.T) {
 			c := fakeNSClient{isDeleting: tc.isDeleting}
 			o.client = c
 			// Test
-			got := o.handle(context.Background(), l, op, tc.inst, tc.oldInst)
+			got := o.handle(context.Background(), l, op, tc.inst, tc.oldInst, tc.exempt)
 			// Report
 			code := got.AdmissionResponse.Result.Code
 			reason := got.AdmissionResponse.Result.Reason
@@ -702,7 +758,7 @@ func TestCreatingConflictSource(t *testing.T) {
 			inst.SetGroupVersionKind(schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Secret"})
 			inst.SetAnnotations(tc.newInstAnnotation)
 			// Test
-			got := o.handle(context.Background(), l, op, inst, &unstructured.Unstructured{})
+			got := o.handle(context.Background(), l, op, inst, &unstructured.Unstructured{}, false)
 			// Report
 			code := got.AdmissionResponse.Result.Code
 			reason := got.AdmissionResponse.Result.Reason
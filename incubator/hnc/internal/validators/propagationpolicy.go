@@ -0,0 +1,79 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
+)
+
+// PropagationPolicyServingPath is where the validator will run. Must be kept in sync with the
+// kubebuilder markers below.
+const (
+	PropagationPolicyServingPath = "/validate-hnc-x-k8s-io-v1alpha2-propagationpolicies"
+)
+
+// Note: the validating webhook FAILS CLOSE. This means that if the webhook goes down, all further
+// changes are denied.
+//
+// +kubebuilder:webhook:admissionReviewVersions=v1;v1beta1,path=/validate-hnc-x-k8s-io-v1alpha2-propagationpolicies,mutating=false,failurePolicy=fail,groups="hnc.x-k8s.io",resources=propagationpolicies,sideEffects=None,verbs=create;update,versions=v1alpha2,name=propagationpolicies.hnc.x-k8s.io
+
+type PropagationPolicy struct {
+	Log     logr.Logger
+	decoder *admission.Decoder
+}
+
+func (v *PropagationPolicy) Handle(ctx context.Context, req admission.Request) admission.Response {
+	log := v.Log.WithValues("ns", req.Namespace, "nm", req.Name, "op", req.Operation, "user", req.UserInfo.Username)
+	if isHNCServiceAccount(&req.AdmissionRequest.UserInfo) {
+		return allow("HNC SA")
+	}
+
+	inst := &api.PropagationPolicy{}
+	if err := v.decoder.Decode(req, inst); err != nil {
+		log.Error(err, "Couldn't decode request")
+		return deny(metav1.StatusReasonBadRequest, err.Error())
+	}
+
+	resp := v.handle(inst)
+	if !resp.Allowed {
+		log.Info("Denied", "code", resp.Result.Code, "reason", resp.Result.Reason, "message", resp.Result.Message)
+	}
+	return resp
+}
+
+// handle implements the validation logic, allowing it to be easily unit tested without
+// constructing a full admission.Request.
+func (v *PropagationPolicy) handle(inst *api.PropagationPolicy) admission.Response {
+	for i, rule := range inst.Spec.Rules {
+		f := field.NewPath("spec", "rules").Index(i)
+		if rule.APIVersion == "" {
+			return denyInvalid(f.Child("apiVersion"), "must not be empty")
+		}
+		if rule.Kind == "" {
+			return denyInvalid(f.Child("kind"), "must not be empty")
+		}
+		if rule.NamePattern != "" {
+			if _, err := path.Match(rule.NamePattern, ""); err != nil {
+				return denyInvalid(f.Child("namePattern"), fmt.Sprintf("invalid pattern: %s", err.Error()))
+			}
+		}
+		if rule.Selector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(rule.Selector); err != nil {
+				return denyInvalid(f.Child("selector"), err.Error())
+			}
+		}
+	}
+	return allow("")
+}
+
+func (v *PropagationPolicy) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
@@ -1,14 +1,23 @@
 package validators
 
 import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	cert "github.com/open-policy-agent/cert-controller/pkg/rotator"
+	api "sigs.k8s.io/multi-tenancy/incubator/hnc/api/v1alpha2"
 	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/forest"
+	"sigs.k8s.io/multi-tenancy/incubator/hnc/internal/stats"
 )
 
 const (
@@ -19,6 +28,15 @@ const (
 	secretNamespace = "hnc-system"
 	secretName      = "hnc-webhook-server-cert"
 	certDir         = "/tmp/k8s-webhook-server/serving-certs"
+
+	// slowCertSetupThreshold is how long cert generation can take at startup before HNC starts
+	// warning about it - webhooks reject every request until the certs are ready, so an operator
+	// investigating a stuck rollout needs to know this is why.
+	slowCertSetupThreshold = 30 * time.Second
+
+	// certExpiryCheckPeriod is how often the webhook serving cert's expiry is re-checked once
+	// startup has finished.
+	certExpiryCheckPeriod = 1 * time.Hour
 )
 
 // DNSName is <service name>.<namespace>.svc
@@ -32,7 +50,7 @@ func CreateCertsIfNeeded(mgr ctrl.Manager, novalidation, internalCert, restartOn
 		return setupFinished, nil
 	}
 
-	return setupFinished, cert.AddRotator(mgr, &cert.CertRotator{
+	if err := cert.AddRotator(mgr, &cert.CertRotator{
 		SecretKey: types.NamespacedName{
 			Namespace: secretNamespace,
 			Name:      secretName,
@@ -47,7 +65,63 @@ func CreateCertsIfNeeded(mgr ctrl.Manager, novalidation, internalCert, restartOn
 			Name: vwhName,
 		}},
 		RestartOnSecretRefresh: restartOnSecretRefresh,
-	})
+	}); err != nil {
+		return setupFinished, err
+	}
+
+	go monitorCertHealth(mgr, setupFinished)
+	return setupFinished, nil
+}
+
+// monitorCertHealth watches how long the webhook serving cert takes to become ready at startup,
+// warning if it's slow, and then periodically checks its expiry so operators can be warned well
+// before HNC's webhooks start rejecting every request because the cert has lapsed.
+func monitorCertHealth(mgr ctrl.Manager, setupFinished chan struct{}) {
+	recorder := mgr.GetEventRecorderFor("hnc-cert-rotator")
+	singleton := &api.HNCConfiguration{ObjectMeta: metav1.ObjectMeta{Name: api.HNCConfigSingleton}}
+
+	started := time.Now()
+	timer := time.NewTimer(slowCertSetupThreshold)
+	defer timer.Stop()
+	slow := false
+	select {
+	case <-setupFinished:
+	case <-timer.C:
+		slow = true
+		recorder.Eventf(singleton, corev1.EventTypeWarning, "SlowCertSetup",
+			"Webhook serving cert generation has been running for over %s; webhooks will reject all requests until it completes", slowCertSetupThreshold)
+		<-setupFinished
+	}
+	stats.RecordCertSetupDuration(time.Since(started), slow)
+
+	// The cache used by mgr.GetClient() may not have started yet at this point (this can run
+	// before mgr.Start()), and won't ever start if novalidation/internalCert take us down a path
+	// that never calls it, so read the secret directly from the apiserver instead.
+	reader := mgr.GetAPIReader()
+	for {
+		if notAfter, err := readCertExpiry(reader); err == nil {
+			stats.RecordCertExpiry(notAfter)
+		}
+		time.Sleep(certExpiryCheckPeriod)
+	}
+}
+
+// readCertExpiry reads the webhook serving cert's NotAfter time out of its secret.
+func readCertExpiry(reader client.Reader) (time.Time, error) {
+	secret := &corev1.Secret{}
+	if err := reader.Get(context.Background(), types.NamespacedName{Namespace: secretNamespace, Name: secretName}, secret); err != nil {
+		return time.Time{}, err
+	}
+
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return time.Time{}, fmt.Errorf("no PEM data found in %s/%s", secretNamespace, secretName)
+	}
+	crt, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return crt.NotAfter, nil
 }
 
 // Create creates all validators. This function is called from main.go.
@@ -60,8 +134,9 @@ func Create(mgr ctrl.Manager, f *forest.Forest) {
 
 	// Create webhooks for managed objects
 	mgr.GetWebhookServer().Register(ObjectsServingPath, &webhook.Admission{Handler: &Object{
-		Log:    ctrl.Log.WithName("validators").WithName("Object"),
-		Forest: f,
+		Log:           ctrl.Log.WithName("validators").WithName("Object"),
+		Forest:        f,
+		EventRecorder: mgr.GetEventRecorderFor("hnc-object-webhook"),
 	}})
 
 	// Create webhook for the config
@@ -81,4 +156,9 @@ func Create(mgr ctrl.Manager, f *forest.Forest) {
 		Log:    ctrl.Log.WithName("validators").WithName("Namespace"),
 		Forest: f,
 	}})
+
+	// Create webhook for the propagation policies.
+	mgr.GetWebhookServer().Register(PropagationPolicyServingPath, &webhook.Admission{Handler: &PropagationPolicy{
+		Log: ctrl.Log.WithName("validators").WithName("PropagationPolicy"),
+	}})
 }
@@ -0,0 +1,185 @@
+// hns2ans is the reverse of ans2hns: instead of turning a structured Git repo into HNC objects,
+// it connects to a live cluster, reads the Namespaces and HierarchyConfigurations it finds there,
+// and writes out the `namespaces/<root>/<child>/...` directory layout that ans2hns expects as
+// input. This lets a GitOps repo be bootstrapped from a cluster's existing hierarchy instead of
+// having to be written by hand.
+//
+// See ../README.md for more information.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	namespaceGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	hcGVR        = schema.GroupVersionResource{Group: "hnc.x-k8s.io", Version: "v1alpha2", Resource: "hierarchyconfigurations"}
+)
+
+// nsInfo is the live counterpart of the forestType entries in ../main.go: the parent inferred
+// from the namespace's HierarchyConfiguration (if any), plus the Namespace object itself so we
+// have something to write back out.
+type nsInfo struct {
+	parent string
+	ns     *unstructured.Unstructured
+}
+
+func main() {
+	outDir := pflag.String("out-dir", "namespaces", "directory to write the namespace hierarchy into")
+	kubecfgFlags := genericclioptions.NewConfigFlags(false)
+	kubecfgFlags.AddFlags(pflag.CommandLine)
+	pflag.Parse()
+
+	if err := run(kubecfgFlags, *outDir); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(kubecfgFlags *genericclioptions.ConfigFlags, outDir string) error {
+	config, err := kubecfgFlags.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("couldn't load kubeconfig: %w", err)
+	}
+	dc, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("couldn't create client: %w", err)
+	}
+
+	forest, err := buildForest(dc)
+	if err != nil {
+		return err
+	}
+
+	for nnm := range forest {
+		if err := writeNamespace(forest, nnm, outDir); err != nil {
+			return fmt.Errorf("couldn't write %q: %w", nnm, err)
+		}
+	}
+	return nil
+}
+
+// buildForest lists every Namespace and HierarchyConfiguration on the cluster and returns the
+// inferred parent/child structure, keyed by namespace name. It's the live mirror image of the
+// forestType that ../main.go infers from a directory layout.
+func buildForest(dc dynamic.Interface) (map[string]*nsInfo, error) {
+	nsList, err := dc.Resource(namespaceGVR).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list namespaces: %w", err)
+	}
+	hcList, err := dc.Resource(hcGVR).Namespace("").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list hierarchyconfigurations: %w", err)
+	}
+
+	parents := map[string]string{}
+	for _, hc := range hcList.Items {
+		if hc.GetName() != "hierarchy" {
+			// Only the singleton named "hierarchy" is meaningful to HNC; ignore anything else a user
+			// might have created under the same GVK.
+			continue
+		}
+		parent, _, _ := unstructured.NestedString(hc.Object, "spec", "parent")
+		parents[hc.GetNamespace()] = parent
+	}
+
+	forest := map[string]*nsInfo{}
+	for i := range nsList.Items {
+		ns := &nsList.Items[i]
+		forest[ns.GetName()] = &nsInfo{parent: parents[ns.GetName()], ns: ns}
+	}
+	return forest, nil
+}
+
+// path returns the root-to-leaf list of namespace names from the forest root down to and
+// including nnm, e.g. ["acme-org", "eng", "team-a"].
+func path(forest map[string]*nsInfo, nnm string) ([]string, error) {
+	segs := []string{nnm}
+	seen := map[string]bool{nnm: true}
+	for {
+		pnm := forest[nnm].parent
+		if pnm == "" {
+			return segs, nil
+		}
+		if seen[pnm] {
+			return nil, fmt.Errorf("cycle detected involving %q", pnm)
+		}
+		segs = append([]string{pnm}, segs...)
+		seen[pnm] = true
+		nnm = pnm
+	}
+}
+
+// writeNamespace writes nnm's namespace.yaml, and its hierarchyconfiguration.yaml if it has a
+// parent, into its position under outDir.
+func writeNamespace(forest map[string]*nsInfo, nnm, outDir string) error {
+	segs, err := path(forest, nnm)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(append([]string{outDir}, segs...)...)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("couldn't create %q: %w", dir, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Writing %s/namespace.yaml\n", dir)
+	if err := writeManifest(filepath.Join(dir, "namespace.yaml"), sanitized(forest[nnm].ns)); err != nil {
+		return err
+	}
+
+	if parent := forest[nnm].parent; parent != "" {
+		fmt.Fprintf(os.Stderr, "Writing %s/hierarchyconfiguration.yaml\n", dir)
+		if err := writeManifest(filepath.Join(dir, "hierarchyconfiguration.yaml"), hierarchyConfig(nnm, parent)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sanitized strips the server-populated fields from inst that have no place in a Git repo - the
+// status, and the metadata that only makes sense for a live object on a specific cluster.
+func sanitized(inst *unstructured.Unstructured) *unstructured.Unstructured {
+	out := inst.DeepCopy()
+	delete(out.Object, "status")
+	for _, f := range []string{"creationTimestamp", "generation", "managedFields", "resourceVersion", "selfLink", "uid"} {
+		unstructured.RemoveNestedField(out.Object, "metadata", f)
+	}
+	return out
+}
+
+// hierarchyConfig returns the HierarchyConfiguration manifest for namespace nnm, whose parent is
+// pnm.
+func hierarchyConfig(nnm, pnm string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "hnc.x-k8s.io/v1alpha2",
+		"kind":       "HierarchyConfiguration",
+		"metadata": map[string]interface{}{
+			"name":      "hierarchy",
+			"namespace": nnm,
+		},
+		"spec": map[string]interface{}{
+			"parent": pnm,
+		},
+	}}
+}
+
+// writeManifest writes obj to path as YAML.
+func writeManifest(path string, obj *unstructured.Unstructured) error {
+	b, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal %q: %w", path, err)
+	}
+	return os.WriteFile(path, b, 0644)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vcclient "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/client/clientset/versioned"
+)
+
+const (
+	deleteExample = `
+	# Delete VirtualCluster bar in namespace foo
+	kubectl vc delete -n foo bar
+
+	# Specific vc by namespaced name
+	kubectl vc delete foo/bar`
+)
+
+type DeleteOptions struct {
+	vcclient  vcclient.Interface
+	namespace string
+	name      string
+}
+
+func NewCmdDelete(f Factory) *cobra.Command {
+	o := &DeleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "delete VC_NAME",
+		Short:   "Delete a VirtualCluster",
+		Example: deleteExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			CheckErr(o.Complete(f, cmd, args))
+			CheckErr(o.Run())
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", metav1.NamespaceDefault, "If present, the namespace scope for this CLI request")
+
+	return cmd
+}
+
+func (o *DeleteOptions) Complete(f Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.vcclient, err = f.VirtualClusterClientSet()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return UsageErrorf(cmd, "VC_NAME should not be empty")
+	}
+
+	o.name = args[0]
+	if strings.Contains(o.name, "/") {
+		namespacedName := strings.SplitN(o.name, "/", 2)
+		o.namespace = namespacedName[0]
+		o.name = namespacedName[1]
+	}
+
+	return nil
+}
+
+func (o *DeleteOptions) Run() error {
+	if err := o.vcclient.TenancyV1alpha1().VirtualClusters(o.namespace).Delete(o.name, &metav1.DeleteOptions{}); err != nil {
+		return err
+	}
+
+	fmt.Printf("VirtualCluster %s/%s deleted\n", o.namespace, o.name)
+
+	return nil
+}
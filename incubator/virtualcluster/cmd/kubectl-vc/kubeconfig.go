@@ -0,0 +1,119 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vcclient "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/client/clientset/versioned"
+)
+
+const (
+	kubeconfigExample = `
+	# Print the tenant admin kubeconfig of a VirtualCluster to stdout
+	kubectl vc kubeconfig -n foo bar
+
+	# Specific vc by namespaced name
+	kubectl vc kubeconfig foo/bar
+
+	# Write the tenant admin kubeconfig to a file instead of stdout
+	kubectl vc kubeconfig -o /path/to/file foo/bar`
+)
+
+type KubeconfigOptions struct {
+	client     client.Client
+	vcclient   vcclient.Interface
+	namespace  string
+	name       string
+	outputPath string
+}
+
+func NewCmdKubeconfig(f Factory) *cobra.Command {
+	o := &KubeconfigOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "kubeconfig VC_NAME",
+		Short:   "Retrieve the tenant admin kubeconfig of a VirtualCluster",
+		Example: kubeconfigExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			CheckErr(o.Complete(f, cmd, args))
+			CheckErr(o.Run())
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", metav1.NamespaceDefault, "If present, the namespace scope for this CLI request")
+	cmd.Flags().StringVarP(&o.outputPath, "output", "o", "", "path to write the kubeconfig to. if empty, it is printed to stdout")
+
+	return cmd
+}
+
+func (o *KubeconfigOptions) Complete(f Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.vcclient, err = f.VirtualClusterClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.client, err = f.GenericClient()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return UsageErrorf(cmd, "VC_NAME should not be empty")
+	}
+
+	o.name = args[0]
+	if strings.Contains(o.name, "/") {
+		namespacedName := strings.SplitN(o.name, "/", 2)
+		o.namespace = namespacedName[0]
+		o.name = namespacedName[1]
+	}
+
+	return nil
+}
+
+func (o *KubeconfigOptions) Run() error {
+	vc, err := o.vcclient.TenancyV1alpha1().VirtualClusters(o.namespace).Get(o.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	cv, err := o.vcclient.TenancyV1alpha1().ClusterVersions().Get(vc.Spec.ClusterVersionName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "cluster version not found")
+	}
+
+	kbBytes, err := genKubeConfig(o.client, vc, cv)
+	if err != nil {
+		return err
+	}
+
+	if o.outputPath == "" {
+		fmt.Print(string(kbBytes))
+		return nil
+	}
+
+	return ioutil.WriteFile(o.outputPath, kbBytes, 0644)
+}
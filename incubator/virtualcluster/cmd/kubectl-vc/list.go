@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vcclient "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/client/clientset/versioned"
+)
+
+const (
+	listExample = `
+	# List VirtualClusters in the "default" namespace (or the one given by -n)
+	kubectl vc list
+
+	# List VirtualClusters in all namespaces
+	kubectl vc list --all-namespaces`
+)
+
+type ListOptions struct {
+	vcclient      vcclient.Interface
+	namespace     string
+	allNamespaces bool
+}
+
+func NewCmdList(f Factory) *cobra.Command {
+	o := &ListOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List VirtualClusters",
+		Example: listExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			CheckErr(o.Complete(f, cmd))
+			CheckErr(o.Run())
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", metav1.NamespaceDefault, "If present, the namespace scope for this CLI request")
+	cmd.Flags().BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "If present, list VirtualClusters across all namespaces")
+
+	return cmd
+}
+
+func (o *ListOptions) Complete(f Factory, cmd *cobra.Command) error {
+	var err error
+	o.vcclient, err = f.VirtualClusterClientSet()
+	if err != nil {
+		return err
+	}
+
+	if o.allNamespaces {
+		o.namespace = metav1.NamespaceAll
+	}
+
+	return nil
+}
+
+func (o *ListOptions) Run() error {
+	vcs, err := o.vcclient.TenancyV1alpha1().VirtualClusters(o.namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tCLUSTERVERSION\tPHASE")
+	for _, vc := range vcs.Items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", vc.Namespace, vc.Name, vc.Spec.ClusterVersionName, vc.Status.Phase)
+	}
+
+	return nil
+}
@@ -41,6 +41,9 @@ func main() {
 	}
 
 	rootCmd.AddCommand(NewCmdCreate(f))
+	rootCmd.AddCommand(NewCmdList(f))
+	rootCmd.AddCommand(NewCmdDelete(f))
+	rootCmd.AddCommand(NewCmdKubeconfig(f))
 	rootCmd.AddCommand(NewCmdExec(f))
 
 	CheckErr(rootCmd.Execute())
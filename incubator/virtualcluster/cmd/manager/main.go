@@ -53,7 +53,7 @@ func main() {
 	)
 	flag.StringVar(&metricsAddr, "metrics-addr", ":0", "The address the metric endpoint binds to.")
 	flag.StringVar(&masterProvisioner, "master-prov", "native",
-		"The underlying platform that will provision master for virtualcluster.")
+		"The underlying platform that will provision master for virtualcluster. One of: native, aliyun, capi.")
 	flag.BoolVar(&leaderElection, "leader-election", true, "If enable leaderelection for vc-manager")
 	flag.StringVar(&leaderElectionCmName, "le-cm-name", "vc-manager-leaderelection-lock",
 		"The name of the configmap that will be used as the resourcelook for leaderelection")
@@ -83,12 +83,15 @@ func NewResourceSyncerOptions() (*ResourceSyncerOptions, error) {
 				},
 				LockObjectName: "syncer-leaderelection-lock",
 			},
-			ClientConnection:           componentbaseconfig.ClientConnectionConfiguration{},
-			DisableServiceAccountToken: true,
-			DefaultOpaqueMetaDomains:   []string{"kubernetes.io", "k8s.io"},
-			ExtraSyncingResources:      []string{},
-			VNAgentPort:                int32(10550),
-			VNAgentNamespacedName:      "vc-manager/vn-agent",
+			ClientConnection:            componentbaseconfig.ClientConnectionConfiguration{},
+			DisableServiceAccountToken:  true,
+			DefaultOpaqueMetaDomains:    []string{"kubernetes.io", "k8s.io"},
+			ExtraSyncingResources:       []string{},
+			VNAgentPort:                 int32(10550),
+			VNAgentNamespacedName:       "vc-manager/vn-agent",
+			ColdStartQPS:                float32(1),
+			ColdStartBurst:              5,
+			PlatformPushTargetNamespace: "kube-system",
 			FeatureGates: map[string]bool{
 				featuregate.SuperClusterPooling:        false,
 				featuregate.SuperClusterServiceNetwork: false,
@@ -120,6 +123,9 @@ func (o *ResourceSyncerOptions) Flags() cliflag.NamedFlagSets {
 	fs.Var(cliflag.NewMapStringBool(&o.ComponentConfig.FeatureGates), "feature-gates", "A set of key=value pairs that describe featuregate gates for various features.")
 	fs.Int32Var(&o.ComponentConfig.VNAgentPort, "vn-agent-port", 10550, "Port the vn-agent listens on")
 	fs.StringVar(&o.ComponentConfig.VNAgentNamespacedName, "vn-agent-namespace-name", "vc-manager/vn-agent", "Namespace/Name of the vn-agent running in cluster, used for VNodeProviderService")
+	fs.Float32Var(&o.ComponentConfig.ColdStartQPS, "cold-start-qps", o.ComponentConfig.ColdStartQPS, "Maximum number of VirtualClusters per second the syncer starts registering informers for on (re)start, to avoid a thundering herd against tenant apiservers. <= 0 disables throttling.")
+	fs.IntVar(&o.ComponentConfig.ColdStartBurst, "cold-start-burst", o.ComponentConfig.ColdStartBurst, "Burst size that goes with --cold-start-qps.")
+	fs.StringVar(&o.ComponentConfig.PlatformPushTargetNamespace, "platform-push-target-namespace", o.ComponentConfig.PlatformPushTargetNamespace, "Namespace created in every tenant cluster to receive ConfigMaps/Secrets marked for platform broadcast.")
 
 	serverFlags := fss.FlagSet("metricsServer")
 	serverFlags.StringVar(&o.Address, "address", o.Address, "The server address.")
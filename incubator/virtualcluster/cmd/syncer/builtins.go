@@ -24,9 +24,12 @@ import (
 	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/node"
 	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/persistentvolume"
 	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/persistentvolumeclaim"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/platformconfigmap"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/platformsecret"
 	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/pod"
 	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/secret"
 	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/service"
 	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/serviceaccount"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/statefulset"
 	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/storageclass"
 )
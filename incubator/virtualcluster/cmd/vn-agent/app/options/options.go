@@ -53,6 +53,20 @@ type ServerOption struct {
 
 	// FeatureGates enabled by the user.
 	FeatureGates map[string]bool
+
+	// BackendConfigFile, if set, points to a YAML file listing additional kubelet-compatible
+	// backends to route requests to based on the target pod's RuntimeClass. See
+	// config.LoadBackendConfig.
+	BackendConfigFile string
+
+	// TenantAuthnMode selects how vn-agent identifies the calling tenant: "cert" (the default) to
+	// use the client certificate's CommonName, or "token" to validate a bearer token against one
+	// of TenantAPIServersConfigFile's tenant apiservers.
+	TenantAuthnMode string
+	// TenantAPIServersConfigFile, required when TenantAuthnMode is "token", points to a YAML file
+	// listing the tenant apiservers vn-agent may validate bearer tokens against. See
+	// config.LoadTenantAPIServerConfig.
+	TenantAPIServersConfigFile string
 }
 
 // Subset of the full options exposed in k8s.io/kubernetes/pkg/kubelet/client.KubeletClientConfig
@@ -86,6 +100,9 @@ func (o *Options) Flags() cliflag.NamedFlagSets {
 	serverFS.StringVar(&o.TLSPrivateKeyFile, "tls-private-key-file", o.TLSPrivateKeyFile, "TLSPrivateKeyFile is the file containing x509 private key matching tlsCertFile")
 	serverFS.UintVar(&o.Port, "port", 10550, "Port is the server listening on")
 	serverFS.Var(cliflag.NewMapStringBool(&o.ServerOption.FeatureGates), "feature-gates", "A set of key=value pairs that describe featuregate gates for various features.")
+	serverFS.StringVar(&o.BackendConfigFile, "backend-config", o.BackendConfigFile, "Path to a YAML file listing additional kubelet-compatible backends to route requests to by RuntimeClass, e.g. for kata or virtual-kubelet nodes")
+	serverFS.StringVar(&o.TenantAuthnMode, "tenant-authn-mode", "cert", "How vn-agent identifies the calling tenant. One of: cert, token")
+	serverFS.StringVar(&o.TenantAPIServersConfigFile, "tenant-apiservers-config", o.TenantAPIServersConfigFile, "Path to a YAML file listing the tenant apiservers vn-agent may validate bearer tokens against. Required when --tenant-authn-mode=token")
 
 	kubeletFS := fss.FlagSet("kubelet")
 	kubeletFS.StringVar(&o.KubeletOption.CertFile, "kubelet-client-certificate", o.KubeletOption.CertFile, "Path to a client cert file for TLS")
@@ -105,9 +122,18 @@ func fileNotExistOrEmpty(fn string) bool {
 
 // Config is the config to create a vn-agent server handler.
 func (o *Options) Config() (*config.Config, *ServerOption, error) {
+	authnMode, tenantAPIServers, err := o.tenantAuthnConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// vc-kubelet-client may be a place holder that contains empty certificate and key data
 	if fileNotExistOrEmpty(o.KubeletOption.CertFile) || fileNotExistOrEmpty(o.KubeletOption.KeyFile) {
-		return &config.Config{KubeletClientCert: nil}, &o.ServerOption, nil
+		return &config.Config{
+			KubeletClientCert: nil,
+			AuthnMode:         authnMode,
+			TenantAPIServers:  tenantAPIServers,
+		}, &o.ServerOption, nil
 	}
 	kubeletClientCertPair, err := tls.LoadX509KeyPair(o.KubeletOption.CertFile, o.KubeletOption.KeyFile)
 	if err != nil {
@@ -119,8 +145,39 @@ func (o *Options) Config() (*config.Config, *ServerOption, error) {
 		return nil, nil, err
 	}
 
+	var backends []config.Backend
+	if o.BackendConfigFile != "" {
+		backends, err = config.LoadBackendConfig(o.BackendConfigFile)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to load backend config")
+		}
+	}
+
 	return &config.Config{
 		KubeletClientCert: &kubeletClientCertPair,
 		KubeletServerHost: fmt.Sprintf("https://127.0.0.1:%v", o.KubeletOption.Port),
+		Backends:          backends,
+		AuthnMode:         authnMode,
+		TenantAPIServers:  tenantAPIServers,
 	}, &o.ServerOption, nil
 }
+
+// tenantAuthnConfig resolves TenantAuthnMode and, if it's config.AuthnModeToken, loads
+// TenantAPIServersConfigFile.
+func (o *Options) tenantAuthnConfig() (config.AuthnMode, []config.TenantAPIServer, error) {
+	authnMode := config.AuthnMode(o.TenantAuthnMode)
+	if authnMode == "" {
+		authnMode = config.AuthnModeCert
+	}
+	if authnMode != config.AuthnModeToken {
+		return authnMode, nil, nil
+	}
+	if o.TenantAPIServersConfigFile == "" {
+		return "", nil, errors.New("--tenant-apiservers-config is required when --tenant-authn-mode=token")
+	}
+	tenantAPIServers, err := config.LoadTenantAPIServerConfig(o.TenantAPIServersConfigFile)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to load tenant apiservers config")
+	}
+	return authnMode, tenantAPIServers, nil
+}
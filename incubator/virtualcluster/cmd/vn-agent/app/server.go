@@ -94,6 +94,7 @@ func Run(c *config.Config, serverOption *options.ServerOption, stopCh <-chan str
 	if err != nil {
 		return errors.Wrapf(err, "create server")
 	}
+	handler.StartHealthChecks(stopCh)
 
 	s := &http.Server{
 		Addr:    fmt.Sprintf(":%d", serverOption.Port),
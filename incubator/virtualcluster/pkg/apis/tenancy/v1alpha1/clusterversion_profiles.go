@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// componentProfile is the set of defaults a ClusterVersionProfile applies to a single
+// StatefulSetSvcBundle component (APIServer, ControllerManager or ETCD).
+type componentProfile struct {
+	replicas  int32
+	resources corev1.ResourceRequirements
+}
+
+// profileDefaults maps each ClusterVersionProfile to the defaults it applies to every
+// component. These are intentionally conservative: they only ever fill in a component's
+// replica count and container resource requests, never touch images, flags or volumes, so
+// they can't clobber a component the user has already specified in detail.
+var profileDefaults = map[ClusterVersionProfile]componentProfile{
+	ProfileSmall: {
+		replicas: 1,
+		resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+		},
+	},
+	ProfileMedium: {
+		replicas: 1,
+		resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	},
+	ProfileLarge: {
+		replicas: 3,
+		resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+	},
+}
+
+// supportedKubernetesVersions is the compatibility matrix consulted at admission time: it
+// restricts which Kubernetes minor versions each profile has been validated against.
+// Profiles aren't a proxy for the Kubernetes version itself (a "large" control plane can back
+// any version it lists), but this lets us reject combinations that are known not to work,
+// e.g. because a component's default resource requests are too small for a newer version's
+// control plane.
+var supportedKubernetesVersions = map[ClusterVersionProfile][]string{
+	ProfileSmall:  {"1.18", "1.19", "1.20"},
+	ProfileMedium: {"1.18", "1.19", "1.20", "1.21"},
+	ProfileLarge:  {"1.19", "1.20", "1.21"},
+}
+
+// latestSupportedKubernetesVersion returns the newest Kubernetes version in the profile's
+// compatibility matrix, used to default Spec.KubernetesVersion when it's left empty.
+func latestSupportedKubernetesVersion(p ClusterVersionProfile) string {
+	versions := supportedKubernetesVersions[p]
+	if len(versions) == 0 {
+		return ""
+	}
+	return versions[len(versions)-1]
+}
+
+// isSupportedKubernetesVersion reports whether version is in the profile's compatibility
+// matrix. A profile with no matrix entry (i.e. not a recognized profile) supports nothing.
+func isSupportedKubernetesVersion(p ClusterVersionProfile, version string) bool {
+	for _, v := range supportedKubernetesVersions[p] {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckKubernetesVersionCompatibility checks a VirtualCluster's requested Kubernetes version, if
+// any, against this ClusterVersion's profile compatibility matrix. It's a no-op if either the
+// VirtualCluster didn't request a specific version or this ClusterVersion has no profile, since
+// hand-specified ClusterVersions aren't covered by the matrix.
+func (cv *ClusterVersion) CheckKubernetesVersionCompatibility(requestedKubernetesVersion string) error {
+	if requestedKubernetesVersion == "" || cv.Spec.Profile == "" {
+		return nil
+	}
+	if !isSupportedKubernetesVersion(cv.Spec.Profile, requestedKubernetesVersion) {
+		return fmt.Errorf("virtualcluster requested kubernetesVersion %q, but clusterversion %q's profile %q only supports %v",
+			requestedKubernetesVersion, cv.Name, cv.Spec.Profile, supportedKubernetesVersions[cv.Spec.Profile])
+	}
+	return nil
+}
+
+// applyProfileToComponent fills in a component's replica count and container resource
+// requests from the profile if they aren't already set, leaving everything else untouched.
+func applyProfileToComponent(defaults componentProfile, c *StatefulSetSvcBundle) {
+	if c == nil || c.StatefulSet == nil {
+		return
+	}
+	if c.StatefulSet.Spec.Replicas == nil {
+		replicas := defaults.replicas
+		c.StatefulSet.Spec.Replicas = &replicas
+	}
+	for i := range c.StatefulSet.Spec.Template.Spec.Containers {
+		ctr := &c.StatefulSet.Spec.Template.Spec.Containers[i]
+		if ctr.Resources.Requests == nil && ctr.Resources.Limits == nil {
+			ctr.Resources = defaults.resources
+		}
+	}
+}
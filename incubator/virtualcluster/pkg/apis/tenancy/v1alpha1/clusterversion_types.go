@@ -20,10 +20,41 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ClusterVersionProfile names a built-in control plane sizing profile. Setting
+// Spec.Profile to one of these lets the defaulting webhook fill in the replica
+// count and container resources of whichever APIServer/ControllerManager/ETCD
+// components are present, where those fields are unset. A component that is
+// left out is not created, and fields the user already set are left untouched.
+type ClusterVersionProfile string
+
+const (
+	ProfileSmall  ClusterVersionProfile = "small"
+	ProfileMedium ClusterVersionProfile = "medium"
+	ProfileLarge  ClusterVersionProfile = "large"
 )
 
 // ClusterVersionSpec defines the desired state of ClusterVersion
 type ClusterVersionSpec struct {
+	// Profile is a named sizing profile ("small", "medium" or "large") used by
+	// the defaulting webhook to fill in unset replica counts and container
+	// resources of the APIServer/ControllerManager/ETCD components below; it
+	// doesn't create a component that is left out. Leave empty to specify all
+	// three components by hand.
+	// +optional
+	// +kubebuilder:validation:Enum=small;medium;large
+	Profile ClusterVersionProfile `json:"profile,omitempty"`
+
+	// KubernetesVersion is the Kubernetes minor version (e.g. "1.20") that this
+	// ClusterVersion's control plane images implement. If Profile is set and
+	// this is left empty, it defaults to the newest version the profile has
+	// been validated against. It's checked against a VirtualCluster's
+	// requestedKubernetesVersion, if any, when the tenant is provisioned.
+	// +optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
 	// APIserver configuration of the virtual cluster
 	APIServer *StatefulSetSvcBundle `json:"apiServer,omitempty"`
 
@@ -32,6 +63,17 @@ type ClusterVersionSpec struct {
 
 	// ETCD configuration of the virtual cluster
 	ETCD *StatefulSetSvcBundle `json:"etcd,omitempty"`
+
+	// CAPIClusterTemplates are arbitrary Cluster API resources (e.g. a Cluster, an
+	// infrastructure-provider Cluster such as a DockerCluster/AWSCluster, and a control plane
+	// resource such as a KubeadmControlPlane) that the "capi" master provisioner instantiates
+	// verbatim into the virtual cluster's root namespace, letting VirtualCluster reuse whatever
+	// CAPI infrastructure provider is already installed instead of HNC having to know about it.
+	// The object of Kind "Cluster" has its name overwritten to the VirtualCluster's name so it can
+	// be found again later; every other object, and every cross-reference between them, is left
+	// exactly as authored.
+	// +optional
+	CAPIClusterTemplates []runtime.RawExtension `json:"capiClusterTemplates,omitempty"`
 }
 
 // StatefulSetSvcBundle contains a StatefulSet and the Service that exposed
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var cvlog = logf.Log.WithName("clusterversion-webhook")
+
+func (cv *ClusterVersion) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	cvlog.Info("setup clusterversion validation/defaulting webhook")
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(cv).
+		Complete()
+}
+
+var _ webhook.Defaulter = &ClusterVersion{}
+
+// Default implements webhook.Defaulter so that a mutating webhook will be registered for the
+// type. From Spec.Profile it fills in unset replica counts and container resources of the
+// APIServer/ControllerManager/ETCD components that are present (a missing component is not
+// created), and defaults KubernetesVersion to the newest version the profile supports.
+func (cv *ClusterVersion) Default() {
+	cvlog.Info("default", "cv-name", cv.Name)
+	if cv.Spec.Profile == "" {
+		return
+	}
+	defaults, ok := profileDefaults[cv.Spec.Profile]
+	if !ok {
+		// An unrecognized profile is rejected by ValidateCreate/ValidateUpdate; nothing to default.
+		return
+	}
+	applyProfileToComponent(defaults, cv.Spec.APIServer)
+	applyProfileToComponent(defaults, cv.Spec.ControllerManager)
+	applyProfileToComponent(defaults, cv.Spec.ETCD)
+	if cv.Spec.KubernetesVersion == "" {
+		cv.Spec.KubernetesVersion = latestSupportedKubernetesVersion(cv.Spec.Profile)
+	}
+}
+
+var _ webhook.Validator = &ClusterVersion{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (cv *ClusterVersion) ValidateCreate() error {
+	cvlog.Info("validate create", "cv-name", cv.Name)
+	return cv.validateClusterVersion()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (cv *ClusterVersion) ValidateUpdate(old runtime.Object) error {
+	cvlog.Info("validate update", "cv-name", cv.Name)
+	return cv.validateClusterVersion()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (cv *ClusterVersion) ValidateDelete() error {
+	cvlog.Info("validate delete", "cv-name", cv.Name)
+	// do nothing for delete request
+	return nil
+}
+
+func (cv *ClusterVersion) validateClusterVersion() error {
+	if cv.Spec.Profile == "" {
+		if cv.Spec.APIServer == nil || cv.Spec.ControllerManager == nil || cv.Spec.ETCD == nil {
+			return fmt.Errorf("clusterversion %q must either set profile or fully specify apiServer, controllerManager and etcd", cv.Name)
+		}
+		return nil
+	}
+	if _, ok := profileDefaults[cv.Spec.Profile]; !ok {
+		return fmt.Errorf("clusterversion %q has unknown profile %q", cv.Name, cv.Spec.Profile)
+	}
+	if cv.Spec.KubernetesVersion != "" && !isSupportedKubernetesVersion(cv.Spec.Profile, cv.Spec.KubernetesVersion) {
+		return fmt.Errorf("clusterversion %q requests kubernetesVersion %q, which is not in the compatibility matrix for profile %q (supported: %v)",
+			cv.Name, cv.Spec.KubernetesVersion, cv.Spec.Profile, supportedKubernetesVersions[cv.Spec.Profile])
+	}
+	return nil
+}
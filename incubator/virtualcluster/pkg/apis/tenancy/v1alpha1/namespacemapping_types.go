@@ -0,0 +1,64 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NamespaceMappingSpec records one entry of the tenant/super cluster namespace name lookup
+// table used by the UUID-based namespace name translation strategy (see
+// pkg/syncer/conversion.UUIDTableNamespaceStrategy). The object's Name is always set to the
+// super cluster namespace name, since that's guaranteed to be unique cluster-wide.
+type NamespaceMappingSpec struct {
+	// Cluster is the name of the virtual cluster that owns TenantNamespace.
+	Cluster string `json:"cluster"`
+
+	// TenantNamespace is the namespace name as seen inside the virtual cluster.
+	TenantNamespace string `json:"tenantNamespace"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=nsmap
+// +k8s:openapi-gen=true
+
+// NamespaceMapping is the Schema for the namespacemappings API. It's used as a lookup table
+// entry mapping a (cluster, tenant namespace) pair to the super cluster namespace it was
+// translated to, so the mapping can be recovered later without re-deriving it.
+// +k8s:openapi-gen=true
+type NamespaceMapping struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NamespaceMappingSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NamespaceMappingList contains a list of NamespaceMapping
+type NamespaceMappingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespaceMapping `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NamespaceMapping{}, &NamespaceMappingList{})
+}
@@ -32,6 +32,13 @@ type VirtualClusterSpec struct {
 	// The name of the desired cluster version
 	ClusterVersionName string `json:"clusterVersionName"`
 
+	// RequestedKubernetesVersion is the Kubernetes minor version (e.g. "1.20") the tenant expects
+	// its control plane to run. If set, it's checked against the referenced ClusterVersion's
+	// compatibility matrix when the virtual cluster is provisioned; if left empty, whatever
+	// version the ClusterVersion implements is used without any compatibility check.
+	// +optional
+	RequestedKubernetesVersion string `json:"requestedKubernetesVersion,omitempty"`
+
 	// The valid period of the tenant cluster PKI, if not set
 	// the PKI will never expire (i.e. 10 years)
 	// +optional
@@ -51,6 +58,65 @@ type VirtualClusterSpec struct {
 	// Service CIDRs used by VirtualCluster
 	// +optional
 	ServiceCidr string `json:"serviceCidr,omitempty"`
+
+	// DisabledAPIs restricts the Kubernetes API surface offered to this tenant, e.g. to offer a
+	// cheaper or more locked-down tenancy tier. Each entry is a bare resource name
+	// ("persistentvolumeclaims") to block every verb on that resource. Only the entries in
+	// SupportedDisabledAPIs are recognized; anything else is rejected by the validating webhook.
+	// +optional
+	DisabledAPIs []string `json:"disabledAPIs,omitempty"`
+
+	// EnforcePodSecurityCompliance blocks any tenant Pod that requests a hostPath volume,
+	// hostNetwork, or a privileged container from ever being created in the super cluster,
+	// regardless of what PodSecurityPolicy or admission control the tenant has configured on
+	// its own apiserver - a tenant could always relax or remove that.
+	// +optional
+	EnforcePodSecurityCompliance bool `json:"enforcePodSecurityCompliance,omitempty"`
+
+	// Paused scales the tenant control plane down to zero replicas and suspends syncer
+	// reconciliation for this virtual cluster, while preserving its etcd data so it can be resumed
+	// later. This is meant for cutting cost on idle dev/test tenants that don't need to be
+	// reachable all the time.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Backup configures automated periodic etcd snapshots of this tenant's control plane to
+	// object storage, so it can later be restored via RestoreFromSnapshot. If nil, no automated
+	// backups are taken.
+	// +optional
+	Backup *BackupSpec `json:"backup,omitempty"`
+
+	// RestoreFromSnapshot requests that this tenant's etcd be restored from the named snapshot
+	// (see Status.BackupStatus.LastSnapshotName for the most recent one) the next time its
+	// control plane is reconciled. It's cleared once the restore completes; after a failed
+	// attempt it stays set and the cluster stays Restoring, so the restore is run again.
+	// +optional
+	RestoreFromSnapshot string `json:"restoreFromSnapshot,omitempty"`
+}
+
+// BackupSpec configures scheduled etcd snapshots of a VirtualCluster's tenant control plane.
+type BackupSpec struct {
+	// Schedule is a standard cron expression (e.g. "0 * * * *") for how often to snapshot etcd.
+	Schedule string `json:"schedule"`
+
+	// StorageSecretRef names a Secret, in the same namespace as the VirtualCluster, that holds
+	// the object storage destination the snapshot is uploaded to: key "endpoint" is an HTTP(S)
+	// URL the snapshot is PUT to (with the snapshot's name appended as the last path segment),
+	// and key "authorizationHeader", if present, is sent verbatim as the request's Authorization
+	// header.
+	StorageSecretRef string `json:"storageSecretRef"`
+}
+
+// SupportedDisabledAPIs is the set of resource names that can be listed in
+// VirtualClusterSpec.DisabledAPIs. It's deliberately an allow-list rather than accepting arbitrary
+// strings, since each entry has to be wired into a real enforcement point to actually do
+// anything - today that's just the PVC syncer (see conversion.IsAPIDisabled's call site in
+// pkg/syncer/resources/persistentvolumeclaim). Subresources like "pods/exec" and "nodes/proxy"
+// aren't listed here: blocking them would require tenant apiserver flags or an admission
+// webhook in front of the tenant apiserver, neither of which exists yet. Add an entry here only
+// once its enforcement point exists, so this list never promises isolation it doesn't provide.
+var SupportedDisabledAPIs = map[string]bool{
+	"persistentvolumeclaims": true,
 }
 
 // VirtualClusterStatus defines the observed state of VirtualCluster
@@ -76,6 +142,155 @@ type VirtualClusterStatus struct {
 
 	// Cluster Conditions
 	Conditions []ClusterCondition `json:"conditions,omitempty"`
+
+	// SmokeTestStatus records the outcome of the end-to-end smoke test run against the
+	// tenant cluster once it reaches the Running phase.
+	// +optional
+	SmokeTestStatus *SmokeTestStatus `json:"smokeTestStatus,omitempty"`
+
+	// AppliedClusterVersionName is the name of the ClusterVersion currently deployed for this
+	// virtual cluster's control plane. It's set once the control plane is first created, and again
+	// each time an upgrade to a new ClusterVersionName completes. Comparing it against
+	// Spec.ClusterVersionName is how the controller notices that an upgrade has been requested.
+	// +optional
+	AppliedClusterVersionName string `json:"appliedClusterVersionName,omitempty"`
+
+	// UpgradeStatus records the progress of an in-place control plane upgrade, i.e. one triggered
+	// by changing Spec.ClusterVersionName while the cluster is Running. It's only set while
+	// Phase is Updating or if the most recent upgrade attempt failed.
+	// +optional
+	UpgradeStatus *UpgradeStatus `json:"upgradeStatus,omitempty"`
+
+	// BackupStatus reports the outcome of the most recent automated etcd snapshot taken per
+	// Spec.Backup.
+	// +optional
+	BackupStatus *BackupStatus `json:"backupStatus,omitempty"`
+
+	// RestoreStatus records the progress of restoring etcd from a snapshot, i.e. one triggered
+	// by setting Spec.RestoreFromSnapshot. It's only set while Phase is Restoring or if the most
+	// recent restore attempt failed.
+	// +optional
+	RestoreStatus *RestoreStatus `json:"restoreStatus,omitempty"`
+}
+
+// SnapshotPhase is the outcome of a single automated etcd snapshot attempt.
+type SnapshotPhase string
+
+const (
+	// The snapshot Job has been created but hasn't finished yet.
+	SnapshotRunning SnapshotPhase = "Running"
+
+	// The snapshot was taken and uploaded to object storage successfully.
+	SnapshotCompleted SnapshotPhase = "Completed"
+
+	// The snapshot Job failed; Message has details.
+	SnapshotFailed SnapshotPhase = "Failed"
+)
+
+// BackupStatus reports the outcome of automated etcd snapshots taken per Spec.Backup.
+type BackupStatus struct {
+	// LastScheduleTime is when the backup CronJob most recently started a snapshot Job.
+	// +optional
+	LastScheduleTime metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastSnapshotName is the object storage key of the most recently completed snapshot,
+	// suitable for use as Spec.RestoreFromSnapshot.
+	// +optional
+	LastSnapshotName string `json:"lastSnapshotName,omitempty"`
+
+	// LastSnapshotPhase is the outcome of the most recent snapshot attempt.
+	// +optional
+	LastSnapshotPhase SnapshotPhase `json:"lastSnapshotPhase,omitempty"`
+
+	// A human readable message with details about LastSnapshotPhase.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RestoreStatus reports the progress of restoring a tenant control plane's etcd from a backup
+// snapshot, triggered by Spec.RestoreFromSnapshot.
+type RestoreStatus struct {
+	// SnapshotName is the snapshot this restore attempt is applying.
+	SnapshotName string `json:"snapshotName"`
+
+	// A human readable message with details about the restore's progress or failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is when this restore attempt began.
+	// +optional
+	StartTime metav1.Time `json:"startTime,omitempty"`
+}
+
+// UpgradePhase is the current state of an in-place control plane upgrade.
+type UpgradePhase string
+
+const (
+	// The upgrade is rolling out; CurrentComponent names the component currently being upgraded.
+	UpgradeInProgress UpgradePhase = "InProgress"
+
+	// The upgrade failed to complete; the control plane may be left with a mix of component
+	// versions until Spec.ClusterVersionName is reverted or the upgrade is retried.
+	UpgradeFailed UpgradePhase = "Failed"
+)
+
+// UpgradeStatus reports the progress of a rolling upgrade of the tenant control plane's
+// etcd, apiserver and controller-manager StatefulSets from one ClusterVersion to another.
+type UpgradeStatus struct {
+	// Phase of the upgrade.
+	Phase UpgradePhase `json:"phase"`
+
+	// FromClusterVersionName is the ClusterVersion the control plane was running before this
+	// upgrade started.
+	FromClusterVersionName string `json:"fromClusterVersionName"`
+
+	// ToClusterVersionName is the ClusterVersion the control plane is being upgraded to.
+	ToClusterVersionName string `json:"toClusterVersionName"`
+
+	// CurrentComponent names the StatefulSet bundle (e.g. "etcd", "apiserver",
+	// "controller-manager") currently being rolled, or the one that failed to roll if
+	// Phase is Failed.
+	// +optional
+	CurrentComponent string `json:"currentComponent,omitempty"`
+
+	// A human readable message indicating details about the upgrade's progress or failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is when this upgrade attempt began.
+	// +optional
+	StartTime metav1.Time `json:"startTime,omitempty"`
+}
+
+// SmokeTestPhase is the current state of the VirtualCluster's smoke test.
+type SmokeTestPhase string
+
+const (
+	// The smoke test job has been created but hasn't finished yet.
+	SmokeTestRunning SmokeTestPhase = "Running"
+
+	// The smoke test job completed successfully.
+	SmokeTestPassed SmokeTestPhase = "Passed"
+
+	// The smoke test job failed or ran out of retries.
+	SmokeTestFailed SmokeTestPhase = "Failed"
+)
+
+// SmokeTestStatus reports the result of the automated smoke test (create namespace,
+// deployment, service, exec, logs) that is run against a tenant cluster once it becomes
+// Running, so operators don't have to take "the control plane came up" as a proxy for
+// "the tenant can actually use it".
+type SmokeTestStatus struct {
+	// Phase of the smoke test job.
+	Phase SmokeTestPhase `json:"phase"`
+
+	// A human readable message indicating details about the smoke test result.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastRunTime is when the smoke test job was last (re)created.
+	// +optional
+	LastRunTime metav1.Time `json:"lastRunTime,omitempty"`
 }
 
 type ClusterPhase string
@@ -93,6 +308,15 @@ const (
 	// Cluster can not be initiated, or occur the error that Operator
 	// can not recover
 	ClusterError ClusterPhase = "Error"
+
+	// The control plane has been scaled down to zero replicas in response to Spec.Paused, and
+	// the syncer has stopped reconciling this tenant. etcd data is preserved so the cluster can
+	// be resumed by clearing Spec.Paused.
+	ClusterPaused ClusterPhase = "Paused"
+
+	// The control plane's etcd is being restored from a snapshot named by
+	// Spec.RestoreFromSnapshot; see Status.RestoreStatus.
+	ClusterRestoring ClusterPhase = "Restoring"
 )
 
 type ClusterCondition struct {
@@ -17,21 +17,46 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"errors"
+	"sort"
+	"strconv"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 var vclog = logf.Log.WithName("virtualcluster-webhook")
 
+// ConfirmDestructiveChangeAnnotation must be set to "true" on a VirtualCluster to allow a
+// change that would otherwise be denied as destructive: a clusterVersion/Kubernetes version
+// downgrade, a PKI reset, or a deletion while the tenant namespace still has workloads. It's
+// read straight off the incoming object, so it works the same way under `--dry-run=server` as
+// it does for a real request.
+const ConfirmDestructiveChangeAnnotation = "tenancy.x-k8s.io/confirm-destructive-change"
+
+// vcClient is used by ValidateDelete to check whether the tenant namespace still has running
+// workloads. webhook.Validator methods aren't given a client of their own, so this is wired up
+// once via SetClient when the webhook server starts.
+var vcClient client.Client
+
+// SetClient gives the VirtualCluster webhook a client to look up live workloads with. It must
+// be called once, during webhook setup, before the webhook starts serving requests.
+func SetClient(c client.Client) {
+	vcClient = c
+}
+
 func (vc *VirtualCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	vclog.Info("setup virtualcluster validation webhook")
+	SetClient(mgr.GetClient())
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(vc).
 		Complete()
@@ -42,7 +67,11 @@ var _ webhook.Validator = &VirtualCluster{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (vc *VirtualCluster) ValidateCreate() error {
 	vclog.Info("validate create", "vc-name", vc.Name)
-	// do nothing for delete request
+	if errs := validateDisabledAPIs(vc.Spec.DisabledAPIs); len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: "tenancy.x-k8s.io", Kind: "VirtualCluster"},
+			vc.Name, errs)
+	}
 	return nil
 }
 
@@ -52,11 +81,32 @@ func (new *VirtualCluster) ValidateUpdate(old runtime.Object) error {
 	return new.validateVirtualClusterUpdate(old)
 }
 
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type. It
+// blocks deleting a VirtualCluster whose tenant namespace still has running workloads, unless
+// the deletion carries ConfirmDestructiveChangeAnnotation.
 func (vc *VirtualCluster) ValidateDelete() error {
 	vclog.Info("validate delete", "vc-name", vc.Name)
-	// do nothing for delete request
-	return nil
+	if vc.Annotations[ConfirmDestructiveChangeAnnotation] == "true" {
+		return nil
+	}
+	if vc.Status.ClusterNamespace == "" || vcClient == nil {
+		return nil
+	}
+	var pods corev1.PodList
+	if err := vcClient.List(context.TODO(), &pods, client.InNamespace(vc.Status.ClusterNamespace)); err != nil {
+		vclog.Error(err, "fail to list workloads in tenant namespace, allowing deletion", "namespace", vc.Status.ClusterNamespace)
+		return nil
+	}
+	if len(pods.Items) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: "tenancy.x-k8s.io", Kind: "VirtualCluster"},
+		vc.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("status").Child("clusterNamespace"),
+				"tenant namespace "+vc.Status.ClusterNamespace+" still has "+strconv.Itoa(len(pods.Items))+
+					" pod(s) running; set the "+ConfirmDestructiveChangeAnnotation+" annotation to confirm deletion anyway"),
+		})
 }
 
 func (vc *VirtualCluster) validateVirtualClusterUpdate(old runtime.Object) error {
@@ -65,6 +115,8 @@ func (vc *VirtualCluster) validateVirtualClusterUpdate(old runtime.Object) error
 	if !ok {
 		return errors.New("fail to assert runtime.Object to tenancyv1alpha1.VirtualCluster")
 	}
+	confirmed := vc.Annotations[ConfirmDestructiveChangeAnnotation] == "true"
+
 	// once the VC.Status.Phase is set, it can't be set to empty again
 	if oldVC.Status.Phase != "" && vc.Status.Phase == "" {
 		allErrs = append(allErrs,
@@ -74,5 +126,91 @@ func (vc *VirtualCluster) validateVirtualClusterUpdate(old runtime.Object) error
 			schema.GroupKind{Group: "tenancy.x-k8s.io", Kind: "VirtualCluster"},
 			vc.Name, allErrs)
 	}
+
+	// A downgrade of the requested Kubernetes version can leave the tenant control plane
+	// running components it doesn't understand (e.g. CRDs from a newer version); require an
+	// explicit confirmation unless the field is being cleared.
+	if !confirmed && isKubernetesVersionDowngrade(oldVC.Spec.RequestedKubernetesVersion, vc.Spec.RequestedKubernetesVersion) {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("requestedKubernetesVersion"),
+				"downgrading from "+oldVC.Spec.RequestedKubernetesVersion+" to "+vc.Spec.RequestedKubernetesVersion+
+					" is a destructive change; set the "+ConfirmDestructiveChangeAnnotation+" annotation to confirm"))
+	}
+
+	// Changing pkiExpireDays on a cluster that's already provisioned re-issues the tenant PKI,
+	// invalidating every certificate (and thus kubeconfig) handed out under the old one.
+	if !confirmed && oldVC.Status.Phase != "" && oldVC.Status.Phase != ClusterPending && oldVC.Spec.PKIExpireDays != vc.Spec.PKIExpireDays {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec").Child("pkiExpireDays"),
+				"changing pkiExpireDays on a provisioned virtualcluster resets its PKI and invalidates existing kubeconfigs; set the "+
+					ConfirmDestructiveChangeAnnotation+" annotation to confirm"))
+	}
+
+	allErrs = append(allErrs, validateDisabledAPIs(vc.Spec.DisabledAPIs)...)
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: "tenancy.x-k8s.io", Kind: "VirtualCluster"},
+			vc.Name, allErrs)
+	}
 	return nil
 }
+
+// validateDisabledAPIs rejects any entry in disabledAPIs that isn't in SupportedDisabledAPIs,
+// since an unrecognized entry wouldn't be wired into any enforcement point and would silently do
+// nothing.
+func validateDisabledAPIs(disabledAPIs []string) field.ErrorList {
+	var errs field.ErrorList
+	for i, api := range disabledAPIs {
+		if !SupportedDisabledAPIs[api] {
+			errs = append(errs, field.NotSupported(
+				field.NewPath("spec").Child("disabledAPIs").Index(i), api, supportedDisabledAPIList()))
+		}
+	}
+	return errs
+}
+
+func supportedDisabledAPIList() []string {
+	apis := make([]string, 0, len(SupportedDisabledAPIs))
+	for api := range SupportedDisabledAPIs {
+		apis = append(apis, api)
+	}
+	sort.Strings(apis)
+	return apis
+}
+
+// isKubernetesVersionDowngrade reports whether newVersion is a lower Kubernetes minor version
+// than oldVersion. Both are expected in "<major>.<minor>" form, matching
+// VirtualClusterSpec.RequestedKubernetesVersion; anything else is treated as not a downgrade,
+// since it's not this webhook's job to reject a malformed version (that's already handled when
+// the version is checked against the ClusterVersion's compatibility matrix).
+func isKubernetesVersionDowngrade(oldVersion, newVersion string) bool {
+	if oldVersion == "" || newVersion == "" || oldVersion == newVersion {
+		return false
+	}
+	oldMajor, oldMinor, ok := parseMajorMinor(oldVersion)
+	if !ok {
+		return false
+	}
+	newMajor, newMinor, ok := parseMajorMinor(newVersion)
+	if !ok {
+		return false
+	}
+	return newMajor < oldMajor || (newMajor == oldMajor && newMinor < oldMinor)
+}
+
+func parseMajorMinor(version string) (major, minor int, ok bool) {
+	parts := strings.SplitN(version, ".", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
@@ -71,6 +71,14 @@ func AddToManager(m *vcmanager.VirtualClusterManager, masterProvisioner string)
 		if err := f(m, masterProvisioner); err != nil {
 			return err
 		}
+	case "capi":
+		f, exist := AddToManagerFuncs[VirtualClusterController]
+		if !exist {
+			return fmt.Errorf("%s not found", VirtualClusterController)
+		}
+		if err := f(m, masterProvisioner); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -24,6 +24,7 @@ import (
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -94,6 +95,34 @@ func WaitStatefulSetReady(cli client.Client, namespace, name string, timeOutSec,
 	}
 }
 
+// WaitJobComplete checks if the Job 'namespace/name' completes successfully within the
+// 'timeout', returning an error if it instead fails or doesn't finish in time.
+func WaitJobComplete(cli client.Client, namespace, name string, timeOutSec, periodSec int64) error {
+	timeOut := time.After(time.Duration(timeOutSec) * time.Second)
+	for {
+		period := time.After(time.Duration(periodSec) * time.Second)
+		select {
+		case <-timeOut:
+			return fmt.Errorf("%s/%s did not complete in %d seconds", namespace, name, timeOutSec)
+		case <-period:
+			job := &batchv1.Job{}
+			if err := cli.Get(context.TODO(), types.NamespacedName{
+				Namespace: namespace,
+				Name:      name,
+			}, job); err != nil {
+				return err
+			}
+
+			if job.Status.Succeeded > 0 {
+				return nil
+			}
+			if job.Status.Failed > 0 {
+				return fmt.Errorf("%s/%s failed", namespace, name)
+			}
+		}
+	}
+}
+
 // CreateRootNS creates the root namespace for the vc
 func CreateRootNS(cli client.Client, vc *tenancyv1alpha1.VirtualCluster) (string, error) {
 	nsName := conversion.ToClusterKey(vc)
@@ -0,0 +1,249 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backup builds the CronJob and Job that take and restore etcd snapshots of a tenant
+// control plane, per VirtualClusterSpec.Backup and Spec.RestoreFromSnapshot.
+package backup
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tenancyv1alpha1 "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/apis/tenancy/v1alpha1"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/controller/secret"
+)
+
+// CronJobName is the name of the backup CronJob created in the virtual cluster's cluster
+// namespace while Spec.Backup is set. It's fixed so reconciling Spec.Backup again simply
+// updates the existing CronJob instead of creating a new one.
+const CronJobName = "etcd-backup"
+
+// RestoreJobName is the name of the one-off restore Job created to service a
+// Spec.RestoreFromSnapshot request. It's fixed so a re-run simply replaces the previous
+// attempt's Job.
+const RestoreJobName = "etcd-restore"
+
+// curlImage uploads/downloads the snapshot over HTTP; it's kept separate from the etcdctl step
+// so the etcdctl container can keep using the tenant's own etcd image.
+const curlImage = "curlimages/curl:7.78.0"
+
+const (
+	pkiMountPath    = "/etc/kubernetes/pki"
+	snapshotMount   = "/snapshot"
+	snapshotFile    = snapshotMount + "/snapshot.db"
+	etcdClientURL   = "https://etcd:2379"
+	snapshotVolName = "snapshot"
+)
+
+// etcdctlArgs are the flags every etcdctl invocation in these Jobs needs to talk to the
+// tenant's etcd over TLS, reusing the etcd server's own certificate as the client certificate,
+// the same way the etcd StatefulSet's own liveness probe does.
+var etcdctlArgs = []string{
+	"--endpoints=" + etcdClientURL,
+	"--cacert=" + pkiMountPath + "/root/tls.crt",
+	"--cert=" + pkiMountPath + "/etcd/tls.crt",
+	"--key=" + pkiMountPath + "/etcd/tls.key",
+}
+
+func pkiVolumes() []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name:         "etcd-ca",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secret.ETCDCASecretName}},
+		},
+		{
+			Name:         "root-ca",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secret.RootCASecretName}},
+		},
+		{
+			Name:         snapshotVolName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+	}
+}
+
+func pkiVolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{Name: "etcd-ca", MountPath: pkiMountPath + "/etcd", ReadOnly: true},
+		{Name: "root-ca", MountPath: pkiMountPath + "/root", ReadOnly: true},
+	}
+}
+
+func snapshotVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{Name: snapshotVolName, MountPath: snapshotMount}
+}
+
+// storageEnv turns the keys of the Spec.Backup.StorageSecretRef Secret into the environment
+// variables the curl containers below use to reach object storage.
+func storageEnv(storageSecretRef string) []corev1.EnvVar {
+	optional := true
+	return []corev1.EnvVar{
+		{
+			Name: "STORAGE_ENDPOINT",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: storageSecretRef},
+				Key:                  "endpoint",
+			}},
+		},
+		{
+			Name: "STORAGE_AUTHORIZATION_HEADER",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: storageSecretRef},
+				Key:                  "authorizationHeader",
+				Optional:             &optional,
+			}},
+		},
+	}
+}
+
+// uploadScript PUTs the snapshot taken by the etcdctl initContainer to
+// "$STORAGE_ENDPOINT/<snapshotName>".
+const uploadScript = `set -euo pipefail
+curl -fsS -X PUT -H "Authorization: ${STORAGE_AUTHORIZATION_HEADER:-}" --data-binary @` + snapshotFile + ` "$STORAGE_ENDPOINT/$SNAPSHOT_NAME"`
+
+// downloadScript fetches the named snapshot from "$STORAGE_ENDPOINT/<snapshotName>" ahead of
+// the etcdctl restore container.
+const downloadScript = `set -euo pipefail
+curl -fsS -H "Authorization: ${STORAGE_AUTHORIZATION_HEADER:-}" -o ` + snapshotFile + ` "$STORAGE_ENDPOINT/$SNAPSHOT_NAME"`
+
+// NewCronJob builds the CronJob that periodically snapshots vc's etcd and uploads it to object
+// storage per backupSpec, running on backupSpec.Schedule. etcdImage is the image of vc's own
+// etcd component, so etcdctl is guaranteed to match the server it's talking to.
+func NewCronJob(vc *tenancyv1alpha1.VirtualCluster, namespace, etcdImage string, backupSpec *tenancyv1alpha1.BackupSpec) *batchv1beta1.CronJob {
+	backoffLimit := int32(2)
+	successHistory := int32(3)
+	failedHistory := int32(3)
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		InitContainers: []corev1.Container{
+			{
+				Name:         "snapshot",
+				Image:        etcdImage,
+				Command:      []string{"etcdctl"},
+				Args:         append([]string{"snapshot", "save", snapshotFile}, etcdctlArgs...),
+				Env:          []corev1.EnvVar{{Name: "ETCDCTL_API", Value: "3"}},
+				VolumeMounts: append(pkiVolumeMounts(), snapshotVolumeMount()),
+			},
+		},
+		Containers: []corev1.Container{
+			{
+				Name:    "upload",
+				Image:   curlImage,
+				Command: []string{"sh", "-c", uploadScript},
+				// The upload destination is keyed by the Job name the CronJob generates for this run, so
+				// each snapshot gets a distinct, discoverable object storage key. JOB_NAME must come
+				// before SNAPSHOT_NAME in this list: Kubernetes only expands a container's $(VAR)
+				// references against variables defined earlier in the same Env list.
+				Env: append([]corev1.EnvVar{
+					{
+						Name: "JOB_NAME",
+						ValueFrom: &corev1.EnvVarSource{
+							FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels['job-name']"},
+						},
+					},
+					{Name: "SNAPSHOT_NAME", Value: "$(JOB_NAME).db"},
+				}, storageEnv(backupSpec.StorageSecretRef)...),
+				VolumeMounts: []corev1.VolumeMount{snapshotVolumeMount()},
+			},
+		},
+		Volumes: pkiVolumes(),
+	}
+
+	return &batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CronJobName,
+			Namespace: namespace,
+			Labels:    map[string]string{"tenancy.x-k8s.io/virtualcluster": vc.Name},
+		},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:                   backupSpec.Schedule,
+			SuccessfulJobsHistoryLimit: &successHistory,
+			FailedJobsHistoryLimit:     &failedHistory,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					BackoffLimit: &backoffLimit,
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"tenancy.x-k8s.io/virtualcluster": vc.Name}},
+						Spec:       podSpec,
+					},
+				},
+			},
+		},
+	}
+}
+
+// NewRestoreJob builds the one-off Job that downloads snapshotName from object storage and
+// restores it into dataVolume, the same Volume the etcd StatefulSet mounts its data directory
+// from - the caller is responsible for scaling that StatefulSet to zero replicas first and back
+// up afterwards. restoreArgs are the extra etcdctl flags (--name, --initial-cluster,
+// --initial-advertise-peer-urls) needed to bring the restored member back up with the same
+// identity the StatefulSet's Pod expects.
+func NewRestoreJob(vc *tenancyv1alpha1.VirtualCluster, namespace, etcdImage, storageSecretRef, snapshotName, dataDir string, dataVolume corev1.Volume, dataVolumeMount corev1.VolumeMount, restoreArgs []string) *batchv1.Job {
+	backoffLimit := int32(0)
+
+	restoreCmd := append([]string{"snapshot", "restore", snapshotFile, "--data-dir=" + dataDir}, restoreArgs...)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RestoreJobName,
+			Namespace: namespace,
+			Labels:    map[string]string{"tenancy.x-k8s.io/virtualcluster": vc.Name},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"tenancy.x-k8s.io/virtualcluster": vc.Name}},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					InitContainers: []corev1.Container{
+						{
+							Name:         "download",
+							Image:        curlImage,
+							Command:      []string{"sh", "-c", downloadScript},
+							Env:          append(storageEnv(storageSecretRef), corev1.EnvVar{Name: "SNAPSHOT_NAME", Value: snapshotName}),
+							VolumeMounts: []corev1.VolumeMount{snapshotVolumeMount()},
+						},
+						{
+							// Wipe out the old data directory so etcdctl snapshot restore, which
+							// refuses to write into an existing one, can recreate it from scratch.
+							Name:         "clean-data-dir",
+							Image:        etcdImage,
+							Command:      []string{"sh", "-c", "rm -rf " + dataDir},
+							VolumeMounts: []corev1.VolumeMount{dataVolumeMount},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:         "restore",
+							Image:        etcdImage,
+							Command:      []string{"etcdctl"},
+							Args:         restoreCmd,
+							Env:          []corev1.EnvVar{{Name: "ETCDCTL_API", Value: "3"}},
+							VolumeMounts: []corev1.VolumeMount{snapshotVolumeMount(), dataVolumeMount},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{Name: snapshotVolName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+						dataVolume,
+					},
+				},
+			},
+		},
+	}
+}
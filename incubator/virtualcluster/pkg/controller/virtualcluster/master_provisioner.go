@@ -22,6 +22,23 @@ import (
 
 type MasterProvisioner interface {
 	CreateVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error
+	// UpdateVirtualCluster reconciles the control plane of an already-Running vc towards the
+	// ClusterVersion named by vc.Spec.ClusterVersionName, which may differ from the one it was
+	// created or last upgraded to (vc.Status.AppliedClusterVersionName).
+	UpdateVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error
+	// PauseVirtualCluster scales the control plane of a Running vc down to zero replicas in
+	// response to Spec.Paused, preserving its etcd data so it can be resumed later.
+	PauseVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error
+	// ResumeVirtualCluster scales the control plane of a Paused vc back up to its normal replica
+	// counts.
+	ResumeVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error
+	// EnsureBackupSchedule reconciles the periodic etcd snapshot CronJob for vc towards
+	// vc.Spec.Backup (creating, updating or deleting it as Spec.Backup is set, changed or
+	// cleared), and refreshes vc.Status.BackupStatus from the most recent snapshot Job it finds.
+	EnsureBackupSchedule(vc *tenancyv1alpha1.VirtualCluster) error
+	// RestoreVirtualCluster restores vc's etcd from the named snapshot in object storage (see
+	// Spec.Backup.StorageSecretRef), scaling the etcd StatefulSet down and back up around it.
+	RestoreVirtualCluster(vc *tenancyv1alpha1.VirtualCluster, snapshotName string) error
 	DeleteVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error
 	GetMasterProvisioner() string
 }
@@ -220,6 +220,35 @@ PollASK:
 	return nil
 }
 
+// UpdateVirtualCluster is not yet supported for the Aliyun provisioner: the ASK cluster's control
+// plane is managed by Aliyun, not by StatefulSets the VirtualCluster controller could roll itself, so an in-place
+// ClusterVersion upgrade has no equivalent operation to perform here yet.
+func (mpa *MasterProvisionerAliyun) UpdateVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error {
+	return fmt.Errorf("in-place upgrade is not supported by the aliyun master provisioner")
+}
+
+// PauseVirtualCluster is not yet supported for the Aliyun provisioner: the ASK cluster's control
+// plane is managed by Aliyun, not by StatefulSets the VirtualCluster controller could scale to zero itself.
+func (mpa *MasterProvisionerAliyun) PauseVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error {
+	return fmt.Errorf("pausing is not supported by the aliyun master provisioner")
+}
+
+// ResumeVirtualCluster is not yet supported for the Aliyun provisioner; see PauseVirtualCluster.
+func (mpa *MasterProvisionerAliyun) ResumeVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error {
+	return fmt.Errorf("resuming is not supported by the aliyun master provisioner")
+}
+
+// EnsureBackupSchedule is not yet supported for the Aliyun provisioner: the ASK cluster's etcd
+// is managed by Aliyun, which has its own backup mechanism outside of the VirtualCluster controller's control.
+func (mpa *MasterProvisionerAliyun) EnsureBackupSchedule(vc *tenancyv1alpha1.VirtualCluster) error {
+	return fmt.Errorf("automated backup is not supported by the aliyun master provisioner")
+}
+
+// RestoreVirtualCluster is not yet supported for the Aliyun provisioner; see EnsureBackupSchedule.
+func (mpa *MasterProvisionerAliyun) RestoreVirtualCluster(vc *tenancyv1alpha1.VirtualCluster, snapshotName string) error {
+	return fmt.Errorf("restoring from a snapshot is not supported by the aliyun master provisioner")
+}
+
 // DeleteVirtualCluster deletes the ASK cluster corresponding to the given VirtualCluster
 // NOTE DeleteVirtualCluster only sends the deletion request to Aliyun and do not promise
 // the ASK will be deleted
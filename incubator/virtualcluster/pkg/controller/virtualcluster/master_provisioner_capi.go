@@ -0,0 +1,221 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualcluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	tenancyv1alpha1 "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/apis/tenancy/v1alpha1"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/controller/secret"
+	kubeutil "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/controller/util/kube"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/conversion"
+)
+
+const (
+	// capiClusterKind is the Kind of the one object in a ClusterVersion's CAPIClusterTemplates
+	// that identifies the Cluster API Cluster itself, as opposed to its infrastructure or control
+	// plane resources.
+	capiClusterKind = "Cluster"
+
+	// capiKubeconfigWaitTimeoutSec/PeriodSec bound how long MasterProvisionerCapi waits for CAPI's
+	// own controllers to provision the tenant control plane and publish its kubeconfig Secret.
+	capiKubeconfigWaitTimeoutSec = 900
+	capiKubeconfigPeriodSec      = 5
+)
+
+// MasterProvisionerCapi provisions a virtual cluster's control plane by instantiating a
+// ClusterVersion's CAPIClusterTemplates (a Cluster API Cluster plus whatever infrastructure and
+// control plane resources it references) instead of running the control plane as StatefulSets on
+// the meta cluster, so VirtualCluster can reuse any infrastructure provider CAPI already supports.
+type MasterProvisionerCapi struct {
+	client.Client
+	scheme *runtime.Scheme
+}
+
+func NewMasterProvisionerCapi(mgr manager.Manager) (*MasterProvisionerCapi, error) {
+	return &MasterProvisionerCapi{
+		Client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+	}, nil
+}
+
+// CreateVirtualCluster instantiates vc's ClusterVersion's CAPIClusterTemplates in vc's root
+// namespace, then waits for CAPI to publish the resulting cluster's kubeconfig and republishes it
+// as the standard admin-kubeconfig secret the rest of VirtualCluster expects.
+func (mpc *MasterProvisionerCapi) CreateVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error {
+	cvs := &tenancyv1alpha1.ClusterVersionList{}
+	if err := mpc.List(context.TODO(), cvs, client.InNamespace("")); err != nil {
+		return err
+	}
+	cv := getClusterVersion(cvs, vc.Spec.ClusterVersionName)
+	if cv == nil {
+		return fmt.Errorf("desired ClusterVersion %s not found", vc.Spec.ClusterVersionName)
+	}
+	if len(cv.Spec.CAPIClusterTemplates) == 0 {
+		return fmt.Errorf("ClusterVersion %s has no capiClusterTemplates set, required by the %q master provisioner", cv.Name, mpc.GetMasterProvisioner())
+	}
+
+	ns, err := kubeutil.CreateRootNS(mpc, vc)
+	if err != nil {
+		return err
+	}
+
+	if err := mpc.createCapiResources(vc, cv, ns); err != nil {
+		return err
+	}
+
+	kubeconfig, err := mpc.waitCapiKubeconfig(vc.Name, ns)
+	if err != nil {
+		return err
+	}
+
+	adminSrt := secret.KubeconfigToSecret(secret.AdminSecretName, ns, kubeconfig)
+	if err := mpc.Create(context.TODO(), adminSrt); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// createCapiResources instantiates every object in cv.Spec.CAPIClusterTemplates into namespace
+// ns, renaming the Cluster object to vc.Name so it can be found again by DeleteVirtualCluster and
+// waitCapiKubeconfig. Every other object, and every cross-reference between them (e.g. a
+// KubeadmControlPlane's infrastructureRef), is created exactly as authored.
+func (mpc *MasterProvisionerCapi) createCapiResources(vc *tenancyv1alpha1.VirtualCluster, cv *tenancyv1alpha1.ClusterVersion, ns string) error {
+	for i, raw := range cv.Spec.CAPIClusterTemplates {
+		obj := &unstructured.Unstructured{}
+		if _, _, err := unstructured.UnstructuredJSONScheme.Decode(raw.Raw, nil, obj); err != nil {
+			return fmt.Errorf("decode ClusterVersion %s CAPIClusterTemplates[%d]: %v", cv.Name, i, err)
+		}
+		obj.SetNamespace(ns)
+		if obj.GetKind() == capiClusterKind {
+			obj.SetName(vc.Name)
+		}
+
+		if err := mpc.Create(context.TODO(), obj); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf("create %s %s/%s: %v", obj.GetKind(), ns, obj.GetName(), err)
+			}
+			log.Info("CAPI resource already exists", "kind", obj.GetKind(), "namespace", ns, "name", obj.GetName())
+		}
+	}
+	return nil
+}
+
+// waitCapiKubeconfig polls for the kubeconfig Secret that Cluster API's control plane controller
+// publishes for the Cluster named clusterName, under the "<clusterName>-kubeconfig" naming
+// convention shared by every CAPI control plane provider, and returns its contents.
+func (mpc *MasterProvisionerCapi) waitCapiKubeconfig(clusterName, ns string) (string, error) {
+	secretName := clusterName + "-kubeconfig"
+	timeOut := time.After(capiKubeconfigWaitTimeoutSec * time.Second)
+	for {
+		select {
+		case <-timeOut:
+			return "", fmt.Errorf("kubeconfig secret %s/%s not published within %d seconds", ns, secretName, capiKubeconfigWaitTimeoutSec)
+		case <-time.After(capiKubeconfigPeriodSec * time.Second):
+			srt := &v1.Secret{}
+			err := mpc.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: secretName}, srt)
+			if err != nil {
+				if !apierrors.IsNotFound(err) {
+					return "", err
+				}
+				continue
+			}
+			if kubeconfig, ok := srt.Data["value"]; ok {
+				return string(kubeconfig), nil
+			}
+		}
+	}
+}
+
+// UpdateVirtualCluster is not yet supported for the CAPI provisioner: the control plane is
+// managed by the Cluster API templates in the ClusterVersion, and rolling an in-place upgrade of
+// those objects safely (rather than just deleting and recreating the Cluster) isn't implemented.
+func (mpc *MasterProvisionerCapi) UpdateVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error {
+	return fmt.Errorf("in-place upgrade is not supported by the capi master provisioner")
+}
+
+// PauseVirtualCluster is not yet supported for the CAPI provisioner: the control plane is managed
+// by the Cluster API templates in the ClusterVersion, which don't expose a scale-to-zero knob the
+// VirtualCluster controller can drive directly.
+func (mpc *MasterProvisionerCapi) PauseVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error {
+	return fmt.Errorf("pausing is not supported by the capi master provisioner")
+}
+
+// ResumeVirtualCluster is not yet supported for the CAPI provisioner; see PauseVirtualCluster.
+func (mpc *MasterProvisionerCapi) ResumeVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error {
+	return fmt.Errorf("resuming is not supported by the capi master provisioner")
+}
+
+// EnsureBackupSchedule is not yet supported for the CAPI provisioner: the control plane's etcd
+// is managed by the Cluster API templates in the ClusterVersion, which don't expose a snapshot
+// hook the VirtualCluster controller can drive directly.
+func (mpc *MasterProvisionerCapi) EnsureBackupSchedule(vc *tenancyv1alpha1.VirtualCluster) error {
+	return fmt.Errorf("automated backup is not supported by the capi master provisioner")
+}
+
+// RestoreVirtualCluster is not yet supported for the CAPI provisioner; see EnsureBackupSchedule.
+func (mpc *MasterProvisionerCapi) RestoreVirtualCluster(vc *tenancyv1alpha1.VirtualCluster, snapshotName string) error {
+	return fmt.Errorf("restoring from a snapshot is not supported by the capi master provisioner")
+}
+
+// DeleteVirtualCluster deletes the CAPI Cluster object for vc; deleting it triggers CAPI's own
+// controllers to tear down the control plane and its infrastructure.
+func (mpc *MasterProvisionerCapi) DeleteVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error {
+	ns := conversion.ToClusterKey(vc)
+	cvs := &tenancyv1alpha1.ClusterVersionList{}
+	if err := mpc.List(context.TODO(), cvs, client.InNamespace("")); err != nil {
+		return err
+	}
+	cv := getClusterVersion(cvs, vc.Spec.ClusterVersionName)
+	if cv == nil {
+		// Nothing we know how to identify the Cluster object's apiVersion/kind from - nothing left
+		// to do beyond what the root namespace's own deletion (handled elsewhere) already covers.
+		return nil
+	}
+
+	for _, raw := range cv.Spec.CAPIClusterTemplates {
+		obj := &unstructured.Unstructured{}
+		if _, _, err := unstructured.UnstructuredJSONScheme.Decode(raw.Raw, nil, obj); err != nil {
+			continue
+		}
+		if obj.GetKind() != capiClusterKind {
+			continue
+		}
+		obj.SetNamespace(ns)
+		obj.SetName(vc.Name)
+		if err := mpc.Delete(context.TODO(), obj); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mpc *MasterProvisionerCapi) GetMasterProvisioner() string {
+	return "capi"
+}
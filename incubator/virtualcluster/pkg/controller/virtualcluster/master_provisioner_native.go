@@ -21,10 +21,16 @@ import (
 	"crypto/rsa"
 	"errors"
 	"fmt"
+	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/cert"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -34,6 +40,7 @@ import (
 	vcpki "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/controller/pki"
 	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/controller/secret"
 	kubeutil "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/controller/util/kube"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/controller/virtualcluster/backup"
 	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/conversion"
 	pkiutil "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/pki"
 )
@@ -71,6 +78,9 @@ func (mpn *MasterProvisionerNative) CreateVirtualCluster(vc *tenancyv1alpha1.Vir
 			vc.Spec.ClusterVersionName)
 		return err
 	}
+	if err := cv.CheckKubernetesVersionCompatibility(vc.Spec.RequestedKubernetesVersion); err != nil {
+		return err
+	}
 	// 1. create the root ns
 	_, err = kubeutil.CreateRootNS(mpn, vc)
 	if err != nil {
@@ -118,6 +128,69 @@ func (mpn *MasterProvisionerNative) CreateVirtualCluster(vc *tenancyv1alpha1.Vir
 	return nil
 }
 
+// UpdateVirtualCluster rolls the control plane of vc from its currently-applied ClusterVersion to
+// the one named by vc.Spec.ClusterVersionName, one StatefulSet at a time. etcd goes first since
+// the other two components depend on it, then apiserver, then controller-manager; each is only
+// rolled once the previous one is fully ready, the same ordering CreateVirtualCluster uses to
+// stand the control plane up in the first place.
+func (mpn *MasterProvisionerNative) UpdateVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error {
+	cvs := &tenancyv1alpha1.ClusterVersionList{}
+	if err := mpn.List(context.TODO(), cvs, client.InNamespace("")); err != nil {
+		return err
+	}
+
+	cv := getClusterVersion(cvs, vc.Spec.ClusterVersionName)
+	if cv == nil {
+		return fmt.Errorf("desired ClusterVersion %s not found", vc.Spec.ClusterVersionName)
+	}
+	if err := cv.CheckKubernetesVersionCompatibility(vc.Spec.RequestedKubernetesVersion); err != nil {
+		return err
+	}
+
+	for _, ssBdl := range []*tenancyv1alpha1.StatefulSetSvcBundle{cv.Spec.ETCD, cv.Spec.APIServer, cv.Spec.ControllerManager} {
+		if vc.Status.UpgradeStatus != nil {
+			vc.Status.UpgradeStatus.CurrentComponent = ssBdl.Name
+		}
+		if err := mpn.upgradeComponent(vc, ssBdl); err != nil {
+			return fmt.Errorf("failed to upgrade %s: %v", ssBdl.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// upgradeComponent rolls the existing StatefulSet for a master component to the Pod template
+// defined by the target ClusterVersion's ssBdl, then waits for the rollout to finish. Unlike
+// deployComponent, it doesn't touch the component's Service, since a Service's spec shouldn't
+// change across a ClusterVersion upgrade.
+func (mpn *MasterProvisionerNative) upgradeComponent(vc *tenancyv1alpha1.VirtualCluster, ssBdl *tenancyv1alpha1.StatefulSetSvcBundle) error {
+	log.Info("upgrading StatefulSet for master component", "component", ssBdl.Name)
+
+	ns := conversion.ToClusterKey(vc)
+	switch ssBdl.Name {
+	case "etcd":
+		complementETCDTemplate(ns, ssBdl)
+	case "apiserver":
+		complementAPIServerTemplate(ns, ssBdl)
+	case "controller-manager":
+		complementCtrlMgrTemplate(ns, ssBdl)
+	default:
+		return fmt.Errorf("try to upgrade unknown component: %s", ssBdl.Name)
+	}
+
+	existing := &appsv1.StatefulSet{}
+	key := types.NamespacedName{Namespace: ns, Name: ssBdl.StatefulSet.Name}
+	if err := mpn.Get(context.TODO(), key, existing); err != nil {
+		return err
+	}
+	existing.Spec.Template = ssBdl.StatefulSet.Spec.Template
+	if err := mpn.Update(context.TODO(), existing); err != nil {
+		return err
+	}
+
+	return kubeutil.WaitStatefulSetReady(mpn, ns, ssBdl.GetName(), DeployTimeOutSec, ComponentPollPeriodSec)
+}
+
 // genInitialClusterArgs generates the values for `--inital-cluster` option of etcd based on the number of
 // replicas specified in etcd StatefulSet
 func genInitialClusterArgs(replicas int32, stsName, svcName string) (argsVal string) {
@@ -343,6 +416,268 @@ func (mpn *MasterProvisionerNative) createPKI(vc *tenancyv1alpha1.VirtualCluster
 	return nil
 }
 
+// PauseVirtualCluster scales every master component StatefulSet down to zero replicas, leaving
+// their PVCs (and thus etcd's data) in place so ResumeVirtualCluster can bring them back.
+func (mpn *MasterProvisionerNative) PauseVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error {
+	return mpn.scaleComponents(vc, 0)
+}
+
+// ResumeVirtualCluster scales every master component StatefulSet back up to the replica count
+// defined by the vc's applied ClusterVersion.
+func (mpn *MasterProvisionerNative) ResumeVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error {
+	cvs := &tenancyv1alpha1.ClusterVersionList{}
+	if err := mpn.List(context.TODO(), cvs, client.InNamespace("")); err != nil {
+		return err
+	}
+	cv := getClusterVersion(cvs, vc.Status.AppliedClusterVersionName)
+	if cv == nil {
+		return fmt.Errorf("applied ClusterVersion %s not found", vc.Status.AppliedClusterVersionName)
+	}
+
+	ns := conversion.ToClusterKey(vc)
+	for _, ssBdl := range []*tenancyv1alpha1.StatefulSetSvcBundle{cv.Spec.ETCD, cv.Spec.APIServer, cv.Spec.ControllerManager} {
+		replicas := *ssBdl.StatefulSet.Spec.Replicas
+		if err := mpn.scaleComponent(ns, ssBdl.StatefulSet.Name, replicas); err != nil {
+			return fmt.Errorf("failed to resume %s: %v", ssBdl.Name, err)
+		}
+		if err := kubeutil.WaitStatefulSetReady(mpn, ns, ssBdl.StatefulSet.Name, DeployTimeOutSec, ComponentPollPeriodSec); err != nil {
+			return fmt.Errorf("failed to wait for %s to become ready: %v", ssBdl.Name, err)
+		}
+	}
+	return nil
+}
+
+// scaleComponents scales every master component StatefulSet of vc's control plane namespace to
+// the given number of replicas.
+func (mpn *MasterProvisionerNative) scaleComponents(vc *tenancyv1alpha1.VirtualCluster, replicas int32) error {
+	ns := conversion.ToClusterKey(vc)
+	stsList := &appsv1.StatefulSetList{}
+	if err := mpn.List(context.TODO(), stsList, client.InNamespace(ns)); err != nil {
+		return err
+	}
+	for i := range stsList.Items {
+		if err := mpn.scaleComponent(ns, stsList.Items[i].Name, replicas); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scaleComponent patches the replica count of the named StatefulSet in namespace ns.
+func (mpn *MasterProvisionerNative) scaleComponent(ns, name string, replicas int32) error {
+	sts := &appsv1.StatefulSet{}
+	key := types.NamespacedName{Namespace: ns, Name: name}
+	if err := mpn.Get(context.TODO(), key, sts); err != nil {
+		return err
+	}
+	if *sts.Spec.Replicas == replicas {
+		return nil
+	}
+	sts.Spec.Replicas = &replicas
+	return mpn.Update(context.TODO(), sts)
+}
+
+// EnsureBackupSchedule reconciles the etcd snapshot CronJob for vc towards vc.Spec.Backup, and
+// refreshes vc.Status.BackupStatus from the most recently created snapshot Job.
+func (mpn *MasterProvisionerNative) EnsureBackupSchedule(vc *tenancyv1alpha1.VirtualCluster) error {
+	ns := conversion.ToClusterKey(vc)
+	key := types.NamespacedName{Namespace: ns, Name: backup.CronJobName}
+
+	if vc.Spec.Backup == nil {
+		existing := &batchv1beta1.CronJob{}
+		if err := mpn.Get(context.TODO(), key, existing); err == nil {
+			if err := mpn.Delete(context.TODO(), existing); err != nil {
+				return err
+			}
+		} else if !apierrors.IsNotFound(err) {
+			return err
+		}
+		vc.Status.BackupStatus = nil
+		return nil
+	}
+
+	cvs := &tenancyv1alpha1.ClusterVersionList{}
+	if err := mpn.List(context.TODO(), cvs, client.InNamespace("")); err != nil {
+		return err
+	}
+	cv := getClusterVersion(cvs, vc.Status.AppliedClusterVersionName)
+	if cv == nil {
+		return fmt.Errorf("applied ClusterVersion %s not found", vc.Status.AppliedClusterVersionName)
+	}
+	etcdImage := cv.Spec.ETCD.StatefulSet.Spec.Template.Spec.Containers[0].Image
+
+	desired := backup.NewCronJob(vc, ns, etcdImage, vc.Spec.Backup)
+	existing := &batchv1beta1.CronJob{}
+	if err := mpn.Get(context.TODO(), key, existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err := mpn.Create(context.TODO(), desired); err != nil {
+			return err
+		}
+	} else {
+		existing.Spec = desired.Spec
+		if err := mpn.Update(context.TODO(), existing); err != nil {
+			return err
+		}
+	}
+
+	return mpn.refreshBackupStatus(vc, ns)
+}
+
+// refreshBackupStatus finds the most recently created snapshot Job owned by the backup
+// CronJob and records its outcome in vc.Status.BackupStatus.
+func (mpn *MasterProvisionerNative) refreshBackupStatus(vc *tenancyv1alpha1.VirtualCluster, ns string) error {
+	jobs := &batchv1.JobList{}
+	if err := mpn.List(context.TODO(), jobs, client.InNamespace(ns)); err != nil {
+		return err
+	}
+
+	var latest *batchv1.Job
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		owned := false
+		for _, ref := range job.OwnerReferences {
+			if ref.Kind == "CronJob" && ref.Name == backup.CronJobName {
+				owned = true
+				break
+			}
+		}
+		if !owned {
+			continue
+		}
+		if latest == nil || job.CreationTimestamp.After(latest.CreationTimestamp.Time) {
+			latest = job
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+
+	status := &tenancyv1alpha1.BackupStatus{LastScheduleTime: latest.CreationTimestamp}
+	switch {
+	case latest.Status.Succeeded > 0:
+		status.LastSnapshotPhase = tenancyv1alpha1.SnapshotCompleted
+		status.LastSnapshotName = latest.Name + ".db"
+		status.Message = "snapshot uploaded successfully"
+	case latest.Status.Failed > 0:
+		status.LastSnapshotPhase = tenancyv1alpha1.SnapshotFailed
+		status.Message = "snapshot job failed; see Job " + latest.Name + " for details"
+	default:
+		status.LastSnapshotPhase = tenancyv1alpha1.SnapshotRunning
+		status.Message = "snapshot job running"
+	}
+	vc.Status.BackupStatus = status
+	return nil
+}
+
+// RestoreVirtualCluster restores vc's etcd from the named snapshot in object storage. It only
+// supports ClusterVersions whose etcd component mounts its data directory from a named Volume
+// (rather than the container's own ephemeral filesystem), since that's the only way this Job
+// and the etcd Pod can share the restored data.
+func (mpn *MasterProvisionerNative) RestoreVirtualCluster(vc *tenancyv1alpha1.VirtualCluster, snapshotName string) error {
+	if vc.Spec.Backup == nil {
+		return errors.New("vc.Spec.Backup must be set to know where to restore the snapshot from")
+	}
+
+	cvs := &tenancyv1alpha1.ClusterVersionList{}
+	if err := mpn.List(context.TODO(), cvs, client.InNamespace("")); err != nil {
+		return err
+	}
+	cv := getClusterVersion(cvs, vc.Status.AppliedClusterVersionName)
+	if cv == nil {
+		return fmt.Errorf("applied ClusterVersion %s not found", vc.Status.AppliedClusterVersionName)
+	}
+	etcdBdl := cv.Spec.ETCD
+	etcdContainer := etcdBdl.StatefulSet.Spec.Template.Spec.Containers[0]
+
+	dataDir := "/var/lib/etcd/data"
+	for _, arg := range etcdContainer.Args {
+		if strings.HasPrefix(arg, "--data-dir=") {
+			dataDir = strings.TrimPrefix(arg, "--data-dir=")
+		}
+	}
+
+	dataMount, dataVolume, err := findDataVolume(etcdBdl.StatefulSet, etcdContainer, dataDir)
+	if err != nil {
+		return err
+	}
+
+	ns := conversion.ToClusterKey(vc)
+	replicas := *etcdBdl.StatefulSet.Spec.Replicas
+	if err := mpn.scaleComponent(ns, etcdBdl.StatefulSet.Name, 0); err != nil {
+		return fmt.Errorf("failed to scale down etcd before restore: %v", err)
+	}
+
+	memberName := fmt.Sprintf("%s-0", etcdBdl.StatefulSet.Name)
+	peerURL := fmt.Sprintf("https://%s.%s:%d", memberName, etcdBdl.Service.Name, DefaultETCDPeerPort)
+	restoreArgs := []string{
+		"--name=" + memberName,
+		"--initial-cluster=" + memberName + "=" + peerURL,
+		"--initial-advertise-peer-urls=" + peerURL,
+	}
+
+	job := backup.NewRestoreJob(vc, ns, etcdContainer.Image, vc.Spec.Backup.StorageSecretRef, snapshotName, dataDir, dataVolume, dataMount, restoreArgs)
+	existing := &batchv1.Job{}
+	key := types.NamespacedName{Namespace: ns, Name: backup.RestoreJobName}
+	if err := mpn.Get(context.TODO(), key, existing); err == nil {
+		if err := mpn.Delete(context.TODO(), existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+			return err
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err := mpn.Create(context.TODO(), job); err != nil {
+		return err
+	}
+
+	if err := kubeutil.WaitJobComplete(mpn, ns, backup.RestoreJobName, DeployTimeOutSec, ComponentPollPeriodSec); err != nil {
+		return fmt.Errorf("restore job did not complete: %v", err)
+	}
+
+	if err := mpn.scaleComponent(ns, etcdBdl.StatefulSet.Name, replicas); err != nil {
+		return fmt.Errorf("failed to scale etcd back up after restore: %v", err)
+	}
+	return kubeutil.WaitStatefulSetReady(mpn, ns, etcdBdl.StatefulSet.Name, DeployTimeOutSec, ComponentPollPeriodSec)
+}
+
+// findDataVolume locates the VolumeMount and Volume backing etcdContainer's data-dir, so a
+// restore Job can mount the same storage. It returns an error if etcd's data directory isn't
+// backed by a named Volume, since there's then nothing durable to restore into.
+func findDataVolume(sts *appsv1.StatefulSet, etcdContainer v1.Container, dataDir string) (v1.VolumeMount, v1.Volume, error) {
+	var mountName string
+	for _, m := range etcdContainer.VolumeMounts {
+		if m.MountPath == dataDir || strings.HasPrefix(dataDir, strings.TrimSuffix(m.MountPath, "/")+"/") {
+			mountName = m.Name
+			break
+		}
+	}
+	if mountName == "" {
+		return v1.VolumeMount{}, v1.Volume{}, fmt.Errorf(
+			"etcd's data directory %s isn't mounted from a named Volume; restoring in place requires the "+
+				"ClusterVersion's etcd component to mount a persistent Volume (or PVC template) at its data-dir", dataDir)
+	}
+
+	for _, vol := range sts.Spec.Template.Spec.Volumes {
+		if vol.Name == mountName {
+			return v1.VolumeMount{Name: mountName, MountPath: dataDir}, vol, nil
+		}
+	}
+	for _, tmpl := range sts.Spec.VolumeClaimTemplates {
+		if tmpl.Name == mountName {
+			pvcName := fmt.Sprintf("%s-%s-0", tmpl.Name, sts.Name)
+			vol := v1.Volume{
+				Name: mountName,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+				},
+			}
+			return v1.VolumeMount{Name: mountName, MountPath: dataDir}, vol, nil
+		}
+	}
+	return v1.VolumeMount{}, v1.Volume{}, fmt.Errorf("could not find Volume or VolumeClaimTemplate named %q for etcd's data-dir", mountName)
+}
+
 func (mpn *MasterProvisionerNative) DeleteVirtualCluster(vc *tenancyv1alpha1.VirtualCluster) error {
 	return nil
 }
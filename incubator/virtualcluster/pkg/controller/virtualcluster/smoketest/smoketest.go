@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package smoketest builds the Job that end-to-end tests a tenant cluster right after it
+// becomes Running: it talks to the tenant apiserver as an admin user would, so exec and
+// logs requests are proxied through vn-agent exactly like a real tenant's would be.
+package smoketest
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tenancyv1alpha1 "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/apis/tenancy/v1alpha1"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/controller/secret"
+)
+
+// JobName is the name of the smoke test Job created in the virtual cluster's cluster
+// namespace once it reaches Running. It's fixed (rather than generated) so a re-run
+// simply replaces the previous attempt's Job.
+const JobName = "smoke-test"
+
+const kubeconfigMountPath = "/etc/virtualcluster"
+
+// smokeTestScript exercises the tenant cluster the way a real user would: create a
+// namespace, a deployment and a service, wait for the pod to come up, exec into it and
+// fetch its logs, then clean up after itself. Exec and logs both go through the tenant
+// apiserver's proxy to vn-agent, so a passing run means the tenant is actually usable
+// end-to-end, not just that its control plane came up.
+const smokeTestScript = `
+set -euo pipefail
+export KUBECONFIG=` + kubeconfigMountPath + `/` + secret.AdminSecretName + `
+ns=smoke-test-$(date +%s 2>/dev/null || echo 0)
+trap 'kubectl delete namespace "$ns" --ignore-not-found --wait=false' EXIT
+kubectl create namespace "$ns"
+kubectl create deployment smoke-test --image=busybox -n "$ns" -- sh -c "sleep 3600"
+kubectl expose deployment smoke-test --port=80 -n "$ns"
+kubectl rollout status deployment/smoke-test -n "$ns" --timeout=120s
+pod=$(kubectl get pods -n "$ns" -l app=smoke-test -o jsonpath='{.items[0].metadata.name}')
+kubectl exec "$pod" -n "$ns" -- echo smoke-test-exec-ok
+kubectl logs "$pod" -n "$ns"
+`
+
+// NewJob builds the smoke test Job for vc. clusterNamespace is the super master namespace
+// the tenant's control plane and the tenant admin kubeconfig secret live in.
+func NewJob(vc *tenancyv1alpha1.VirtualCluster, clusterNamespace string) *batchv1.Job {
+	backoffLimit := int32(2)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      JobName,
+			Namespace: clusterNamespace,
+			Labels: map[string]string{
+				"tenancy.x-k8s.io/virtualcluster": vc.Name,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:    "smoke-test",
+							Image:   "bitnami/kubectl:latest",
+							Command: []string{"sh", "-c", smokeTestScript},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "admin-kubeconfig",
+									MountPath: kubeconfigMountPath,
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "admin-kubeconfig",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: secret.AdminSecretName,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
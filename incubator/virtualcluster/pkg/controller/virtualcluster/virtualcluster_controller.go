@@ -21,9 +21,14 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -36,10 +41,15 @@ import (
 	kubeutil "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/controller/util/kube"
 	strutil "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/controller/util/strings"
 	vcmanager "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/controller/vcmanager"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/controller/virtualcluster/smoketest"
 )
 
 var log = logf.Log.WithName("virtualcluster-controller")
 
+// smokeTestPollInterval is how often a running virtual cluster is re-reconciled while its
+// smoke test job hasn't completed yet.
+const smokeTestPollInterval = 10 * time.Second
+
 // Add creates a new VirtualCluster Controller and adds it to the Manager with
 // default RBAC. The Manager will set fields on the Controller and Start it
 // when the Manager is Started.
@@ -68,6 +78,11 @@ func newReconciler(mgr manager.Manager, masterProv string) (reconcile.Reconciler
 		if err != nil {
 			return nil, err
 		}
+	case "capi":
+		mp, err = NewMasterProvisionerCapi(mgr)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &ReconcileVirtualCluster{
@@ -122,6 +137,8 @@ type ReconcileVirtualCluster struct {
 // +kubebuilder:rbac:groups=tenancy.x-k8s.io,resources=virtualclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=tenancy.x-k8s.io,resources=clusterversions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=tenancy.x-k8s.io,resources=clusterversions/status,verbs=get
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
 func (r *ReconcileVirtualCluster) Reconcile(request reconcile.Request) (rncilRslt reconcile.Result, err error) {
 	log.Info("reconciling VirtualCluster...")
 	vc := &tenancyv1alpha1.VirtualCluster{}
@@ -187,6 +204,7 @@ func (r *ReconcileVirtualCluster) Reconcile(request reconcile.Request) (rncilRsl
 			} else {
 				kubeutil.SetVCStatus(vc, tenancyv1alpha1.ClusterRunning,
 					"tenant master is running", "TenantMasterRunning")
+				vc.Status.AppliedClusterVersionName = vc.Spec.ClusterVersionName
 			}
 		} else {
 			kubeutil.SetVCStatus(vc, tenancyv1alpha1.ClusterError,
@@ -197,6 +215,102 @@ func (r *ReconcileVirtualCluster) Reconcile(request reconcile.Request) (rncilRsl
 		return
 	case tenancyv1alpha1.ClusterRunning:
 		log.Info("VirtualCluster is running", "vc", vc.GetName())
+		if vc.Spec.Paused {
+			log.Info("VirtualCluster is being paused", "vc", vc.GetName())
+			if err = r.mp.PauseVirtualCluster(vc); err != nil {
+				log.Error(err, "fail to pause virtualcluster", "vc", vc.GetName())
+				return
+			}
+			kubeutil.SetVCStatus(vc, tenancyv1alpha1.ClusterPaused,
+				"tenant master is paused", "TenantMasterPaused")
+			err = kubeutil.RetryUpdateVCStatusOnConflict(context.TODO(), r, vc, log)
+			return
+		}
+		if vc.Spec.ClusterVersionName != vc.Status.AppliedClusterVersionName {
+			log.Info("ClusterVersionName changed, starting control plane upgrade", "vc", vc.GetName(),
+				"from", vc.Status.AppliedClusterVersionName, "to", vc.Spec.ClusterVersionName)
+			kubeutil.SetVCStatus(vc, tenancyv1alpha1.ClusterUpdating,
+				fmt.Sprintf("upgrading from %s to %s", vc.Status.AppliedClusterVersionName, vc.Spec.ClusterVersionName),
+				"ClusterUpgrading")
+			vc.Status.UpgradeStatus = &tenancyv1alpha1.UpgradeStatus{
+				Phase:                  tenancyv1alpha1.UpgradeInProgress,
+				FromClusterVersionName: vc.Status.AppliedClusterVersionName,
+				ToClusterVersionName:   vc.Spec.ClusterVersionName,
+				Message:                "upgrade starting",
+				StartTime:              metav1.Now(),
+			}
+			err = kubeutil.RetryUpdateVCStatusOnConflict(context.TODO(), r, vc, log)
+			return
+		}
+		if vc.Spec.RestoreFromSnapshot != "" {
+			log.Info("RestoreFromSnapshot requested, starting etcd restore", "vc", vc.GetName(),
+				"snapshot", vc.Spec.RestoreFromSnapshot)
+			kubeutil.SetVCStatus(vc, tenancyv1alpha1.ClusterRestoring,
+				fmt.Sprintf("restoring etcd from snapshot %s", vc.Spec.RestoreFromSnapshot), "ClusterRestoring")
+			vc.Status.RestoreStatus = &tenancyv1alpha1.RestoreStatus{
+				SnapshotName: vc.Spec.RestoreFromSnapshot,
+				Message:      "restore starting",
+				StartTime:    metav1.Now(),
+			}
+			err = kubeutil.RetryUpdateVCStatusOnConflict(context.TODO(), r, vc, log)
+			return
+		}
+		if err = r.mp.EnsureBackupSchedule(vc); err != nil {
+			log.Error(err, "fail to reconcile backup schedule", "vc", vc.GetName())
+			return
+		}
+		if err = r.reconcileSmokeTest(vc); err != nil {
+			log.Error(err, "fail to reconcile smoke test", "vc", vc.GetName())
+			return
+		}
+		if err = kubeutil.RetryUpdateVCStatusOnConflict(context.TODO(), r, vc, log); err != nil {
+			return
+		}
+		if vc.Status.SmokeTestStatus != nil && vc.Status.SmokeTestStatus.Phase == tenancyv1alpha1.SmokeTestRunning {
+			rncilRslt.RequeueAfter = smokeTestPollInterval
+		}
+		return
+	case tenancyv1alpha1.ClusterUpdating:
+		log.Info("VirtualCluster is upgrading", "vc", vc.GetName())
+		if err = r.mp.UpdateVirtualCluster(vc); err != nil {
+			log.Error(err, "fail to upgrade virtualcluster", "vc", vc.GetName())
+			vc.Status.UpgradeStatus.Phase = tenancyv1alpha1.UpgradeFailed
+			vc.Status.UpgradeStatus.Message = err.Error()
+			kubeutil.SetVCStatus(vc, tenancyv1alpha1.ClusterUpdating, err.Error(), "ClusterUpgradeFailed")
+			err = kubeutil.RetryUpdateVCStatusOnConflict(context.TODO(), r, vc, log)
+			return
+		}
+		vc.Status.AppliedClusterVersionName = vc.Spec.ClusterVersionName
+		vc.Status.UpgradeStatus = nil
+		kubeutil.SetVCStatus(vc, tenancyv1alpha1.ClusterRunning, "tenant master upgraded", "TenantMasterUpgraded")
+		err = kubeutil.RetryUpdateVCStatusOnConflict(context.TODO(), r, vc, log)
+		return
+	case tenancyv1alpha1.ClusterRestoring:
+		log.Info("VirtualCluster etcd is restoring", "vc", vc.GetName())
+		if err = r.mp.RestoreVirtualCluster(vc, vc.Status.RestoreStatus.SnapshotName); err != nil {
+			log.Error(err, "fail to restore virtualcluster", "vc", vc.GetName())
+			vc.Status.RestoreStatus.Message = err.Error()
+			kubeutil.SetVCStatus(vc, tenancyv1alpha1.ClusterRestoring, err.Error(), "ClusterRestoreFailed")
+			err = kubeutil.RetryUpdateVCStatusOnConflict(context.TODO(), r, vc, log)
+			return
+		}
+		vc.Spec.RestoreFromSnapshot = ""
+		vc.Status.RestoreStatus = nil
+		kubeutil.SetVCStatus(vc, tenancyv1alpha1.ClusterRunning, "tenant master restored", "TenantMasterRestored")
+		err = kubeutil.RetryUpdateVCStatusOnConflict(context.TODO(), r, vc, log)
+		return
+	case tenancyv1alpha1.ClusterPaused:
+		log.Info("VirtualCluster is paused", "vc", vc.GetName())
+		if vc.Spec.Paused {
+			return
+		}
+		log.Info("VirtualCluster is being resumed", "vc", vc.GetName())
+		if err = r.mp.ResumeVirtualCluster(vc); err != nil {
+			log.Error(err, "fail to resume virtualcluster", "vc", vc.GetName())
+			return
+		}
+		kubeutil.SetVCStatus(vc, tenancyv1alpha1.ClusterRunning, "tenant master is running", "TenantMasterRunning")
+		err = kubeutil.RetryUpdateVCStatusOnConflict(context.TODO(), r, vc, log)
 		return
 	case tenancyv1alpha1.ClusterError:
 		log.Info("fail to create virtualcluster", "vc", vc.GetName())
@@ -206,3 +320,54 @@ func (r *ReconcileVirtualCluster) Reconcile(request reconcile.Request) (rncilRsl
 		return
 	}
 }
+
+// reconcileSmokeTest creates the smoke test Job the first time vc is seen Running, and
+// otherwise checks a previously created Job for completion, recording the result in
+// vc.Status.SmokeTestStatus. It never blocks the VirtualCluster from being considered
+// Running: a still-running or even failed smoke test is surfaced in status, not returned
+// as a reconcile error.
+func (r *ReconcileVirtualCluster) reconcileSmokeTest(vc *tenancyv1alpha1.VirtualCluster) error {
+	job := &batchv1.Job{}
+	key := types.NamespacedName{Namespace: vc.Status.ClusterNamespace, Name: smoketest.JobName}
+	err := r.Get(context.TODO(), key, job)
+	if apierrors.IsNotFound(err) {
+		job = smoketest.NewJob(vc, vc.Status.ClusterNamespace)
+		if err := r.Create(context.TODO(), job); err != nil {
+			return err
+		}
+		vc.Status.SmokeTestStatus = &tenancyv1alpha1.SmokeTestStatus{
+			Phase:       tenancyv1alpha1.SmokeTestRunning,
+			Message:     "smoke test job created",
+			LastRunTime: metav1.Now(),
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	lastRunTime := metav1.Now()
+	if vc.Status.SmokeTestStatus != nil {
+		lastRunTime = vc.Status.SmokeTestStatus.LastRunTime
+	}
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			vc.Status.SmokeTestStatus = &tenancyv1alpha1.SmokeTestStatus{
+				Phase:       tenancyv1alpha1.SmokeTestPassed,
+				Message:     "smoke test job completed successfully",
+				LastRunTime: lastRunTime,
+			}
+		case batchv1.JobFailed:
+			vc.Status.SmokeTestStatus = &tenancyv1alpha1.SmokeTestStatus{
+				Phase:       tenancyv1alpha1.SmokeTestFailed,
+				Message:     fmt.Sprintf("smoke test job failed: %s", c.Message),
+				LastRunTime: lastRunTime,
+			}
+		}
+	}
+	return nil
+}
@@ -69,6 +69,21 @@ type SyncerConfiguration struct {
 
 	// Super cluster rest config
 	RestConfig *rest.Config
+
+	// ColdStartQPS caps how many VirtualClusters the syncer starts registering informers for,
+	// per second, across the whole process. It exists to smooth out the "thundering herd" of
+	// relists against every tenant apiserver (and the super cluster) that would otherwise happen
+	// when the syncer restarts with many VirtualClusters already Running. A value <= 0 disables
+	// throttling, matching the syncer's pre-existing unthrottled behavior.
+	ColdStartQPS float32
+
+	// ColdStartBurst is the burst size that goes with ColdStartQPS.
+	ColdStartBurst int
+
+	// PlatformPushTargetNamespace is the namespace created in every tenant cluster to receive
+	// ConfigMaps and Secrets that the platform team has marked for broadcast (see
+	// constants.PublicObjectKey). Defaults to "kube-system".
+	PlatformPushTargetNamespace string
 }
 
 // SyncerLeaderElectionConfiguration expands LeaderElectionConfiguration
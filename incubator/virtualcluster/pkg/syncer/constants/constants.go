@@ -65,6 +65,18 @@ const (
 	// PublicObjectKey is a label key which marks the super master object that should be populated to every tenant master.
 	PublicObjectKey = "tenancy.x-k8s.io/super.public"
 
+	// LabelTenantTier is a label key set on a VirtualCluster to classify it into a tenancy tier
+	// (e.g. "gold", "restricted"). It's read by the platformconfig syncer to decide which
+	// PublicObjectKey ConfigMaps/Secrets get pushed into the tenant cluster; see
+	// AnnotationTargetTiers.
+	LabelTenantTier = "tenancy.x-k8s.io/tier"
+
+	// AnnotationTargetTiers is an annotation key on a super master ConfigMap or Secret already
+	// marked with PublicObjectKey. Its value is a comma-separated list of tenancy tiers (see
+	// LabelTenantTier) that should receive the object; a missing or empty value means every tenant
+	// cluster receives it, regardless of tier.
+	AnnotationTargetTiers = "tenancy.x-k8s.io/target-tiers"
+
 	LabelVirtualNode = "tenancy.x-k8s.io/virtualnode"
 	// LabelSuperClusterID is a label key added to the vNode object in tenant when SuperClusterPooling feature is enabled.
 	LabelSuperClusterID = "tenancy.x-k8s.io/superclusterid"
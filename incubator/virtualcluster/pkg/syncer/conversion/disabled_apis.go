@@ -0,0 +1,41 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/apis/tenancy/v1alpha1"
+)
+
+// IsAPIDisabled reports whether resource is listed in the owning VirtualCluster's
+// spec.disabledAPIs, and should therefore never be realized in the super cluster. vcObj is
+// expected to be the runtime.Object returned by ClusterInterface.GetObject(); any other type (or
+// nil) is treated as "nothing disabled" rather than an error, since callers use this as a
+// best-effort guard, not a source of truth for whether the cluster exists.
+func IsAPIDisabled(vcObj runtime.Object, resource string) bool {
+	vc, ok := vcObj.(*v1alpha1.VirtualCluster)
+	if !ok || vc == nil {
+		return false
+	}
+	for _, disabled := range vc.Spec.DisabledAPIs {
+		if disabled == resource {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"testing"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/apis/tenancy/v1alpha1"
+)
+
+func TestIsAPIDisabled(t *testing.T) {
+	vc := &v1alpha1.VirtualCluster{
+		Spec: v1alpha1.VirtualClusterSpec{
+			DisabledAPIs: []string{"persistentvolumeclaims", "pods/exec"},
+		},
+	}
+
+	if !IsAPIDisabled(vc, "persistentvolumeclaims") {
+		t.Errorf("expected persistentvolumeclaims to be disabled")
+	}
+	if IsAPIDisabled(vc, "services") {
+		t.Errorf("expected services to not be disabled")
+	}
+	if IsAPIDisabled(nil, "persistentvolumeclaims") {
+		t.Errorf("expected nil object to report nothing disabled")
+	}
+}
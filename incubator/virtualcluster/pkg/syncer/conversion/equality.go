@@ -20,6 +20,7 @@ import (
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	v1beta1extensions "k8s.io/api/extensions/v1beta1"
 	v1scheduling "k8s.io/api/scheduling/v1"
@@ -231,14 +232,7 @@ func (e vcEquality) checkUWKVEquality(pKV, vKV map[string]string) (map[string]st
 // The exceptional keys that used by super master object are specified in
 // VC.Spec.TransparentMetaPrefixes plus a white list (e.g., tenancy.x-k8s.io).
 func (e vcEquality) checkDWKVEquality(pKV, vKV map[string]string) (map[string]string, bool) {
-	var exceptionsList []string
-	if e.vc != nil {
-		exceptions := sets.NewString()
-		exceptions.Insert(e.vc.Spec.TransparentMetaPrefixes...)
-		exceptions.Insert(e.vc.Spec.OpaqueMetaPrefixes...)
-		exceptions.Insert(constants.DefaultOpaqueMetaPrefix, constants.DefaultTransparentMetaPrefix)
-		exceptionsList = exceptions.UnsortedList()
-	}
+	exceptionsList := e.dwExceptions()
 
 	// key in virtual more or diff then super
 	moreOrDiff := make(map[string]string)
@@ -291,6 +285,48 @@ func (e vcEquality) checkDWKVEquality(pKV, vKV map[string]string) (map[string]st
 	return updated, false
 }
 
+// dwExceptions returns the label/annotation key prefixes a tenant is always allowed to set on the
+// super cluster translation of its object: VC.Spec.TransparentMetaPrefixes/OpaqueMetaPrefixes plus
+// the package defaults. checkDWKVEquality and FilterDWObjectMeta both start from this list.
+func (e vcEquality) dwExceptions() []string {
+	if e.vc == nil {
+		return nil
+	}
+	exceptions := sets.NewString()
+	exceptions.Insert(e.vc.Spec.TransparentMetaPrefixes...)
+	exceptions.Insert(e.vc.Spec.OpaqueMetaPrefixes...)
+	exceptions.Insert(constants.DefaultOpaqueMetaPrefix, constants.DefaultTransparentMetaPrefix)
+	return exceptions.UnsortedList()
+}
+
+// FilterDWObjectMeta strips vObj's labels and annotations down to the keys a tenant is allowed to
+// set on the super cluster translation of its object, applying the same policy
+// checkDWKVEquality enforces on updates (VC.Spec.TransparentMetaPrefixes/OpaqueMetaPrefixes, the
+// package defaults, and DefaultOpaqueMetaDomains). Unlike checkDWKVEquality, it doesn't diff
+// against an existing pObj, so it's meant for the DWS create path, where the super cluster object
+// doesn't exist yet. blocked lists the keys that were dropped, so the caller can report the
+// conflict instead of silently discarding part of the tenant's request.
+func (e vcEquality) FilterDWObjectMeta(vObj *metav1.ObjectMeta) (labels, annotations map[string]string, blocked []string) {
+	labels, labelsBlocked := e.filterDWKV(vObj.Labels)
+	annotations, annotationsBlocked := e.filterDWKV(vObj.Annotations)
+	return labels, annotations, append(labelsBlocked, annotationsBlocked...)
+}
+
+func (e vcEquality) filterDWKV(vKV map[string]string) (map[string]string, []string) {
+	exceptionsList := e.dwExceptions()
+
+	filtered := make(map[string]string)
+	var blocked []string
+	for k, v := range vKV {
+		if hasPrefixInArray(k, exceptionsList) || e.isOpaquedKey(k) {
+			blocked = append(blocked, k)
+			continue
+		}
+		filtered[k] = v
+	}
+	return filtered, blocked
+}
+
 func (e vcEquality) isOpaquedKey(key string) bool {
 	if e.config == nil {
 		return false
@@ -696,7 +732,8 @@ func (e vcEquality) CheckPVCEquality(pObj, vObj *v1.PersistentVolumeClaim) *v1.P
 		}
 		updated.Spec.Resources.Requests["storage"] = vObj.Spec.Resources.Requests["storage"]
 	}
-	// We don't check PVC status since it will be managed by tenant/master pv binder controller independently.
+	// PVC.Status is intentionally not compared here: it's owned by the super master's PV binder
+	// controller and is back-populated into the tenant PVC by the UWS BackPopulate path instead.
 	return updated
 }
 
@@ -710,6 +747,36 @@ func (e vcEquality) CheckPVSpecEquality(pObj, vObj *v1.PersistentVolumeSpec) *v1
 	return updatedPVSpec
 }
 
+// CheckStatefulSetEquality checks the DW direction: super master's StatefulSet.Spec is overwritten
+// by the tenant's whenever they diverge. Status is excluded since it's owned by the super master's
+// statefulset controller and is back-populated by CheckUWStatefulSetStatusEquality instead.
+func (e vcEquality) CheckStatefulSetEquality(pObj, vObj *appsv1.StatefulSet) *appsv1.StatefulSet {
+	var updated *appsv1.StatefulSet
+	updatedMeta := e.CheckDWObjectMetaEquality(&pObj.ObjectMeta, &vObj.ObjectMeta)
+	if updatedMeta != nil {
+		updated = pObj.DeepCopy()
+		updated.ObjectMeta = *updatedMeta
+	}
+	if !equality.Semantic.DeepEqual(pObj.Spec, vObj.Spec) {
+		if updated == nil {
+			updated = pObj.DeepCopy()
+		}
+		updated.Spec = *vObj.Spec.DeepCopy()
+	}
+	return updated
+}
+
+// CheckUWStatefulSetStatusEquality computes the StatefulSet.Status to be back-populated from
+// super master to the tenant master, which is where the corresponding PVCs (and, transitively,
+// their bound PVs) are actually observable by the tenant.
+func (e vcEquality) CheckUWStatefulSetStatusEquality(pObj, vObj *appsv1.StatefulSet) *appsv1.StatefulSetStatus {
+	if equality.Semantic.DeepEqual(pObj.Status, vObj.Status) {
+		return nil
+	}
+	newStatus := pObj.Status.DeepCopy()
+	return newStatus
+}
+
 func (e vcEquality) CheckNamespaceEquality(pObj, vObj *v1.Namespace) *v1.Namespace {
 	var updated *v1.Namespace
 	updatedMeta := e.CheckDWObjectMetaEquality(&pObj.ObjectMeta, &vObj.ObjectMeta)
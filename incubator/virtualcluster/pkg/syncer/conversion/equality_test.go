@@ -21,6 +21,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
 	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/apis/tenancy/v1alpha1"
@@ -186,6 +187,51 @@ func TestCheckDWKVEquality(t *testing.T) {
 	}
 }
 
+func TestFilterDWObjectMeta(t *testing.T) {
+	syncerConfig := &config.SyncerConfiguration{
+		DefaultOpaqueMetaDomains: []string{"kubernetes.io"},
+	}
+	vc := v1alpha1.VirtualCluster{
+		Spec: v1alpha1.VirtualClusterSpec{
+			TransparentMetaPrefixes: []string{"tp.x-k8s.io"},
+			OpaqueMetaPrefixes:      []string{"tenancy.x-k8s.io"},
+		},
+	}
+
+	vObj := &metav1.ObjectMeta{
+		Labels: map[string]string{
+			"istio-injection":      "enabled",
+			"tenancy.x-k8s.io/foo": "bar",
+		},
+		Annotations: map[string]string{
+			"pod-security.kubernetes.io/enforce": "restricted",
+			"foo.kubernetes.io/bar":              "baz",
+		},
+	}
+
+	labels, annotations, blocked := Equality(syncerConfig, &vc).FilterDWObjectMeta(vObj)
+
+	if want := map[string]string{"istio-injection": "enabled"}; !equality.Semantic.DeepEqual(labels, want) {
+		t.Errorf("expected labels %+v, got %+v", want, labels)
+	}
+	if len(annotations) != 0 {
+		t.Errorf("expected all annotations to be blocked, got %+v", annotations)
+	}
+	wantBlocked := map[string]bool{
+		"tenancy.x-k8s.io/foo":               true,
+		"pod-security.kubernetes.io/enforce": true,
+		"foo.kubernetes.io/bar":              true,
+	}
+	if len(blocked) != len(wantBlocked) {
+		t.Errorf("expected %d blocked keys, got %+v", len(wantBlocked), blocked)
+	}
+	for _, k := range blocked {
+		if !wantBlocked[k] {
+			t.Errorf("unexpected blocked key %q", k)
+		}
+	}
+}
+
 func TestCheckUWKVEquality(t *testing.T) {
 	vc := v1alpha1.VirtualCluster{
 		Spec: v1alpha1.VirtualClusterSpec{
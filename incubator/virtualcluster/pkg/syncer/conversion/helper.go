@@ -33,11 +33,12 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/util/validation"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
 	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog"
 
 	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/apis/tenancy/v1alpha1"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/apis/config"
 	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/constants"
 	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/util/featuregate"
 )
@@ -49,13 +50,11 @@ func ToClusterKey(vc *v1alpha1.VirtualCluster) string {
 	return vc.GetNamespace() + "-" + hex.EncodeToString(digest[0:])[0:6] + "-" + vc.GetName()
 }
 
+// ToSuperMasterNamespace translates a tenant cluster/namespace pair into the corresponding super
+// cluster namespace name, using the process-wide NamespaceNameStrategy (see
+// SetNamespaceNameStrategy). It defaults to HashSuffixNamespaceStrategy.
 func ToSuperMasterNamespace(cluster, ns string) string {
-	targetNamespace := strings.Join([]string{cluster, ns}, "-")
-	if len(targetNamespace) > validation.DNS1123SubdomainMaxLength {
-		digest := sha256.Sum256([]byte(targetNamespace))
-		return targetNamespace[0:57] + "-" + hex.EncodeToString(digest[0:])[0:5]
-	}
-	return targetNamespace
+	return namespaceNameStrategy.ToSuperClusterNamespace(cluster, ns)
 }
 
 // GetVirtualNamespace is used to find the corresponding namespace in tenant master for objects created in super master originally, e.g., events.
@@ -148,13 +147,23 @@ func BuildMetadata(cluster, vcns, vcname, targetNamespace string, obj runtime.Ob
 	return target, nil
 }
 
-func BuildSuperMasterNamespace(cluster, vcName, vcNamespace, vcUID string, obj runtime.Object) (runtime.Object, error) {
+func BuildSuperMasterNamespace(cluster, vcName, vcNamespace, vcUID string, obj runtime.Object, syncerConfig *config.SyncerConfiguration, vc *v1alpha1.VirtualCluster) (runtime.Object, error) {
 	target := obj.DeepCopyObject()
 	m, err := meta.Accessor(target)
 	if err != nil {
 		return nil, err
 	}
 
+	// A tenant's namespace can carry labels/annotations meant for the super cluster (e.g. PSA
+	// labels, istio-injection), but not every key is safe to let a tenant set on shared
+	// infrastructure, so only the keys allowed by policy are copied over.
+	labels, annotations, blocked := Equality(syncerConfig, vc).FilterDWObjectMeta(&metav1.ObjectMeta{Labels: m.GetLabels(), Annotations: m.GetAnnotations()})
+	if len(blocked) > 0 {
+		klog.Warningf("namespace %s in cluster %s set blocked label/annotation keys %v, dropping them from the super cluster namespace", m.GetName(), cluster, blocked)
+	}
+	m.SetLabels(labels)
+	m.SetAnnotations(annotations)
+
 	anno := m.GetAnnotations()
 	if anno == nil {
 		anno = make(map[string]string)
@@ -208,6 +217,24 @@ func BuildVirtualStorageClass(cluster string, pStorageClass *storagev1.StorageCl
 	return vStorageClass
 }
 
+// BuildVirtualPlatformConfigMap converts a super master ConfigMap marked with
+// constants.PublicObjectKey into the copy that's pushed into targetNamespace of a tenant cluster.
+func BuildVirtualPlatformConfigMap(targetNamespace string, pConfigMap *v1.ConfigMap) *v1.ConfigMap {
+	vConfigMap := pConfigMap.DeepCopy()
+	ResetMetadata(vConfigMap)
+	vConfigMap.SetNamespace(targetNamespace)
+	return vConfigMap
+}
+
+// BuildVirtualPlatformSecret converts a super master Secret marked with constants.PublicObjectKey
+// into the copy that's pushed into targetNamespace of a tenant cluster.
+func BuildVirtualPlatformSecret(targetNamespace string, pSecret *v1.Secret) *v1.Secret {
+	vSecret := pSecret.DeepCopy()
+	ResetMetadata(vSecret)
+	vSecret.SetNamespace(targetNamespace)
+	return vSecret
+}
+
 func BuildVirtualPriorityClass(cluster string, pPriorityClass *v1scheduling.PriorityClass) *v1scheduling.PriorityClass {
 	vPriorityClass := pPriorityClass.DeepCopy()
 	ResetMetadata(vPriorityClass)
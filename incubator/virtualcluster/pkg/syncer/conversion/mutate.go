@@ -168,10 +168,10 @@ func PodMutateDefault(vPod *v1.Pod, saSecretMap map[string]string, services []*v
 		}
 		mutateDNSConfig(p, vPod, vc.Spec.ClusterDomain, nameServer)
 
-		// FIXME(zhuangqh): how to support pod subdomain.
-		if p.pPod.Spec.Subdomain != "" {
-			p.pPod.Spec.Subdomain = ""
-		}
+		// Subdomain is left untouched: it names a headless Service in the pod's own namespace, and
+		// the service dws syncer creates that Service under the same name in the pod's translated
+		// super cluster namespace (see ToSuperMasterNamespace), so the reference still resolves once
+		// the pod lands in the super cluster.
 
 		return nil
 	}
@@ -397,6 +397,34 @@ func PodMutateAutoMountServiceAccountToken(disable bool) PodMutator {
 	}
 }
 
+// PodMutateSecurityCompliance rejects a pod that requests a hostPath volume, hostNetwork, or a
+// privileged container when enforce is true (i.e. the owning VirtualCluster has
+// spec.enforcePodSecurityCompliance set). It runs before the pod is ever created in the super
+// cluster, so it can't be bypassed by a tenant loosening its own apiserver's admission control.
+func PodMutateSecurityCompliance(enforce bool) PodMutator {
+	return func(p *podMutateCtx) error {
+		if !enforce {
+			return nil
+		}
+		if p.pPod.Spec.HostNetwork {
+			return fmt.Errorf("pod %s/%s requests hostNetwork, which this virtual cluster's security policy does not allow", p.pPod.Namespace, p.pPod.Name)
+		}
+		for _, volume := range p.pPod.Spec.Volumes {
+			if volume.HostPath != nil {
+				return fmt.Errorf("pod %s/%s requests hostPath volume %q, which this virtual cluster's security policy does not allow", p.pPod.Namespace, p.pPod.Name, volume.Name)
+			}
+		}
+		for _, containers := range [][]v1.Container{p.pPod.Spec.InitContainers, p.pPod.Spec.Containers} {
+			for _, c := range containers {
+				if c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
+					return fmt.Errorf("pod %s/%s container %q requests privileged mode, which this virtual cluster's security policy does not allow", p.pPod.Namespace, p.pPod.Name, c.Name)
+				}
+			}
+		}
+		return nil
+	}
+}
+
 func PodMutateServiceLink(disableServiceLinks bool) PodMutator {
 	return func(p *podMutateCtx) error {
 		if disableServiceLinks {
@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestPodMutateSecurityCompliance(t *testing.T) {
+	privileged := &v1.Pod{Spec: v1.PodSpec{
+		Containers: []v1.Container{{Name: "c", SecurityContext: &v1.SecurityContext{Privileged: pointer.BoolPtr(true)}}},
+	}}
+	hostNetwork := &v1.Pod{Spec: v1.PodSpec{HostNetwork: true}}
+	hostPath := &v1.Pod{Spec: v1.PodSpec{
+		Volumes: []v1.Volume{{Name: "v", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/etc"}}}},
+	}}
+	benign := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "c"}}}}
+
+	testcases := map[string]struct {
+		pod       *v1.Pod
+		enforce   bool
+		expectErr bool
+	}{
+		"privileged container rejected when enforced":    {privileged, true, true},
+		"privileged container allowed when not enforced": {privileged, false, false},
+		"hostNetwork rejected when enforced":             {hostNetwork, true, true},
+		"hostPath volume rejected when enforced":         {hostPath, true, true},
+		"benign pod allowed when enforced":               {benign, true, false},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			p := &podMutateCtx{pPod: tc.pod}
+			err := p.Mutate(PodMutateSecurityCompliance(tc.enforce))
+			if tc.expectErr && err == nil {
+				t.Errorf("%s: expected an error, got none", k)
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("%s: expected no error, got %v", k, err)
+			}
+		})
+	}
+}
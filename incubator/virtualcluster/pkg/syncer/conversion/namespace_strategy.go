@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/apis/tenancy/v1alpha1"
+)
+
+// NamespaceNameStrategy computes the super cluster namespace name a tenant namespace should be
+// translated to, and (where possible) reverses that mapping. ToSuperMasterNamespace and
+// GetVirtualNamespace delegate to the process-wide strategy set by SetNamespaceNameStrategy, so
+// switching strategies changes the translation used consistently by the syncer and the vn-agent.
+type NamespaceNameStrategy interface {
+	// ToSuperClusterNamespace returns the super cluster namespace name for the given tenant
+	// cluster and namespace.
+	ToSuperClusterNamespace(cluster, ns string) string
+
+	// FromSuperClusterNamespace reverses ToSuperClusterNamespace, if the strategy supports it.
+	// found is false if pNamespace is unknown to this strategy, or the strategy can't reverse
+	// names on its own (e.g. because the name doesn't carry enough information).
+	FromSuperClusterNamespace(pNamespace string) (cluster, ns string, found bool)
+}
+
+// namespaceNameStrategy is the strategy used by ToSuperMasterNamespace. It defaults to
+// HashSuffixNamespaceStrategy, which preserves the syncer's original <cluster>-<ns> behavior.
+var namespaceNameStrategy NamespaceNameStrategy = HashSuffixNamespaceStrategy{}
+
+// SetNamespaceNameStrategy overrides the namespace name translation strategy used by
+// ToSuperMasterNamespace. It must be called before the syncer or vn-agent start translating
+// namespace names, since it's not safe to change concurrently with lookups.
+func SetNamespaceNameStrategy(s NamespaceNameStrategy) {
+	namespaceNameStrategy = s
+}
+
+// HashSuffixNamespaceStrategy joins the cluster and namespace names with a "-", falling back to a
+// truncated 63-character name with a content hash suffix if the result is longer than
+// validation.DNS1123SubdomainMaxLength (253) characters. This is a pure function of its inputs, so it can't reverse a name back into its
+// cluster/namespace parts - callers that need the reverse mapping have to track it themselves
+// (e.g. via the LabelCluster/LabelNamespace annotations BuildSuperMasterNamespace sets).
+type HashSuffixNamespaceStrategy struct{}
+
+func (HashSuffixNamespaceStrategy) ToSuperClusterNamespace(cluster, ns string) string {
+	targetNamespace := strings.Join([]string{cluster, ns}, "-")
+	if len(targetNamespace) > validation.DNS1123SubdomainMaxLength {
+		digest := sha256.Sum256([]byte(targetNamespace))
+		return targetNamespace[0:57] + "-" + hex.EncodeToString(digest[0:])[0:5]
+	}
+	return targetNamespace
+}
+
+func (HashSuffixNamespaceStrategy) FromSuperClusterNamespace(pNamespace string) (cluster, ns string, found bool) {
+	return "", "", false
+}
+
+// UUIDTableNamespaceStrategy assigns every tenant namespace an opaque <cluster>-<uuid> super
+// cluster namespace name, and records the (cluster, tenant namespace) -> super cluster namespace
+// mapping in a NamespaceMapping object so it can be looked up again later - by either direction -
+// without needing to re-derive or guess it. This avoids both the 63-char DNS label limit and the
+// name collisions HashSuffixNamespaceStrategy can suffer when truncation makes two different
+// tenant namespaces hash to the same prefix.
+type UUIDTableNamespaceStrategy struct {
+	Client client.Client
+}
+
+func (s UUIDTableNamespaceStrategy) ToSuperClusterNamespace(cluster, ns string) string {
+	ctx := context.TODO()
+
+	list := &v1alpha1.NamespaceMappingList{}
+	if err := s.Client.List(ctx, list, client.MatchingLabels{
+		namespaceMappingClusterLabel:   cluster,
+		namespaceMappingNamespaceLabel: ns,
+	}); err == nil {
+		for _, m := range list.Items {
+			return m.Name
+		}
+	}
+
+	superClusterNamespace := cluster + "-" + string(uuid.NewUUID())[0:8]
+	mapping := &v1alpha1.NamespaceMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: superClusterNamespace,
+			Labels: map[string]string{
+				namespaceMappingClusterLabel:   cluster,
+				namespaceMappingNamespaceLabel: ns,
+			},
+		},
+		Spec: v1alpha1.NamespaceMappingSpec{
+			Cluster:         cluster,
+			TenantNamespace: ns,
+		},
+	}
+	if err := s.Client.Create(ctx, mapping); err != nil && !errors.IsAlreadyExists(err) {
+		// Fall back to a best-effort name; the caller will retry the reconcile if this doesn't
+		// eventually converge with what's stored on the apiserver.
+		return superClusterNamespace
+	}
+	return superClusterNamespace
+}
+
+func (s UUIDTableNamespaceStrategy) FromSuperClusterNamespace(pNamespace string) (cluster, ns string, found bool) {
+	mapping := &v1alpha1.NamespaceMapping{}
+	if err := s.Client.Get(context.TODO(), client.ObjectKey{Name: pNamespace}, mapping); err != nil {
+		return "", "", false
+	}
+	return mapping.Spec.Cluster, mapping.Spec.TenantNamespace, true
+}
+
+const (
+	namespaceMappingClusterLabel   = "tenancy.x-k8s.io/cluster"
+	namespaceMappingNamespaceLabel = "tenancy.x-k8s.io/tenant-namespace"
+)
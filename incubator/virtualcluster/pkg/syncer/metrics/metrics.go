@@ -36,6 +36,10 @@ const (
 	UWSOperationCounterKey   = "uws_operations_total"
 	UWSOperationDurationKey  = "uws_operations_duration_seconds"
 	ClusterHealthKey         = "virtual_cluster_health"
+	ColdStartRemainingKey    = "cold_start_clusters_remaining"
+	ColdStartCompletedKey    = "cold_start_clusters_completed_total"
+	TenantObjectCountKey     = "tenant_object_count"
+	TranslationCacheSizeKey  = "translation_cache_size"
 )
 
 var (
@@ -120,6 +124,36 @@ var (
 		},
 		[]string{"status"},
 	)
+	ColdStartRemaining = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Subsystem: ResourceSyncerSubsystem,
+			Name:      ColdStartRemainingKey,
+			Help:      "Number of VirtualClusters that were Running when the syncer (re)started and haven't finished their initial cache sync yet.",
+		},
+	)
+	ColdStartCompleted = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Subsystem: ResourceSyncerSubsystem,
+			Name:      ColdStartCompletedKey,
+			Help:      "Cumulative number of VirtualClusters that have finished their initial cache sync since the syncer (re)started.",
+		},
+	)
+	TenantObjectCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: ResourceSyncerSubsystem,
+			Name:      TenantObjectCountKey,
+			Help:      "Number of objects of a given resource type currently owned by a tenant, as last seen by its patroller.",
+		},
+		[]string{"resource", "vc_name"},
+	)
+	TranslationCacheSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: ResourceSyncerSubsystem,
+			Name:      TranslationCacheSizeKey,
+			Help:      "Number of entries currently held in a superMaster<->tenant translation cache.",
+		},
+		[]string{"cache"},
+	)
 )
 
 var registerMetrics sync.Once
@@ -137,9 +171,38 @@ func Register() {
 		prometheus.MustRegister(UWSOperationDuration)
 		prometheus.MustRegister(UWSOperationCounter)
 		prometheus.MustRegister(ClusterHealthStats)
+		prometheus.MustRegister(ColdStartRemaining)
+		prometheus.MustRegister(ColdStartCompleted)
+		prometheus.MustRegister(TenantObjectCount)
+		prometheus.MustRegister(TranslationCacheSize)
 	})
 }
 
+// SetColdStartRemaining reports how many VirtualClusters are still waiting on their initial
+// cache sync after a syncer (re)start.
+func SetColdStartRemaining(n int) {
+	ColdStartRemaining.Set(float64(n))
+}
+
+// RecordColdStartCompleted reports that one more VirtualCluster has finished its initial cache
+// sync, and decrements the remaining count.
+func RecordColdStartCompleted() {
+	ColdStartCompleted.Inc()
+	ColdStartRemaining.Dec()
+}
+
+// SetTenantObjectCount reports how many objects of resource kind a tenant currently owns, as seen
+// by that resource's patroller on its last scan.
+func SetTenantObjectCount(resource, cluster string, n int) {
+	TenantObjectCount.WithLabelValues(resource, cluster).Set(float64(n))
+}
+
+// SetTranslationCacheSize reports how many entries a named superMaster<->tenant translation cache
+// currently holds.
+func SetTranslationCacheSize(cache string, n int) {
+	TranslationCacheSize.WithLabelValues(cache).Set(float64(n))
+}
+
 // Gets the time since the specified start in microseconds.
 func SinceInMicroseconds(start time.Time) float64 {
 	return float64(time.Since(start).Nanoseconds() / time.Microsecond.Nanoseconds())
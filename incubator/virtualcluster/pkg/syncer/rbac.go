@@ -0,0 +1,73 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncer
+
+import (
+	"context"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/apis/config"
+)
+
+// SyncerClusterRoleName is the name of the ClusterRole bound to the syncer's ServiceAccount. It
+// must match the name of the ClusterRole shipped in config/setup/all_in_one.yaml, which also grants
+// the syncer permission to update this ClusterRole so EnsureMinimalClusterRole can narrow it.
+const SyncerClusterRoleName = "vc-syncer-role"
+
+// baseRBACRules lists the super cluster permissions the syncer needs regardless of which resource
+// syncers are enabled, e.g. to discover the VirtualClusters it's syncing for in the first place.
+var baseRBACRules = []rbacv1.PolicyRule{
+	{APIGroups: []string{"tenancy.x-k8s.io"}, Resources: []string{"virtualclusters"}, Verbs: []string{"get", "list", "watch"}},
+	{APIGroups: []string{"tenancy.x-k8s.io"}, Resources: []string{"virtualclusters/status"}, Verbs: []string{"get"}},
+}
+
+// RBACRulesForConfig returns the minimal set of super cluster RBAC rules the syncer needs to run
+// the resource syncers that config would enable, i.e. exactly the plugins LoadPlugins returns. A
+// resource syncer that's never enabled (e.g. "ingress", which defaults to disabled) never
+// contributes its rules, so turning on ExtraSyncingResources is the only way to widen the role.
+func RBACRulesForConfig(config *config.SyncerConfiguration) []rbacv1.PolicyRule {
+	rules := append([]rbacv1.PolicyRule{}, baseRBACRules...)
+	for _, r := range LoadPlugins(config) {
+		rules = append(rules, r.RBACRules...)
+	}
+	return rules
+}
+
+// EnsureMinimalClusterRole narrows the syncer's own ClusterRole down to RBACRulesForConfig(config),
+// so a deployment that never enables e.g. the "ingress" resource syncer never keeps the permissions
+// that syncer would need. It's called once at startup; if it fails, the syncer logs the error and
+// keeps running with whatever permissions the ClusterRole already had; over-broad permissions are a
+// hardening gap, not a functional one, so they shouldn't stop the syncer from serving VirtualClusters.
+func EnsureMinimalClusterRole(client clientset.Interface, config *config.SyncerConfiguration) error {
+	role, err := client.RbacV1().ClusterRoles().Get(context.TODO(), SyncerClusterRoleName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.Warningf("ClusterRole %q not found, skipping RBAC narrowing", SyncerClusterRoleName)
+			return nil
+		}
+		return err
+	}
+
+	role.Rules = RBACRulesForConfig(config)
+	_, err = client.RbacV1().ClusterRoles().Update(context.TODO(), role, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncer
+
+import (
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/apis/config"
+	// Blank-imported so their init() functions register with plugin.SyncerResourceRegister, matching
+	// cmd/syncer/builtins.go's set of built-in resource syncers.
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/configmap"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/endpoints"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/event"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/ingress"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/namespace"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/node"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/persistentvolume"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/persistentvolumeclaim"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/pod"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/secret"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/service"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/serviceaccount"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/statefulset"
+	_ "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/resources/storageclass"
+)
+
+func stringInSlice(s string, list []string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsRule(rules []rbacv1.PolicyRule, apiGroup, resource string) bool {
+	for _, r := range rules {
+		if !stringInSlice(apiGroup, r.APIGroups) {
+			continue
+		}
+		if stringInSlice(resource, r.Resources) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRBACRulesForConfigOmitsDisabledResourceSyncers(t *testing.T) {
+	cfg := &config.SyncerConfiguration{}
+
+	rules := RBACRulesForConfig(cfg)
+
+	if !containsRule(rules, "", "pods") {
+		t.Errorf("expected rules for the always-enabled \"pod\" resource syncer, got %+v", rules)
+	}
+	if containsRule(rules, "extensions", "ingresses") {
+		t.Errorf("did not expect rules for the disabled-by-default \"ingress\" resource syncer, got %+v", rules)
+	}
+}
+
+func TestRBACRulesForConfigIncludesExtraSyncingResources(t *testing.T) {
+	cfg := &config.SyncerConfiguration{ExtraSyncingResources: []string{"ingress"}}
+
+	rules := RBACRulesForConfig(cfg)
+
+	if !containsRule(rules, "extensions", "ingresses") {
+		t.Errorf("expected rules for \"ingress\" once it's added to ExtraSyncingResources, got %+v", rules)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog"
 
@@ -119,10 +120,16 @@ func (c *controller) reconcileConfigMapUpdate(clusterName, targetNamespace, requ
 	}
 	updatedConfigMap := conversion.Equality(c.Config, vc).CheckConfigMapEquality(pConfigMap, vConfigMap)
 	if updatedConfigMap != nil {
-		pConfigMap, err = c.configMapClient.ConfigMaps(targetNamespace).Update(context.TODO(), updatedConfigMap, metav1.UpdateOptions{})
+		patch, err := util.CreateMergePatch(pConfigMap, updatedConfigMap, v1.ConfigMap{})
 		if err != nil {
 			return err
 		}
+		if patch != nil {
+			pConfigMap, err = c.configMapClient.ConfigMaps(targetNamespace).Patch(context.TODO(), pConfigMap.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+			if err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -21,11 +21,11 @@ import (
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	core "k8s.io/client-go/testing"
+	syncerutil "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/util"
 	util "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/util/test"
 
 	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/apis/tenancy/v1alpha1"
@@ -351,12 +351,18 @@ func TestDWConfigMapUpdate(t *testing.T) {
 			}
 			for i, obj := range tc.ExpectedUpdatedPObject {
 				action := actions[i]
-				if !action.Matches("update", "configmaps") {
+				if !action.Matches("patch", "configmaps") {
 					t.Errorf("%s: Unexpected action %s", k, action)
+					continue
 				}
-				actionObj := action.(core.UpdateAction).GetObject()
-				if !equality.Semantic.DeepEqual(obj, actionObj) {
-					t.Errorf("%s: Expected updated cm is %v, got %v", k, obj, actionObj)
+				expected := obj.(*v1.ConfigMap)
+				patch, err := syncerutil.CreateMergePatch(tc.ExistingObjectInSuper[0], expected, v1.ConfigMap{})
+				if err != nil {
+					t.Errorf("%s: failed to compute expected patch: %v", k, err)
+					continue
+				}
+				if string(action.(core.PatchAction).GetPatch()) != string(patch) {
+					t.Errorf("%s: expected patch %s, got %s", k, patch, action.(core.PatchAction).GetPatch())
 				}
 			}
 		})
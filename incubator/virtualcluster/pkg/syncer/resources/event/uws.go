@@ -21,6 +21,8 @@ import (
 	"fmt"
 
 	pkgerr "github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -87,7 +89,7 @@ func (c *controller) BackPopulate(key string) error {
 	}
 
 	vEvent := conversion.BuildVirtualEvent(clusterName, pEvent, vInvolvedObject.(metav1.Object))
-	_, err = c.MultiClusterController.Get(clusterName, tenantNS, vEvent.Name)
+	vEventObj, err := c.MultiClusterController.Get(clusterName, tenantNS, vEvent.Name)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			_, err = tenantClient.CoreV1().Events(tenantNS).Create(context.TODO(), vEvent, metav1.CreateOptions{})
@@ -95,5 +97,22 @@ func (c *controller) BackPopulate(key string) error {
 		}
 		return err
 	}
-	return nil
+
+	// The super master event may have been patched since it was first synced down, e.g. its Count
+	// bumped by a repeated occurrence, so keep the tenant copy's mutable fields in sync instead of
+	// only ever creating it once.
+	existingVEvent := vEventObj.(*v1.Event)
+	if existingVEvent.Count == vEvent.Count &&
+		existingVEvent.Message == vEvent.Message &&
+		equality.Semantic.DeepEqual(existingVEvent.LastTimestamp, vEvent.LastTimestamp) {
+		return nil
+	}
+
+	updatedVEvent := existingVEvent.DeepCopy()
+	updatedVEvent.Count = vEvent.Count
+	updatedVEvent.Message = vEvent.Message
+	updatedVEvent.LastTimestamp = vEvent.LastTimestamp
+	updatedVEvent.Series = vEvent.Series
+	_, err = tenantClient.CoreV1().Events(tenantNS).Update(context.TODO(), updatedVEvent, metav1.UpdateOptions{})
+	return err
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	v1beta1 "k8s.io/api/extensions/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
@@ -41,6 +42,9 @@ import (
 func init() {
 	plugin.SyncerResourceRegister.Register(&plugin.Registration{
 		ID: "ingress",
+		RBACRules: []rbacv1.PolicyRule{
+			{APIGroups: []string{"extensions"}, Resources: []string{"ingresses"}, Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"}},
+		},
 		InitFn: func(ctx *plugin.InitContext) (interface{}, error) {
 			return NewIngressController(ctx.Config.(*config.SyncerConfiguration), ctx.Client, ctx.Informer, ctx.VCClient, ctx.VCInformer, manager.ResourceSyncerOptions{})
 		},
@@ -93,7 +93,12 @@ func (c *controller) reconcileNamespaceCreate(clusterName, targetNamespace, requ
 		return err
 	}
 
-	newObj, err := conversion.BuildSuperMasterNamespace(clusterName, vcName, vcNamespace, vcUID, vNamespace)
+	vc, err := util.GetVirtualClusterObject(c.MultiClusterController, clusterName)
+	if err != nil {
+		return err
+	}
+
+	newObj, err := conversion.BuildSuperMasterNamespace(clusterName, vcName, vcNamespace, vcUID, vNamespace, c.Config, vc)
 	if err != nil {
 		return err
 	}
@@ -17,7 +17,11 @@ limitations under the License.
 package persistentvolumeclaim
 
 import (
+	"fmt"
+
 	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -29,6 +33,7 @@ import (
 	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/apis/config"
 	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/manager"
 	pa "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/patrol"
+	uw "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/uwcontroller"
 	mc "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/mccontroller"
 	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/plugin"
 )
@@ -36,6 +41,10 @@ import (
 func init() {
 	plugin.SyncerResourceRegister.Register(&plugin.Registration{
 		ID: "persistentvolumeclaim",
+		RBACRules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"persistentvolumeclaims"}, Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+			{APIGroups: []string{""}, Resources: []string{"persistentvolumeclaims/status"}, Verbs: []string{"get"}},
+		},
 		InitFn: func(ctx *plugin.InitContext) (interface{}, error) {
 			return NewPVCController(ctx.Config.(*config.SyncerConfiguration), ctx.Client, ctx.Informer, ctx.VCClient, ctx.VCInformer, manager.ResourceSyncerOptions{})
 		},
@@ -82,5 +91,32 @@ func NewPVCController(config *config.SyncerConfiguration,
 		return nil, err
 	}
 
+	c.UpwardController, err = uw.NewUWController(&v1.PersistentVolumeClaim{}, c, uw.WithOptions(options.UWOptions))
+	if err != nil {
+		return nil, err
+	}
+
+	informer.Core().V1().PersistentVolumeClaims().Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: c.enqueuePVC,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				newPVC := newObj.(*v1.PersistentVolumeClaim)
+				oldPVC := oldObj.(*v1.PersistentVolumeClaim)
+				if newPVC.ResourceVersion != oldPVC.ResourceVersion {
+					c.enqueuePVC(newObj)
+				}
+			},
+		},
+	)
+
 	return c, nil
 }
+
+func (c *controller) enqueuePVC(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %v: %v", obj, err))
+		return
+	}
+	c.UpwardController.AddToQueue(key)
+}
@@ -62,6 +62,12 @@ func (c *controller) Reconcile(request reconciler.Request) (reconciler.Result, e
 	}
 
 	if vExists && !pExists {
+		if cluster := c.MultiClusterController.GetCluster(request.ClusterName); cluster != nil {
+			if vcObj, err := cluster.GetObject(); err == nil && conversion.IsAPIDisabled(vcObj, "persistentvolumeclaims") {
+				klog.Infof("pvc creation is disabled for cluster %s, dropping %s/%s", request.ClusterName, request.Namespace, request.Name)
+				return reconciler.Result{}, nil
+			}
+		}
 		vPVC := vPVCObj.(*v1.PersistentVolumeClaim)
 		err := c.reconcilePVCCreate(request.ClusterName, targetNamespace, request.UID, vPVC)
 		if err != nil {
@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolumeclaim
+
+import (
+	"context"
+	"fmt"
+
+	pkgerr "github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/constants"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/conversion"
+)
+
+// StartUWS starts the upward syncer
+// and blocks until an empty struct is sent to the stop channel.
+func (c *controller) StartUWS(stopCh <-chan struct{}) error {
+	if !cache.WaitForCacheSync(stopCh, c.pvcSynced) {
+		return fmt.Errorf("failed to wait for caches to sync persistentvolumeclaim")
+	}
+	return c.UpwardController.Start(stopCh)
+}
+
+// BackPopulate back-populates a super master pvc's Status - in particular its Phase,
+// AccessModes and Capacity, which the super master's PV binder controller fills in once the pvc
+// is bound - into the corresponding tenant pvc, so tenant workloads observe the same binding
+// state as super master does.
+func (c *controller) BackPopulate(key string) error {
+	pNamespace, pName, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	pPVC, err := c.pvcLister.PersistentVolumeClaims(pNamespace).Get(pName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	clusterName, vNamespace := conversion.GetVirtualOwner(pPVC)
+	if clusterName == "" || vNamespace == "" {
+		return nil
+	}
+
+	vPVCObj, err := c.MultiClusterController.Get(clusterName, vNamespace, pName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return pkgerr.Wrapf(err, "could not find pPVC %s/%s's vPVC in controller cache", vNamespace, pName)
+	}
+	vPVC := vPVCObj.(*v1.PersistentVolumeClaim)
+	if pPVC.Annotations[constants.LabelUID] != string(vPVC.UID) {
+		return fmt.Errorf("BackPopulated pPVC %s/%s delegated UID is different from updated object", pPVC.Namespace, pPVC.Name)
+	}
+
+	if equality.Semantic.DeepEqual(pPVC.Status, vPVC.Status) {
+		return nil
+	}
+
+	tenantClient, err := c.MultiClusterController.GetClusterClient(clusterName)
+	if err != nil {
+		return pkgerr.Wrapf(err, "failed to create client from cluster %s config", clusterName)
+	}
+
+	newPVC := vPVC.DeepCopy()
+	newPVC.Status = *pPVC.Status.DeepCopy()
+	_, err = tenantClient.CoreV1().PersistentVolumeClaims(vNamespace).UpdateStatus(context.TODO(), newPVC, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persistentvolumeclaim
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	core "k8s.io/client-go/testing"
+	util "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/util/test"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/apis/tenancy/v1alpha1"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/conversion"
+)
+
+func applyStatusToPVC(pvc *v1.PersistentVolumeClaim, status *v1.PersistentVolumeClaimStatus) *v1.PersistentVolumeClaim {
+	pvc.Status = *status.DeepCopy()
+	return pvc
+}
+
+// expectedBackPopulatedPVC mirrors the TypeMeta the controller-runtime fake client stamps onto
+// objects returned by Get, since the expected object here is derived from what BackPopulate reads
+// out of the tenant cluster's cache rather than from a literal the test constructs by hand.
+func expectedBackPopulatedPVC(pvc *v1.PersistentVolumeClaim) *v1.PersistentVolumeClaim {
+	pvc.TypeMeta = metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"}
+	return pvc
+}
+
+func TestUWPVCStatusUpdate(t *testing.T) {
+	testTenant := &v1alpha1.VirtualCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "tenant-1",
+			UID:       "7374a172-c35d-45b1-9c8e-bf5c5b614937",
+		},
+		Status: v1alpha1.VirtualClusterStatus{
+			Phase: v1alpha1.ClusterRunning,
+		},
+	}
+
+	defaultClusterKey := conversion.ToClusterKey(testTenant)
+	superDefaultNSName := conversion.ToSuperMasterNamespace(defaultClusterKey, "default")
+
+	pendingStatus := &v1.PersistentVolumeClaimStatus{
+		Phase: v1.ClaimPending,
+	}
+
+	boundStatus := &v1.PersistentVolumeClaimStatus{
+		Phase:       v1.ClaimBound,
+		AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+		Capacity: v1.ResourceList{
+			v1.ResourceStorage: resource.MustParse("20Gi"),
+		},
+	}
+
+	testcases := map[string]struct {
+		ExistingObjectInSuper  []runtime.Object
+		ExistingObjectInTenant []runtime.Object
+		EnqueuedKey            string
+		ExpectedUpdatedObject  []runtime.Object
+		ExpectedError          string
+	}{
+		"pPVC not found": {
+			ExistingObjectInTenant: []runtime.Object{
+				tenantPVC("pvc-1", "default", "12345"),
+			},
+			EnqueuedKey: superDefaultNSName + "/pvc-1",
+		},
+		"pPVC exists, vPVC does not exist": {
+			ExistingObjectInSuper: []runtime.Object{
+				applyStatusToPVC(superPVC("pvc-1", superDefaultNSName, "12345", defaultClusterKey), boundStatus),
+			},
+			EnqueuedKey: superDefaultNSName + "/pvc-1",
+		},
+		"pPVC exists, vPVC exists with different uid": {
+			ExistingObjectInSuper: []runtime.Object{
+				applyStatusToPVC(superPVC("pvc-1", superDefaultNSName, "12345", defaultClusterKey), boundStatus),
+			},
+			ExistingObjectInTenant: []runtime.Object{
+				applyStatusToPVC(tenantPVC("pvc-1", "default", "123456"), pendingStatus),
+			},
+			EnqueuedKey:   superDefaultNSName + "/pvc-1",
+			ExpectedError: "delegated UID is different",
+		},
+		"no status diff": {
+			ExistingObjectInSuper: []runtime.Object{
+				applyStatusToPVC(superPVC("pvc-1", superDefaultNSName, "12345", defaultClusterKey), pendingStatus),
+			},
+			ExistingObjectInTenant: []runtime.Object{
+				applyStatusToPVC(tenantPVC("pvc-1", "default", "12345"), pendingStatus),
+			},
+			EnqueuedKey: superDefaultNSName + "/pvc-1",
+		},
+		"status diff, pvc bound": {
+			ExistingObjectInSuper: []runtime.Object{
+				applyStatusToPVC(superPVC("pvc-1", superDefaultNSName, "12345", defaultClusterKey), boundStatus),
+			},
+			ExistingObjectInTenant: []runtime.Object{
+				applyStatusToPVC(tenantPVC("pvc-1", "default", "12345"), pendingStatus),
+			},
+			EnqueuedKey: superDefaultNSName + "/pvc-1",
+			ExpectedUpdatedObject: []runtime.Object{
+				expectedBackPopulatedPVC(applyStatusToPVC(tenantPVC("pvc-1", "default", "12345"), boundStatus)),
+			},
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			actions, reconcileErr, err := util.RunUpwardSync(NewPVCController, testTenant, tc.ExistingObjectInSuper, tc.ExistingObjectInTenant, tc.EnqueuedKey, nil)
+			if err != nil {
+				t.Errorf("%s: error running upward sync: %v", k, err)
+				return
+			}
+
+			if reconcileErr != nil {
+				if tc.ExpectedError == "" {
+					t.Errorf("expected no error, but got \"%v\"", reconcileErr)
+				} else if !strings.Contains(reconcileErr.Error(), tc.ExpectedError) {
+					t.Errorf("expected error msg \"%s\", but got \"%v\"", tc.ExpectedError, reconcileErr)
+				}
+			} else if tc.ExpectedError != "" {
+				t.Errorf("expected error msg \"%s\", but got empty", tc.ExpectedError)
+			}
+
+			if len(tc.ExpectedUpdatedObject) != len(actions) {
+				t.Errorf("%s: Expected to update pvc %#v. Actual actions were: %#v", k, tc.ExpectedUpdatedObject, actions)
+				return
+			}
+			for i, obj := range tc.ExpectedUpdatedObject {
+				action := actions[i]
+				if !action.Matches("update", "persistentvolumeclaims") {
+					t.Errorf("%s: Unexpected action %s", k, action)
+				}
+				actionObj := action.(core.UpdateAction).GetObject()
+				if !equality.Semantic.DeepEqual(obj, actionObj) {
+					t.Errorf("%s: Expected updated pvc is %v, got %v", k, obj, actionObj)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformconfigmap
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/constants"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/metrics"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/tenanttier"
+)
+
+var numMissMatchedPlatformConfigMaps uint64
+
+func (c *controller) StartPatrol(stopCh <-chan struct{}) error {
+	if !cache.WaitForCacheSync(stopCh, c.configMapSynced) {
+		return fmt.Errorf("failed to wait for caches to sync before starting platform configmap checker")
+	}
+	c.Patroller.Start(stopCh)
+	return nil
+}
+
+// PatrollerDo checks that every pushed ConfigMap stays consistent between the super master and
+// every tenant cluster whose tier it targets, deleting orphaned tenant copies (source gone, or the
+// tenant's tier no longer matches) and re-enqueueing any that have drifted or are missing.
+func (c *controller) PatrollerDo() {
+	clusterNames := c.MultiClusterController.GetClusterNames()
+	if len(clusterNames) == 0 {
+		klog.Infof("tenant masters has no clusters, give up platform configmap period checker")
+		return
+	}
+
+	wg := sync.WaitGroup{}
+	numMissMatchedPlatformConfigMaps = 0
+
+	for _, clusterName := range clusterNames {
+		wg.Add(1)
+		go func(clusterName string) {
+			defer wg.Done()
+			c.checkConfigMapsOfTenantCluster(clusterName)
+		}(clusterName)
+	}
+	wg.Wait()
+
+	pConfigMapList, err := c.configMapLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("error listing configmap from super master informer cache: %v", err)
+		return
+	}
+
+	for _, pConfigMap := range pConfigMapList {
+		if !pushConfigMap(pConfigMap) {
+			continue
+		}
+		for _, clusterName := range clusterNames {
+			if !c.tenantTierMatches(clusterName, pConfigMap) {
+				continue
+			}
+			if _, err := c.MultiClusterController.Get(clusterName, c.targetNamespace, pConfigMap.Name); err != nil {
+				if errors.IsNotFound(err) {
+					metrics.CheckerRemedyStats.WithLabelValues("RequeuedSuperMasterPlatformConfigMaps").Inc()
+					c.UpwardController.AddToQueue(clusterName + "/" + pConfigMap.Namespace + "/" + pConfigMap.Name)
+					continue
+				}
+				klog.Errorf("fail to get configmap from cluster %s: %v", clusterName, err)
+			}
+		}
+	}
+
+	metrics.CheckerMissMatchStats.WithLabelValues("MissMatchedPlatformConfigMaps").Set(float64(numMissMatchedPlatformConfigMaps))
+}
+
+func (c *controller) checkConfigMapsOfTenantCluster(clusterName string) {
+	listObj, err := c.MultiClusterController.List(clusterName)
+	if err != nil {
+		klog.Errorf("error listing configmap from cluster %s informer cache: %v", clusterName, err)
+		return
+	}
+	klog.V(4).Infof("check platform configmap consistency in cluster %s", clusterName)
+	cmList := listObj.(*v1.ConfigMapList)
+	for i, vConfigMap := range cmList.Items {
+		if vConfigMap.Namespace != c.targetNamespace {
+			continue
+		}
+		pConfigMap, err := c.configMapLister.ConfigMaps(vConfigMap.Namespace).Get(vConfigMap.Name)
+		if errors.IsNotFound(err) || (err == nil && !pushConfigMap(pConfigMap)) || (err == nil && !c.tenantTierMatches(clusterName, pConfigMap)) {
+			c.deleteOrphanConfigMap(clusterName, &cmList.Items[i])
+			continue
+		}
+		if err != nil {
+			klog.Errorf("failed to get pConfigMap %s from super master cache: %v", vConfigMap.Name, err)
+			continue
+		}
+
+		if updatedConfigMap := checkConfigMapEquality(pConfigMap, &cmList.Items[i]); updatedConfigMap != nil {
+			atomic.AddUint64(&numMissMatchedPlatformConfigMaps, 1)
+			klog.Warningf("data of platform configmap %v/%v diff in super&tenant master", vConfigMap.Namespace, vConfigMap.Name)
+			c.UpwardController.AddToQueue(clusterName + "/" + pConfigMap.Namespace + "/" + pConfigMap.Name)
+		}
+	}
+}
+
+func (c *controller) deleteOrphanConfigMap(clusterName string, vConfigMap *v1.ConfigMap) {
+	tenantClient, err := c.MultiClusterController.GetClusterClient(clusterName)
+	if err != nil {
+		klog.Errorf("error getting cluster %s clientset: %v", clusterName, err)
+		return
+	}
+	if err := tenantClient.CoreV1().ConfigMaps(vConfigMap.Namespace).Delete(context.TODO(), vConfigMap.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		klog.Errorf("error deleting platform configmap %v/%v in cluster %s: %v", vConfigMap.Namespace, vConfigMap.Name, clusterName, err)
+		return
+	}
+	metrics.CheckerRemedyStats.WithLabelValues("DeletedOrphanTenantPlatformConfigMaps").Inc()
+}
+
+// tenantTierMatches reports whether pConfigMap's target tiers include the tier of the
+// VirtualCluster owning clusterName.
+func (c *controller) tenantTierMatches(clusterName string, pConfigMap *v1.ConfigMap) bool {
+	vc, err := c.MultiClusterController.GetClusterObject(clusterName)
+	if err != nil {
+		klog.Errorf("failed to get virtualcluster object for cluster %s: %v", clusterName, err)
+		return false
+	}
+	vcMeta, err := meta.Accessor(vc)
+	if err != nil {
+		klog.Errorf("failed to access virtualcluster object for cluster %s: %v", clusterName, err)
+		return false
+	}
+	return tenanttier.Matches(pConfigMap.Annotations[constants.AnnotationTargetTiers], tenanttier.Of(vcMeta))
+}
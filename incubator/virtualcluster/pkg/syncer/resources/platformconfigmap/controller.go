@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package platformconfigmap pushes ConfigMaps that the platform team has marked for broadcast
+// (constants.PublicObjectKey) from the super master into a fixed namespace - normally kube-system
+// - of every tenant cluster, optionally restricted to a subset of tenancy tiers via
+// constants.AnnotationTargetTiers. It's the ConfigMap half of the "platform push" channel; see
+// sibling package platformsecret for the Secret half.
+//
+// Unlike the configmap package, which mirrors tenant-created ConfigMaps into their corresponding
+// super master namespace, this is a one-way, super-to-tenant broadcast: the tenant's copy is
+// never read back, and the super master ConfigMap doesn't need to live in any particular
+// namespace.
+package platformconfigmap
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/informers"
+	clientset "k8s.io/client-go/kubernetes"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	vcclient "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/client/clientset/versioned"
+	vcinformers "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/client/informers/externalversions/tenancy/v1alpha1"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/apis/config"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/constants"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/manager"
+	pa "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/patrol"
+	uw "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/uwcontroller"
+	mc "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/mccontroller"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/plugin"
+)
+
+func init() {
+	plugin.SyncerResourceRegister.Register(&plugin.Registration{
+		ID: "platformconfigmap",
+		RBACRules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "list", "watch"}},
+		},
+		InitFn: func(ctx *plugin.InitContext) (interface{}, error) {
+			return NewPlatformConfigMapController(ctx.Config.(*config.SyncerConfiguration), ctx.Client, ctx.Informer, ctx.VCClient, ctx.VCInformer, manager.ResourceSyncerOptions{})
+		},
+	})
+}
+
+type controller struct {
+	manager.BaseResourceSyncer
+	// super master configMap client
+	configMapClient v1core.ConfigMapsGetter
+	// super master configMap informer lister/synced function
+	configMapLister listersv1.ConfigMapLister
+	configMapSynced cache.InformerSynced
+	// targetNamespace is the namespace in every tenant cluster that pushed ConfigMaps are
+	// created in.
+	targetNamespace string
+}
+
+func NewPlatformConfigMapController(config *config.SyncerConfiguration,
+	client clientset.Interface,
+	informer informers.SharedInformerFactory,
+	vcClient vcclient.Interface,
+	vcInformer vcinformers.VirtualClusterInformer,
+	options manager.ResourceSyncerOptions) (manager.ResourceSyncer, error) {
+
+	c := &controller{
+		BaseResourceSyncer: manager.BaseResourceSyncer{
+			Config: config,
+		},
+		configMapClient: client.CoreV1(),
+		targetNamespace: config.PlatformPushTargetNamespace,
+	}
+
+	var err error
+	c.MultiClusterController, err = mc.NewMCController(&v1.ConfigMap{}, &v1.ConfigMapList{}, c, mc.WithOptions(options.MCOptions))
+	if err != nil {
+		return nil, err
+	}
+
+	c.configMapLister = informer.Core().V1().ConfigMaps().Lister()
+	if options.IsFake {
+		c.configMapSynced = func() bool { return true }
+	} else {
+		c.configMapSynced = informer.Core().V1().ConfigMaps().Informer().HasSynced
+	}
+
+	c.UpwardController, err = uw.NewUWController(&v1.ConfigMap{}, c, uw.WithOptions(options.UWOptions))
+	if err != nil {
+		return nil, err
+	}
+
+	c.Patroller, err = pa.NewPatroller(&v1.ConfigMap{}, c, pa.WithOptions(options.PatrolOptions))
+	if err != nil {
+		return nil, err
+	}
+
+	informer.Core().V1().ConfigMaps().Informer().AddEventHandler(
+		cache.FilteringResourceEventHandler{
+			FilterFunc: func(obj interface{}) bool {
+				switch t := obj.(type) {
+				case *v1.ConfigMap:
+					return pushConfigMap(t)
+				case cache.DeletedFinalStateUnknown:
+					if e, ok := t.Obj.(*v1.ConfigMap); ok {
+						return pushConfigMap(e)
+					}
+					utilruntime.HandleError(fmt.Errorf("unable to convert object %v to *v1.ConfigMap", obj))
+					return false
+				default:
+					utilruntime.HandleError(fmt.Errorf("unable to handle object in platform configmap controller: %v", obj))
+					return false
+				}
+			},
+			Handler: cache.ResourceEventHandlerFuncs{
+				AddFunc: c.enqueueConfigMap,
+				UpdateFunc: func(oldObj, newObj interface{}) {
+					newConfigMap := newObj.(*v1.ConfigMap)
+					oldConfigMap := oldObj.(*v1.ConfigMap)
+					if newConfigMap.ResourceVersion != oldConfigMap.ResourceVersion {
+						c.enqueueConfigMap(newObj)
+					}
+				},
+				DeleteFunc: c.enqueueConfigMap,
+			},
+		})
+	return c, nil
+}
+
+// pushConfigMap returns true if pCM is marked to be broadcast to (a subset of) tenant clusters.
+func pushConfigMap(pCM *v1.ConfigMap) bool {
+	return pCM.Labels[constants.PublicObjectKey] == "true"
+}
+
+func (c *controller) enqueueConfigMap(obj interface{}) {
+	// key is "namespace/name" of the source ConfigMap in the super master.
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %v: %v", obj, err))
+		return
+	}
+	clusterNames := c.MultiClusterController.GetClusterNames()
+	if len(clusterNames) == 0 {
+		klog.Infof("no tenant clusters, skip pushing configmap %v", key)
+		return
+	}
+	for _, clusterName := range clusterNames {
+		// BackPopulate splits this back into (clusterName, pNamespace, pName).
+		c.UpwardController.AddToQueue(clusterName + "/" + key)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformconfigmap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/constants"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/conversion"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/reconciler"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/tenanttier"
+)
+
+// StartUWS starts the upward syncer
+// and blocks until an empty struct is sent to the stop channel.
+func (c *controller) StartUWS(stopCh <-chan struct{}) error {
+	if !cache.WaitForCacheSync(stopCh, c.configMapSynced) {
+		return fmt.Errorf("failed to wait for caches to sync platform configmap")
+	}
+	return c.UpwardController.Start(stopCh)
+}
+
+// BackPopulate pushes the super master ConfigMap named by key into the tenant cluster it was
+// enqueued for. key is "clusterName/pNamespace/pName".
+func (c *controller) BackPopulate(key string) error {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid platform configmap key: %v", key)
+	}
+	clusterName, pKey := parts[0], parts[1]
+	pNamespace, pName, err := cache.SplitMetaNamespaceKey(pKey)
+	if err != nil {
+		return err
+	}
+
+	op := reconciler.AddEvent
+	pConfigMap, err := c.configMapLister.ConfigMaps(pNamespace).Get(pName)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		op = reconciler.DeleteEvent
+	}
+
+	tenantClient, err := c.MultiClusterController.GetClusterClient(clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to create client from cluster %s config: %v", clusterName, err)
+	}
+
+	if op != reconciler.DeleteEvent {
+		vc, err := c.MultiClusterController.GetClusterObject(clusterName)
+		if err != nil {
+			return fmt.Errorf("failed to get virtualcluster object for cluster %s: %v", clusterName, err)
+		}
+		vcMeta, err := meta.Accessor(vc)
+		if err != nil {
+			return err
+		}
+		if !tenanttier.Matches(pConfigMap.Annotations[constants.AnnotationTargetTiers], tenanttier.Of(vcMeta)) {
+			// The tenant's tier no longer wants this configmap; treat it like a delete.
+			op = reconciler.DeleteEvent
+		}
+	}
+
+	vConfigMapObj, err := c.MultiClusterController.Get(clusterName, c.targetNamespace, pName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			if op == reconciler.AddEvent {
+				vConfigMap := conversion.BuildVirtualPlatformConfigMap(c.targetNamespace, pConfigMap)
+				_, err := tenantClient.CoreV1().ConfigMaps(c.targetNamespace).Create(context.TODO(), vConfigMap, metav1.CreateOptions{})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return err
+	}
+
+	if op == reconciler.DeleteEvent {
+		if err := tenantClient.CoreV1().ConfigMaps(c.targetNamespace).Delete(context.TODO(), pName, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if updatedConfigMap := checkConfigMapEquality(pConfigMap, vConfigMapObj.(*v1.ConfigMap)); updatedConfigMap != nil {
+		if _, err := tenantClient.CoreV1().ConfigMaps(c.targetNamespace).Update(context.TODO(), updatedConfigMap, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkConfigMapEquality returns a copy of pConfigMap ready to push into a tenant cluster (with
+// vConfigMap's identity) if pConfigMap and vConfigMap have diverged, or nil if they already match.
+// Unlike conversion.Equality's CheckConfigMapEquality, the super master copy is always the source
+// of truth here, so there's no DWS-style meta reconciliation.
+func checkConfigMapEquality(pConfigMap, vConfigMap *v1.ConfigMap) *v1.ConfigMap {
+	updated := pConfigMap.DeepCopy()
+	updated.ObjectMeta = vConfigMap.ObjectMeta
+	updated.TypeMeta = vConfigMap.TypeMeta
+
+	if !equality.Semantic.DeepEqual(vConfigMap, updated) {
+		return updated
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformconfigmap
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/apis/tenancy/v1alpha1"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/constants"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/conversion"
+	util "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/util/test"
+)
+
+// makePlatformConfigMap builds a super master ConfigMap marked for platform push. The test harness
+// (util.RunUpwardSync) doesn't set config.PlatformPushTargetNamespace, so it defaults to "" - the
+// tenant-side copy is expected there too; see makeVirtualPlatformConfigMap.
+func makePlatformConfigMap(name, uid string, mFuncs ...func(*v1.ConfigMap)) *v1.ConfigMap {
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "kube-public",
+			UID:       types.UID(uid),
+			Labels:    map[string]string{constants.PublicObjectKey: "true"},
+		},
+		Data: map[string]string{"k": "v"},
+	}
+	for _, f := range mFuncs {
+		f(cm)
+	}
+	return cm
+}
+
+// makeVirtualPlatformConfigMap builds the tenant-side copy, which lives in the (test-default empty)
+// target namespace rather than the super master ConfigMap's own namespace.
+func makeVirtualPlatformConfigMap(name, uid string, mFuncs ...func(*v1.ConfigMap)) *v1.ConfigMap {
+	cm := makePlatformConfigMap(name, uid, mFuncs...)
+	cm.Namespace = ""
+	return cm
+}
+
+func TestUWPlatformConfigMapCreation(t *testing.T) {
+	testTenant := &v1alpha1.VirtualCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "tenant-1",
+			UID:       "7374a172-c35d-45b1-9c8e-bf5c5b614937",
+		},
+		Status: v1alpha1.VirtualClusterStatus{
+			Phase: v1alpha1.ClusterRunning,
+		},
+	}
+	defaultClusterKey := conversion.ToClusterKey(testTenant)
+
+	testcases := map[string]struct {
+		ExistingObjectInSuper  []runtime.Object
+		ExistingObjectInTenant []runtime.Object
+		EnqueuedKey            string
+		ExpectedCreatedObject  []string
+		ExpectedNoOperation    bool
+	}{
+		"pCM exists but vCM not found": {
+			ExistingObjectInSuper: []runtime.Object{
+				makePlatformConfigMap("cm", "12345"),
+			},
+			EnqueuedKey: defaultClusterKey + "/kube-public/cm",
+			ExpectedCreatedObject: []string{
+				"cm",
+			},
+		},
+		"pCM exists but targets a different tier": {
+			ExistingObjectInSuper: []runtime.Object{
+				makePlatformConfigMap("cm", "12345", func(cm *v1.ConfigMap) {
+					cm.Annotations = map[string]string{constants.AnnotationTargetTiers: "gold"}
+				}),
+			},
+			EnqueuedKey:         defaultClusterKey + "/kube-public/cm",
+			ExpectedNoOperation: true,
+		},
+		"pCM exists, vCM exists": {
+			ExistingObjectInSuper: []runtime.Object{
+				makePlatformConfigMap("cm", "12345"),
+			},
+			ExistingObjectInTenant: []runtime.Object{
+				makeVirtualPlatformConfigMap("cm", "123456"),
+			},
+			EnqueuedKey:         defaultClusterKey + "/kube-public/cm",
+			ExpectedNoOperation: true,
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			actions, reconcileErr, err := util.RunUpwardSync(NewPlatformConfigMapController, testTenant, tc.ExistingObjectInSuper, tc.ExistingObjectInTenant, tc.EnqueuedKey, nil)
+			if err != nil {
+				t.Errorf("%s: error running upward sync: %v", k, err)
+				return
+			}
+			if reconcileErr != nil {
+				t.Errorf("%s: expected no error, but got \"%v\"", k, reconcileErr)
+				return
+			}
+
+			if tc.ExpectedNoOperation {
+				if len(actions) != 0 {
+					t.Errorf("%s: expect no operation, got %v", k, actions)
+				}
+				return
+			}
+
+			for _, expectedName := range tc.ExpectedCreatedObject {
+				matched := false
+				for _, action := range actions {
+					if !action.Matches("create", "configmaps") {
+						continue
+					}
+					created := action.(core.CreateAction).GetObject().(*v1.ConfigMap)
+					if created.Name == expectedName {
+						matched = true
+						break
+					}
+				}
+				if !matched {
+					t.Errorf("%s: expect created configmap %+v but not found", k, expectedName)
+				}
+			}
+		})
+	}
+}
+
+func TestUWPlatformConfigMapUpdate(t *testing.T) {
+	testTenant := &v1alpha1.VirtualCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "tenant-1",
+			UID:       "7374a172-c35d-45b1-9c8e-bf5c5b614937",
+		},
+		Status: v1alpha1.VirtualClusterStatus{
+			Phase: v1alpha1.ClusterRunning,
+		},
+	}
+	defaultClusterKey := conversion.ToClusterKey(testTenant)
+
+	existingSuper := makePlatformConfigMap("cm", "12345", func(cm *v1.ConfigMap) {
+		cm.Data = map[string]string{"k": "new"}
+	})
+	existingTenant := makeVirtualPlatformConfigMap("cm", "123456", func(cm *v1.ConfigMap) {
+		cm.Data = map[string]string{"k": "old"}
+	})
+
+	actions, reconcileErr, err := util.RunUpwardSync(NewPlatformConfigMapController, testTenant,
+		[]runtime.Object{existingSuper}, []runtime.Object{existingTenant}, defaultClusterKey+"/kube-public/cm", nil)
+	if err != nil {
+		t.Fatalf("error running upward sync: %v", err)
+	}
+	if reconcileErr != nil {
+		t.Fatalf("expected no error, but got \"%v\"", reconcileErr)
+	}
+
+	matched := false
+	for _, action := range actions {
+		if !action.Matches("update", "configmaps") {
+			continue
+		}
+		updated := action.(core.UpdateAction).GetObject().(*v1.ConfigMap)
+		if updated.Data["k"] == "new" {
+			matched = true
+		}
+	}
+	if !matched {
+		t.Errorf("expect configmap data updated to \"new\" but not found in actions %v", actions)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformsecret
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/constants"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/metrics"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/tenanttier"
+)
+
+var numMissMatchedPlatformSecrets uint64
+
+func (c *controller) StartPatrol(stopCh <-chan struct{}) error {
+	if !cache.WaitForCacheSync(stopCh, c.secretSynced) {
+		return fmt.Errorf("failed to wait for caches to sync before starting platform secret checker")
+	}
+	c.Patroller.Start(stopCh)
+	return nil
+}
+
+// PatrollerDo checks that every pushed Secret stays consistent between the super master and every
+// tenant cluster whose tier it targets, deleting orphaned tenant copies (source gone, or the
+// tenant's tier no longer matches) and re-enqueueing any that have drifted or are missing.
+func (c *controller) PatrollerDo() {
+	clusterNames := c.MultiClusterController.GetClusterNames()
+	if len(clusterNames) == 0 {
+		klog.Infof("tenant masters has no clusters, give up platform secret period checker")
+		return
+	}
+
+	wg := sync.WaitGroup{}
+	numMissMatchedPlatformSecrets = 0
+
+	for _, clusterName := range clusterNames {
+		wg.Add(1)
+		go func(clusterName string) {
+			defer wg.Done()
+			c.checkSecretsOfTenantCluster(clusterName)
+		}(clusterName)
+	}
+	wg.Wait()
+
+	pSecretList, err := c.secretLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("error listing secret from super master informer cache: %v", err)
+		return
+	}
+
+	for _, pSecret := range pSecretList {
+		if !pushSecret(pSecret) {
+			continue
+		}
+		for _, clusterName := range clusterNames {
+			if !c.tenantTierMatches(clusterName, pSecret) {
+				continue
+			}
+			if _, err := c.MultiClusterController.Get(clusterName, c.targetNamespace, pSecret.Name); err != nil {
+				if errors.IsNotFound(err) {
+					metrics.CheckerRemedyStats.WithLabelValues("RequeuedSuperMasterPlatformSecrets").Inc()
+					c.UpwardController.AddToQueue(clusterName + "/" + pSecret.Namespace + "/" + pSecret.Name)
+					continue
+				}
+				klog.Errorf("fail to get secret from cluster %s: %v", clusterName, err)
+			}
+		}
+	}
+
+	metrics.CheckerMissMatchStats.WithLabelValues("MissMatchedPlatformSecrets").Set(float64(numMissMatchedPlatformSecrets))
+}
+
+func (c *controller) checkSecretsOfTenantCluster(clusterName string) {
+	listObj, err := c.MultiClusterController.List(clusterName)
+	if err != nil {
+		klog.Errorf("error listing secret from cluster %s informer cache: %v", clusterName, err)
+		return
+	}
+	klog.V(4).Infof("check platform secret consistency in cluster %s", clusterName)
+	secretList := listObj.(*v1.SecretList)
+	for i, vSecret := range secretList.Items {
+		if vSecret.Namespace != c.targetNamespace {
+			continue
+		}
+		pSecret, err := c.secretLister.Secrets(vSecret.Namespace).Get(vSecret.Name)
+		if errors.IsNotFound(err) || (err == nil && !pushSecret(pSecret)) || (err == nil && !c.tenantTierMatches(clusterName, pSecret)) {
+			c.deleteOrphanSecret(clusterName, &secretList.Items[i])
+			continue
+		}
+		if err != nil {
+			klog.Errorf("failed to get pSecret %s from super master cache: %v", vSecret.Name, err)
+			continue
+		}
+
+		if updatedSecret := checkSecretEquality(pSecret, &secretList.Items[i]); updatedSecret != nil {
+			atomic.AddUint64(&numMissMatchedPlatformSecrets, 1)
+			klog.Warningf("data of platform secret %v/%v diff in super&tenant master", vSecret.Namespace, vSecret.Name)
+			c.UpwardController.AddToQueue(clusterName + "/" + pSecret.Namespace + "/" + pSecret.Name)
+		}
+	}
+}
+
+func (c *controller) deleteOrphanSecret(clusterName string, vSecret *v1.Secret) {
+	tenantClient, err := c.MultiClusterController.GetClusterClient(clusterName)
+	if err != nil {
+		klog.Errorf("error getting cluster %s clientset: %v", clusterName, err)
+		return
+	}
+	if err := tenantClient.CoreV1().Secrets(vSecret.Namespace).Delete(context.TODO(), vSecret.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		klog.Errorf("error deleting platform secret %v/%v in cluster %s: %v", vSecret.Namespace, vSecret.Name, clusterName, err)
+		return
+	}
+	metrics.CheckerRemedyStats.WithLabelValues("DeletedOrphanTenantPlatformSecrets").Inc()
+}
+
+// tenantTierMatches reports whether pSecret's target tiers include the tier of the VirtualCluster
+// owning clusterName.
+func (c *controller) tenantTierMatches(clusterName string, pSecret *v1.Secret) bool {
+	vc, err := c.MultiClusterController.GetClusterObject(clusterName)
+	if err != nil {
+		klog.Errorf("failed to get virtualcluster object for cluster %s: %v", clusterName, err)
+		return false
+	}
+	vcMeta, err := meta.Accessor(vc)
+	if err != nil {
+		klog.Errorf("failed to access virtualcluster object for cluster %s: %v", clusterName, err)
+		return false
+	}
+	return tenanttier.Matches(pSecret.Annotations[constants.AnnotationTargetTiers], tenanttier.Of(vcMeta))
+}
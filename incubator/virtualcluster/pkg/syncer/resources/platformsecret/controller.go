@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package platformsecret pushes Secrets that the platform team has marked for broadcast
+// (constants.PublicObjectKey) from the super master into a fixed namespace - normally kube-system
+// - of every tenant cluster, optionally restricted to a subset of tenancy tiers via
+// constants.AnnotationTargetTiers. It's the Secret half of the "platform push" channel; see sibling
+// package platformconfigmap for the ConfigMap half.
+//
+// Unlike the secret package, which mirrors tenant-created Secrets into their corresponding super
+// master namespace, this is a one-way, super-to-tenant broadcast: the tenant's copy is never read
+// back, and the super master Secret doesn't need to live in any particular namespace.
+package platformsecret
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/informers"
+	clientset "k8s.io/client-go/kubernetes"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	vcclient "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/client/clientset/versioned"
+	vcinformers "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/client/informers/externalversions/tenancy/v1alpha1"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/apis/config"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/constants"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/manager"
+	pa "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/patrol"
+	uw "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/uwcontroller"
+	mc "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/mccontroller"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/plugin"
+)
+
+func init() {
+	plugin.SyncerResourceRegister.Register(&plugin.Registration{
+		ID: "platformsecret",
+		RBACRules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list", "watch"}},
+		},
+		InitFn: func(ctx *plugin.InitContext) (interface{}, error) {
+			return NewPlatformSecretController(ctx.Config.(*config.SyncerConfiguration), ctx.Client, ctx.Informer, ctx.VCClient, ctx.VCInformer, manager.ResourceSyncerOptions{})
+		},
+	})
+}
+
+type controller struct {
+	manager.BaseResourceSyncer
+	// super master secret client
+	secretClient v1core.SecretsGetter
+	// super master secret informer lister/synced function
+	secretLister listersv1.SecretLister
+	secretSynced cache.InformerSynced
+	// targetNamespace is the namespace in every tenant cluster that pushed Secrets are
+	// created in.
+	targetNamespace string
+}
+
+func NewPlatformSecretController(config *config.SyncerConfiguration,
+	client clientset.Interface,
+	informer informers.SharedInformerFactory,
+	vcClient vcclient.Interface,
+	vcInformer vcinformers.VirtualClusterInformer,
+	options manager.ResourceSyncerOptions) (manager.ResourceSyncer, error) {
+
+	c := &controller{
+		BaseResourceSyncer: manager.BaseResourceSyncer{
+			Config: config,
+		},
+		secretClient:    client.CoreV1(),
+		targetNamespace: config.PlatformPushTargetNamespace,
+	}
+
+	var err error
+	c.MultiClusterController, err = mc.NewMCController(&v1.Secret{}, &v1.SecretList{}, c, mc.WithOptions(options.MCOptions))
+	if err != nil {
+		return nil, err
+	}
+
+	c.secretLister = informer.Core().V1().Secrets().Lister()
+	if options.IsFake {
+		c.secretSynced = func() bool { return true }
+	} else {
+		c.secretSynced = informer.Core().V1().Secrets().Informer().HasSynced
+	}
+
+	c.UpwardController, err = uw.NewUWController(&v1.Secret{}, c, uw.WithOptions(options.UWOptions))
+	if err != nil {
+		return nil, err
+	}
+
+	c.Patroller, err = pa.NewPatroller(&v1.Secret{}, c, pa.WithOptions(options.PatrolOptions))
+	if err != nil {
+		return nil, err
+	}
+
+	informer.Core().V1().Secrets().Informer().AddEventHandler(
+		cache.FilteringResourceEventHandler{
+			FilterFunc: func(obj interface{}) bool {
+				switch t := obj.(type) {
+				case *v1.Secret:
+					return pushSecret(t)
+				case cache.DeletedFinalStateUnknown:
+					if e, ok := t.Obj.(*v1.Secret); ok {
+						return pushSecret(e)
+					}
+					utilruntime.HandleError(fmt.Errorf("unable to convert object %v to *v1.Secret", obj))
+					return false
+				default:
+					utilruntime.HandleError(fmt.Errorf("unable to handle object in platform secret controller: %v", obj))
+					return false
+				}
+			},
+			Handler: cache.ResourceEventHandlerFuncs{
+				AddFunc: c.enqueueSecret,
+				UpdateFunc: func(oldObj, newObj interface{}) {
+					newSecret := newObj.(*v1.Secret)
+					oldSecret := oldObj.(*v1.Secret)
+					if newSecret.ResourceVersion != oldSecret.ResourceVersion {
+						c.enqueueSecret(newObj)
+					}
+				},
+				DeleteFunc: c.enqueueSecret,
+			},
+		})
+	return c, nil
+}
+
+// pushSecret returns true if pSecret is marked to be broadcast to (a subset of) tenant clusters.
+func pushSecret(pSecret *v1.Secret) bool {
+	return pSecret.Labels[constants.PublicObjectKey] == "true"
+}
+
+func (c *controller) enqueueSecret(obj interface{}) {
+	// key is "namespace/name" of the source Secret in the super master.
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %v: %v", obj, err))
+		return
+	}
+	clusterNames := c.MultiClusterController.GetClusterNames()
+	if len(clusterNames) == 0 {
+		klog.Infof("no tenant clusters, skip pushing secret %v", key)
+		return
+	}
+	for _, clusterName := range clusterNames {
+		// BackPopulate splits this back into (clusterName, pNamespace, pName).
+		c.UpwardController.AddToQueue(clusterName + "/" + key)
+	}
+}
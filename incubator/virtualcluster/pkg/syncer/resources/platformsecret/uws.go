@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformsecret
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/constants"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/conversion"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/reconciler"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/tenanttier"
+)
+
+// StartUWS starts the upward syncer
+// and blocks until an empty struct is sent to the stop channel.
+func (c *controller) StartUWS(stopCh <-chan struct{}) error {
+	if !cache.WaitForCacheSync(stopCh, c.secretSynced) {
+		return fmt.Errorf("failed to wait for caches to sync platform secret")
+	}
+	return c.UpwardController.Start(stopCh)
+}
+
+// BackPopulate pushes the super master Secret named by key into the tenant cluster it was
+// enqueued for. key is "clusterName/pNamespace/pName".
+func (c *controller) BackPopulate(key string) error {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid platform secret key: %v", key)
+	}
+	clusterName, pKey := parts[0], parts[1]
+	pNamespace, pName, err := cache.SplitMetaNamespaceKey(pKey)
+	if err != nil {
+		return err
+	}
+
+	op := reconciler.AddEvent
+	pSecret, err := c.secretLister.Secrets(pNamespace).Get(pName)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		op = reconciler.DeleteEvent
+	}
+
+	tenantClient, err := c.MultiClusterController.GetClusterClient(clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to create client from cluster %s config: %v", clusterName, err)
+	}
+
+	if op != reconciler.DeleteEvent {
+		vc, err := c.MultiClusterController.GetClusterObject(clusterName)
+		if err != nil {
+			return fmt.Errorf("failed to get virtualcluster object for cluster %s: %v", clusterName, err)
+		}
+		vcMeta, err := meta.Accessor(vc)
+		if err != nil {
+			return err
+		}
+		if !tenanttier.Matches(pSecret.Annotations[constants.AnnotationTargetTiers], tenanttier.Of(vcMeta)) {
+			// The tenant's tier no longer wants this secret; treat it like a delete.
+			op = reconciler.DeleteEvent
+		}
+	}
+
+	vSecretObj, err := c.MultiClusterController.Get(clusterName, c.targetNamespace, pName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			if op == reconciler.AddEvent {
+				vSecret := conversion.BuildVirtualPlatformSecret(c.targetNamespace, pSecret)
+				_, err := tenantClient.CoreV1().Secrets(c.targetNamespace).Create(context.TODO(), vSecret, metav1.CreateOptions{})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return err
+	}
+
+	if op == reconciler.DeleteEvent {
+		if err := tenantClient.CoreV1().Secrets(c.targetNamespace).Delete(context.TODO(), pName, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if updatedSecret := checkSecretEquality(pSecret, vSecretObj.(*v1.Secret)); updatedSecret != nil {
+		if _, err := tenantClient.CoreV1().Secrets(c.targetNamespace).Update(context.TODO(), updatedSecret, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSecretEquality returns a copy of pSecret ready to push into a tenant cluster (with
+// vSecret's identity) if pSecret and vSecret have diverged, or nil if they already match. Service
+// account token secrets are never pushed - they're scoped to a single cluster's apiserver. Unlike
+// conversion.Equality's CheckSecretEquality, the super master copy is always the source of truth
+// here, so there's no DWS-style meta reconciliation.
+func checkSecretEquality(pSecret, vSecret *v1.Secret) *v1.Secret {
+	if vSecret.Type == v1.SecretTypeServiceAccountToken {
+		return nil
+	}
+
+	updated := pSecret.DeepCopy()
+	updated.ObjectMeta = vSecret.ObjectMeta
+	updated.TypeMeta = vSecret.TypeMeta
+
+	if !equality.Semantic.DeepEqual(vSecret, updated) {
+		return updated
+	}
+	return nil
+}
@@ -71,6 +71,19 @@ func (c *controller) vNodeGCDo() {
 	candidates := func() []Candidate {
 		c.Lock()
 		defer c.Unlock()
+
+		var podEntries, gcEntries int
+		for _, nodeMap := range c.clusterVNodePodMap {
+			for _, pods := range nodeMap {
+				podEntries += len(pods)
+			}
+		}
+		for _, nodeMap := range c.clusterVNodeGCMap {
+			gcEntries += len(nodeMap)
+		}
+		metrics.SetTranslationCacheSize("pod_vnode_map", podEntries)
+		metrics.SetTranslationCacheSize("pod_vnode_gc_map", gcEntries)
+
 		var candidates []Candidate
 		for cluster, nodeMap := range c.clusterVNodeGCMap {
 			for nodeName, status := range nodeMap {
@@ -164,6 +177,7 @@ func (c *controller) PatrollerDo() {
 			continue
 		}
 		vList := listObj.(*v1.PodList)
+		metrics.SetTenantObjectCount("pod", cluster, len(vList.Items))
 		for i := range vList.Items {
 			if featuregate.DefaultFeatureGate.Enabled(featuregate.SuperClusterPooling) {
 				cname, ok := vList.Items[i].GetAnnotations()[utilconstants.LabelScheduledCluster]
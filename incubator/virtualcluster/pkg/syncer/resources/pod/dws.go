@@ -200,7 +200,13 @@ func (c *controller) reconcilePodCreate(clusterName, targetNamespace, requestUID
 		return fmt.Errorf("failed to find nameserver: %v", err)
 	}
 
+	vc, err := util.GetVirtualClusterObject(c.MultiClusterController, clusterName)
+	if err != nil {
+		return err
+	}
+
 	var ms = []conversion.PodMutator{
+		conversion.PodMutateSecurityCompliance(vc.Spec.EnforcePodSecurityCompliance),
 		conversion.PodMutateServiceLink(c.Config.DisablePodServiceLinks),
 		conversion.PodMutateDefault(vPod, pSecretMap, services, nameServer),
 		conversion.PodMutateAutoMountServiceAccountToken(c.Config.DisableServiceAccountToken),
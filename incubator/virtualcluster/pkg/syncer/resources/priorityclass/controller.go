@@ -18,6 +18,7 @@ package priorityclass
 
 import (
 	"fmt"
+	rbacv1 "k8s.io/api/rbac/v1"
 	v1 "k8s.io/api/scheduling/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/informers"
@@ -42,6 +43,9 @@ import (
 func init() {
 	plugin.SyncerResourceRegister.Register(&plugin.Registration{
 		ID: "priorityclass",
+		RBACRules: []rbacv1.PolicyRule{
+			{APIGroups: []string{"scheduling.k8s.io"}, Resources: []string{"priorityclasses"}, Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+		},
 		InitFn: func(ctx *plugin.InitContext) (interface{}, error) {
 			return NewPriorityClassController(ctx.Config.(*config.SyncerConfiguration), ctx.Client, ctx.Informer, ctx.VCClient, ctx.VCInformer, manager.ResourceSyncerOptions{})
 		},
@@ -127,6 +127,7 @@ func (c *controller) checkSecretOfTenantCluster(clusterName string) {
 	}
 	klog.V(4).Infof("check secrets consistency in cluster %s", clusterName)
 	secretList := listObj.(*v1.SecretList)
+	metrics.SetTenantObjectCount("secret", clusterName, len(secretList.Items))
 	for i, vSecret := range secretList.Items {
 		targetNamespace := conversion.ToSuperMasterNamespace(clusterName, vSecret.Namespace)
 
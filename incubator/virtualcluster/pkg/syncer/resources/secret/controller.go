@@ -18,6 +18,7 @@ package secret
 
 import (
 	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -36,6 +37,9 @@ import (
 func init() {
 	plugin.SyncerResourceRegister.Register(&plugin.Registration{
 		ID: "secret",
+		RBACRules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+		},
 		InitFn: func(ctx *plugin.InitContext) (interface{}, error) {
 			return NewSecretController(ctx.Config.(*config.SyncerConfiguration), ctx.Client, ctx.Informer, ctx.VCClient, ctx.VCInformer, manager.ResourceSyncerOptions{})
 		},
@@ -81,6 +81,7 @@ func (c *controller) PatrollerDo() {
 			continue
 		}
 		vList := listObj.(*v1.ServiceList)
+		metrics.SetTenantObjectCount("service", cluster, len(vList.Items))
 		for i := range vList.Items {
 			vSet.Insert(differ.ClusterObject{
 				Object:       &vList.Items[i],
@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/constants"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/conversion"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/util"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/reconciler"
+)
+
+func (c *controller) StartDWS(stopCh <-chan struct{}) error {
+	if !cache.WaitForCacheSync(stopCh, c.ssSynced) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+	return c.MultiClusterController.Start(stopCh)
+}
+
+// The reconcile logic for tenant master statefulset informer
+func (c *controller) Reconcile(request reconciler.Request) (reconciler.Result, error) {
+	klog.V(4).Infof("reconcile statefulset %s/%s event for cluster %s", request.Namespace, request.Name, request.ClusterName)
+
+	targetNamespace := conversion.ToSuperMasterNamespace(request.ClusterName, request.Namespace)
+	pSS, err := c.ssLister.StatefulSets(targetNamespace).Get(request.Name)
+	pExists := true
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return reconciler.Result{Requeue: true}, err
+		}
+		pExists = false
+	}
+	vExists := true
+	vSSObj, err := c.MultiClusterController.Get(request.ClusterName, request.Namespace, request.Name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return reconciler.Result{Requeue: true}, err
+		}
+		vExists = false
+	}
+
+	if vExists && !pExists {
+		vSS := vSSObj.(*appsv1.StatefulSet)
+		err := c.reconcileStatefulSetCreate(request.ClusterName, targetNamespace, request.UID, vSS)
+		if err != nil {
+			klog.Errorf("failed reconcile statefulset %s/%s CREATE of cluster %s %v", request.Namespace, request.Name, request.ClusterName, err)
+			return reconciler.Result{Requeue: true}, err
+		}
+	} else if !vExists && pExists {
+		err := c.reconcileStatefulSetRemove(request.ClusterName, targetNamespace, request.UID, request.Name, pSS)
+		if err != nil {
+			klog.Errorf("failed reconcile statefulset %s/%s DELETE of cluster %s %v", request.Namespace, request.Name, request.ClusterName, err)
+			return reconciler.Result{Requeue: true}, err
+		}
+	} else if vExists && pExists {
+		vSS := vSSObj.(*appsv1.StatefulSet)
+		err := c.reconcileStatefulSetUpdate(request.ClusterName, targetNamespace, request.UID, pSS, vSS)
+		if err != nil {
+			klog.Errorf("failed reconcile statefulset %s/%s UPDATE of cluster %s %v", request.Namespace, request.Name, request.ClusterName, err)
+			return reconciler.Result{Requeue: true}, err
+		}
+	} else {
+		// object is gone.
+	}
+	return reconciler.Result{}, nil
+}
+
+func (c *controller) reconcileStatefulSetCreate(clusterName, targetNamespace, requestUID string, ss *appsv1.StatefulSet) error {
+	vcName, vcNS, _, err := c.MultiClusterController.GetOwnerInfo(clusterName)
+	if err != nil {
+		return err
+	}
+	newObj, err := conversion.BuildMetadata(clusterName, vcNS, vcName, targetNamespace, ss)
+	if err != nil {
+		return err
+	}
+
+	pSS := newObj.(*appsv1.StatefulSet)
+
+	pSS, err = c.client.StatefulSets(targetNamespace).Create(context.TODO(), pSS, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		if pSS.Annotations[constants.LabelUID] == requestUID {
+			klog.Infof("statefulset %s/%s of cluster %s already exist in super master", targetNamespace, pSS.Name, clusterName)
+			return nil
+		}
+		return fmt.Errorf("pStatefulSet %s/%s exists but its delegated object UID is different", targetNamespace, pSS.Name)
+	}
+	return err
+}
+
+func (c *controller) reconcileStatefulSetUpdate(clusterName, targetNamespace, requestUID string, pSS, vSS *appsv1.StatefulSet) error {
+	if pSS.Annotations[constants.LabelUID] != requestUID {
+		return fmt.Errorf("pStatefulSet %s/%s delegated UID is different from updated object", targetNamespace, pSS.Name)
+	}
+	vc, err := util.GetVirtualClusterObject(c.MultiClusterController, clusterName)
+	if err != nil {
+		return err
+	}
+	updatedSS := conversion.Equality(c.Config, vc).CheckStatefulSetEquality(pSS, vSS)
+	if updatedSS != nil {
+		_, err = c.client.StatefulSets(targetNamespace).Update(context.TODO(), updatedSS, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *controller) reconcileStatefulSetRemove(clusterName, targetNamespace, requestUID, name string, pSS *appsv1.StatefulSet) error {
+	if pSS.Annotations[constants.LabelUID] != requestUID {
+		return fmt.Errorf("To be deleted pStatefulSet %s/%s delegated UID is different from deleted object", targetNamespace, pSS.Name)
+	}
+	opts := &metav1.DeleteOptions{
+		PropagationPolicy: &constants.DefaultDeletionPolicy,
+	}
+	err := c.client.StatefulSets(targetNamespace).Delete(context.TODO(), name, *opts)
+	if errors.IsNotFound(err) {
+		klog.Warningf("statefulset %s/%s of cluster %s not found in super master", targetNamespace, name, clusterName)
+		return nil
+	}
+	return err
+}
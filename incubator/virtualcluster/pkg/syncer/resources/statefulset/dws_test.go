@@ -0,0 +1,337 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	core "k8s.io/client-go/testing"
+	"k8s.io/utils/pointer"
+	util "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/util/test"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/apis/tenancy/v1alpha1"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/constants"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/conversion"
+)
+
+func tenantStatefulSet(name, namespace, uid string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       types.UID(uid),
+		},
+	}
+}
+
+func superStatefulSet(name, namespace, uid, clusterKey string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				constants.LabelUID:       uid,
+				constants.LabelCluster:   clusterKey,
+				constants.LabelNamespace: "default",
+			},
+		},
+	}
+}
+
+func unknownStatefulSet(name, namespace string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
+
+func applySpecToStatefulSet(ss *appsv1.StatefulSet, spec *appsv1.StatefulSetSpec) *appsv1.StatefulSet {
+	ss.Spec = *spec.DeepCopy()
+	return ss
+}
+
+func TestDWStatefulSetCreation(t *testing.T) {
+	testTenant := &v1alpha1.VirtualCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "tenant-1",
+			UID:       "7374a172-c35d-45b1-9c8e-bf5c5b614937",
+		},
+		Spec: v1alpha1.VirtualClusterSpec{},
+		Status: v1alpha1.VirtualClusterStatus{
+			Phase: v1alpha1.ClusterRunning,
+		},
+	}
+
+	defaultClusterKey := conversion.ToClusterKey(testTenant)
+	superDefaultNSName := conversion.ToSuperMasterNamespace(defaultClusterKey, "default")
+
+	testcases := map[string]struct {
+		ExistingObjectInSuper       []runtime.Object
+		ExistingObjectInTenant      []runtime.Object
+		ExpectedCreatedStatefulSets []string
+		ExpectedError               string
+	}{
+		"new statefulset": {
+			ExistingObjectInSuper: []runtime.Object{},
+			ExistingObjectInTenant: []runtime.Object{
+				tenantStatefulSet("sts-1", "default", "12345"),
+			},
+			ExpectedCreatedStatefulSets: []string{superDefaultNSName + "/sts-1"},
+		},
+		"new statefulset but already exists": {
+			ExistingObjectInSuper: []runtime.Object{
+				superStatefulSet("sts-1", superDefaultNSName, "12345", defaultClusterKey),
+			},
+			ExistingObjectInTenant: []runtime.Object{
+				tenantStatefulSet("sts-1", "default", "12345"),
+			},
+			ExpectedCreatedStatefulSets: []string{},
+		},
+		"new statefulset but existing different uid one": {
+			ExistingObjectInSuper: []runtime.Object{
+				superStatefulSet("sts-1", superDefaultNSName, "123456", defaultClusterKey),
+			},
+			ExistingObjectInTenant: []runtime.Object{
+				tenantStatefulSet("sts-1", "default", "12345"),
+			},
+			ExpectedCreatedStatefulSets: []string{},
+			ExpectedError:               "delegated UID is different",
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			actions, reconcileErr, err := util.RunDownwardSync(NewStatefulSetController, testTenant, tc.ExistingObjectInSuper, tc.ExistingObjectInTenant, tc.ExistingObjectInTenant[0], nil)
+			if err != nil {
+				t.Errorf("%s: error running downward sync: %v", k, err)
+				return
+			}
+
+			if reconcileErr != nil {
+				if tc.ExpectedError == "" {
+					t.Errorf("expected no error, but got \"%v\"", reconcileErr)
+				} else if !strings.Contains(reconcileErr.Error(), tc.ExpectedError) {
+					t.Errorf("expected error msg \"%s\", but got \"%v\"", tc.ExpectedError, reconcileErr)
+				}
+			} else if tc.ExpectedError != "" {
+				t.Errorf("expected error msg \"%s\", but got empty", tc.ExpectedError)
+			}
+
+			if len(tc.ExpectedCreatedStatefulSets) != len(actions) {
+				t.Errorf("%s: Expected to create statefulset %#v. Actual actions were: %#v", k, tc.ExpectedCreatedStatefulSets, actions)
+				return
+			}
+			for i, expectedName := range tc.ExpectedCreatedStatefulSets {
+				action := actions[i]
+				if !action.Matches("create", "statefulsets") {
+					t.Errorf("%s: Unexpected action %s", k, action)
+				}
+				created := action.(core.CreateAction).GetObject().(*appsv1.StatefulSet)
+				fullName := created.Namespace + "/" + created.Name
+				if fullName != expectedName {
+					t.Errorf("%s: Expected %s to be created, got %s", k, expectedName, fullName)
+				}
+			}
+		})
+	}
+}
+
+func TestDWStatefulSetDeletion(t *testing.T) {
+	testTenant := &v1alpha1.VirtualCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "tenant-1",
+			UID:       "7374a172-c35d-45b1-9c8e-bf5c5b614937",
+		},
+		Spec: v1alpha1.VirtualClusterSpec{},
+		Status: v1alpha1.VirtualClusterStatus{
+			Phase: v1alpha1.ClusterRunning,
+		},
+	}
+
+	defaultClusterKey := conversion.ToClusterKey(testTenant)
+	superDefaultNSName := conversion.ToSuperMasterNamespace(defaultClusterKey, "default")
+
+	testcases := map[string]struct {
+		ExistingObjectInSuper       []runtime.Object
+		EnqueueObject               *appsv1.StatefulSet
+		ExpectedDeletedStatefulSets []string
+		ExpectedError               string
+	}{
+		"delete statefulset": {
+			ExistingObjectInSuper: []runtime.Object{
+				superStatefulSet("sts-1", superDefaultNSName, "12345", defaultClusterKey),
+			},
+			EnqueueObject:               tenantStatefulSet("sts-1", "default", "12345"),
+			ExpectedDeletedStatefulSets: []string{superDefaultNSName + "/sts-1"},
+		},
+		"delete statefulset but already gone": {
+			ExistingObjectInSuper:       []runtime.Object{},
+			EnqueueObject:               tenantStatefulSet("sts-1", "default", "12345"),
+			ExpectedDeletedStatefulSets: []string{},
+		},
+		"delete statefulset but existing different uid one": {
+			ExistingObjectInSuper: []runtime.Object{
+				superStatefulSet("sts-1", superDefaultNSName, "123456", defaultClusterKey),
+			},
+			EnqueueObject:               tenantStatefulSet("sts-1", "default", "12345"),
+			ExpectedDeletedStatefulSets: []string{},
+			ExpectedError:               "delegated UID is different",
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			actions, reconcileErr, err := util.RunDownwardSync(NewStatefulSetController, testTenant, tc.ExistingObjectInSuper, nil, tc.EnqueueObject, nil)
+			if err != nil {
+				t.Errorf("%s: error running downward sync: %v", k, err)
+				return
+			}
+
+			if reconcileErr != nil {
+				if tc.ExpectedError == "" {
+					t.Errorf("expected no error, but got \"%v\"", reconcileErr)
+				} else if !strings.Contains(reconcileErr.Error(), tc.ExpectedError) {
+					t.Errorf("expected error msg \"%s\", but got \"%v\"", tc.ExpectedError, reconcileErr)
+				}
+			} else if tc.ExpectedError != "" {
+				t.Errorf("expected error msg \"%s\", but got empty", tc.ExpectedError)
+			}
+
+			if len(tc.ExpectedDeletedStatefulSets) != len(actions) {
+				t.Errorf("%s: Expected to delete statefulset %#v. Actual actions were: %#v", k, tc.ExpectedDeletedStatefulSets, actions)
+				return
+			}
+			for i, expectedName := range tc.ExpectedDeletedStatefulSets {
+				action := actions[i]
+				if !action.Matches("delete", "statefulsets") {
+					t.Errorf("%s: Unexpected action %s", k, action)
+				}
+				fullName := action.(core.DeleteAction).GetNamespace() + "/" + action.(core.DeleteAction).GetName()
+				if fullName != expectedName {
+					t.Errorf("%s: Expected %s to be deleted, got %s", k, expectedName, fullName)
+				}
+			}
+		})
+	}
+}
+
+func TestDWStatefulSetUpdate(t *testing.T) {
+	testTenant := &v1alpha1.VirtualCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "tenant-1",
+			UID:       "7374a172-c35d-45b1-9c8e-bf5c5b614937",
+		},
+		Spec: v1alpha1.VirtualClusterSpec{},
+		Status: v1alpha1.VirtualClusterStatus{
+			Phase: v1alpha1.ClusterRunning,
+		},
+	}
+
+	defaultClusterKey := conversion.ToClusterKey(testTenant)
+	superDefaultNSName := conversion.ToSuperMasterNamespace(defaultClusterKey, "default")
+
+	spec1 := &appsv1.StatefulSetSpec{
+		Replicas: pointer.Int32Ptr(2),
+	}
+	spec2 := &appsv1.StatefulSetSpec{
+		Replicas: pointer.Int32Ptr(3),
+	}
+
+	testcases := map[string]struct {
+		ExistingObjectInSuper       []runtime.Object
+		ExistingObjectInTenant      []runtime.Object
+		ExpectedUpdatedStatefulSets []runtime.Object
+		ExpectedError               string
+	}{
+		"no diff": {
+			ExistingObjectInSuper: []runtime.Object{
+				applySpecToStatefulSet(superStatefulSet("sts-1", superDefaultNSName, "12345", defaultClusterKey), spec1),
+			},
+			ExistingObjectInTenant: []runtime.Object{
+				applySpecToStatefulSet(tenantStatefulSet("sts-1", "default", "12345"), spec1),
+			},
+			ExpectedUpdatedStatefulSets: []runtime.Object{},
+		},
+		"diff in replicas": {
+			ExistingObjectInSuper: []runtime.Object{
+				applySpecToStatefulSet(superStatefulSet("sts-1", superDefaultNSName, "12345", defaultClusterKey), spec1),
+			},
+			ExistingObjectInTenant: []runtime.Object{
+				applySpecToStatefulSet(tenantStatefulSet("sts-1", "default", "12345"), spec2),
+			},
+			ExpectedUpdatedStatefulSets: []runtime.Object{
+				applySpecToStatefulSet(superStatefulSet("sts-1", superDefaultNSName, "12345", defaultClusterKey), spec2),
+			},
+		},
+		"diff exists but uid is wrong": {
+			ExistingObjectInSuper: []runtime.Object{
+				applySpecToStatefulSet(superStatefulSet("sts-1", superDefaultNSName, "12345", defaultClusterKey), spec1),
+			},
+			ExistingObjectInTenant: []runtime.Object{
+				applySpecToStatefulSet(tenantStatefulSet("sts-1", "default", "123456"), spec2),
+			},
+			ExpectedUpdatedStatefulSets: []runtime.Object{},
+			ExpectedError:               "delegated UID is different",
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			actions, reconcileErr, err := util.RunDownwardSync(NewStatefulSetController, testTenant, tc.ExistingObjectInSuper, tc.ExistingObjectInTenant, tc.ExistingObjectInTenant[0], nil)
+			if err != nil {
+				t.Errorf("%s: error running downward sync: %v", k, err)
+				return
+			}
+
+			if reconcileErr != nil {
+				if tc.ExpectedError == "" {
+					t.Errorf("expected no error, but got \"%v\"", reconcileErr)
+				} else if !strings.Contains(reconcileErr.Error(), tc.ExpectedError) {
+					t.Errorf("expected error msg \"%s\", but got \"%v\"", tc.ExpectedError, reconcileErr)
+				}
+			} else if tc.ExpectedError != "" {
+				t.Errorf("expected error msg \"%s\", but got empty", tc.ExpectedError)
+			}
+
+			if len(tc.ExpectedUpdatedStatefulSets) != len(actions) {
+				t.Errorf("%s: Expected to update statefulset %#v. Actual actions were: %#v", k, tc.ExpectedUpdatedStatefulSets, actions)
+				return
+			}
+			for i, obj := range tc.ExpectedUpdatedStatefulSets {
+				action := actions[i]
+				if !action.Matches("update", "statefulsets") {
+					t.Errorf("%s: Unexpected action %s", k, action)
+				}
+				actionObj := action.(core.UpdateAction).GetObject()
+				if !equality.Semantic.DeepEqual(obj, actionObj) {
+					t.Errorf("%s: Expected updated statefulset is %v, got %v", k, obj, actionObj)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"context"
+	"fmt"
+
+	pkgerr "github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/constants"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/conversion"
+)
+
+// StartUWS starts the upward syncer
+// and blocks until an empty struct is sent to the stop channel.
+func (c *controller) StartUWS(stopCh <-chan struct{}) error {
+	if !cache.WaitForCacheSync(stopCh, c.ssSynced) {
+		return fmt.Errorf("failed to wait for caches to sync statefulset")
+	}
+	return c.UpwardController.Start(stopCh)
+}
+
+// BackPopulate back-populates a super master statefulset's Status - which reflects the readiness
+// of the PVCs its pods are bound to, in addition to the pods themselves - into the corresponding
+// tenant statefulset.
+func (c *controller) BackPopulate(key string) error {
+	pNamespace, pName, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	pSS, err := c.ssLister.StatefulSets(pNamespace).Get(pName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	clusterName, vNamespace := conversion.GetVirtualOwner(pSS)
+	if clusterName == "" || vNamespace == "" {
+		return nil
+	}
+
+	vSSObj, err := c.MultiClusterController.Get(clusterName, vNamespace, pName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return pkgerr.Wrapf(err, "could not find pStatefulSet %s/%s's vStatefulSet in controller cache", vNamespace, pName)
+	}
+	vSS := vSSObj.(*appsv1.StatefulSet)
+	if pSS.Annotations[constants.LabelUID] != string(vSS.UID) {
+		return fmt.Errorf("BackPopulated pStatefulSet %s/%s delegated UID is different from updated object", pSS.Namespace, pSS.Name)
+	}
+
+	updatedStatus := conversion.Equality(c.Config, nil).CheckUWStatefulSetStatusEquality(pSS, vSS)
+	if updatedStatus == nil {
+		return nil
+	}
+
+	tenantClient, err := c.MultiClusterController.GetClusterClient(clusterName)
+	if err != nil {
+		return pkgerr.Wrapf(err, "failed to create client from cluster %s config", clusterName)
+	}
+
+	newSS := vSS.DeepCopy()
+	newSS.Status = *updatedStatus
+	_, err = tenantClient.AppsV1().StatefulSets(vNamespace).UpdateStatus(context.TODO(), newSS, metav1.UpdateOptions{})
+	return err
+}
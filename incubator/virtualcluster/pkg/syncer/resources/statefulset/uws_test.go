@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	core "k8s.io/client-go/testing"
+	util "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/util/test"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/apis/tenancy/v1alpha1"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/conversion"
+)
+
+func applyStatusToStatefulSet(ss *appsv1.StatefulSet, status *appsv1.StatefulSetStatus) *appsv1.StatefulSet {
+	ss.Status = *status.DeepCopy()
+	return ss
+}
+
+// expectedBackPopulatedStatefulSet mirrors the TypeMeta the controller-runtime fake client stamps
+// onto objects returned by Get, since the expected object here is derived from what BackPopulate
+// reads out of the tenant cluster's cache rather than from a literal the test constructs by hand.
+func expectedBackPopulatedStatefulSet(ss *appsv1.StatefulSet) *appsv1.StatefulSet {
+	ss.TypeMeta = metav1.TypeMeta{Kind: "StatefulSet", APIVersion: "apps/v1"}
+	return ss
+}
+
+func TestUWStatefulSetStatusUpdate(t *testing.T) {
+	testTenant := &v1alpha1.VirtualCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "tenant-1",
+			UID:       "7374a172-c35d-45b1-9c8e-bf5c5b614937",
+		},
+		Status: v1alpha1.VirtualClusterStatus{
+			Phase: v1alpha1.ClusterRunning,
+		},
+	}
+
+	defaultClusterKey := conversion.ToClusterKey(testTenant)
+	superDefaultNSName := conversion.ToSuperMasterNamespace(defaultClusterKey, "default")
+
+	notReadyStatus := &appsv1.StatefulSetStatus{
+		Replicas:      2,
+		ReadyReplicas: 0,
+	}
+	readyStatus := &appsv1.StatefulSetStatus{
+		Replicas:      2,
+		ReadyReplicas: 2,
+	}
+
+	testcases := map[string]struct {
+		ExistingObjectInSuper       []runtime.Object
+		ExistingObjectInTenant      []runtime.Object
+		EnqueuedKey                 string
+		ExpectedUpdatedStatefulSets []runtime.Object
+		ExpectedError               string
+	}{
+		"pStatefulSet not found": {
+			ExistingObjectInTenant: []runtime.Object{
+				tenantStatefulSet("sts-1", "default", "12345"),
+			},
+			EnqueuedKey: superDefaultNSName + "/sts-1",
+		},
+		"pStatefulSet exists, vStatefulSet does not exist": {
+			ExistingObjectInSuper: []runtime.Object{
+				applyStatusToStatefulSet(superStatefulSet("sts-1", superDefaultNSName, "12345", defaultClusterKey), readyStatus),
+			},
+			EnqueuedKey: superDefaultNSName + "/sts-1",
+		},
+		"pStatefulSet exists, vStatefulSet exists with different uid": {
+			ExistingObjectInSuper: []runtime.Object{
+				applyStatusToStatefulSet(superStatefulSet("sts-1", superDefaultNSName, "12345", defaultClusterKey), readyStatus),
+			},
+			ExistingObjectInTenant: []runtime.Object{
+				applyStatusToStatefulSet(tenantStatefulSet("sts-1", "default", "123456"), notReadyStatus),
+			},
+			EnqueuedKey:   superDefaultNSName + "/sts-1",
+			ExpectedError: "delegated UID is different",
+		},
+		"no status diff": {
+			ExistingObjectInSuper: []runtime.Object{
+				applyStatusToStatefulSet(superStatefulSet("sts-1", superDefaultNSName, "12345", defaultClusterKey), notReadyStatus),
+			},
+			ExistingObjectInTenant: []runtime.Object{
+				applyStatusToStatefulSet(tenantStatefulSet("sts-1", "default", "12345"), notReadyStatus),
+			},
+			EnqueuedKey: superDefaultNSName + "/sts-1",
+		},
+		"status diff, statefulset ready": {
+			ExistingObjectInSuper: []runtime.Object{
+				applyStatusToStatefulSet(superStatefulSet("sts-1", superDefaultNSName, "12345", defaultClusterKey), readyStatus),
+			},
+			ExistingObjectInTenant: []runtime.Object{
+				applyStatusToStatefulSet(tenantStatefulSet("sts-1", "default", "12345"), notReadyStatus),
+			},
+			EnqueuedKey: superDefaultNSName + "/sts-1",
+			ExpectedUpdatedStatefulSets: []runtime.Object{
+				expectedBackPopulatedStatefulSet(applyStatusToStatefulSet(tenantStatefulSet("sts-1", "default", "12345"), readyStatus)),
+			},
+		},
+	}
+
+	for k, tc := range testcases {
+		t.Run(k, func(t *testing.T) {
+			actions, reconcileErr, err := util.RunUpwardSync(NewStatefulSetController, testTenant, tc.ExistingObjectInSuper, tc.ExistingObjectInTenant, tc.EnqueuedKey, nil)
+			if err != nil {
+				t.Errorf("%s: error running upward sync: %v", k, err)
+				return
+			}
+
+			if reconcileErr != nil {
+				if tc.ExpectedError == "" {
+					t.Errorf("expected no error, but got \"%v\"", reconcileErr)
+				} else if !strings.Contains(reconcileErr.Error(), tc.ExpectedError) {
+					t.Errorf("expected error msg \"%s\", but got \"%v\"", tc.ExpectedError, reconcileErr)
+				}
+			} else if tc.ExpectedError != "" {
+				t.Errorf("expected error msg \"%s\", but got empty", tc.ExpectedError)
+			}
+
+			if len(tc.ExpectedUpdatedStatefulSets) != len(actions) {
+				t.Errorf("%s: Expected to update statefulset %#v. Actual actions were: %#v", k, tc.ExpectedUpdatedStatefulSets, actions)
+				return
+			}
+			for i, obj := range tc.ExpectedUpdatedStatefulSets {
+				action := actions[i]
+				if !action.Matches("update", "statefulsets") {
+					t.Errorf("%s: Unexpected action %s", k, action)
+				}
+				actionObj := action.(core.UpdateAction).GetObject()
+				if !equality.Semantic.DeepEqual(obj, actionObj) {
+					t.Errorf("%s: Expected updated statefulset is %v, got %v", k, obj, actionObj)
+				}
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -37,6 +38,7 @@ import (
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog"
 
@@ -79,6 +81,17 @@ type Syncer struct {
 	// clusterSet holds the cluster collection in which cluster is running.
 	mu         sync.Mutex
 	clusterSet map[string]mc.ClusterInterface
+
+	// coldStartLimiter staggers how often a tenant cluster's informers are actually started
+	// (cache.Start, which triggers a List+Watch against the tenant apiserver), so a syncer
+	// restart with many VirtualClusters already Running doesn't relist all of them at once. It's
+	// nil, meaning unthrottled, if ColdStartQPS <= 0.
+	coldStartLimiter flowcontrol.RateLimiter
+
+	// coldStartRemaining tracks how many of the VirtualClusters seen at startup still haven't
+	// finished their initial cache sync, purely for the ColdStartRemaining metric; it's read and
+	// written with atomic ops since it's touched from multiple runCluster goroutines.
+	coldStartRemaining int64
 }
 
 type virtualclusterGetter struct {
@@ -119,6 +132,9 @@ func New(
 		workers:     constants.UwsControllerWorkerLow,
 		clusterSet:  make(map[string]mc.ClusterInterface),
 	}
+	if config.ColdStartQPS > 0 {
+		syncer.coldStartLimiter = flowcontrol.NewTokenBucketRateLimiter(config.ColdStartQPS, config.ColdStartBurst)
+	}
 
 	// Handle VirtualCluster add&delete
 	virtualClusterInformer.Informer().AddEventHandler(
@@ -143,6 +159,11 @@ func New(
 	syncer.controllerManager = multiClusterControllerManager
 
 	plugins := LoadPlugins(config)
+
+	if err := EnsureMinimalClusterRole(superClusterClient, config); err != nil {
+		klog.Errorf("failed to narrow %q to the enabled resource syncers' permissions: %v", SyncerClusterRoleName, err)
+	}
+
 	initContext := &plugin.InitContext{
 		Context:    context.Background(),
 		Config:     config,
@@ -243,6 +264,7 @@ func (s *Syncer) Run(stopChan <-chan struct{}) {
 		if !cache.WaitForCacheSync(stopChan, s.virtualClusterSynced) {
 			return
 		}
+		s.recordColdStartTotal()
 
 		klog.V(5).Infof("starting workers")
 		for i := 0; i < s.workers; i++ {
@@ -311,7 +333,9 @@ func (s *Syncer) syncVirtualCluster(key string) error {
 	switch vc.Status.Phase {
 	case v1alpha1.ClusterRunning:
 		return s.addCluster(key, vc)
-	case v1alpha1.ClusterError:
+	case v1alpha1.ClusterError, v1alpha1.ClusterPaused:
+		// A paused cluster's control plane has been scaled to zero, so there's nothing left to
+		// reconcile against until it's resumed and becomes Running again.
 		s.removeCluster(key)
 		return nil
 	default:
@@ -377,7 +401,48 @@ func (s *Syncer) addCluster(key string, vc *v1alpha1.VirtualCluster) error {
 	return nil
 }
 
+// recordColdStartTotal counts the VirtualClusters that were already Running when the syncer
+// process (re)started, and reports it as the initial value of the ColdStartRemaining metric. It's
+// only meaningful once, right after the VirtualCluster informer's own initial cache sync
+// completes; VirtualClusters that start Running afterwards are ordinary steady-state additions,
+// not part of a cold start, so they aren't counted here.
+func (s *Syncer) recordColdStartTotal() {
+	vcs, err := s.lister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("failed to list virtual clusters for cold start accounting: %v", err)
+		return
+	}
+	var total int64
+	for _, vc := range vcs {
+		if vc.Status.Phase == v1alpha1.ClusterRunning {
+			total++
+		}
+	}
+	atomic.StoreInt64(&s.coldStartRemaining, total)
+	metrics.SetColdStartRemaining(int(total))
+	klog.Infof("%d virtual cluster(s) already running at startup; staggering their initial sync", total)
+}
+
+// recordColdStartProgress reports that one more virtual cluster has finished its initial cache
+// sync, if it was still counted as part of the cold start.
+func (s *Syncer) recordColdStartProgress() {
+	for {
+		remaining := atomic.LoadInt64(&s.coldStartRemaining)
+		if remaining <= 0 {
+			return
+		}
+		if atomic.CompareAndSwapInt64(&s.coldStartRemaining, remaining, remaining-1) {
+			metrics.RecordColdStartCompleted()
+			return
+		}
+	}
+}
+
 func (s *Syncer) runCluster(cluster *cluster.Cluster, vc *v1alpha1.VirtualCluster) {
+	if s.coldStartLimiter != nil {
+		s.coldStartLimiter.Accept()
+	}
+
 	go func() {
 		err := cluster.Start()
 		klog.Infof("cluster %s shutdown: %v", cluster.GetClusterName(), err)
@@ -398,6 +463,7 @@ func (s *Syncer) runCluster(cluster *cluster.Cluster, vc *v1alpha1.VirtualCluste
 		return
 	}
 	cluster.SetSynced()
+	s.recordColdStartProgress()
 	klog.Infof("cluster %s cache sync done", cluster.GetClusterName())
 
 	// start watching cluster resource event after cache sync done.
@@ -17,8 +17,12 @@ limitations under the License.
 package util
 
 import (
+	"encoding/json"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
 	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/apis/tenancy/v1alpha1"
 	mc "sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/util/mccontroller"
 )
@@ -36,3 +40,28 @@ func GetVirtualClusterObject(mc *mc.MultiClusterController, clustername string)
 
 	return vc, nil
 }
+
+// CreateMergePatch computes the strategic merge patch that turns original into updated, so
+// callers can Patch a downstream object instead of sending a full Update - this avoids clobbering
+// fields the apiserver or another controller wrote concurrently, and cuts write amplification on
+// large tenants where most reconciles find nothing changed. dataStruct is the zero value of the
+// object's type (e.g. v1.ConfigMap{}); it's consulted for patchStrategy/patchMergeKey struct tags.
+// It returns a nil patch, rather than an error, if original and updated are identical.
+func CreateMergePatch(original, updated runtime.Object, dataStruct interface{}) ([]byte, error) {
+	originalData, err := json.Marshal(original)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal original object: %v", err)
+	}
+	updatedData, err := json.Marshal(updated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal updated object: %v", err)
+	}
+	patch, err := strategicpatch.CreateTwoWayMergePatch(originalData, updatedData, dataStruct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create merge patch: %v", err)
+	}
+	if string(patch) == "{}" {
+		return nil, nil
+	}
+	return patch, nil
+}
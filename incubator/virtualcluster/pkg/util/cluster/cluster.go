@@ -28,7 +28,6 @@ import (
 	"k8s.io/client-go/rest"
 	restclient "k8s.io/client-go/rest"
 	clientgocache "k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -88,6 +87,13 @@ type Options struct {
 	// RequestTimeout is the rest client request timeout.
 	// Set this to something reasonable so request to apiserver don't hang forever.
 	RequestTimeout time.Duration
+	// RestConfigFunc builds the rest.Config used to talk to the tenant apiserver from the raw
+	// secret payload passed to NewCluster. If unset, it defaults to BuildRestConfigFromKubeConfig,
+	// which treats the payload as a serialized kubeconfig - see that function's doc comment for how
+	// exec credential plugins and rotated bound service account tokens both fit within that default
+	// without needing a custom RestConfigFunc. This field remains the extension point for anything
+	// that doesn't fit, e.g. a payload format other than a kubeconfig entirely.
+	RestConfigFunc func(configBytes []byte) (*rest.Config, error)
 }
 
 // CacheOptions is embedded in Options to configure the new Cluster's cache.
@@ -104,7 +110,11 @@ type CacheOptions struct {
 var _ mccontroller.ClusterInterface = &Cluster{}
 
 func NewCluster(key, namespace, name, uid string, getter mccontroller.Getter, configBytes []byte, o Options) (*Cluster, error) {
-	clusterRestConfig, err := clientcmd.RESTConfigFromKubeConfig(configBytes)
+	restConfigFunc := o.RestConfigFunc
+	if restConfigFunc == nil {
+		restConfigFunc = BuildRestConfigFromKubeConfig
+	}
+	clusterRestConfig, err := restConfigFunc(configBytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build rest config: %v", err)
 	}
@@ -0,0 +1,38 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// BuildRestConfigFromKubeConfig builds a rest.Config by parsing configBytes as a serialized
+// kubeconfig. This is the default Options.RestConfigFunc, matching how tenant clusters have
+// always authenticated: a static admin kubeconfig pulled from a secret.
+//
+// Neither exec credential plugins nor short-lived, rotated tokens need a different
+// Options.RestConfigFunc: clientcmd already handles both once they're expressed in the
+// kubeconfig itself. A user stanza with an "exec" provider makes clientcmd shell out to the
+// plugin itself, the same way kubectl does. A user stanza with "tokenFile" instead of a static
+// "token" makes clientcmd set rest.Config.BearerTokenFile, which client-go's transport re-reads
+// on every request older than a minute - so a Cluster built from a kubeconfig pointing at a
+// projected, kubelet-rotated bound service account token file picks up each rotation for free,
+// without ever needing to be recreated.
+func BuildRestConfigFromKubeConfig(configBytes []byte) (*rest.Config, error) {
+	return clientcmd.RESTConfigFromKubeConfig(configBytes)
+}
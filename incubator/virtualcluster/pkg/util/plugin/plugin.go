@@ -20,6 +20,7 @@ import (
 	"sync"
 
 	pkgerr "github.com/pkg/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 var (
@@ -37,6 +38,12 @@ type Registration struct {
 	InitFn func(*InitContext) (interface{}, error)
 	// Disable the plugin from loading
 	Disable bool
+
+	// RBACRules lists the super cluster permissions this resource syncer needs in order to run. It's
+	// the source of truth for the syncer's minimal ClusterRole: only the rules belonging to
+	// registrations that are actually loaded (see ResourceRegister.List and the Disable field) end up
+	// in the generated role, so an operator who never enables e.g. "ingress" never grants its rules.
+	RBACRules []rbacv1.PolicyRule
 }
 
 // Init the registered plugin
@@ -0,0 +1,54 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tenanttier holds the small amount of logic shared by every "platform push" syncer
+// resource (platformconfigmap, platformsecret, ...) for deciding whether a given VirtualCluster's
+// tenancy tier should receive a given super master object.
+package tenanttier
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/constants"
+)
+
+// Of returns the tenancy tier of a VirtualCluster, as set by constants.LabelTenantTier. It
+// returns "" if vc is nil, isn't a metav1.Object, or doesn't carry the label - all of which are
+// treated as "untiered" by Matches.
+func Of(vc metav1.Object) string {
+	if vc == nil {
+		return ""
+	}
+	return vc.GetLabels()[constants.LabelTenantTier]
+}
+
+// Matches reports whether an object annotated with targetTiers (the raw value of
+// constants.AnnotationTargetTiers, a comma-separated tier list) should be pushed to a
+// VirtualCluster of the given tier. An empty targetTiers matches every tier, tiered or not.
+func Matches(targetTiers, tier string) bool {
+	targetTiers = strings.TrimSpace(targetTiers)
+	if targetTiers == "" {
+		return true
+	}
+	for _, t := range strings.Split(targetTiers, ",") {
+		if strings.TrimSpace(t) == tier {
+			return true
+		}
+	}
+	return false
+}
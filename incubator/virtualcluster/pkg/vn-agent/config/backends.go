@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// LoadBackendConfig reads a routing table of Backends from a YAML (or JSON) file and loads each
+// backend's client certificate, if any. The file is a plain list, e.g.:
+//
+//   - name: kata
+//     runtimeClasses: ["kata"]
+//     kubeletServerHost: "https://127.0.0.1:10251"
+//     tlsOptions:
+//     certFile: /etc/vn-agent/kata-client.crt
+//     keyFile: /etc/vn-agent/kata-client.key
+func LoadBackendConfig(path string) ([]Backend, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read backend config %q", path)
+	}
+
+	var backends []Backend
+	if err := yaml.Unmarshal(raw, &backends); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse backend config %q", path)
+	}
+
+	seen := make(map[string]bool, len(backends))
+	for i := range backends {
+		b := &backends[i]
+		if b.Name == "" {
+			return nil, fmt.Errorf("backend %d in %q is missing a name", i, path)
+		}
+		if seen[b.Name] {
+			return nil, fmt.Errorf("backend %d in %q reuses name %q", i, path, b.Name)
+		}
+		seen[b.Name] = true
+		if b.KubeletServerHost == "" {
+			return nil, fmt.Errorf("backend %q in %q is missing kubeletServerHost", b.Name, path)
+		}
+		u, err := url.Parse(b.KubeletServerHost)
+		if err != nil {
+			return nil, errors.Wrapf(err, "backend %q in %q has an invalid kubeletServerHost", b.Name, path)
+		}
+		// Normalized to a bare host:port, matching how Config.KubeletServerHost is stored once
+		// NewServer parses it - the scheme is always https when proxying to a backend.
+		b.KubeletServerHost = u.Host
+		if len(b.RuntimeClasses) == 0 {
+			return nil, fmt.Errorf("backend %q in %q lists no runtimeClasses, so it can never be routed to", b.Name, path)
+		}
+		if b.TLSOptions.CertFile == "" || b.TLSOptions.KeyFile == "" {
+			continue
+		}
+		cert, err := tls.LoadX509KeyPair(b.TLSOptions.CertFile, b.TLSOptions.KeyFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load client cert for backend %q", b.Name)
+		}
+		b.kubeletClientCert = &cert
+	}
+
+	return backends, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestLoadBackendConfig(t *testing.T) {
+	f, err := ioutil.TempFile("", "backends-*.yaml")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	content := `
+- name: kata
+  runtimeClasses: ["kata"]
+  kubeletServerHost: "https://127.0.0.1:10251"
+- name: virtual-kubelet
+  runtimeClasses: ["vk", "virtual-kubelet"]
+  kubeletServerHost: "https://127.0.0.1:10252"
+  healthCheckPath: "/ready"
+`
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	f.Close()
+
+	backends, err := LoadBackendConfig(f.Name())
+	if err != nil {
+		t.Fatalf("LoadBackendConfig() returned error: %v", err)
+	}
+	if len(backends) != 2 {
+		t.Fatalf("got %d backends, want 2", len(backends))
+	}
+	if backends[0].KubeletServerHost != "127.0.0.1:10251" {
+		t.Errorf("got kubeletServerHost %q, want normalized bare host", backends[0].KubeletServerHost)
+	}
+
+	cfg := &Config{Backends: backends}
+	if b := cfg.Backend("kata"); b == nil || b.Name != "kata" {
+		t.Errorf("Backend(%q) = %v, want the kata backend", "kata", b)
+	}
+	if b := cfg.Backend("vk"); b == nil || b.Name != "virtual-kubelet" {
+		t.Errorf("Backend(%q) = %v, want the virtual-kubelet backend", "vk", b)
+	}
+	if b := cfg.Backend("runc"); b != nil {
+		t.Errorf("Backend(%q) = %v, want nil for an unmatched runtime class", "runc", b)
+	}
+	if b := cfg.Backend(""); b != nil {
+		t.Errorf("Backend(\"\") = %v, want nil", b)
+	}
+}
+
+func TestLoadBackendConfigRejectsDuplicateNames(t *testing.T) {
+	f, err := ioutil.TempFile("", "backends-*.yaml")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	content := `
+- name: kata
+  runtimeClasses: ["kata"]
+  kubeletServerHost: "https://127.0.0.1:10251"
+- name: kata
+  runtimeClasses: ["kata2"]
+  kubeletServerHost: "https://127.0.0.1:10252"
+`
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	f.Close()
+
+	if _, err := LoadBackendConfig(f.Name()); err == nil {
+		t.Fatal("LoadBackendConfig() with duplicate backend names should have failed")
+	}
+}
@@ -28,8 +28,88 @@ type TLSOptions struct {
 	KeyFile string
 }
 
+// RuntimeClassNameHeader is the request header the caller sets to tell vn-agent which
+// RuntimeClass the target pod uses, so the request can be routed to the right Backend. It's
+// left unset for pods that don't use a non-default runtime, which always fall back to
+// Config.KubeletServerHost.
+const RuntimeClassNameHeader = "Vn-Agent-Runtime-Class"
+
+// AuthnMode selects how vn-agent identifies which tenant is making a request.
+type AuthnMode string
+
+const (
+	// AuthnModeCert is the original mode: the tenant name is the CommonName of the client
+	// certificate presented in the mTLS handshake.
+	AuthnModeCert AuthnMode = "cert"
+	// AuthnModeToken authenticates the caller's bearer token against the tenant apiserver named
+	// in the TenantHeader (via TokenReview), and derives the tenant name from the token's
+	// ServiceAccount claims once validated.
+	AuthnModeToken AuthnMode = "token"
+)
+
+// Backend describes one additional kubelet-compatible endpoint vn-agent can proxy requests to,
+// and the RuntimeClass name(s) that route to it. This is how a single vn-agent instance
+// supports a node that runs more than one container runtime side by side, e.g. runc pods
+// talking to the real kubelet while kata pods talk to a separate kata-shim agent.
+type Backend struct {
+	// Name identifies the backend in logs and health-check results. Must be unique among a
+	// Config's Backends.
+	Name string `json:"name"`
+	// RuntimeClasses lists the RuntimeClassNames routed to this backend.
+	RuntimeClasses []string `json:"runtimeClasses"`
+	// KubeletServerHost is this backend's kubelet-compatible server address, in the same form
+	// as Config.KubeletServerHost.
+	KubeletServerHost string `json:"kubeletServerHost"`
+	// TLSOptions is the client certificate vn-agent presents to this backend. If empty, the
+	// backend is dialed without a client certificate.
+	TLSOptions TLSOptions `json:"tlsOptions,omitempty"`
+	// HealthCheckPath is the path polled to decide whether the backend is up. Defaults to
+	// "/healthz" if empty.
+	HealthCheckPath string `json:"healthCheckPath,omitempty"`
+
+	// kubeletClientCert is TLSOptions loaded into memory, populated by LoadBackendConfig.
+	kubeletClientCert *tls.Certificate
+}
+
+// KubeletClientCert returns the backend's loaded client certificate, or nil if it doesn't use
+// one.
+func (b *Backend) KubeletClientCert() *tls.Certificate {
+	return b.kubeletClientCert
+}
+
 // Config holds the config of the server.
 type Config struct {
 	KubeletClientCert *tls.Certificate
 	KubeletServerHost string
+
+	// Backends is the routing table used to send a request to a different kubelet-compatible
+	// endpoint based on the target pod's RuntimeClassName (see RuntimeClassNameHeader). It's
+	// optional: a Config with no Backends behaves exactly as before, always proxying to
+	// KubeletServerHost.
+	Backends []Backend
+
+	// AuthnMode selects how the tenant name is determined for an incoming request. Defaults to
+	// AuthnModeCert if empty.
+	AuthnMode AuthnMode
+
+	// TenantAPIServers is the set of tenant apiservers vn-agent may validate bearer tokens
+	// against. Only used, and required, when AuthnMode is AuthnModeToken.
+	TenantAPIServers []TenantAPIServer
+}
+
+// Backend returns the Backend whose RuntimeClasses contains runtimeClass, or nil if
+// runtimeClass is empty or doesn't match any configured backend - the caller should then fall
+// back to KubeletServerHost.
+func (c *Config) Backend(runtimeClass string) *Backend {
+	if runtimeClass == "" {
+		return nil
+	}
+	for i := range c.Backends {
+		for _, rc := range c.Backends[i].RuntimeClasses {
+			if rc == runtimeClass {
+				return &c.Backends[i]
+			}
+		}
+	}
+	return nil
 }
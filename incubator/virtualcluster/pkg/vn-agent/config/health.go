@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"crypto/tls"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+)
+
+const (
+	defaultHealthCheckPath     = "/healthz"
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultHealthCheckTimeout  = 5 * time.Second
+)
+
+// BackendHealthChecker periodically polls every configured Backend's health-check endpoint and
+// tracks whether it's currently reachable, so the server can fall back to KubeletServerHost
+// instead of proxying to a backend that's known to be down.
+type BackendHealthChecker struct {
+	backends []Backend
+	interval time.Duration
+	timeout  time.Duration
+
+	mu     sync.RWMutex
+	health map[string]bool
+}
+
+// NewBackendHealthChecker creates a checker for the given backends. Call Run to start polling.
+// A zero interval or timeout uses the package defaults.
+func NewBackendHealthChecker(backends []Backend, interval, timeout time.Duration) *BackendHealthChecker {
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+	return &BackendHealthChecker{
+		backends: backends,
+		interval: interval,
+		timeout:  timeout,
+		health:   make(map[string]bool, len(backends)),
+	}
+}
+
+// Run polls every backend once immediately, and then every interval, until stopCh is closed.
+// It's meant to be run in its own goroutine.
+func (c *BackendHealthChecker) Run(stopCh <-chan struct{}) {
+	c.pollAll()
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.pollAll()
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// IsHealthy reports the last known health of the named backend. A backend that hasn't been
+// polled yet, or isn't recognized, is treated as healthy - a health-check misconfiguration
+// should fail open rather than blackhole every request routed to that backend.
+func (c *BackendHealthChecker) IsHealthy(name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	healthy, ok := c.health[name]
+	return !ok || healthy
+}
+
+func (c *BackendHealthChecker) pollAll() {
+	for _, b := range c.backends {
+		healthy := poll(b, c.timeout)
+		c.mu.Lock()
+		c.health[b.Name] = healthy
+		c.mu.Unlock()
+		if !healthy {
+			klog.Warningf("vn-agent backend %q (%s) failed its health check", b.Name, b.KubeletServerHost)
+		}
+	}
+}
+
+func poll(b Backend, timeout time.Duration) bool {
+	path := b.HealthCheckPath
+	if path == "" {
+		path = defaultHealthCheckPath
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+	if cert := b.KubeletClientCert(); cert != nil {
+		tlsConfig.Certificates = []tls.Certificate{*cert}
+	}
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+	resp, err := client.Get("https://" + b.KubeletServerHost + path)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode < 300
+}
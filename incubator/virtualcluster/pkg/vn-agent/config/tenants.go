@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// TenantHeader is the request header a caller sets, in token authn mode, to tell vn-agent which
+// tenant apiserver issued the bearer token it's presenting, so vn-agent knows which
+// TenantAPIServer to send the TokenReview to.
+const TenantHeader = "Vn-Agent-Tenant"
+
+// TenantAPIServer describes one tenant apiserver vn-agent can validate ServiceAccount tokens
+// against in token authn mode.
+type TenantAPIServer struct {
+	// Name identifies the tenant. It's what a caller sends in the TenantHeader, and what's used
+	// as the tenant name (in place of the client certificate CN in cert authn mode) once its
+	// token has been validated.
+	Name string `json:"name"`
+	// Server is the tenant apiserver's URL, e.g. "https://10.0.0.5:6443".
+	Server string `json:"server"`
+	// CAFile is the path to the PEM-encoded CA bundle used to verify Server's certificate.
+	CAFile string `json:"caFile"`
+}
+
+// LoadTenantAPIServerConfig reads the set of tenant apiservers vn-agent may validate tokens
+// against from a YAML (or JSON) file. The file is a plain list, e.g.:
+//
+//   - name: tenant-a
+//     server: "https://10.0.0.5:6443"
+//     caFile: /etc/vn-agent/tenant-a-ca.crt
+func LoadTenantAPIServerConfig(path string) ([]TenantAPIServer, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read tenant apiserver config %q", path)
+	}
+
+	var tenants []TenantAPIServer
+	if err := yaml.Unmarshal(raw, &tenants); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse tenant apiserver config %q", path)
+	}
+
+	seen := make(map[string]bool, len(tenants))
+	for i, t := range tenants {
+		if t.Name == "" {
+			return nil, fmt.Errorf("tenant apiserver %d in %q is missing a name", i, path)
+		}
+		if seen[t.Name] {
+			return nil, fmt.Errorf("tenant apiserver %d in %q reuses name %q", i, path, t.Name)
+		}
+		seen[t.Name] = true
+		if t.Server == "" {
+			return nil, fmt.Errorf("tenant apiserver %q in %q is missing server", t.Name, path)
+		}
+	}
+
+	return tenants, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestLoadTenantAPIServerConfig(t *testing.T) {
+	f, err := ioutil.TempFile("", "tenants-*.yaml")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	content := `
+- name: tenant-a
+  server: "https://10.0.0.5:6443"
+  caFile: /etc/vn-agent/tenant-a-ca.crt
+- name: tenant-b
+  server: "https://10.0.0.6:6443"
+`
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	f.Close()
+
+	tenants, err := LoadTenantAPIServerConfig(f.Name())
+	if err != nil {
+		t.Fatalf("LoadTenantAPIServerConfig() returned error: %v", err)
+	}
+	if len(tenants) != 2 {
+		t.Fatalf("got %d tenants, want 2", len(tenants))
+	}
+	if tenants[0].Name != "tenant-a" || tenants[0].CAFile != "/etc/vn-agent/tenant-a-ca.crt" {
+		t.Errorf("got %+v, want tenant-a with its CAFile set", tenants[0])
+	}
+}
+
+func TestLoadTenantAPIServerConfigRejectsInvalidEntries(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+	}{
+		{"missing name", `- server: "https://10.0.0.5:6443"`},
+		{"missing server", `- name: tenant-a`},
+		{"duplicate name", `
+- name: tenant-a
+  server: "https://10.0.0.5:6443"
+- name: tenant-a
+  server: "https://10.0.0.6:6443"
+`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f, err := ioutil.TempFile("", "tenants-*.yaml")
+			if err != nil {
+				t.Fatalf("failed to create temp file: %v", err)
+			}
+			defer os.Remove(f.Name())
+			if _, err := f.WriteString(c.content); err != nil {
+				t.Fatalf("failed to write temp file: %v", err)
+			}
+			f.Close()
+
+			if _, err := LoadTenantAPIServerConfig(f.Name()); err == nil {
+				t.Fatalf("LoadTenantAPIServerConfig() with %s should have failed", c.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	Subsystem            = "vnagent"
+	RequestsKey          = "requests_total"
+	RequestDurationKey   = "request_duration_seconds"
+	StreamConnectionsKey = "stream_connections"
+)
+
+var (
+	// RequestsTotal counts every proxied request, labeled by the tenant that made it (derived from
+	// the client cert CN), the operation it hit (e.g. "getExec", "getLogs"), and the response code,
+	// so a noisy or failing tenant shows up without having to correlate raw kubelet/apiserver logs.
+	RequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: Subsystem,
+			Name:      RequestsKey,
+			Help:      "Cumulative number of requests proxied by vn-agent, by tenant, operation and response code.",
+		},
+		[]string{"tenant", "operation", "code"},
+	)
+	RequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: Subsystem,
+			Name:      RequestDurationKey,
+			Help:      "Duration in seconds of requests proxied by vn-agent, by tenant and operation.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"tenant", "operation"},
+	)
+	// StreamConnections tracks the number of currently open exec/attach/port-forward streams, by
+	// tenant and operation. Unlike RequestsTotal/RequestDuration, these requests hijack the
+	// connection and can stay open indefinitely, so a count of in-flight requests is more useful
+	// than a duration histogram for spotting e.g. a tenant leaking exec sessions.
+	StreamConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: Subsystem,
+			Name:      StreamConnectionsKey,
+			Help:      "Number of currently open exec/attach/port-forward stream connections, by tenant and operation.",
+		},
+		[]string{"tenant", "operation"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// Register registers vn-agent's metrics with the default Prometheus registry. It's safe to call
+// more than once.
+func Register() {
+	registerMetrics.Do(func() {
+		prometheus.MustRegister(RequestsTotal)
+		prometheus.MustRegister(RequestDuration)
+		prometheus.MustRegister(StreamConnections)
+	})
+}
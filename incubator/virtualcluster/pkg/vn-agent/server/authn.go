@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/vn-agent/config"
+)
+
+// tenantAuthenticator determines which tenant is making a request, and whether it's allowed to.
+type tenantAuthenticator interface {
+	// authenticate returns the tenant name for req, or ok=false if req can't be authenticated.
+	authenticate(req *http.Request) (tenantName string, ok bool)
+}
+
+// certAuthenticator is the original authn mode: the tenant name is the CommonName of the client
+// certificate presented in the mTLS handshake.
+type certAuthenticator struct{}
+
+func (certAuthenticator) authenticate(req *http.Request) (string, bool) {
+	if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+		return "", false
+	}
+	return req.TLS.PeerCertificates[0].Subject.CommonName, true
+}
+
+// tokenAuthenticator validates the bearer token in an incoming request against the tenant
+// apiserver named in config.TenantHeader, via TokenReview, and derives the tenant name from the
+// resulting ServiceAccount identity.
+type tokenAuthenticator struct {
+	// restConfigs holds one TLS-only rest.Config per configured tenant apiserver, keyed by
+	// TenantAPIServer.Name. The bearer token is filled in per request rather than baked in here,
+	// since it's different for every caller.
+	restConfigs map[string]*rest.Config
+}
+
+func newTokenAuthenticator(tenants []config.TenantAPIServer) (*tokenAuthenticator, error) {
+	restConfigs := make(map[string]*rest.Config, len(tenants))
+	for _, t := range tenants {
+		restConfigs[t.Name] = &rest.Config{
+			Host: t.Server,
+			TLSClientConfig: rest.TLSClientConfig{
+				CAFile: t.CAFile,
+			},
+		}
+	}
+	return &tokenAuthenticator{restConfigs: restConfigs}, nil
+}
+
+func (a *tokenAuthenticator) authenticate(req *http.Request) (string, bool) {
+	tenantName := req.Header.Get(config.TenantHeader)
+	if tenantName == "" {
+		return "", false
+	}
+	restConfig, ok := a.restConfigs[tenantName]
+	if !ok {
+		klog.Warningf("token authn: no tenant apiserver configured for %q", tenantName)
+		return "", false
+	}
+
+	token := bearerToken(req)
+	if token == "" {
+		return "", false
+	}
+
+	cfg := *restConfig
+	cfg.BearerToken = token
+	client, err := kubernetes.NewForConfig(&cfg)
+	if err != nil {
+		klog.Errorf("token authn: failed to build client for tenant %q: %v", tenantName, err)
+		return "", false
+	}
+
+	review, err := client.AuthenticationV1().TokenReviews().Create(context.TODO(),
+		&authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}, metav1.CreateOptions{})
+	if err != nil {
+		klog.Errorf("token authn: TokenReview against tenant %q failed: %v", tenantName, err)
+		return "", false
+	}
+	if !review.Status.Authenticated {
+		klog.Warningf("token authn: token rejected by tenant %q apiserver: %s", tenantName, review.Status.Error)
+		return "", false
+	}
+
+	// The username of a ServiceAccount token is "system:serviceaccount:<namespace>:<name>"; a
+	// successful TokenReview against tenantName's own apiserver is already proof the token
+	// belongs to that tenant, so tenantName itself is the value to use going forward.
+	if !strings.HasPrefix(review.Status.User.Username, "system:serviceaccount:") {
+		klog.Warningf("token authn: rejecting non-ServiceAccount identity %q for tenant %q", review.Status.User.Username, tenantName)
+		return "", false
+	}
+	return tenantName, true
+}
+
+func bearerToken(req *http.Request) string {
+	const prefix = "Bearer "
+	auth := req.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(auth, prefix)
+}
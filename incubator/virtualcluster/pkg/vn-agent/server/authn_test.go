@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"testing"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/vn-agent/config"
+)
+
+func TestCertAuthenticatorAuthenticate(t *testing.T) {
+	req := &http.Request{}
+	if _, ok := (certAuthenticator{}).authenticate(req); ok {
+		t.Fatal("authenticate() with no TLS connection state should have failed")
+	}
+
+	req.TLS = &tls.ConnectionState{}
+	if _, ok := (certAuthenticator{}).authenticate(req); ok {
+		t.Fatal("authenticate() with no peer certificates should have failed")
+	}
+
+	req.TLS.PeerCertificates = []*x509.Certificate{{Subject: pkix.Name{CommonName: "tenant-a"}}}
+	tenantName, ok := (certAuthenticator{}).authenticate(req)
+	if !ok || tenantName != "tenant-a" {
+		t.Fatalf("authenticate() = (%q, %v), want (\"tenant-a\", true)", tenantName, ok)
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	cases := []struct {
+		header string
+		want   string
+	}{
+		{"Bearer abc123", "abc123"},
+		{"", ""},
+		{"Basic abc123", ""},
+	}
+	for _, c := range cases {
+		req := &http.Request{Header: http.Header{}}
+		if c.header != "" {
+			req.Header.Set("Authorization", c.header)
+		}
+		if got := bearerToken(req); got != c.want {
+			t.Errorf("bearerToken() with Authorization %q = %q, want %q", c.header, got, c.want)
+		}
+	}
+}
+
+func TestTokenAuthenticatorRequiresTenantHeaderAndToken(t *testing.T) {
+	a, err := newTokenAuthenticator([]config.TenantAPIServer{{Name: "tenant-a", Server: "https://127.0.0.1:1"}})
+	if err != nil {
+		t.Fatalf("newTokenAuthenticator() returned error: %v", err)
+	}
+
+	req := &http.Request{Header: http.Header{}}
+	if _, ok := a.authenticate(req); ok {
+		t.Fatal("authenticate() with no tenant header should have failed")
+	}
+
+	req.Header.Set(config.TenantHeader, "unknown-tenant")
+	req.Header.Set("Authorization", "Bearer abc123")
+	if _, ok := a.authenticate(req); ok {
+		t.Fatal("authenticate() for an unconfigured tenant should have failed")
+	}
+
+	req.Header.Set(config.TenantHeader, "tenant-a")
+	req.Header.Del("Authorization")
+	if _, ok := a.authenticate(req); ok {
+		t.Fatal("authenticate() with no bearer token should have failed")
+	}
+}
@@ -17,14 +17,39 @@ limitations under the License.
 package server
 
 import (
+	"bufio"
+	"fmt"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/emicklei/go-restful"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/apimachinery/pkg/util/proxy"
 	"k8s.io/klog"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/vn-agent/config"
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/vn-agent/metrics"
 )
 
+// streamRoutePrefixes are the routes whose requests hijack the connection and stay open for as
+// long as the tenant keeps the session running, rather than completing immediately. They're
+// tracked as gauges (metrics.StreamConnections) instead of the request duration histogram other
+// routes use.
+var streamRoutePrefixes = []string{"/exec/", "/attach/", "/portForward/"}
+
+func isStreamRoute(routePath string) bool {
+	for _, prefix := range streamRoutePrefixes {
+		if strings.HasPrefix(routePath, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // InstallHandlers set router and handlers.
 func (s *Server) InstallHandlers() {
 	ws := new(restful.WebService)
@@ -110,31 +135,49 @@ func (s *Server) InstallHandlers() {
 		To(s.proxy).
 		Operation("getPortForward"))
 	s.restfulCont.Add(ws)
+
+	metrics.Register()
+	ws = new(restful.WebService)
+	ws.Path("/metrics")
+	ws.Route(ws.GET("").To(func(req *restful.Request, resp *restful.Response) {
+		promhttp.Handler().ServeHTTP(resp, req.Request)
+	}))
+	s.restfulCont.Add(ws)
 }
 
 func (s *Server) proxy(req *restful.Request, resp *restful.Response) {
 	klog.V(4).Infof("request %+v", req.Request.URL)
 
-	// there must be a peer certificate in the tls connection
-	if req.Request.TLS == nil || len(req.Request.TLS.PeerCertificates) == 0 {
+	operation := req.SelectedRoutePath()
+	streaming := isStreamRoute(operation)
+
+	tenantName, ok := s.authenticator.authenticate(req.Request)
+	if !ok {
 		resp.ResponseWriter.WriteHeader(http.StatusForbidden)
 		return
 	}
 
+	transport := s.transport
 	if s.config.KubeletClientCert != nil {
-		klog.Info("will forward request to kubelet")
-		// forward request to kubelet
-		req.Request.URL.Host = s.config.KubeletServerHost
+		// forward request to kubelet, or to whichever backend handles the target pod's
+		// RuntimeClass, if any and if it's currently healthy.
+		host := s.config.KubeletServerHost
+		if backend := s.selectBackend(req.Request); backend != nil {
+			klog.V(4).Infof("will forward request to backend %q (%s)", backend.Name, backend.KubeletServerHost)
+			host = backend.KubeletServerHost
+			transport = s.backendTransports[backend.Name]
+		} else {
+			klog.Info("will forward request to kubelet")
+		}
+		req.Request.URL.Host = host
 		req.Request.URL.Scheme = "https"
 
-		tenantName := req.Request.TLS.PeerCertificates[0].Subject.CommonName
 		TranslatePath(req, tenantName)
 
 		klog.V(4).Infof("request after translate %+v", req.Request.URL)
 	} else {
 		klog.Info("will forward request to super apiserver")
 		// forward request to super apiserver
-		tenantName := req.Request.TLS.PeerCertificates[0].Subject.CommonName
 		err := TranslatePathForSuper(req, tenantName)
 		if err != nil {
 			klog.Errorf("fail to translate url path for super master: %s", err)
@@ -150,8 +193,57 @@ func (s *Server) proxy(req *restful.Request, resp *restful.Response) {
 		req.Request.Header.Add("Authorization", "Bearer "+s.restConfig.BearerToken)
 	}
 
-	handler := proxy.NewUpgradeAwareHandler(req.Request.URL, s.transport /*transport*/, false /*wrapTransport*/, httpstream.IsUpgradeRequest(req.Request) /*upgradeRequired*/, &responder{})
-	handler.ServeHTTP(resp.ResponseWriter, req.Request)
+	if streaming {
+		metrics.StreamConnections.WithLabelValues(tenantName, operation).Inc()
+		defer metrics.StreamConnections.WithLabelValues(tenantName, operation).Dec()
+	}
+	start := time.Now()
+	statusWriter := &statusCapturingResponseWriter{ResponseWriter: resp.ResponseWriter, status: http.StatusOK}
+
+	handler := proxy.NewUpgradeAwareHandler(req.Request.URL, transport /*transport*/, false /*wrapTransport*/, httpstream.IsUpgradeRequest(req.Request) /*upgradeRequired*/, &responder{})
+	handler.ServeHTTP(statusWriter, req.Request)
+
+	metrics.RequestsTotal.WithLabelValues(tenantName, operation, strconv.Itoa(statusWriter.status)).Inc()
+	metrics.RequestDuration.WithLabelValues(tenantName, operation).Observe(time.Since(start).Seconds())
+}
+
+// statusCapturingResponseWriter records the status code a handler writes, so it can be reported to
+// metrics.RequestsTotal even though the proxy handler is given the raw ResponseWriter rather than
+// the restful.Response wrapper (which never sees the write once the request has been proxied).
+type statusCapturingResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusCapturingResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Hijack lets the wrapped writer support the streaming (exec/attach/port-forward) upgrade path,
+// which requires the ResponseWriter passed to it to implement http.Hijacker.
+func (w *statusCapturingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("underlying ResponseWriter does not support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+// selectBackend returns the healthy Backend that the request's RuntimeClassNameHeader routes
+// to, or nil if the header is unset, matches no backend, or the matching backend is currently
+// failing its health check - in all of those cases the caller should fall back to the default
+// kubelet.
+func (s *Server) selectBackend(r *http.Request) *config.Backend {
+	backend := s.config.Backend(r.Header.Get(config.RuntimeClassNameHeader))
+	if backend == nil {
+		return nil
+	}
+	if s.healthChecker != nil && !s.healthChecker.IsHealthy(backend.Name) {
+		klog.Warningf("backend %q is unhealthy, falling back to the default kubelet", backend.Name)
+		return nil
+	}
+	return backend
 }
 
 type responder struct{}
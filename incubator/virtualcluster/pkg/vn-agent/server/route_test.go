@@ -0,0 +1,38 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import "testing"
+
+func TestIsStreamRoute(t *testing.T) {
+	cases := []struct {
+		routePath string
+		want      bool
+	}{
+		{"/exec/{podNamespace}/{podID}/{containerName}", true},
+		{"/attach/{podNamespace}/{podID}/{containerName}", true},
+		{"/portForward/{podNamespace}/{podID}", true},
+		{"/logs/{logpath:*}", false},
+		{"/containerLogs/{podNamespace}/{podID}/{containerName}", false},
+		{"/pods", false},
+	}
+	for _, c := range cases {
+		if got := isStreamRoute(c.routePath); got != c.want {
+			t.Errorf("isStreamRoute(%q) = %v, want %v", c.routePath, got, c.want)
+		}
+	}
+}
@@ -34,8 +34,20 @@ type Server struct {
 	config                *config.Config
 	restfulCont           *restful.Container
 	transport             *http.Transport
+	backendTransports     map[string]*http.Transport
+	healthChecker         *config.BackendHealthChecker
 	superAPIServerAddress *url.URL
 	restConfig            *rest.Config
+	authenticator         tenantAuthenticator
+}
+
+// StartHealthChecks starts polling every configured backend's health-check endpoint in its own
+// goroutine, until stopCh is closed. It's a no-op if the server has no backends.
+func (s *Server) StartHealthChecks(stopCh <-chan struct{}) {
+	if s.healthChecker == nil {
+		return
+	}
+	go s.healthChecker.Run(stopCh)
 }
 
 // ServeHTTP responds to HTTP requests on the vn-agent.
@@ -56,6 +68,19 @@ func NewServer(cfg *config.Config) (*Server, error) {
 		config:      cfg,
 	}
 
+	switch cfg.AuthnMode {
+	case config.AuthnModeToken:
+		authenticator, err := newTokenAuthenticator(cfg.TenantAPIServers)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to configure token authentication")
+		}
+		server.authenticator = authenticator
+	case config.AuthnModeCert, "":
+		server.authenticator = certAuthenticator{}
+	default:
+		return nil, errors.Errorf("unknown authn mode %q", cfg.AuthnMode)
+	}
+
 	server.InstallHandlers()
 
 	if server.config.KubeletClientCert != nil {
@@ -65,6 +90,18 @@ func NewServer(cfg *config.Config) (*Server, error) {
 				Certificates:       []tls.Certificate{*server.config.KubeletClientCert},
 			},
 		}
+
+		if len(cfg.Backends) > 0 {
+			server.backendTransports = make(map[string]*http.Transport, len(cfg.Backends))
+			for _, backend := range cfg.Backends {
+				tlsConfig := &tls.Config{InsecureSkipVerify: true}
+				if cert := backend.KubeletClientCert(); cert != nil {
+					tlsConfig.Certificates = []tls.Certificate{*cert}
+				}
+				server.backendTransports[backend.Name] = &http.Transport{TLSClientConfig: tlsConfig}
+			}
+			server.healthChecker = config.NewBackendHealthChecker(cfg.Backends, 0, 0)
+		}
 	} else {
 		restConfig, err := rest.InClusterConfig()
 		if err != nil {
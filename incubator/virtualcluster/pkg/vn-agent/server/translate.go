@@ -24,6 +24,8 @@ import (
 
 	"github.com/emicklei/go-restful"
 	"k8s.io/klog"
+
+	"sigs.k8s.io/multi-tenancy/incubator/virtualcluster/pkg/syncer/conversion"
 )
 
 // TranslatePath translate the naming between tenant and master cluster.
@@ -32,9 +34,9 @@ func TranslatePath(req *restful.Request, tenantName string) {
 	path := req.Request.URL.Path
 	if podNamespace != "" {
 		// eg.   /containerLogs/{podNamespace}/{podID}/{containerName}
-		//    to /containerLogs/{tenantName}-{podNamespace}/{podID}/{containerName}
+		//    to /containerLogs/{superClusterNamespace}/{podID}/{containerName}
 		secondSlash := strings.IndexByte(path[1:], '/')
-		path = path[:secondSlash+2] + tenantName + "-" + path[secondSlash+2:]
+		path = path[:secondSlash+2] + conversion.ToSuperMasterNamespace(tenantName, podNamespace) + path[secondSlash+2:]
 	}
 	req.Request.URL.Path = path
 }
@@ -104,17 +106,17 @@ func TranslatePathForSuper(req *restful.Request, tenantName string) error {
 	podNamespace := pathParas["podNamespace"]
 	podID := pathParas["podID"]
 	containerName := pathParas["containerName"]
-	commonPath := fmt.Sprintf("/api/v1/namespaces/%s-%s/pods/%s", tenantName, podNamespace, podID)
+	commonPath := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s", conversion.ToSuperMasterNamespace(tenantName, podNamespace), podID)
 
 	switch action {
 	case "containerLogs":
 		// eg. 	/containerLogs/{podNamespace}/{podID}/{containerName}
-		// to   /api/v1/namespaces/{tenantName}-{podNamespace}/pods/{podID}/log
+		// to   /api/v1/namespaces/{superClusterNamespace}/pods/{podID}/log
 		apiserverPath = path.Join(commonPath, "log")
 		translateRawQuery(req, containerName)
 	case "exec":
 		// eg. /exec/{podNamespace}/podID/{containerName}
-		// to  /api/v1/namespaces/{tenantName}-{podNamespace}/pods/{podID}/exec
+		// to  /api/v1/namespaces/{superClusterNamespace}/pods/{podID}/exec
 		apiserverPath = path.Join(commonPath, "exec")
 		translateRawQuery(req, containerName)
 	case "attach":
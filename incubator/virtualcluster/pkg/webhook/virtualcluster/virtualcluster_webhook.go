@@ -65,6 +65,7 @@ const (
 	VCWebhookServiceName      = "virtualcluster-webhook-service"
 	DefaultVCWebhookServiceNs = "vc-manager"
 	VCWebhookCfgName          = "virtualcluster-validating-webhook-configuration"
+	CVWebhookCfgName          = "clusterversion-mutating-webhook-configuration"
 	VCWebhookCAFile           = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
 	VCWebhookCSRName          = "virtualcluster-webhook-csr"
 )
@@ -102,8 +103,21 @@ func Add(mgr manager.Manager, certDir string) error {
 	}
 	log.Info(fmt.Sprintf("successfully created validatingwebhookconfiguration/%s", VCWebhookCfgName))
 
-	// 4. register the validating webhook
-	return (&tenancyv1alpha1.VirtualCluster{}).SetupWebhookWithManager(mgr)
+	// 4. create the MutatingWebhookConfiguration for ClusterVersion's profile defaulting
+	log.Info(fmt.Sprintf("will create mutatingwebhookconfiguration/%s", CVWebhookCfgName))
+	if err := createClusterVersionMutatingWebhookConfiguration(mgr.GetClient()); err != nil {
+		return fmt.Errorf("fail to create clusterversion mutating webhook configuration: %s", err)
+	}
+	log.Info(fmt.Sprintf("successfully created mutatingwebhookconfiguration/%s", CVWebhookCfgName))
+
+	// 5. register the validating webhook for VirtualCluster
+	if err := (&tenancyv1alpha1.VirtualCluster{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("fail to setup virtualcluster webhook: %s", err)
+	}
+
+	// 6. register the validating/defaulting webhook for ClusterVersion. It shares the same
+	// webhook server, service and certificate as VirtualCluster above.
+	return (&tenancyv1alpha1.ClusterVersion{}).SetupWebhookWithManager(mgr)
 }
 
 // createVirtualClusterWebhookService creates the service for exposing the webhook server
@@ -141,7 +155,8 @@ func createVirtualClusterWebhookService(client client.Client) error {
 
 // createValidatingWebhookConfiguration creates the validatingwebhookconfiguration for the webhook
 func createValidatingWebhookConfiguration(client client.Client) error {
-	validatePath := "/validate-tenancy-x-k8s-io-v1alpha1-virtualcluster"
+	validateVCPath := "/validate-tenancy-x-k8s-io-v1alpha1-virtualcluster"
+	validateCVPath := "/validate-tenancy-x-k8s-io-v1alpha1-clusterversion"
 	svcPort := int32(constants.VirtualClusterWebhookPort)
 	// reject request if the webhook doesn't work
 	failPolicy := admv1beta1.Fail
@@ -164,7 +179,7 @@ func createValidatingWebhookConfiguration(client client.Client) error {
 					Service: &admv1beta1.ServiceReference{
 						Name:      VCWebhookServiceName,
 						Namespace: VCWebhookServiceNs,
-						Path:      &validatePath,
+						Path:      &validateVCPath,
 						Port:      &svcPort,
 					},
 					CABundle: CAPemByts,
@@ -183,6 +198,33 @@ func createValidatingWebhookConfiguration(client client.Client) error {
 					},
 				},
 			},
+			{
+				// ClusterVersion also gets a defaulting webhook (for named profiles), but that's
+				// registered separately since this is a ValidatingWebhookConfiguration.
+				Name: "clusterversion.validating.webhook",
+				ClientConfig: admv1beta1.WebhookClientConfig{
+					Service: &admv1beta1.ServiceReference{
+						Name:      VCWebhookServiceName,
+						Namespace: VCWebhookServiceNs,
+						Path:      &validateCVPath,
+						Port:      &svcPort,
+					},
+					CABundle: CAPemByts,
+				},
+				FailurePolicy: &failPolicy,
+				Rules: []admv1beta1.RuleWithOperations{
+					{
+						Operations: []admv1beta1.OperationType{
+							admv1beta1.OperationAll,
+						},
+						Rule: admv1beta1.Rule{
+							APIGroups:   []string{"tenancy.x-k8s.io"},
+							APIVersions: []string{"v1alpha1"},
+							Resources:   []string{"clusterversions"},
+						},
+					},
+				},
+			},
 		},
 	}
 
@@ -197,6 +239,65 @@ func createValidatingWebhookConfiguration(client client.Client) error {
 	return nil
 }
 
+// createClusterVersionMutatingWebhookConfiguration creates the mutatingwebhookconfiguration that
+// applies ClusterVersion's profile defaulting. It shares the certificate and service created
+// above for the validating webhook.
+func createClusterVersionMutatingWebhookConfiguration(client client.Client) error {
+	mutatePath := "/mutate-tenancy-x-k8s-io-v1alpha1-clusterversion"
+	svcPort := int32(constants.VirtualClusterWebhookPort)
+	failPolicy := admv1beta1.Fail
+	CAPemByts, err := ioutil.ReadFile(VCWebhookCAFile)
+	if err != nil {
+		return fmt.Errorf("fail to read ca file(%s): %s", VCWebhookCAFile, err)
+	}
+	mwhCfg := admv1beta1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: CVWebhookCfgName,
+			Labels: map[string]string{
+				"virtualcluster-webhook": "true",
+			},
+		},
+		Webhooks: []admv1beta1.MutatingWebhook{
+			{
+				Name: "clusterversion.defaulting.webhook",
+				ClientConfig: admv1beta1.WebhookClientConfig{
+					Service: &admv1beta1.ServiceReference{
+						Name:      VCWebhookServiceName,
+						Namespace: VCWebhookServiceNs,
+						Path:      &mutatePath,
+						Port:      &svcPort,
+					},
+					CABundle: CAPemByts,
+				},
+				FailurePolicy: &failPolicy,
+				Rules: []admv1beta1.RuleWithOperations{
+					{
+						Operations: []admv1beta1.OperationType{
+							admv1beta1.Create,
+							admv1beta1.Update,
+						},
+						Rule: admv1beta1.Rule{
+							APIGroups:   []string{"tenancy.x-k8s.io"},
+							APIVersions: []string{"v1alpha1"},
+							Resources:   []string{"clusterversions"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := client.Create(context.TODO(), &mwhCfg); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		log.Info(fmt.Sprintf("mutatingwebhookconfiguration/%s already exist", CVWebhookCfgName))
+		return nil
+	}
+	log.Info(fmt.Sprintf("successfully created mutatingwebhookconfiguration/%s", CVWebhookCfgName))
+	return nil
+}
+
 // genCertificate generates the serving cerficiate for the webhook server
 func genCertificate(mgr manager.Manager, certDir string) error {
 	// client-go client for generating certificate
@@ -12,21 +12,15 @@
 package main
 
 import (
-	"context"
 	"flag"
 	"os"
-	"os/signal"
-	"syscall"
-	"time"
 
 	"github.com/golang/glog"
-	k8sclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
-	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
-	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
 	tenantsv1alpha "sigs.k8s.io/multi-tenancy/poc/tenant-controller/pkg/apis/tenants/v1alpha1"
-	tenantsclient "sigs.k8s.io/multi-tenancy/poc/tenant-controller/pkg/clients/tenants/clientset/v1alpha1"
-	tenantsinformers "sigs.k8s.io/multi-tenancy/poc/tenant-controller/pkg/clients/tenants/informers/externalversions"
 	tenants "sigs.k8s.io/multi-tenancy/poc/tenant-controller/pkg/controllers/tenants"
 )
 
@@ -35,10 +29,6 @@ var (
 	kubeconfig = os.Getenv("KUBECONFIG")
 )
 
-const (
-	defaultResyncInterval = time.Duration(0)
-)
-
 func init() {
 	flag.StringVar(&masterURL, "master", masterURL, "The URL of the Kubernetes API server.")
 	flag.StringVar(&kubeconfig, "kubeconfig", kubeconfig, "Path to kubeconfig file.")
@@ -47,45 +37,34 @@ func init() {
 func main() {
 	flag.Parse()
 
-	cfg, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfig)
-	if err != nil {
-		glog.Fatalf("building kubeconfig: %v", err)
+	ctrl.SetLogger(zap.New())
+
+	if err := tenantsv1alpha.AddToScheme(scheme.Scheme); err != nil {
+		glog.Fatalf("register tenants scheme: %v", err)
 	}
 
-	tenantsClient, err := tenantsclient.NewForConfig(cfg)
+	cfg, err := ctrl.GetConfig()
 	if err != nil {
-		glog.Fatalf("create tenants client: %v", err)
+		glog.Fatalf("building kubeconfig: %v", err)
 	}
 
-	k8sClient, err := k8sclient.NewForConfig(cfg)
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{Scheme: scheme.Scheme})
 	if err != nil {
-		glog.Fatalf("create std k8s client: %v", err)
+		glog.Fatalf("create manager: %v", err)
 	}
 
-	tenantsInformerFactory := tenantsinformers.NewSharedInformerFactory(tenantsClient, defaultResyncInterval)
-
-	tenantsv1alpha.AddToScheme(scheme.Scheme)
-
-	tenantsCtl := tenants.NewController(k8sClient, tenantsClient, tenantsInformerFactory)
-
-	daemonCtx, cancelFn := context.WithCancel(context.TODO())
-	sigCh, errCh := make(chan os.Signal, 1), make(chan error, 1)
-	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
-	go func() {
-		<-sigCh
-		// the first signal notifies cancels the context.
-		cancelFn()
-		<-sigCh
-		// the second signal forcibly terminate the process.
-		os.Exit(1)
-	}()
-
-	go tenantsInformerFactory.Start(daemonCtx.Done())
-	go func() {
-		errCh <- tenantsCtl.Run(daemonCtx)
-	}()
+	reconciler := &tenants.TenantReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("Tenant"),
+		Recorder: mgr.GetEventRecorderFor("tenant-controller"),
+	}
+	if err := reconciler.SetupWithManager(mgr); err != nil {
+		glog.Fatalf("setup Tenant controller: %v", err)
+	}
 
-	if err = <-errCh; err != nil {
+	glog.Info("controller started")
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		glog.Fatalf("controller error: %v", err)
 	}
+	glog.Info("controller stopped")
 }
@@ -12,6 +12,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apirt "k8s.io/apimachinery/pkg/runtime"
@@ -26,7 +27,7 @@ import (
 // Under a tenant, one or more namespaces are created.
 // The OwerReferences in namespace resource will point to this Tenant resource, so
 // once the Tenant resource is deleted, the namespaces will be garbage collected.
-// Beyond this, the following labels are proposed to be associated with namespaces:
+// Beyond this, the following label is associated with namespaces belonging to the tenant:
 //     tenants.k8s.io/tenant=<name of Tenant resource>
 type Tenant struct {
 	metav1.TypeMeta `json:",inline"`
@@ -55,6 +56,17 @@ type TenantSpec struct {
 	// Namespaces are the namespaces created for the tenant.
 	// +optional
 	Namespaces []TenantNamespace `json:"namespaces"`
+
+	// AdoptionDryRun, if true, makes the controller compute and report (via TenantStatus) what
+	// adopting any pre-existing namespaces marked with Adopt would change, without actually
+	// modifying those namespaces.
+	// +optional
+	AdoptionDryRun bool `json:"adoptionDryRun,omitempty"`
+
+	// DeletionPolicy controls what happens to this tenant's namespaces and RBAC when the Tenant is
+	// deleted. Defaults to TenantDeletionPolicyDelete.
+	// +optional
+	DeletionPolicy TenantDeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // TenantStatus defines the status of a tenant resource.
@@ -70,12 +82,95 @@ type TenantStatus struct {
 	// Reason is a brief CamelCase string describing the status.
 	// +optional
 	Reason string `json:"reason,omitempty" protobuf:"bytes,3,opt,name=reason"`
+
+	// ObservedGeneration is the generation of the Tenant that was last processed by the controller.
+	// Automation can compare this against metadata.generation to know whether the rest of the
+	// status reflects the most recently submitted spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// NamespaceStatuses reports the provisioning progress of each namespace in Spec.Namespaces.
+	// +optional
+	NamespaceStatuses []TenantNamespaceStatus `json:"namespaceStatuses,omitempty"`
+
+	// Conditions represent the latest available observations of the tenant's state.
+	// +optional
+	Conditions []TenantCondition `json:"conditions,omitempty"`
+}
+
+// TenantNamespaceStatus reports how far provisioning has progressed for a single namespace
+// requested in Spec.Namespaces.
+type TenantNamespaceStatus struct {
+	// Name is the namespace's name, as given in the corresponding TenantNamespace.Name.
+	Name string `json:"name"`
+
+	// NamespaceCreated is true once the namespace itself exists (whether created or adopted).
+	NamespaceCreated bool `json:"namespaceCreated"`
+
+	// RBACApplied is true once the tenant's admin RoleBinding has been applied to the namespace.
+	RBACApplied bool `json:"rbacApplied"`
+
+	// QuotaApplied is true once the namespace's template (which may include a ResourceQuota) has
+	// been applied. It's true whenever no template was requested, since there's nothing to apply.
+	QuotaApplied bool `json:"quotaApplied"`
+
+	// Ready is true once every step above has completed successfully.
+	Ready bool `json:"ready"`
+
+	// Message provides human-readable detail on the last error encountered provisioning this
+	// namespace, if any.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// TenantConditionType is the type of a TenantCondition.
+type TenantConditionType string
+
+// Known tenant condition types.
+const (
+	// TenantReady is True when every namespace in Spec.Namespaces is Ready.
+	TenantReady TenantConditionType = "Ready"
+	// TenantNamespacesReady is True when every namespace in Spec.Namespaces exists (whether
+	// created or adopted) and, where a template was requested, has had that template applied.
+	TenantNamespacesReady TenantConditionType = "NamespacesReady"
+	// TenantRBACReady is True when the tenant's admin RoleBinding has been applied to every
+	// namespace in Spec.Namespaces.
+	TenantRBACReady TenantConditionType = "RBACReady"
+)
+
+// TenantCondition describes one aspect of a tenant's current state.
+type TenantCondition struct {
+	// Type of tenant condition.
+	Type TenantConditionType `json:"type"`
+
+	// Status of the condition: True, False, or Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief CamelCase string describing the reason for the condition's last
+	// transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human-readable message indicating details about the last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // TenantNamespace defines the namespaces belonging to this tenant.
 type TenantNamespace struct {
 	Name     string `json:"name"`
 	Template string `json:"template"`
+
+	// Adopt indicates that, if a namespace named Name already exists and isn't already owned by a
+	// different tenant, the controller should take ownership of it instead of treating its
+	// existence as a conflict. Adoption only ever adds the tenant's label and OwnerReference to the
+	// namespace; it never touches any of the namespace's other fields, labels or annotations.
+	// +optional
+	Adopt bool `json:"adopt,omitempty"`
 }
 
 // TenantPhase defines the phase of tenant status.
@@ -92,6 +187,26 @@ const (
 	TenantActive TenantPhase = "Active"
 	// TenantTerminating means tenant is being removed.
 	TenantTerminating TenantPhase = "Terminating"
+	// TenantFailed means the controller hit an error provisioning or pruning the tenant's
+	// namespaces or RBAC. Status.Reason and Status.Message describe what failed; the controller
+	// keeps retrying, so this isn't terminal the way TenantTerminating is.
+	TenantFailed TenantPhase = "Failed"
+)
+
+// TenantDeletionPolicy defines what happens to a tenant's namespaces and RBAC when the Tenant is
+// deleted.
+type TenantDeletionPolicy string
+
+// Known tenant deletion policies.
+const (
+	// TenantDeletionPolicyDelete deletes every namespace owned by the tenant, waits for them to
+	// finish terminating, and then revokes the tenant's RBAC. This is the default.
+	TenantDeletionPolicyDelete TenantDeletionPolicy = "Delete"
+	// TenantDeletionPolicyRetain detaches the tenant's namespaces (removing the tenant's label and
+	// OwnerReference, but not the namespace itself) and then revokes RBAC.
+	TenantDeletionPolicyRetain TenantDeletionPolicy = "Retain"
+	// TenantDeletionPolicyOrphan leaves the tenant's namespaces and RBAC completely untouched.
+	TenantDeletionPolicyOrphan TenantDeletionPolicy = "Orphan"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
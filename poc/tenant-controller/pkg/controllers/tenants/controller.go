@@ -14,32 +14,33 @@ package tenants
 import (
 	"context"
 	"fmt"
-	"sort"
 	"strings"
-	"sync"
+	"time"
 
-	"github.com/golang/glog"
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8srt "k8s.io/apimachinery/pkg/runtime"
-	utilrt "k8s.io/apimachinery/pkg/util/runtime"
-	k8sclient "k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	tenantsapi "sigs.k8s.io/multi-tenancy/poc/tenant-controller/pkg/apis/tenants/v1alpha1"
-	tenantsclient "sigs.k8s.io/multi-tenancy/poc/tenant-controller/pkg/clients/tenants/clientset/v1alpha1"
-	tenantsinformers "sigs.k8s.io/multi-tenancy/poc/tenant-controller/pkg/clients/tenants/informers/externalversions"
 )
 
-// Controller is k8s controller managing Tenant CRDs.
-type Controller struct {
-	tenantsInformer     cache.SharedIndexInformer
-	nsTemplatesInformer cache.SharedIndexInformer
-	tenantsclient       tenantsclient.Interface
-	k8sclient           k8sclient.Interface
-	nsTemplates         map[string]*namespaceTemplate
-	nsTemplatesLock     sync.RWMutex
+// TenantReconciler reconciles Tenant objects: it creates, adopts, syncs and tears down the
+// namespaces and RBAC each Tenant requests. Unlike the previous informer-based Controller, it
+// doesn't diff a Tenant's old and new spec directly - instead, on every reconcile it lists the
+// namespaces it already owns and diffs those against the Tenant's current Spec.Namespaces, which
+// is the idiomatic controller-runtime way to reach the same result without having to track state
+// across events.
+type TenantReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
 }
 
 // namespaceTemplate wraps the original tenantsapi.NamespaceTemplate and
@@ -53,197 +54,303 @@ type namespaceTemplate struct {
 const (
 	defaultAdminRoleBindingName = "admins"
 	defaultAdminClusterRole     = "admin"
+
+	// TenantLabel is set on every namespace belonging to a tenant, whether it was created by the
+	// controller or adopted from a pre-existing namespace (see TenantNamespace.Adopt). Its value is
+	// the owning Tenant's name.
+	TenantLabel = "tenants.k8s.io/tenant"
+
+	// TenantFinalizer is added to every Tenant so that finalizeTenant gets a chance to tear down
+	// its namespaces and RBAC (per Spec.DeletionPolicy) before the Tenant object itself is removed.
+	TenantFinalizer = "tenants.k8s.io/finalizer"
+
+	// waitForNamespacesTerminatedInterval is how often finalizeTenant requeues to recheck whether
+	// a TenantDeletionPolicyDelete tenant's namespaces have finished terminating.
+	waitForNamespacesTerminatedInterval = time.Second
+	// waitForNamespacesTerminatedTimeout bounds, counted from DeletionTimestamp, how long
+	// finalizeTenant keeps waiting for those namespaces before giving up and surfacing an error.
+	waitForNamespacesTerminatedTimeout = 5 * time.Minute
 )
 
-// NewController creates the controller.
-func NewController(k8sclient k8sclient.Interface, tenantsclient tenantsclient.Interface, informerFactory tenantsinformers.SharedInformerFactory) *Controller {
-	c := &Controller{
-		tenantsInformer:     informerFactory.Tenants().V1alpha1().Tenants().Informer(),
-		nsTemplatesInformer: informerFactory.Tenants().V1alpha1().NamespaceTemplates().Informer(),
-		tenantsclient:       tenantsclient,
-		k8sclient:           k8sclient,
-		nsTemplates:         make(map[string]*namespaceTemplate),
-	}
-	c.tenantsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    func(o interface{}) { c.createTenant(o.(*tenantsapi.Tenant)) },
-		UpdateFunc: func(o, n interface{}) { c.updateTenant(o.(*tenantsapi.Tenant), n.(*tenantsapi.Tenant)) },
-		DeleteFunc: func(o interface{}) { c.deleteTenant(o.(*tenantsapi.Tenant)) },
-	})
-	c.nsTemplatesInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: func(o interface{}) { c.addNsTemplate(o.(*tenantsapi.NamespaceTemplate)) },
-		UpdateFunc: func(o, n interface{}) {
-			c.updateNsTemplate(o.(*tenantsapi.NamespaceTemplate), n.(*tenantsapi.NamespaceTemplate))
-		},
-		DeleteFunc: func(o interface{}) { c.deleteNsTemplate(o.(*tenantsapi.NamespaceTemplate)) },
-	})
-	return c
+// SetupWithManager registers the reconciler with mgr, triggering a reconcile whenever a Tenant is
+// created, updated or deleted.
+func (r *TenantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&tenantsapi.Tenant{}).
+		Complete(r)
 }
 
-// Run implements the controller logic.
-func (c *Controller) Run(ctx context.Context) error {
-	defer utilrt.HandleCrash()
+// Reconcile implements the controller logic for a single Tenant.
+func (r *TenantReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("tenant", req.Name)
 
-	glog.Info("waiting for cache sync")
-	if !cache.WaitForCacheSync(ctx.Done(),
-		c.tenantsInformer.HasSynced,
-		c.nsTemplatesInformer.HasSynced) {
-		return fmt.Errorf("cache sync failed")
+	var tenant tenantsapi.Tenant
+	if err := r.Get(ctx, req.NamespacedName, &tenant); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	glog.Info("controller started")
-	<-ctx.Done()
-	glog.Info("controller stopped")
+	if tenant.DeletionTimestamp != nil {
+		return r.finalizeTenant(ctx, log, &tenant)
+	}
 
-	return nil
+	if !hasFinalizer(&tenant, TenantFinalizer) {
+		tenant.Finalizers = append(tenant.Finalizers, TenantFinalizer)
+		if err := r.Update(ctx, &tenant); err != nil {
+			return ctrl.Result{}, fmt.Errorf("Tenant %q add finalizer error: %v", tenant.Name, err)
+		}
+		// The Update above will trigger another reconcile with the finalizer already in place.
+		return ctrl.Result{}, nil
+	}
+
+	if tenant.Status.Phase == "" {
+		tenant.Status.Phase = tenantsapi.TenantPending
+	}
+
+	if err := r.syncRBACForTenant(&tenant); err != nil {
+		r.Recorder.Event(&tenant, corev1.EventTypeWarning, "RBACSyncFailed", err.Error())
+		return ctrl.Result{}, r.persistFailure(ctx, log, &tenant, "RBACSyncFailed", err)
+	}
+
+	if err := r.pruneNamespaces(ctx, log, &tenant); err != nil {
+		return ctrl.Result{}, r.persistFailure(ctx, log, &tenant, "NamespacePruneFailed", err)
+	}
+
+	tenant.Status.Phase = tenantsapi.TenantCreating
+	statuses := make([]tenantsapi.TenantNamespaceStatus, len(tenant.Spec.Namespaces))
+	for i := range tenant.Spec.Namespaces {
+		statuses[i] = r.syncNamespaceForTenant(ctx, log, &tenant, &tenant.Spec.Namespaces[i])
+	}
+
+	allReady := true
+	for _, s := range statuses {
+		if !s.Ready {
+			allReady = false
+			break
+		}
+	}
+	if allReady {
+		tenant.Status.Phase = tenantsapi.TenantActive
+	}
+
+	return ctrl.Result{}, r.updateTenantStatus(ctx, &tenant, statuses)
 }
 
-func (c *Controller) createTenant(obj *tenantsapi.Tenant) {
-	glog.V(2).Infof("createTenant: %#v", obj)
-	if err := c.syncRBACForTenant(obj); err != nil {
-		glog.Error(err)
-		return
+// pruneNamespaces deletes any namespace this tenant owns (identified by TenantLabel) that's no
+// longer listed in tenant.Spec.Namespaces.
+func (r *TenantReconciler) pruneNamespaces(ctx context.Context, log logr.Logger, tenant *tenantsapi.Tenant) error {
+	wanted := make(map[string]bool, len(tenant.Spec.Namespaces))
+	for _, nsReq := range tenant.Spec.Namespaces {
+		wanted[namespaceNameByTenant(tenant, nsReq.Name)] = true
 	}
-	for _, nsReq := range obj.Spec.Namespaces {
-		if err := c.createNamespaceForTenant(obj, &nsReq); err != nil {
-			glog.Error(err)
+
+	var owned corev1.NamespaceList
+	if err := r.List(ctx, &owned, client.MatchingLabels{TenantLabel: tenant.Name}); err != nil {
+		return fmt.Errorf("Tenant %q list owned namespaces error: %v", tenant.Name, err)
+	}
+	for i := range owned.Items {
+		ns := &owned.Items[i]
+		if wanted[ns.Name] || !ns.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, ns); err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, "delete namespace no longer in spec", "namespace", ns.Name)
+			r.Recorder.Eventf(tenant, corev1.EventTypeWarning, "NamespaceDeleteFailed", "namespace %q: %v", ns.Name, err)
+			return fmt.Errorf("Tenant %q delete namespace %q error: %v", tenant.Name, ns.Name, err)
 		}
 	}
+	return nil
 }
 
-func (c *Controller) updateTenant(old, obj *tenantsapi.Tenant) {
-	glog.V(2).Infof("updateTenant: %#v", obj)
-	if err := c.syncRBACForTenant(obj); err != nil {
-		glog.Error(err)
-		return
-	}
-	// sort namespaces in old and new tenants to find out which ones
-	// to be created and which ones to be deleted.
-	oldNsList := make([]string, len(old.Spec.Namespaces))
-	for i, ns := range old.Spec.Namespaces {
-		oldNsList[i] = ns.Name
-	}
-	sort.Strings(oldNsList)
-	nsList := make([]*tenantsapi.TenantNamespace, len(obj.Spec.Namespaces))
-	for i := range obj.Spec.Namespaces {
-		nsList[i] = &obj.Spec.Namespaces[i]
-	}
-	sort.Slice(nsList, func(i, j int) bool {
-		return strings.Compare(nsList[i].Name, nsList[j].Name) < 0
-	})
-	var i, j int
-	for i < len(oldNsList) && j < len(nsList) {
-		if res := strings.Compare(oldNsList[i], nsList[j].Name); res == 0 {
-			if err := c.syncNamespaceForTenant(obj, nsList[j]); err != nil {
-				glog.Error(err)
+// finalizeTenant tears down everything tenant owns according to Spec.DeletionPolicy, then removes
+// TenantFinalizer so the apiserver can actually delete the Tenant. It runs in place of the normal
+// sync logic once DeletionTimestamp is set.
+func (r *TenantReconciler) finalizeTenant(ctx context.Context, log logr.Logger, tenant *tenantsapi.Tenant) (ctrl.Result, error) {
+	if !hasFinalizer(tenant, TenantFinalizer) {
+		// Already finalized.
+		return ctrl.Result{}, nil
+	}
+
+	tenant.Status.Phase = tenantsapi.TenantTerminating
+	switch tenant.Spec.DeletionPolicy {
+	case tenantsapi.TenantDeletionPolicyOrphan:
+		// Leave namespaces and RBAC untouched.
+	case tenantsapi.TenantDeletionPolicyRetain:
+		for _, nsReq := range tenant.Spec.Namespaces {
+			if err := r.detachNamespaceForTenant(ctx, tenant, nsReq.Name); err != nil {
+				return ctrl.Result{}, err
 			}
-			i++
-			j++
-		} else if res < 0 {
-			if err := c.deleteNamespaceForTenant(obj, oldNsList[i]); err != nil {
-				glog.Error(err)
+		}
+		if err := r.deleteRBACForTenant(tenant); err != nil {
+			return ctrl.Result{}, err
+		}
+	default:
+		nsNames := make([]string, len(tenant.Spec.Namespaces))
+		for i, nsReq := range tenant.Spec.Namespaces {
+			nsNames[i] = namespaceNameByTenant(tenant, nsReq.Name)
+			if err := r.deleteNamespaceForTenant(ctx, tenant, nsReq.Name); err != nil {
+				return ctrl.Result{}, err
 			}
-			i++
-		} else {
-			if err := c.createNamespaceForTenant(obj, nsList[j]); err != nil {
-				glog.Error(err)
+		}
+		terminated, err := r.namespacesTerminated(ctx, nsNames)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("Tenant %q check namespaces terminated error: %v", tenant.Name, err)
+		}
+		if !terminated {
+			if time.Since(tenant.DeletionTimestamp.Time) > waitForNamespacesTerminatedTimeout {
+				return ctrl.Result{}, fmt.Errorf("Tenant %q namespaces did not terminate within %s", tenant.Name, waitForNamespacesTerminatedTimeout)
 			}
-			j++
+			return ctrl.Result{RequeueAfter: waitForNamespacesTerminatedInterval}, nil
 		}
-	}
-
-	for ; j < len(nsList); j++ {
-		if err := c.createNamespaceForTenant(obj, nsList[j]); err != nil {
-			glog.Error(err)
+		if err := r.deleteRBACForTenant(tenant); err != nil {
+			return ctrl.Result{}, err
 		}
 	}
-	for ; i < len(oldNsList); i++ {
-		if err := c.deleteNamespaceForTenant(obj, oldNsList[i]); err != nil {
-			glog.Error(err)
-		}
+
+	tenant.Finalizers = removeFinalizer(tenant.Finalizers, TenantFinalizer)
+	if err := r.Update(ctx, tenant); err != nil {
+		return ctrl.Result{}, fmt.Errorf("Tenant %q remove finalizer error: %v", tenant.Name, err)
 	}
+	log.Info("finalized tenant")
+	return ctrl.Result{}, nil
 }
 
-func (c *Controller) deleteTenant(obj *tenantsapi.Tenant) {
-	glog.V(2).Infof("deleteTenant: %#v", obj)
-
-	// TODO with OwnerReferences, no extra work is needed in deletion,
-	// remove the following code later.
+// detachNamespaceForTenant strips tenant's label and OwnerReference from the namespace, used by
+// TenantDeletionPolicyRetain so the namespace survives the Tenant's deletion instead of being
+// garbage-collected via its OwnerReference.
+func (r *TenantReconciler) detachNamespaceForTenant(ctx context.Context, tenant *tenantsapi.Tenant, nsName string) error {
+	fullName := namespaceNameByTenant(tenant, nsName)
+	var ns corev1.Namespace
+	if err := r.Get(ctx, client.ObjectKey{Name: fullName}, &ns); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Tenant %q get namespace %q error: %v", tenant.Name, fullName, err)
+	}
 
-	c.deleteRBACForTenant(obj)
-	for _, nsReq := range obj.Spec.Namespaces {
-		c.deleteNamespaceForTenant(obj, nsReq.Name)
+	delete(ns.Labels, TenantLabel)
+	ns.OwnerReferences = removeOwnerRefsForTenant(tenant, ns.OwnerReferences)
+	if err := r.Update(ctx, &ns); err != nil {
+		return fmt.Errorf("Tenant %q detach namespace %q error: %v", tenant.Name, fullName, err)
 	}
+	return nil
 }
 
-func (c *Controller) addNsTemplate(obj *tenantsapi.NamespaceTemplate) {
-	c.updateNsTemplate(nil, obj)
+// namespacesTerminated reports whether none of names exist anymore, so RBAC isn't revoked while
+// workloads in those namespaces might still be running. It checks once rather than blocking until
+// they're gone: finalizeTenant requeues instead when they're not, so a Tenant with slow-
+// terminating namespaces doesn't stall reconciliation of every other Tenant for up to
+// waitForNamespacesTerminatedTimeout - the default controller-runtime manager only runs one
+// reconcile at a time.
+func (r *TenantReconciler) namespacesTerminated(ctx context.Context, names []string) (bool, error) {
+	for _, name := range names {
+		var ns corev1.Namespace
+		err := r.Get(ctx, client.ObjectKey{Name: name}, &ns)
+		if err == nil {
+			return false, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	return true, nil
 }
 
-func (c *Controller) updateNsTemplate(old, obj *tenantsapi.NamespaceTemplate) {
-	tpl, err := decodeNsTemplate(obj)
-	if err != nil {
-		glog.Error(err)
-		// TODO report error.
-		return
-	}
-	c.nsTemplatesLock.Lock()
-	c.nsTemplates[obj.Name] = tpl
-	c.nsTemplatesLock.Unlock()
-	glog.V(2).Infof("updated NamespaceTemplate: %s", obj.Name)
+// hasFinalizer returns true if finalizer is present in tenant.Finalizers.
+func hasFinalizer(tenant *tenantsapi.Tenant, finalizer string) bool {
+	for _, f := range tenant.Finalizers {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
 }
 
-func (c *Controller) deleteNsTemplate(obj *tenantsapi.NamespaceTemplate) {
-	c.nsTemplatesLock.Lock()
-	delete(c.nsTemplates, obj.Name)
-	c.nsTemplatesLock.Unlock()
-	glog.V(2).Infof("deleted NamespaceTemplate: %s", obj.Name)
+// removeFinalizer returns finalizers with finalizer removed.
+func removeFinalizer(finalizers []string, finalizer string) []string {
+	kept := make([]string, 0, len(finalizers))
+	for _, f := range finalizers {
+		if f != finalizer {
+			kept = append(kept, f)
+		}
+	}
+	return kept
 }
 
-func (c *Controller) getNsTemplate(name string) (*namespaceTemplate, error) {
-	c.nsTemplatesLock.RLock()
-	tpl := c.nsTemplates[name]
-	c.nsTemplatesLock.RUnlock()
-	if tpl != nil {
-		return tpl, nil
-	}
-	// if not found, possibly it's still being synced.
-	// use API to get the object.
-	obj, err := c.tenantsclient.TenantsV1alpha1().NamespaceTemplates().Get(name, metav1.GetOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("get NamespaceTemplate %q error: %v", name, err)
+// removeOwnerRefsForTenant returns refs with any OwnerReference to tenant removed.
+func removeOwnerRefsForTenant(tenant *tenantsapi.Tenant, refs []metav1.OwnerReference) []metav1.OwnerReference {
+	kept := make([]metav1.OwnerReference, 0, len(refs))
+	for _, r := range refs {
+		if r.UID != tenant.UID {
+			kept = append(kept, r)
+		}
 	}
-	return decodeNsTemplate(obj)
+	return kept
 }
 
-func (c *Controller) createNamespaceForTenant(tenant *tenantsapi.Tenant, nsReq *tenantsapi.TenantNamespace) error {
-	if err := c.ensureNamespaceExists(tenant, nsReq.Name); err != nil {
-		// TODO update status.
-		return err
-	}
-	if err := c.syncNamespaceForTenant(tenant, nsReq); err != nil {
-		// TODO update status.
-		return err
+// getNsTemplate fetches and decodes the named NamespaceTemplate through the manager's cached
+// client.
+func (r *TenantReconciler) getNsTemplate(ctx context.Context, name string) (*namespaceTemplate, error) {
+	var obj tenantsapi.NamespaceTemplate
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, &obj); err != nil {
+		return nil, fmt.Errorf("get NamespaceTemplate %q error: %v", name, err)
 	}
-	return nil
+	return decodeNsTemplate(&obj)
 }
 
-func (c *Controller) deleteNamespaceForTenant(tenant *tenantsapi.Tenant, nsName string) error {
+func (r *TenantReconciler) deleteNamespaceForTenant(ctx context.Context, tenant *tenantsapi.Tenant, nsName string) error {
 	// TODO add a full set of sanity checks in future before deleting
-	if err := c.k8sclient.CoreV1().Namespaces().Delete(namespaceNameByTenant(tenant, nsName), nil); err != nil {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespaceNameByTenant(tenant, nsName)}}
+	if err := r.Delete(ctx, ns); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("Tenant %q delete namespace %q error: %v", tenant.Name, nsName, err)
 	}
 	return nil
 }
 
-func (c *Controller) ensureNamespaceExists(tenant *tenantsapi.Tenant, nsName string) error {
-	// TODO Add later ... sanity checks to ensure namespaces being requested are valid and not already assigned to another tenant
+// ensureNamespaceExists makes sure the namespace requested by nsReq exists and is owned by tenant.
+// If the namespace doesn't exist yet, it's created. If it already exists and is unowned, it's
+// either adopted (if nsReq.Adopt is set) or rejected as a conflict, so a stray pre-existing
+// namespace can never be silently absorbed into a tenant. If it's already owned by a different
+// tenant, that's always a conflict, adoption or not.
+func (r *TenantReconciler) ensureNamespaceExists(ctx context.Context, tenant *tenantsapi.Tenant, nsReq *tenantsapi.TenantNamespace) error {
+	nsName := namespaceNameByTenant(tenant, nsReq.Name)
+	var existing corev1.Namespace
+	err := r.Get(ctx, client.ObjectKey{Name: nsName}, &existing)
+	if apierrors.IsNotFound(err) {
+		return r.createNamespace(tenant, nsName)
+	}
+	if err != nil {
+		return fmt.Errorf("Tenant %q get namespace %q error: %v", tenant.Name, nsName, err)
+	}
+
+	switch owner := existing.Labels[TenantLabel]; {
+	case owner == tenant.Name:
+		// Already ours, e.g. from a previous reconcile - nothing to do.
+		return nil
+	case owner != "":
+		return fmt.Errorf("Tenant %q cannot use namespace %q: already owned by tenant %q", tenant.Name, nsName, owner)
+	case !nsReq.Adopt:
+		return fmt.Errorf("Tenant %q cannot use namespace %q: namespace already exists and is not owned by any tenant; set adopt: true to take ownership of it", tenant.Name, nsName)
+	}
+
+	report := adoptionReport(tenant, &existing)
+	if tenant.Spec.AdoptionDryRun {
+		r.Log.Info("dry-run adoption", "tenant", tenant.Name, "namespace", nsName, "changes", report)
+		return nil
+	}
+	r.Log.Info("adopting namespace", "tenant", tenant.Name, "namespace", nsName, "changes", report)
+	return r.adoptNamespace(ctx, tenant, &existing)
+}
+
+// createNamespace creates a brand new namespace owned by tenant.
+func (r *TenantReconciler) createNamespace(tenant *tenantsapi.Tenant, nsName string) error {
 	if err := newKubeCtl().addObjects(&corev1.Namespace{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: corev1.SchemeGroupVersion.String(),
 			Kind:       "Namespace",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            namespaceNameByTenant(tenant, nsName),
+			Name:            nsName,
+			Labels:          map[string]string{TenantLabel: tenant.Name},
 			OwnerReferences: ownerRefsForTenant(tenant),
 		},
 	}).apply(); err != nil {
@@ -252,16 +359,83 @@ func (c *Controller) ensureNamespaceExists(tenant *tenantsapi.Tenant, nsName str
 	return nil
 }
 
-func (c *Controller) syncNamespaceForTenant(tenant *tenantsapi.Tenant, nsReq *tenantsapi.TenantNamespace) error {
-	kubectl := newKubeCtl().withNamespace(namespaceNameByTenant(tenant, nsReq.Name))
-	if nsReq.Template != "" {
-		tpl, err := c.getNsTemplate(nsReq.Template)
-		if err != nil {
-			return fmt.Errorf("get NamespaceTemplate %q error: %v", nsReq.Template, err)
-		}
-		kubectl.addObjects(tpl.objects...)
+// adoptNamespace takes ownership of a pre-existing, unowned namespace by adding the tenant's
+// label and OwnerReference to it. Unlike createNamespace, this doesn't go through kubectl apply:
+// adoption must only ever add the tenant's own label and owner reference, never touch anything
+// else already on the namespace, so an explicit read-modify-write is used instead.
+func (r *TenantReconciler) adoptNamespace(ctx context.Context, tenant *tenantsapi.Tenant, ns *corev1.Namespace) error {
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	ns.Labels[TenantLabel] = tenant.Name
+	ns.OwnerReferences = append(ns.OwnerReferences, ownerRefsForTenant(tenant)...)
+	if err := r.Update(ctx, ns); err != nil {
+		return fmt.Errorf("Tenant %q adopt namespace %q error: %v", tenant.Name, ns.Name, err)
+	}
+	return nil
+}
+
+// adoptionReport describes, in human-readable form, the changes that adopting ns into tenant
+// would make. It's used both to log real adoptions and to report what a dry-run adoption
+// (Spec.AdoptionDryRun) would have changed.
+func adoptionReport(tenant *tenantsapi.Tenant, ns *corev1.Namespace) string {
+	return fmt.Sprintf("  set label %s=%s\n  add OwnerReference to Tenant %q", TenantLabel, tenant.Name, tenant.Name)
+}
+
+// syncNamespaceForTenant makes sure nsReq's namespace exists (creating or adopting it if needed)
+// and applies its template and the tenant's admin RBAC, reporting progress as a
+// TenantNamespaceStatus. Failures at each step are surfaced both in the returned status and as
+// Events on tenant, so provisioning problems are visible without having to read Tenant.Status.
+func (r *TenantReconciler) syncNamespaceForTenant(ctx context.Context, log logr.Logger, tenant *tenantsapi.Tenant, nsReq *tenantsapi.TenantNamespace) tenantsapi.TenantNamespaceStatus {
+	status := tenantsapi.TenantNamespaceStatus{Name: nsReq.Name}
+
+	if err := r.ensureNamespaceExists(ctx, tenant, nsReq); err != nil {
+		log.Error(err, "ensure namespace exists", "namespace", nsReq.Name)
+		r.Recorder.Eventf(tenant, corev1.EventTypeWarning, "NamespaceProvisionFailed", "%v", err)
+		status.Message = err.Error()
+		return status
+	}
+	status.NamespaceCreated = true
+
+	if err := r.applyNamespaceTemplate(ctx, tenant, nsReq); err != nil {
+		log.Error(err, "apply namespace template", "namespace", nsReq.Name)
+		r.Recorder.Eventf(tenant, corev1.EventTypeWarning, "TemplateApplyFailed", "%v", err)
+		status.Message = err.Error()
+		return status
+	}
+	status.QuotaApplied = true
+
+	if err := r.applyNamespaceRBAC(tenant, nsReq); err != nil {
+		log.Error(err, "apply namespace RBAC", "namespace", nsReq.Name)
+		r.Recorder.Eventf(tenant, corev1.EventTypeWarning, "RBACApplyFailed", "%v", err)
+		status.Message = err.Error()
+		return status
 	}
-	kubectl.addObjects(&rbacv1.RoleBinding{
+	status.RBACApplied = true
+	status.Ready = true
+	return status
+}
+
+// applyNamespaceTemplate applies nsReq.Template's objects (e.g. NetworkPolicies, ResourceQuotas)
+// into the namespace, if a template was requested.
+func (r *TenantReconciler) applyNamespaceTemplate(ctx context.Context, tenant *tenantsapi.Tenant, nsReq *tenantsapi.TenantNamespace) error {
+	if nsReq.Template == "" {
+		return nil
+	}
+	tpl, err := r.getNsTemplate(ctx, nsReq.Template)
+	if err != nil {
+		return fmt.Errorf("get NamespaceTemplate %q error: %v", nsReq.Template, err)
+	}
+	kubectl := newKubeCtl().withNamespace(namespaceNameByTenant(tenant, nsReq.Name)).addObjects(tpl.objects...)
+	if err := kubectl.apply(); err != nil {
+		return fmt.Errorf("Tenant %q namespace %q apply template %q error: %v", tenant.Name, nsReq.Name, nsReq.Template, err)
+	}
+	return nil
+}
+
+// applyNamespaceRBAC applies the tenant's admin RoleBinding to the namespace.
+func (r *TenantReconciler) applyNamespaceRBAC(tenant *tenantsapi.Tenant, nsReq *tenantsapi.TenantNamespace) error {
+	kubectl := newKubeCtl().withNamespace(namespaceNameByTenant(tenant, nsReq.Name)).addObjects(&rbacv1.RoleBinding{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: rbacv1.SchemeGroupVersion.String(),
 			Kind:       "RoleBinding",
@@ -277,19 +451,87 @@ func (c *Controller) syncNamespaceForTenant(tenant *tenantsapi.Tenant, nsReq *te
 		},
 	})
 	if err := kubectl.apply(); err != nil {
-		return fmt.Errorf("Tenant %q namespace %q sync error: %v", tenant.Name, nsReq.Name, err)
+		return fmt.Errorf("Tenant %q namespace %q apply RBAC error: %v", tenant.Name, nsReq.Name, err)
+	}
+	return nil
+}
+
+// updateTenantStatus reports the given per-namespace statuses on tenant, along with the
+// NamespacesReady, RBACReady and aggregate Ready conditions and the generation that was processed
+// to produce them, so automation can reliably wait for tenant provisioning to complete.
+func (r *TenantReconciler) updateTenantStatus(ctx context.Context, tenant *tenantsapi.Tenant, statuses []tenantsapi.TenantNamespaceStatus) error {
+	tenant.Status.ObservedGeneration = tenant.Generation
+	tenant.Status.NamespaceStatuses = statuses
+	tenant.Status.Conditions = []tenantsapi.TenantCondition{
+		tenantCondition(tenantsapi.TenantNamespacesReady, statuses, tenant.Status.Conditions,
+			func(s tenantsapi.TenantNamespaceStatus) bool { return s.NamespaceCreated && s.QuotaApplied },
+			"AllNamespacesReady", "NamespacesNotReady"),
+		tenantCondition(tenantsapi.TenantRBACReady, statuses, tenant.Status.Conditions,
+			func(s tenantsapi.TenantNamespaceStatus) bool { return s.RBACApplied },
+			"AllRBACApplied", "RBACNotReady"),
+		tenantCondition(tenantsapi.TenantReady, statuses, tenant.Status.Conditions,
+			func(s tenantsapi.TenantNamespaceStatus) bool { return s.Ready },
+			"AllNamespacesReady", "NamespacesNotReady"),
+	}
+	if err := r.Status().Update(ctx, tenant); err != nil {
+		return fmt.Errorf("Tenant %q update status error: %v", tenant.Name, err)
 	}
 	return nil
 }
 
-func (c *Controller) syncRBACForTenant(tenant *tenantsapi.Tenant) error {
+// persistFailure records reason and the error that caused it on tenant.Status before returning
+// err unchanged, so a tenant stuck failing a reconcile at syncRBACForTenant or pruneNamespaces -
+// before there are any per-namespace statuses to report via updateTenantStatus - still leaves
+// something for automation watching Status.Phase to see, rather than only an Event that ages out
+// of `kubectl describe` in about an hour. The status write is best-effort: a failure to persist it
+// is logged but doesn't shadow err, since err is the one that actually needs retrying.
+func (r *TenantReconciler) persistFailure(ctx context.Context, log logr.Logger, tenant *tenantsapi.Tenant, reason string, err error) error {
+	tenant.Status.Phase = tenantsapi.TenantFailed
+	tenant.Status.Reason = reason
+	tenant.Status.Message = err.Error()
+	if updateErr := r.Status().Update(ctx, tenant); updateErr != nil {
+		log.Error(updateErr, "persist failure status", "reason", reason)
+	}
+	return err
+}
+
+// tenantCondition computes a TenantCondition of type condType from statuses, considering it True
+// only if ready holds for every namespace status, and preserving LastTransitionTime from prior if
+// its status hasn't changed.
+func tenantCondition(condType tenantsapi.TenantConditionType, statuses []tenantsapi.TenantNamespaceStatus, prior []tenantsapi.TenantCondition, ready func(tenantsapi.TenantNamespaceStatus) bool, readyReason, notReadyReason string) tenantsapi.TenantCondition {
+	cond := tenantsapi.TenantCondition{
+		Type:    condType,
+		Status:  corev1.ConditionTrue,
+		Reason:  readyReason,
+		Message: fmt.Sprintf("all tenant namespaces satisfy %s", strings.ToLower(string(condType))),
+	}
+	for _, s := range statuses {
+		if !ready(s) {
+			cond.Status = corev1.ConditionFalse
+			cond.Reason = notReadyReason
+			cond.Message = fmt.Sprintf("namespace %q is not ready: %s", s.Name, s.Message)
+			break
+		}
+	}
+
+	cond.LastTransitionTime = metav1.Now()
+	for _, p := range prior {
+		if p.Type == condType && p.Status == cond.Status {
+			cond.LastTransitionTime = p.LastTransitionTime
+			break
+		}
+	}
+	return cond
+}
+
+func (r *TenantReconciler) syncRBACForTenant(tenant *tenantsapi.Tenant) error {
 	if err := newKubeCtl().addObjects(rbacForTenant(tenant)...).apply(); err != nil {
 		return fmt.Errorf("Tenant %q syncRBAC error: %v", tenant.Name, err)
 	}
 	return nil
 }
 
-func (c *Controller) deleteRBACForTenant(tenant *tenantsapi.Tenant) error {
+func (r *TenantReconciler) deleteRBACForTenant(tenant *tenantsapi.Tenant) error {
 	if err := newKubeCtl().addObjects(rbacForTenant(tenant)...).delete(); err != nil {
 		return fmt.Errorf("Tenant %q deleteRBAC error: %v", tenant.Name, err)
 	}
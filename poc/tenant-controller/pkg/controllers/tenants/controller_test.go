@@ -0,0 +1,188 @@
+// Copyright 2017 The Kubernetes Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//     http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenants
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8srt "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	tenantsapi "sigs.k8s.io/multi-tenancy/poc/tenant-controller/pkg/apis/tenants/v1alpha1"
+)
+
+var testLog logr.Logger = ctrl.Log
+
+func newTestReconciler(objs ...k8srt.Object) *TenantReconciler {
+	s := scheme.Scheme
+	if err := tenantsapi.AddToScheme(s); err != nil {
+		panic(err)
+	}
+	return &TenantReconciler{
+		Client:   fake.NewFakeClientWithScheme(s, objs...),
+		Log:      testLog,
+		Recorder: record.NewFakeRecorder(10),
+	}
+}
+
+func TestNamespacesTerminated(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "t1-ns1"}}
+	r := newTestReconciler(ns)
+
+	terminated, err := r.namespacesTerminated(context.Background(), []string{"t1-ns1"})
+	if err != nil {
+		t.Fatalf("namespacesTerminated() error = %v", err)
+	}
+	if terminated {
+		t.Error("namespacesTerminated() = true, want false: t1-ns1 still exists")
+	}
+
+	terminated, err = r.namespacesTerminated(context.Background(), []string{"t1-ns-gone"})
+	if err != nil {
+		t.Fatalf("namespacesTerminated() error = %v", err)
+	}
+	if !terminated {
+		t.Error("namespacesTerminated() = false, want true: t1-ns-gone doesn't exist")
+	}
+}
+
+func TestFinalizeTenant_AlreadyFinalized(t *testing.T) {
+	r := newTestReconciler()
+	tenant := &tenantsapi.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "t1"}}
+
+	res, err := r.finalizeTenant(context.Background(), testLog, tenant)
+	if err != nil {
+		t.Fatalf("finalizeTenant() error = %v", err)
+	}
+	if res.RequeueAfter != 0 {
+		t.Errorf("finalizeTenant() RequeueAfter = %v, want 0", res.RequeueAfter)
+	}
+}
+
+func TestFinalizeTenant_OrphanPolicyRemovesFinalizer(t *testing.T) {
+	now := metav1.Now()
+	tenant := &tenantsapi.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "t1",
+			Finalizers:        []string{TenantFinalizer},
+			DeletionTimestamp: &now,
+		},
+		Spec: tenantsapi.TenantSpec{
+			Namespaces:     []tenantsapi.TenantNamespace{{Name: "ns1"}},
+			DeletionPolicy: tenantsapi.TenantDeletionPolicyOrphan,
+		},
+	}
+	r := newTestReconciler(tenant)
+
+	res, err := r.finalizeTenant(context.Background(), testLog, tenant)
+	if err != nil {
+		t.Fatalf("finalizeTenant() error = %v", err)
+	}
+	if res.RequeueAfter != 0 {
+		t.Errorf("finalizeTenant() RequeueAfter = %v, want 0", res.RequeueAfter)
+	}
+	if hasFinalizer(tenant, TenantFinalizer) {
+		t.Error("finalizeTenant() left TenantFinalizer in place")
+	}
+
+	var ns corev1.Namespace
+	if err := r.Get(context.Background(), client.ObjectKey{Name: "t1-ns1"}, &ns); !apierrors.IsNotFound(err) {
+		t.Errorf("finalizeTenant() with orphan policy should never have created/touched ns1, got err = %v", err)
+	}
+}
+
+func TestPruneNamespaces(t *testing.T) {
+	tenant := &tenantsapi.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "t1"},
+		Spec:       tenantsapi.TenantSpec{Namespaces: []tenantsapi.TenantNamespace{{Name: "keep"}}},
+	}
+	keep := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name: "t1-keep", Labels: map[string]string{TenantLabel: "t1"},
+	}}
+	drop := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name: "t1-drop", Labels: map[string]string{TenantLabel: "t1"},
+	}}
+	r := newTestReconciler(tenant, keep, drop)
+
+	if err := r.pruneNamespaces(context.Background(), testLog, tenant); err != nil {
+		t.Fatalf("pruneNamespaces() error = %v", err)
+	}
+
+	var ns corev1.Namespace
+	if err := r.Get(context.Background(), client.ObjectKey{Name: "t1-keep"}, &ns); err != nil {
+		t.Errorf("pruneNamespaces() removed t1-keep, which is still in Spec.Namespaces: %v", err)
+	}
+	if err := r.Get(context.Background(), client.ObjectKey{Name: "t1-drop"}, &ns); !apierrors.IsNotFound(err) {
+		t.Errorf("pruneNamespaces() kept t1-drop, which is no longer in Spec.Namespaces, err = %v", err)
+	}
+}
+
+func TestPersistFailure(t *testing.T) {
+	tenant := &tenantsapi.Tenant{ObjectMeta: metav1.ObjectMeta{Name: "t1"}}
+	r := newTestReconciler(tenant)
+	cause := errors.New("boom")
+
+	err := r.persistFailure(context.Background(), testLog, tenant, "RBACSyncFailed", cause)
+	if err != cause {
+		t.Errorf("persistFailure() error = %v, want the original error unchanged", err)
+	}
+
+	var got tenantsapi.Tenant
+	if err := r.Get(context.Background(), client.ObjectKey{Name: "t1"}, &got); err != nil {
+		t.Fatalf("Get tenant after persistFailure() error = %v", err)
+	}
+	if got.Status.Phase != tenantsapi.TenantFailed {
+		t.Errorf("Status.Phase = %q, want %q", got.Status.Phase, tenantsapi.TenantFailed)
+	}
+	if got.Status.Reason != "RBACSyncFailed" {
+		t.Errorf("Status.Reason = %q, want %q", got.Status.Reason, "RBACSyncFailed")
+	}
+	if got.Status.Message != cause.Error() {
+		t.Errorf("Status.Message = %q, want %q", got.Status.Message, cause.Error())
+	}
+}
+
+func TestTenantCondition(t *testing.T) {
+	readyFn := func(s tenantsapi.TenantNamespaceStatus) bool { return s.Ready }
+
+	allReady := []tenantsapi.TenantNamespaceStatus{{Name: "ns1", Ready: true}}
+	cond := tenantCondition(tenantsapi.TenantReady, allReady, nil, readyFn, "AllReady", "NotReady")
+	if cond.Status != corev1.ConditionTrue {
+		t.Errorf("cond.Status = %v, want True when every namespace is ready", cond.Status)
+	}
+
+	notReady := []tenantsapi.TenantNamespaceStatus{{Name: "ns1", Ready: false, Message: "boom"}}
+	cond = tenantCondition(tenantsapi.TenantReady, notReady, nil, readyFn, "AllReady", "NotReady")
+	if cond.Status != corev1.ConditionFalse {
+		t.Errorf("cond.Status = %v, want False when a namespace is not ready", cond.Status)
+	}
+	if cond.Reason != "NotReady" {
+		t.Errorf("cond.Reason = %q, want %q", cond.Reason, "NotReady")
+	}
+
+	prior := []tenantsapi.TenantCondition{{Type: tenantsapi.TenantReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(time.Unix(0, 0))}}
+	cond = tenantCondition(tenantsapi.TenantReady, allReady, prior, readyFn, "AllReady", "NotReady")
+	if !cond.LastTransitionTime.Equal(&prior[0].LastTransitionTime) {
+		t.Errorf("cond.LastTransitionTime = %v, want unchanged %v since status didn't flip", cond.LastTransitionTime, prior[0].LastTransitionTime)
+	}
+}
@@ -38,12 +38,47 @@ type TenantSpec struct {
 	// TenantAdmins are the identities with admin privilege for tenant resources.
 	// +optional
 	TenantAdmins []rbacv1.Subject `json:"tenantAdmins,omitempty"`
+	// NetworkIsolation declares the default NetworkPolicy that the controller materializes and
+	// keeps in sync in every namespace belonging to the tenant. If nil, the controller does not
+	// manage NetworkPolicies for this tenant.
+	// +optional
+	NetworkIsolation *NetworkIsolationSpec `json:"networkIsolation,omitempty"`
+}
+
+// NetworkIsolationSpec declares the network isolation policy a tenant wants enforced across all
+// of its namespaces.
+type NetworkIsolationSpec struct {
+	// DenyAllDefault, if true, tells the controller to deny all ingress traffic, and all egress
+	// traffic not otherwise allowed by AllowedEgressCIDRs, in every namespace belonging to the
+	// tenant.
+	// +optional
+	DenyAllDefault bool `json:"denyAllDefault,omitempty"`
+	// AllowedEgressCIDRs lists the CIDR blocks that egress traffic is still allowed to reach when
+	// DenyAllDefault is set. If empty, DenyAllDefault blocks all egress too.
+	// +optional
+	AllowedEgressCIDRs []string `json:"allowedEgressCIDRs,omitempty"`
 }
 
 // TenantStatus defines the observed state of Tenant
 type TenantStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// NetworkPolicyStatus reports, for each namespace belonging to the tenant, whether the
+	// NetworkPolicy materialized from Spec.NetworkIsolation is in place and matches the desired
+	// state as of the most recent reconcile.
+	// +optional
+	NetworkPolicyStatus []NetworkPolicyNamespaceStatus `json:"networkPolicyStatus,omitempty"`
+}
+
+// NetworkPolicyNamespaceStatus reports the state of a tenant namespace's default NetworkPolicy.
+type NetworkPolicyNamespaceStatus struct {
+	// Namespace is the tenant namespace this status applies to.
+	Namespace string `json:"namespace"`
+	// Enforced is true if the namespace's NetworkPolicy already matched Spec.NetworkIsolation
+	// when last observed. It's false if the NetworkPolicy was missing or had drifted from the
+	// desired state and had to be created or corrected.
+	Enforced bool `json:"enforced"`
+	// Message explains why Enforced is false. Empty when Enforced is true.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +genclient:nonNamespaced
@@ -30,7 +30,7 @@ func (in *Tenant) DeepCopyInto(out *Tenant) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -186,6 +186,11 @@ func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 		*out = make([]v1.Subject, len(*in))
 		copy(*out, *in)
 	}
+	if in.NetworkIsolation != nil {
+		in, out := &in.NetworkIsolation, &out.NetworkIsolation
+		*out = new(NetworkIsolationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -202,6 +207,11 @@ func (in *TenantSpec) DeepCopy() *TenantSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantStatus) DeepCopyInto(out *TenantStatus) {
 	*out = *in
+	if in.NetworkPolicyStatus != nil {
+		in, out := &in.NetworkPolicyStatus, &out.NetworkPolicyStatus
+		*out = make([]NetworkPolicyNamespaceStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -214,3 +224,40 @@ func (in *TenantStatus) DeepCopy() *TenantStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkIsolationSpec) DeepCopyInto(out *NetworkIsolationSpec) {
+	*out = *in
+	if in.AllowedEgressCIDRs != nil {
+		in, out := &in.AllowedEgressCIDRs, &out.AllowedEgressCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkIsolationSpec.
+func (in *NetworkIsolationSpec) DeepCopy() *NetworkIsolationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkIsolationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyNamespaceStatus) DeepCopyInto(out *NetworkPolicyNamespaceStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyNamespaceStatus.
+func (in *NetworkPolicyNamespaceStatus) DeepCopy() *NetworkPolicyNamespaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicyNamespaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -19,13 +19,17 @@ package tenant
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	tenancyv1alpha1 "github.com/kubernetes-sigs/multi-tenancy/tenant/pkg/apis/tenancy/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -74,6 +78,15 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	if err != nil {
 		return err
 	}
+
+	// Watch for changes to the default NetworkPolicy materialized in each tenant namespace, so
+	// that drift introduced by someone editing or deleting it directly gets corrected.
+	err = c.Watch(&source.Kind{Type: &networkingv1.NetworkPolicy{}}, &handler.EnqueueRequestForOwner{
+		OwnerType: &tenancyv1alpha1.Tenant{},
+	})
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -107,6 +120,8 @@ func (r *ReconcileTenant) clientApply(obj runtime.Object) error {
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;create;update;patch
 // +kubebuilder:rbac:groups=tenancy.x-k8s.io,resources=tenants,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=tenancy.x-k8s.io,resources=tenants/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=tenancy.x-k8s.io,resources=tenantnamespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch
 func (r *ReconcileTenant) Reconcile(request reconcile.Request) (reconcile.Result, error) {
 	// Fetch the Tenant instance
 	instance := &tenancyv1alpha1.Tenant{}
@@ -267,5 +282,119 @@ func (r *ReconcileTenant) Reconcile(request reconcile.Request) (reconcile.Result
 		}
 	}
 
+	// Materialize and reconcile the default NetworkPolicy declared by Spec.NetworkIsolation in
+	// every namespace belonging to the tenant.
+	if instance.Spec.NetworkIsolation != nil {
+		nsStatus, err := r.reconcileNetworkPolicies(instance, expectedOwnerRef)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		instanceClone := instance.DeepCopy()
+		err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			instanceClone.Status.NetworkPolicyStatus = nsStatus
+			updateErr := r.Update(context.TODO(), instanceClone)
+			if updateErr == nil {
+				return nil
+			}
+			if err := r.Get(context.TODO(), types.NamespacedName{Name: instance.Name}, instanceClone); err != nil {
+				log.Info("Fail to fetch tenant CR on status update failure", "tenant", instance.Name)
+			}
+			return updateErr
+		})
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	return reconcile.Result{}, nil
 }
+
+// tenantNetworkPolicyName is the name of the default NetworkPolicy the tenant controller
+// materializes into every namespace belonging to a tenant that declares Spec.NetworkIsolation.
+const tenantNetworkPolicyName = "tenant-default-network-policy"
+
+// tenantNamespaces returns the tenantAdminNamespace plus every namespace owned by a
+// TenantNamespace CR living in it, i.e. every namespace belonging to the tenant.
+func (r *ReconcileTenant) tenantNamespaces(instance *tenancyv1alpha1.Tenant) ([]string, error) {
+	namespaces := []string{}
+	if instance.Spec.TenantAdminNamespaceName != "" {
+		namespaces = append(namespaces, instance.Spec.TenantAdminNamespaceName)
+	}
+	tnsList := &tenancyv1alpha1.TenantNamespaceList{}
+	if err := r.List(context.TODO(), tnsList, &client.ListOptions{Namespace: instance.Spec.TenantAdminNamespaceName}); err != nil {
+		return nil, err
+	}
+	for _, each := range tnsList.Items {
+		if each.Status.OwnedNamespace != "" {
+			namespaces = append(namespaces, each.Status.OwnedNamespace)
+		}
+	}
+	return namespaces, nil
+}
+
+// networkPolicySpec builds the NetworkPolicySpec that enforces a NetworkIsolationSpec: all
+// ingress is denied, and egress is denied except to the listed CIDRs, if any.
+func networkPolicySpec(isolation *tenancyv1alpha1.NetworkIsolationSpec) networkingv1.NetworkPolicySpec {
+	spec := networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+	}
+	if len(isolation.AllowedEgressCIDRs) > 0 {
+		peers := make([]networkingv1.NetworkPolicyPeer, 0, len(isolation.AllowedEgressCIDRs))
+		for _, cidr := range isolation.AllowedEgressCIDRs {
+			peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+		}
+		spec.Egress = []networkingv1.NetworkPolicyEgressRule{{To: peers}}
+	}
+	return spec
+}
+
+// reconcileNetworkPolicies creates or corrects the default NetworkPolicy in every namespace
+// belonging to the tenant, and reports per-namespace drift in the returned status.
+func (r *ReconcileTenant) reconcileNetworkPolicies(instance *tenancyv1alpha1.Tenant, ownerRef metav1.OwnerReference) ([]tenancyv1alpha1.NetworkPolicyNamespaceStatus, error) {
+	namespaces, err := r.tenantNamespaces(instance)
+	if err != nil {
+		return nil, err
+	}
+	desiredSpec := networkPolicySpec(instance.Spec.NetworkIsolation)
+
+	statuses := make([]tenancyv1alpha1.NetworkPolicyNamespaceStatus, 0, len(namespaces))
+	for _, ns := range namespaces {
+		desired := &networkingv1.NetworkPolicy{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: networkingv1.SchemeGroupVersion.String(),
+				Kind:       "NetworkPolicy",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            tenantNetworkPolicyName,
+				Namespace:       ns,
+				OwnerReferences: []metav1.OwnerReference{ownerRef},
+			},
+			Spec: desiredSpec,
+		}
+
+		existing := &networkingv1.NetworkPolicy{}
+		getErr := r.Get(context.TODO(), types.NamespacedName{Name: tenantNetworkPolicyName, Namespace: ns}, existing)
+		switch {
+		case errors.IsNotFound(getErr):
+			if err := r.Client.Create(context.TODO(), desired); err != nil {
+				return nil, err
+			}
+			statuses = append(statuses, tenancyv1alpha1.NetworkPolicyNamespaceStatus{Namespace: ns, Enforced: true})
+		case getErr != nil:
+			return nil, getErr
+		case reflect.DeepEqual(existing.Spec, desiredSpec):
+			statuses = append(statuses, tenancyv1alpha1.NetworkPolicyNamespaceStatus{Namespace: ns, Enforced: true})
+		default:
+			if err := r.clientApply(desired); err != nil {
+				return nil, err
+			}
+			statuses = append(statuses, tenancyv1alpha1.NetworkPolicyNamespaceStatus{
+				Namespace: ns,
+				Enforced:  false,
+				Message:   "NetworkPolicy had drifted from spec.networkIsolation and was corrected",
+			})
+		}
+	}
+	return statuses, nil
+}
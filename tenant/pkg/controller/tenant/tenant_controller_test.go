@@ -27,6 +27,7 @@ import (
 	"github.com/onsi/gomega"
 	"golang.org/x/net/context"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -113,3 +114,62 @@ func TestReconcile(t *testing.T) {
 		Should(gomega.Succeed())
 
 }
+
+func TestReconcileNetworkIsolation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	instance := &tenancyv1alpha1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "bar",
+		},
+		Spec: tenancyv1alpha1.TenantSpec{
+			TenantAdminNamespaceName: "t2admin",
+			NetworkIsolation: &tenancyv1alpha1.NetworkIsolationSpec{
+				DenyAllDefault:     true,
+				AllowedEgressCIDRs: []string{"10.0.0.0/8"},
+			},
+		},
+	}
+	expectedBarRequest := reconcile.Request{NamespacedName: types.NamespacedName{Name: "bar"}}
+
+	mgr, err := manager.New(cfg, manager.Options{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	c = mgr.GetClient()
+
+	recFn, requests := SetupTestReconcile(newReconciler(mgr))
+	g.Expect(add(mgr, recFn)).NotTo(gomega.HaveOccurred())
+
+	stopMgr, mgrStopped := StartTestManager(mgr, g)
+
+	defer func() {
+		close(stopMgr)
+		mgrStopped.Wait()
+	}()
+
+	// Create the Tenant object and expect a default-deny NetworkPolicy to be materialized in its
+	// tenantAdminNamespace.
+	err = c.Create(context.TODO(), instance)
+	if apierrors.IsInvalid(err) {
+		t.Logf("failed to create object, got an invalid object error: %v", err)
+		return
+	}
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	defer c.Delete(context.TODO(), instance)
+	g.Eventually(requests, timeout).Should(gomega.Receive(gomega.Equal(expectedBarRequest)))
+
+	nskey := types.NamespacedName{Name: "t2admin"}
+	adminNs := &corev1.Namespace{}
+	g.Eventually(func() error { return c.Get(context.TODO(), nskey, adminNs) }, timeout).
+		Should(gomega.Succeed())
+
+	npKey := types.NamespacedName{Name: tenantNetworkPolicyName, Namespace: "t2admin"}
+	np := &networkingv1.NetworkPolicy{}
+	g.Eventually(func() error { return c.Get(context.TODO(), npKey, np) }, timeout).
+		Should(gomega.Succeed())
+	g.Expect(np.Spec.Egress).To(gomega.HaveLen(1))
+
+	// Deleting the NetworkPolicy should cause it to be recreated on the next reconcile.
+	g.Expect(c.Delete(context.TODO(), np)).NotTo(gomega.HaveOccurred())
+	g.Eventually(requests, timeout).Should(gomega.Receive(gomega.Equal(expectedBarRequest)))
+	g.Eventually(func() error { return c.Get(context.TODO(), npKey, np) }, timeout).
+		Should(gomega.Succeed())
+}